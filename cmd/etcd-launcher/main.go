@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,10 +33,15 @@ import (
 	"go.etcd.io/etcd/v3/etcdserver/api/v3rpc/rpctypes"
 	"go.etcd.io/etcd/v3/etcdserver/etcdserverpb"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -59,12 +65,16 @@ type config struct {
 	token                 string
 	enableCorruptionCheck bool
 	initialState          string
+	// initialCluster overrides the initial member list when joining an existing cluster
+	initialCluster []string
 }
 
 type etcdCluster struct {
 	config      *config
 	client      *clientv3.Client
 	localClient *clientv3.Client
+	// learnerID is set if this member joined the cluster as learner and still needs to be promoted
+	learnerID uint64
 }
 
 func main() {
@@ -93,6 +103,10 @@ func main() {
 	if err := clusterClient.Get(context.Background(), types.NamespacedName{Name: strings.ReplaceAll(e.config.namespace, "cluster-", ""), Namespace: ""}, &k8cCluster); err != nil {
 		log.Fatalw("failed to get cluster", zap.Error(err))
 	}
+	e.config.clusterSize, err = e.getClusterSize(context.Background(), clusterClient, &k8cCluster)
+	if err != nil {
+		log.Fatalw("failed to get etcd cluster size", zap.Error(err))
+	}
 	initialMembers := initialMemberList(e.config.clusterSize, e.config.namespace)
 
 	e.config.initialState = "new"
@@ -101,6 +115,27 @@ func main() {
 		e.config.initialState = "existing"
 	}
 
+	restore, err := e.activeRestore(context.Background(), clusterClient)
+	if err != nil {
+		log.Fatalw("failed to check for active etcd restores", zap.Error(err))
+	}
+	if restore != nil {
+		if err := e.restoreFromBackup(context.Background(), log, clusterClient, restore); err != nil {
+			log.Fatalw("failed to restore etcd from backup", zap.Error(err))
+		}
+		// the restored data dir already holds the membership of the new cluster
+		e.config.initialState = "new"
+	}
+
+	if e.config.initialState == "existing" && restore == nil {
+		if err := e.joinCluster(log); err != nil {
+			log.Fatalw("failed to join etcd cluster", zap.Error(err))
+		}
+		if e.config.initialCluster != nil {
+			initialMembers = e.config.initialCluster
+		}
+	}
+
 	log.Info("initializing etcd..")
 	log.Infof("initial-state: %s", e.config.initialState)
 	log.Infof("initial-cluster: %s", strings.Join(initialMembers, ","))
@@ -152,17 +187,12 @@ func main() {
 				continue
 			}
 		}
-	} else { // new etcd member, need to join the cluster
-		log.Info("pod is not a cluster member, trying to join..")
-		// remove possibly stale member data dir..
-		log.Info("removing possibly stale data dir")
-		_ = os.RemoveAll(path.Join(e.config.dataDir, "member"))
-		// join the cluster
-
-		if _, err := e.client.MemberAdd(context.Background(), []string{fmt.Sprintf("http://%s.etcd.%s.svc.cluster.local:2380", e.config.podName, e.config.namespace)}); err != nil {
-			log.Fatalf("failed to join cluster: %v", err)
-		}
-		log.Info("joined etcd cluster succcessfully.")
+	} else {
+		log.Fatal("pod is not a cluster member")
+	}
+
+	if e.learnerID != 0 {
+		e.promoteLearner(log)
 	}
 
 	if err = cmd.Wait(); err != nil {
@@ -194,7 +224,6 @@ func (e *etcdCluster) parseConfigFlags() error {
 	config := &config{}
 
 	flag.StringVar(&config.namespace, "namespace", "", "namespace of the user cluster")
-	flag.IntVar(&config.clusterSize, "etcd-cluster-size", defaultClusterSize, "number of replicas in the etcd cluster")
 	flag.StringVar(&config.podName, "pod-name", "", "name of this etcd pod")
 	flag.StringVar(&config.podIP, "pod-ip", "", "IP address of this etcd pod")
 	flag.StringVar(&config.etcdctlAPIVersion, "api-version", defaultEtcdctlAPIVersion, "etcdctl API version")
@@ -206,10 +235,6 @@ func (e *etcdCluster) parseConfigFlags() error {
 		return errors.New("-namespace is not set")
 	}
 
-	if config.clusterSize < defaultClusterSize {
-		return fmt.Errorf("-etcd-cluster-size is smaller than %d", defaultClusterSize)
-	}
-
 	if config.podName == "" {
 		return errors.New("-pod-name is not set")
 	}
@@ -232,11 +257,34 @@ func (e *etcdCluster) parseConfigFlags() error {
 	return nil
 }
 
+// getClusterSize returns the number of members the etcd cluster should have. The size is read
+// from the cluster instead of the pod spec, so resizing etcd only changes the replicas of the
+// StatefulSet and does not roll the existing members.
+func (e *etcdCluster) getClusterSize(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (int, error) {
+	size, err := etcd.GetClusterSize(cluster)
+	if err == nil {
+		return size, nil
+	}
+	// the StatefulSet keeps its size if an unsupported size is configured
+	set := &appsv1.StatefulSet{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: e.config.namespace, Name: resources.EtcdStatefulSetName}, set); err != nil {
+		return 0, err
+	}
+	if set.Spec.Replicas == nil {
+		return defaultClusterSize, nil
+	}
+	return int(*set.Spec.Replicas), nil
+}
+
 func etcdCmd(config *config) []string {
+	initialCluster := config.initialCluster
+	if initialCluster == nil {
+		initialCluster = initialMemberList(config.clusterSize, config.namespace)
+	}
 	cmd := []string{
 		fmt.Sprintf("--name=%s", config.podName),
 		fmt.Sprintf("--data-dir=%s", config.dataDir),
-		fmt.Sprintf("--initial-cluster=%s", strings.Join(initialMemberList(config.clusterSize, config.namespace), ",")),
+		fmt.Sprintf("--initial-cluster=%s", strings.Join(initialCluster, ",")),
 		fmt.Sprintf("--initial-cluster-token=%s", config.token),
 		fmt.Sprintf("--initial-cluster-state=%s", config.initialState),
 		fmt.Sprintf("--advertise-client-urls=https://%s.etcd.%s.svc.cluster.local:2379,https://%s:2379", config.podName, config.namespace, config.podIP),
@@ -260,6 +308,120 @@ func etcdCmd(config *config) []string {
 	return cmd
 }
 
+func (e *etcdCluster) peerURL() string {
+	return fmt.Sprintf("http://%s.etcd.%s.svc.cluster.local:2380", e.config.podName, e.config.namespace)
+}
+
+// ordinal returns the index of this pod in the StatefulSet
+func (e *etcdCluster) ordinal() (int, error) {
+	i := strings.LastIndex(e.config.podName, "-")
+	if i < 0 {
+		return 0, fmt.Errorf("pod name %q has no ordinal", e.config.podName)
+	}
+	return strconv.Atoi(e.config.podName[i+1:])
+}
+
+// joinCluster adds this pod to an existing cluster, unless it is a member already. New
+// members are added as learners, which do not count towards the quorum until they are
+// promoted after catching up with the leader.
+func (e *etcdCluster) joinCluster(log *zap.SugaredLogger) error {
+	_, err := os.Stat(path.Join(e.config.dataDir, "member"))
+	hasData := err == nil
+
+	var members []*etcdserverpb.Member
+	err = wait.Poll(5*time.Second, 2*time.Minute, func() (bool, error) {
+		if err := e.getClient(); err != nil {
+			return false, nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := e.client.MemberList(ctx)
+		if err != nil {
+			log.Warnw("failed to list members", zap.Error(err))
+			return false, nil
+		}
+		members = resp.Members
+		return true, nil
+	})
+	if err != nil {
+		// All members might be restarting at the same time, so members with data must
+		// not wait for the others
+		if hasData {
+			log.Warn("cluster is not reachable, starting as existing member")
+			return nil
+		}
+		return fmt.Errorf("cluster is not reachable: %v", err)
+	}
+
+	for _, member := range members {
+		if member.Name == e.config.podName || (len(member.PeerURLs) > 0 && member.PeerURLs[0] == e.peerURL()) {
+			if member.IsLearner {
+				e.learnerID = member.ID
+			}
+			return nil
+		}
+	}
+
+	ordinal, err := e.ordinal()
+	if err != nil {
+		return err
+	}
+	if ordinal >= e.config.clusterSize {
+		// The member was removed because the cluster gets scaled in, its pod is deleted next
+		log.Info("pod is not a cluster member and beyond the cluster size, waiting to be deleted")
+		select {}
+	}
+
+	log.Info("pod is not a cluster member, trying to join..")
+	// remove possibly stale member data dir..
+	log.Info("removing possibly stale data dir")
+	if err := os.RemoveAll(path.Join(e.config.dataDir, "member")); err != nil {
+		return fmt.Errorf("failed to remove stale data dir: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := e.client.MemberAddAsLearner(ctx, []string{e.peerURL()})
+	if status.Code(err) == codes.Unimplemented {
+		// etcd 3.3 does not support learners
+		resp, err = e.client.MemberAdd(ctx, []string{e.peerURL()})
+	} else if err == nil {
+		e.learnerID = resp.Member.ID
+	}
+	if err != nil {
+		return err
+	}
+
+	e.config.initialCluster = nil
+	for _, member := range resp.Members {
+		name := member.Name
+		if member.ID == resp.Member.ID {
+			name = e.config.podName
+		}
+		for _, url := range member.PeerURLs {
+			e.config.initialCluster = append(e.config.initialCluster, fmt.Sprintf("%s=%s", name, url))
+		}
+	}
+	log.Infow("joined etcd cluster succcessfully", "learner", e.learnerID != 0)
+	return nil
+}
+
+// promoteLearner makes this member a voting member once it caught up with the leader
+func (e *etcdCluster) promoteLearner(log *zap.SugaredLogger) {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err := e.client.MemberPromote(ctx, e.learnerID)
+		cancel()
+		if err == nil || err == rpctypes.ErrMemberNotLearner {
+			log.Info("learner promoted to voting member")
+			e.learnerID = 0
+			return
+		}
+		log.Infow("learner is not ready to be promoted yet", zap.Error(err))
+		time.Sleep(5 * time.Second)
+	}
+}
+
 func (e *etcdCluster) getClient() error {
 	if e.client != nil {
 		return nil
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"go.etcd.io/etcd/v3/clientv3/snapshot"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/storeuploader"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const snapshotFileName = "restore-snapshot.db"
+
+// activeRestore returns the etcd restore that is currently rebuilding the etcd of this
+// cluster or nil, if there is none.
+func (e *etcdCluster) activeRestore(ctx context.Context, client ctrlruntimeclient.Client) (*kubermaticv1.EtcdRestore, error) {
+	restores := &kubermaticv1.EtcdRestoreList{}
+	if err := client.List(ctx, restores, ctrlruntimeclient.InNamespace(e.config.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list etcd restores: %v", err)
+	}
+	for _, restore := range restores.Items {
+		if restore.Status.Phase == kubermaticv1.EtcdRestorePhaseStsRebuilding {
+			return restore.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
+
+// restoreFromBackup downloads the backup of the given restore and bootstraps the data dir of this
+// member from it. It does nothing if the data dir already contains data, so a restarted member
+// doesn't overwrite its data with the backup again.
+func (e *etcdCluster) restoreFromBackup(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, restore *kubermaticv1.EtcdRestore) error {
+	if _, err := os.Stat(path.Join(e.config.dataDir, "member")); err == nil {
+		log.Infow("data dir already exists, skipping restore", "restore", restore.Name)
+		return nil
+	}
+
+	credentials := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: e.config.namespace, Name: resources.EtcdRestoreCredentialsSecretName}, credentials); err != nil {
+		return fmt.Errorf("failed to get backup download credentials: %v", err)
+	}
+
+	backend, err := storeuploader.NewBackend(restoreStorageConfig(credentials))
+	if err != nil {
+		return fmt.Errorf("failed to create backup downloader: %v", err)
+	}
+	defer backend.Close()
+	downloader := storeuploader.NewWithBackend(backend, log)
+
+	// encrypted backups are decrypted on download with the keys of the seed
+	encodedKeys := map[string][]byte{}
+	for key, value := range credentials.Data {
+		if strings.HasPrefix(key, resources.EtcdRestoreEncryptionKeyPrefix) {
+			encodedKeys[strings.TrimPrefix(key, resources.EtcdRestoreEncryptionKeyPrefix)] = value
+		}
+	}
+	if len(encodedKeys) > 0 {
+		keys, err := storeuploader.ParseKeys(encodedKeys)
+		if err != nil {
+			return fmt.Errorf("failed to parse backup encryption keys: %v", err)
+		}
+		if err := downloader.SetEncryption(keys, ""); err != nil {
+			return err
+		}
+	}
+
+	// the snapshot is stored next to the data dir, so it ends up on the same volume
+	snapshotFile := path.Join(path.Dir(path.Clean(e.config.dataDir)), snapshotFileName)
+	defer os.Remove(snapshotFile)
+	if err := downloader.Download(string(credentials.Data["BUCKET"]), restore.Spec.BackupName, snapshotFile); err != nil {
+		return fmt.Errorf("failed to download backup %q: %v", restore.Spec.BackupName, err)
+	}
+
+	// the restore refuses to write to an existing data dir
+	if err := os.RemoveAll(e.config.dataDir); err != nil {
+		return fmt.Errorf("failed to remove data dir: %v", err)
+	}
+
+	log.Infow("restoring etcd data from backup", "restore", restore.Name, "backup", restore.Spec.BackupName)
+	return snapshot.NewV3(log.Desugar()).Restore(snapshot.RestoreConfig{
+		SnapshotPath:        snapshotFile,
+		Name:                e.config.podName,
+		OutputDataDir:       e.config.dataDir,
+		PeerURLs:            []string{fmt.Sprintf("http://%s", e.endpoint())},
+		InitialCluster:      strings.Join(initialMemberList(e.config.clusterSize, e.config.namespace), ","),
+		InitialClusterToken: e.config.token,
+	})
+}
+
+// restoreStorageConfig returns the settings of the backup storage from the restore credentials,
+// which use the same keys as the environment of the store container.
+func restoreStorageConfig(credentials *corev1.Secret) storeuploader.Config {
+	return storeuploader.Config{
+		Backend:         string(credentials.Data["BACKEND"]),
+		Endpoint:        string(credentials.Data["ENDPOINT"]),
+		Secure:          string(credentials.Data["SECURE"]) == "true",
+		AccessKeyID:     string(credentials.Data["ACCESS_KEY_ID"]),
+		SecretAccessKey: string(credentials.Data["SECRET_ACCESS_KEY"]),
+		Address:         string(credentials.Data["SFTP_ADDRESS"]),
+		HostKey:         string(credentials.Data["SFTP_HOST_KEY"]),
+		Username:        string(credentials.Data["SFTP_USERNAME"]),
+		Password:        string(credentials.Data["SFTP_PASSWORD"]),
+		PrivateKey:      string(credentials.Data["SFTP_PRIVATE_KEY"]),
+	}
+}
@@ -33,6 +33,8 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	operatorv1alpha1 "k8c.io/kubermatic/v2/pkg/crd/operator/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -149,12 +151,23 @@ func createExampleSeed() *kubermaticv1.Seed {
 						GCP: &kubermaticv1.DatacenterSpecGCP{
 							ZoneSuffixes: []string{},
 						},
-						Kubevirt: &kubermaticv1.DatacenterSpecKubevirt{},
-						Alibaba:  &kubermaticv1.DatacenterSpecAlibaba{},
+						Kubevirt: &kubermaticv1.DatacenterSpecKubevirt{
+							ResourceQuota: corev1.ResourceList{
+								corev1.ResourceRequestsCPU: resource.MustParse("0"),
+							},
+						},
+						Alibaba: &kubermaticv1.DatacenterSpecAlibaba{},
 					},
 				},
 			},
 			ProxySettings: &proxySettings,
+			EtcdBackupStorage: &kubermaticv1.EtcdBackupStorage{
+				S3:         &kubermaticv1.EtcdBackupS3Storage{},
+				Filesystem: &kubermaticv1.EtcdBackupFilesystemStorage{},
+				SFTP:       &kubermaticv1.EtcdBackupSFTPStorage{},
+			},
+			EtcdBackupEncryption: &kubermaticv1.EtcdBackupEncryption{},
+			EtcdDefragmentation:  &kubermaticv1.EtcdDefragmentationSettings{},
 		},
 	}
 
@@ -207,6 +220,14 @@ func createExampleKubermaticConfiguration() *operatorv1alpha1.KubermaticConfigur
 			if cfg.Updates[0].AutomaticNodeUpdate == nil {
 				cfg.Updates[0].AutomaticNodeUpdate = pointer.BoolPtr(false)
 			}
+
+			if cfg.Updates[0].Rollout == nil {
+				cfg.Updates[0].Rollout = &operatorv1alpha1.UpdateRolloutPolicy{
+					CanarySelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"canary": "true"},
+					},
+				}
+			}
 		}
 	}
 
@@ -251,6 +251,8 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 		return providers{}, fmt.Errorf("failed to create user info getter due to %v", err)
 	}
 
+	clusterTemplateProvider := kubernetesprovider.NewClusterTemplateProvider(ctx, client)
+
 	kubeMasterInformerFactory.Start(wait.NeverStop)
 	kubeMasterInformerFactory.WaitForCacheSync(wait.NeverStop)
 	kubermaticMasterInformerFactory.Start(wait.NeverStop)
@@ -298,6 +300,7 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 		userWatcher:                           userWatcher,
 		externalClusterProvider:               externalClusterProvider,
 		privilegedExternalClusterProvider:     externalClusterProvider,
+		clusterTemplateProvider:               clusterTemplateProvider,
 	}, nil
 }
 
@@ -401,6 +404,7 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		UserWatcher:                           prov.userWatcher,
 		ExternalClusterProvider:               prov.externalClusterProvider,
 		PrivilegedExternalClusterProvider:     prov.privilegedExternalClusterProvider,
+		ClusterTemplateProvider:               prov.clusterTemplateProvider,
 	}
 
 	r := handler.NewRouting(routingParams)
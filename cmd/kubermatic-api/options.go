@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"strings"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -103,7 +104,7 @@ func newServerRunOptions() (serverRunOptions, error) {
 	flag.StringVar(&rawFeatureGates, "feature-gates", "", "A set of key=value pairs that describe feature gates for various features.")
 	flag.StringVar(&s.domain, "domain", "localhost", "A domain name on which the server is deployed")
 	flag.StringVar(&s.serviceAccountSigningKey, "service-account-signing-key", "", "Signing key authenticates the service account's token value using HMAC. It is recommended to use a key with 32 bytes or longer.")
-	flag.StringVar(&rawExposeStrategy, "expose-strategy", "NodePort", "The strategy to expose the controlplane with, either \"NodePort\" which creates NodePorts with a \"nodeport-proxy.k8s.io/expose: true\" annotation or \"LoadBalancer\", which creates a LoadBalancer")
+	flag.StringVar(&rawExposeStrategy, "expose-strategy", "NodePort", "The strategy to expose the controlplane with, either \"NodePort\" which creates NodePorts with a \"nodeport-proxy.k8s.io/expose: true\" annotation, \"LoadBalancer\", which creates a LoadBalancer or \"SNI\", which exposes the apiserver on the port 443 of the NodePort proxy")
 	flag.BoolVar(&s.dynamicPresets, "dynamic-presets", false, "Whether to enable dynamic presets")
 	flag.StringVar(&s.namespace, "namespace", "kubermatic", "The namespace kubermatic runs in, uses to determine where to look for datacenter custom resources")
 	addFlags(flag.CommandLine)
@@ -120,8 +121,10 @@ func newServerRunOptions() (serverRunOptions, error) {
 		s.exposeStrategy = corev1.ServiceTypeNodePort
 	case "LoadBalancer":
 		s.exposeStrategy = corev1.ServiceTypeLoadBalancer
+	case "SNI":
+		s.exposeStrategy = kubermaticv1.ExposeStrategySNI
 	default:
-		return s, fmt.Errorf("--expose-strategy must be either `NodePort` or `LoadBalancer`, got %q", rawExposeStrategy)
+		return s, fmt.Errorf("--expose-strategy must be one of `NodePort`, `LoadBalancer` or `SNI`, got %q", rawExposeStrategy)
 	}
 
 	s.accessibleAddons = sets.NewString(strings.Split(rawAccessibleAddons, ",")...)
@@ -199,4 +202,5 @@ type providers struct {
 	userWatcher                           watcher.UserWatcher
 	externalClusterProvider               provider.ExternalClusterProvider
 	privilegedExternalClusterProvider     provider.PrivilegedExternalClusterProvider
+	clusterTemplateProvider               provider.ClusterTemplateProvider
 }
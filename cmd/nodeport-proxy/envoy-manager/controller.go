@@ -36,7 +36,9 @@ import (
 	envoyroutev2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
 	envoyhealthv2 "github.com/envoyproxy/go-control-plane/envoy/config/filter/http/health_check/v2"
 	envoyhttpconnectionmanagerv2 "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	envoyrbacfilterv2 "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/rbac/v2"
 	envoytcpfilterv2 "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/tcp_proxy/v2"
+	envoyrbacv2 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v2"
 	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
 	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
@@ -210,18 +212,21 @@ func (r *reconciler) sync() error {
 		return errors.Wrap(err, "failed to get initial config")
 	}
 
+	var sniFilterChains []*envoylistenerv2.FilterChain
 	for _, service := range services.Items {
 		serviceKey := ServiceKey(&service)
 		serviceLog := r.log.With("service", serviceKey)
 
-		// Only cover services which have the annotation: true
-		if strings.ToLower(service.Annotations[exposeAnnotationKey]) != "true" {
-			serviceLog.Debugf("Skipping service: it does not have the annotation %s=true", exposeAnnotationKey)
+		// Only cover services which have the annotation: true or the SNI annotation
+		exposeNodePorts := strings.ToLower(service.Annotations[exposeAnnotationKey]) == "true"
+		sniHostnames := getSNIHostnames(&service)
+		if !exposeNodePorts && len(sniHostnames) == 0 {
+			serviceLog.Debugf("Skipping service: it has neither the annotation %s=true nor the annotation %s", exposeAnnotationKey, sniAnnotationKey)
 			continue
 		}
 
 		// We only manage NodePort services so Kubernetes takes care of allocating a unique port
-		if service.Spec.Type != corev1.ServiceTypeNodePort {
+		if exposeNodePorts && service.Spec.Type != corev1.ServiceTypeNodePort {
 			serviceLog.Warn("Skipping service: it is not of type NodePort")
 			return nil
 		}
@@ -237,78 +242,42 @@ func (r *reconciler) sync() error {
 			continue
 		}
 
-		for _, servicePort := range service.Spec.Ports {
-			serviceNodePortName := fmt.Sprintf("%s-%d", serviceKey, servicePort.NodePort)
-			servicePortLog := serviceLog.With("port", servicePort.NodePort)
+		sourceRanges, err := getAllowedSourceRanges(&service)
+		if err != nil {
+			// Never expose the service without the restriction it asks for
+			serviceLog.Errorw("Skipping service: the allowed source ranges are invalid", zap.Error(err))
+			continue
+		}
 
-			var endpoints []*envoyendpointv2.LbEndpoint
-			for _, pod := range pods {
-				podLog := servicePortLog.With("pod", pod.Name, "namespace", pod.Namespace, "service", service.Name)
-
-				// Get the port on the pod, the NodePort Service port is pointing to
-				podPort := getMatchingPodPort(servicePort, pod)
-				if podPort == 0 {
-					podLog.Debug("Skipping pod for service port: the service port does not match to any of the pods containers")
-					continue
-				}
-
-				podLog.Debug("Using pod as backend for service")
-
-				// Cluster endpoints
-				endpoints = append(endpoints, &envoyendpointv2.LbEndpoint{
-					HostIdentifier: &envoyendpointv2.LbEndpoint_Endpoint{
-						Endpoint: &envoyendpointv2.Endpoint{
-							Address: &envoycorev2.Address{
-								Address: &envoycorev2.Address_SocketAddress{
-									SocketAddress: &envoycorev2.SocketAddress{
-										Protocol: envoycorev2.SocketAddress_TCP,
-										Address:  pod.Status.PodIP,
-										PortSpecifier: &envoycorev2.SocketAddress_PortValue{
-											PortValue: uint32(podPort),
-										},
-									},
-								},
-							},
-						},
-					},
-				})
-			}
+		if len(sniHostnames) > 0 && len(service.Spec.Ports) > 0 {
+			sniClusterName := fmt.Sprintf("%s-sni", serviceKey)
+			sniLog := serviceLog.With("hostnames", sniHostnames)
 
-			// Must be sorted, otherwise we get into trouble when doing the snapshot diff later
-			sort.Slice(endpoints, func(i, j int) bool {
-				addrI := endpoints[i].HostIdentifier.(*envoyendpointv2.LbEndpoint_Endpoint).Endpoint.Address.Address.(*envoycorev2.Address_SocketAddress).SocketAddress.Address
-				addrJ := endpoints[j].HostIdentifier.(*envoyendpointv2.LbEndpoint_Endpoint).Endpoint.Address.Address.(*envoycorev2.Address_SocketAddress).SocketAddress.Address
-				return addrI < addrJ
-			})
+			endpoints := getServicePortEndpoints(sniLog, getSNIServicePort(&service), pods)
+			clusters = append(clusters, newStaticCluster(sniClusterName, endpoints))
 
-			cluster := &envoyv2.Cluster{
-				Name:           serviceNodePortName,
-				ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
-				ClusterDiscoveryType: &envoyv2.Cluster_Type{
-					Type: envoyv2.Cluster_STATIC,
-				},
-				LbPolicy: envoyv2.Cluster_ROUND_ROBIN,
-				LoadAssignment: &envoyv2.ClusterLoadAssignment{
-					ClusterName: serviceNodePortName,
-					Endpoints: []*envoyendpointv2.LocalityLbEndpoints{
-						{
-							LbEndpoints: endpoints,
-						},
-					},
-				},
+			filterChain, err := newSNIFilterChain(sniHostnames, sniClusterName, sourceRanges)
+			if err != nil {
+				return err
 			}
-			clusters = append(clusters, cluster)
+			sniLog.Debug("Routing connections to the SNI listener by hostname")
+			sniFilterChains = append(sniFilterChains, filterChain)
+		}
 
-			tcpProxyConfig := &envoytcpfilterv2.TcpProxy{
-				StatPrefix: "ingress_tcp",
-				ClusterSpecifier: &envoytcpfilterv2.TcpProxy_Cluster{
-					Cluster: serviceNodePortName,
-				},
-			}
+		if !exposeNodePorts {
+			continue
+		}
+
+		for _, servicePort := range service.Spec.Ports {
+			serviceNodePortName := fmt.Sprintf("%s-%d", serviceKey, servicePort.NodePort)
+			servicePortLog := serviceLog.With("port", servicePort.NodePort)
 
-			tcpProxyConfigMarshalled, err := ptypes.MarshalAny(tcpProxyConfig)
+			endpoints := getServicePortEndpoints(servicePortLog, servicePort, pods)
+			clusters = append(clusters, newStaticCluster(serviceNodePortName, endpoints))
+
+			filters, err := newTCPProxyFilters(serviceNodePortName, sourceRanges)
 			if err != nil {
-				return errors.Wrap(err, "failed to marshal tcpProxyConfig")
+				return err
 			}
 
 			r.log.Debugf("Using a listener on port %d", servicePort.NodePort)
@@ -328,14 +297,7 @@ func (r *reconciler) sync() error {
 				},
 				FilterChains: []*envoylistenerv2.FilterChain{
 					{
-						Filters: []*envoylistenerv2.Filter{
-							{
-								Name: envoywellknown.TCPProxy,
-								ConfigType: &envoylistenerv2.Filter_TypedConfig{
-									TypedConfig: tcpProxyConfigMarshalled,
-								},
-							},
-						},
+						Filters: filters,
 					},
 				},
 			}
@@ -343,6 +305,16 @@ func (r *reconciler) sync() error {
 		}
 	}
 
+	// Envoy rejects listeners without filter chains, so the SNI listener is only
+	// created when at least one service is exposed via SNI
+	if len(sniFilterChains) > 0 {
+		// Must be sorted, otherwise we get into trouble when doing the snapshot diff later
+		sort.Slice(sniFilterChains, func(i, j int) bool {
+			return sniFilterChains[i].FilterChainMatch.ServerNames[0] < sniFilterChains[j].FilterChainMatch.ServerNames[0]
+		})
+		listeners = append(listeners, newSNIListener(sniFilterChains))
+	}
+
 	lastUsedVersion, err := semver.NewVersion(r.lastAppliedSnapshot.GetVersion(envoycache.ClusterType))
 	if err != nil {
 		return errors.Wrap(err, "failed to parse version from last snapshot")
@@ -401,3 +373,175 @@ func (r *reconciler) getReadyServicePods(service *corev1.Service) ([]*corev1.Pod
 
 	return readyPods, nil
 }
+
+// getServicePortEndpoints returns the endpoints of the pods the service port is pointing to
+func getServicePortEndpoints(log *zap.SugaredLogger, servicePort corev1.ServicePort, pods []*corev1.Pod) []*envoyendpointv2.LbEndpoint {
+	var endpoints []*envoyendpointv2.LbEndpoint
+	for _, pod := range pods {
+		podLog := log.With("pod", pod.Name, "namespace", pod.Namespace)
+
+		// Get the port on the pod, the Service port is pointing to
+		podPort := getMatchingPodPort(servicePort, pod)
+		if podPort == 0 {
+			podLog.Debug("Skipping pod for service port: the service port does not match to any of the pods containers")
+			continue
+		}
+
+		podLog.Debug("Using pod as backend for service")
+
+		// Cluster endpoints
+		endpoints = append(endpoints, &envoyendpointv2.LbEndpoint{
+			HostIdentifier: &envoyendpointv2.LbEndpoint_Endpoint{
+				Endpoint: &envoyendpointv2.Endpoint{
+					Address: &envoycorev2.Address{
+						Address: &envoycorev2.Address_SocketAddress{
+							SocketAddress: &envoycorev2.SocketAddress{
+								Protocol: envoycorev2.SocketAddress_TCP,
+								Address:  pod.Status.PodIP,
+								PortSpecifier: &envoycorev2.SocketAddress_PortValue{
+									PortValue: uint32(podPort),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// Must be sorted, otherwise we get into trouble when doing the snapshot diff later
+	sort.Slice(endpoints, func(i, j int) bool {
+		addrI := endpoints[i].HostIdentifier.(*envoyendpointv2.LbEndpoint_Endpoint).Endpoint.Address.Address.(*envoycorev2.Address_SocketAddress).SocketAddress.Address
+		addrJ := endpoints[j].HostIdentifier.(*envoyendpointv2.LbEndpoint_Endpoint).Endpoint.Address.Address.(*envoycorev2.Address_SocketAddress).SocketAddress.Address
+		return addrI < addrJ
+	})
+
+	return endpoints
+}
+
+func newStaticCluster(name string, endpoints []*envoyendpointv2.LbEndpoint) *envoyv2.Cluster {
+	return &envoyv2.Cluster{
+		Name:           name,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &envoyv2.Cluster_Type{
+			Type: envoyv2.Cluster_STATIC,
+		},
+		LbPolicy: envoyv2.Cluster_ROUND_ROBIN,
+		LoadAssignment: &envoyv2.ClusterLoadAssignment{
+			ClusterName: name,
+			Endpoints: []*envoyendpointv2.LocalityLbEndpoints{
+				{
+					LbEndpoints: endpoints,
+				},
+			},
+		},
+	}
+}
+
+// newTCPProxyFilters returns the filters which pass the connections to the cluster. If source ranges
+// are given, connections from other sources are rejected before they reach the cluster.
+func newTCPProxyFilters(clusterName string, sourceRanges []*envoycorev2.CidrRange) ([]*envoylistenerv2.Filter, error) {
+	var filters []*envoylistenerv2.Filter
+
+	if len(sourceRanges) > 0 {
+		principals := make([]*envoyrbacv2.Principal, 0, len(sourceRanges))
+		for _, sourceRange := range sourceRanges {
+			principals = append(principals, &envoyrbacv2.Principal{
+				Identifier: &envoyrbacv2.Principal_SourceIp{
+					SourceIp: sourceRange,
+				},
+			})
+		}
+
+		// There must be only one policy, the marshalling of the map is not deterministic otherwise
+		rbacConfig := &envoyrbacfilterv2.RBAC{
+			StatPrefix: "allowed_source_ranges",
+			Rules: &envoyrbacv2.RBAC{
+				Action: envoyrbacv2.RBAC_ALLOW,
+				Policies: map[string]*envoyrbacv2.Policy{
+					"allowed-source-ranges": {
+						Permissions: []*envoyrbacv2.Permission{
+							{
+								Rule: &envoyrbacv2.Permission_Any{Any: true},
+							},
+						},
+						Principals: principals,
+					},
+				},
+			},
+		}
+
+		rbacConfigMarshalled, err := ptypes.MarshalAny(rbacConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal rbacConfig")
+		}
+
+		filters = append(filters, &envoylistenerv2.Filter{
+			Name: envoywellknown.RoleBasedAccessControl,
+			ConfigType: &envoylistenerv2.Filter_TypedConfig{
+				TypedConfig: rbacConfigMarshalled,
+			},
+		})
+	}
+
+	tcpProxyConfig := &envoytcpfilterv2.TcpProxy{
+		StatPrefix: "ingress_tcp",
+		ClusterSpecifier: &envoytcpfilterv2.TcpProxy_Cluster{
+			Cluster: clusterName,
+		},
+	}
+
+	tcpProxyConfigMarshalled, err := ptypes.MarshalAny(tcpProxyConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal tcpProxyConfig")
+	}
+
+	filters = append(filters, &envoylistenerv2.Filter{
+		Name: envoywellknown.TCPProxy,
+		ConfigType: &envoylistenerv2.Filter_TypedConfig{
+			TypedConfig: tcpProxyConfigMarshalled,
+		},
+	})
+
+	return filters, nil
+}
+
+// newSNIFilterChain returns a filter chain which passes the TLS connections for the given
+// hostnames to the cluster. The connections are not terminated by envoy.
+func newSNIFilterChain(hostnames []string, clusterName string, sourceRanges []*envoycorev2.CidrRange) (*envoylistenerv2.FilterChain, error) {
+	filters, err := newTCPProxyFilters(clusterName, sourceRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envoylistenerv2.FilterChain{
+		FilterChainMatch: &envoylistenerv2.FilterChainMatch{
+			ServerNames: hostnames,
+		},
+		Filters: filters,
+	}, nil
+}
+
+// newSNIListener returns the listener which uses the TLS inspector to select the filter chain by the SNI hostname
+func newSNIListener(filterChains []*envoylistenerv2.FilterChain) *envoyv2.Listener {
+	return &envoyv2.Listener{
+		Name: sniListenerName,
+		Address: &envoycorev2.Address{
+			Address: &envoycorev2.Address_SocketAddress{
+				SocketAddress: &envoycorev2.SocketAddress{
+					Protocol: envoycorev2.SocketAddress_TCP,
+					Address:  "0.0.0.0",
+					PortSpecifier: &envoycorev2.SocketAddress_PortValue{
+						PortValue: uint32(envoySNIListenerPort),
+					},
+				},
+			},
+		},
+		ListenerFilters: []*envoylistenerv2.ListenerFilter{
+			{
+				Name: envoywellknown.TlsInspector,
+			},
+		},
+		FilterChains: filterChains,
+	}
+}
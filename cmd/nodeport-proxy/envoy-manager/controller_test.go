@@ -25,12 +25,15 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 
 	envoyv2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoycorev2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	envoyendpointv2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
 	envoylistenerv2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	envoyrbacfilterv2 "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/rbac/v2"
 	envoytcpfilterv2 "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/tcp_proxy/v2"
+	envoyrbacv2 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v2"
 	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache"
 	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
@@ -42,6 +45,9 @@ import (
 )
 
 func TestSync(t *testing.T) {
+	sniAnnotationKey = defaultSNIAnnotationKey
+	envoySNIListenerPort = 6443
+
 	tests := []struct {
 		name             string
 		resources        []runtime.Object
@@ -581,6 +587,176 @@ func TestSync(t *testing.T) {
 			expectedListener: map[string]*envoyv2.Listener{},
 			expectedClusters: map[string]*envoyv2.Cluster{},
 		},
+		{
+			name: "sni-services-sorted-by-hostname",
+			resources: []runtime.Object{
+				newSNITestService("cluster-b", "b.europe-west3-c.dev.kubermatic.io"),
+				newSNITestPod("cluster-b", "172.16.0.2"),
+				newSNITestService("cluster-a", "a.europe-west3-c.dev.kubermatic.io"),
+				newSNITestPod("cluster-a", "172.16.0.1"),
+			},
+			expectedClusters: map[string]*envoyv2.Cluster{
+				"cluster-a/apiserver-external-sni": newStaticCluster("cluster-a/apiserver-external-sni", []*envoyendpointv2.LbEndpoint{
+					newTestEndpoint("172.16.0.1", 32000),
+				}),
+				"cluster-b/apiserver-external-sni": newStaticCluster("cluster-b/apiserver-external-sni", []*envoyendpointv2.LbEndpoint{
+					newTestEndpoint("172.16.0.2", 32000),
+				}),
+			},
+			expectedListener: map[string]*envoyv2.Listener{
+				sniListenerName: {
+					Name: sniListenerName,
+					Address: &envoycorev2.Address{
+						Address: &envoycorev2.Address_SocketAddress{
+							SocketAddress: &envoycorev2.SocketAddress{
+								Protocol: envoycorev2.SocketAddress_TCP,
+								Address:  "0.0.0.0",
+								PortSpecifier: &envoycorev2.SocketAddress_PortValue{
+									PortValue: 6443,
+								},
+							},
+						},
+					},
+					ListenerFilters: []*envoylistenerv2.ListenerFilter{
+						{
+							Name: envoywellknown.TlsInspector,
+						},
+					},
+					FilterChains: []*envoylistenerv2.FilterChain{
+						newTestSNIFilterChain(t, "cluster-a/apiserver-external-sni", "a.europe-west3-c.dev.kubermatic.io"),
+						newTestSNIFilterChain(t, "cluster-b/apiserver-external-sni", "b.europe-west3-c.dev.kubermatic.io"),
+					},
+				},
+			},
+		},
+		{
+			name: "sni-service-routed-to-port-443",
+			resources: []runtime.Object{
+				newMultiPortSNITestService("cluster-a", "openvpn-a.europe-west3-c.dev.kubermatic.io, apiserver-tunnel-a.europe-west3-c.dev.kubermatic.io"),
+				newSNITestPod("cluster-a", "172.16.0.1"),
+			},
+			expectedClusters: map[string]*envoyv2.Cluster{
+				"cluster-a/openvpn-server-sni": newStaticCluster("cluster-a/openvpn-server-sni", []*envoyendpointv2.LbEndpoint{
+					newTestEndpoint("172.16.0.1", 8443),
+				}),
+			},
+			expectedListener: map[string]*envoyv2.Listener{
+				sniListenerName: {
+					Name: sniListenerName,
+					Address: &envoycorev2.Address{
+						Address: &envoycorev2.Address_SocketAddress{
+							SocketAddress: &envoycorev2.SocketAddress{
+								Protocol: envoycorev2.SocketAddress_TCP,
+								Address:  "0.0.0.0",
+								PortSpecifier: &envoycorev2.SocketAddress_PortValue{
+									PortValue: 6443,
+								},
+							},
+						},
+					},
+					ListenerFilters: []*envoylistenerv2.ListenerFilter{
+						{
+							Name: envoywellknown.TlsInspector,
+						},
+					},
+					FilterChains: []*envoylistenerv2.FilterChain{
+						newTestSNIFilterChain(t, "cluster-a/openvpn-server-sni", "openvpn-a.europe-west3-c.dev.kubermatic.io", "apiserver-tunnel-a.europe-west3-c.dev.kubermatic.io"),
+					},
+				},
+			},
+		},
+		{
+			name: "allowed-source-ranges-are-enforced",
+			resources: []runtime.Object{
+				newRestrictedTestService("192.0.2.0/24, 198.51.100.17/32"),
+				newSNITestPod("cluster-a", "172.16.0.1"),
+			},
+			expectedClusters: map[string]*envoyv2.Cluster{
+				"cluster-a/apiserver-external-32000": newStaticCluster("cluster-a/apiserver-external-32000", []*envoyendpointv2.LbEndpoint{
+					newTestEndpoint("172.16.0.1", 32000),
+				}),
+			},
+			expectedListener: map[string]*envoyv2.Listener{
+				"cluster-a/apiserver-external-32000": {
+					Name: "cluster-a/apiserver-external-32000",
+					Address: &envoycorev2.Address{
+						Address: &envoycorev2.Address_SocketAddress{
+							SocketAddress: &envoycorev2.SocketAddress{
+								Protocol: envoycorev2.SocketAddress_TCP,
+								Address:  "0.0.0.0",
+								PortSpecifier: &envoycorev2.SocketAddress_PortValue{
+									PortValue: 32000,
+								},
+							},
+						},
+					},
+					FilterChains: []*envoylistenerv2.FilterChain{
+						{
+							Filters: []*envoylistenerv2.Filter{
+								{
+									Name: envoywellknown.RoleBasedAccessControl,
+									ConfigType: &envoylistenerv2.Filter_TypedConfig{
+										TypedConfig: marshalMessage(t, &envoyrbacfilterv2.RBAC{
+											StatPrefix: "allowed_source_ranges",
+											Rules: &envoyrbacv2.RBAC{
+												Action: envoyrbacv2.RBAC_ALLOW,
+												Policies: map[string]*envoyrbacv2.Policy{
+													"allowed-source-ranges": {
+														Permissions: []*envoyrbacv2.Permission{
+															{
+																Rule: &envoyrbacv2.Permission_Any{Any: true},
+															},
+														},
+														Principals: []*envoyrbacv2.Principal{
+															{
+																Identifier: &envoyrbacv2.Principal_SourceIp{
+																	SourceIp: &envoycorev2.CidrRange{
+																		AddressPrefix: "192.0.2.0",
+																		PrefixLen:     &wrappers.UInt32Value{Value: 24},
+																	},
+																},
+															},
+															{
+																Identifier: &envoyrbacv2.Principal_SourceIp{
+																	SourceIp: &envoycorev2.CidrRange{
+																		AddressPrefix: "198.51.100.17",
+																		PrefixLen:     &wrappers.UInt32Value{Value: 32},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										}),
+									},
+								},
+								{
+									Name: envoywellknown.TCPProxy,
+									ConfigType: &envoylistenerv2.Filter_TypedConfig{
+										TypedConfig: marshalMessage(t, &envoytcpfilterv2.TcpProxy{
+											StatPrefix: "ingress_tcp",
+											ClusterSpecifier: &envoytcpfilterv2.TcpProxy_Cluster{
+												Cluster: "cluster-a/apiserver-external-32000",
+											},
+										}),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid-allowed-source-ranges-are-not-exposed",
+			resources: []runtime.Object{
+				newRestrictedTestService("192.0.2.0/24,office"),
+				newSNITestPod("cluster-a", "172.16.0.1"),
+			},
+			expectedClusters: map[string]*envoyv2.Cluster{},
+			expectedListener: map[string]*envoyv2.Listener{},
+		},
 	}
 
 	for _, test := range tests {
@@ -633,3 +809,123 @@ func marshalMessage(t *testing.T, msg proto.Message) *any.Any {
 
 	return marshalled
 }
+
+func newSNITestService(namespace, hostname string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "apiserver-external",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				defaultSNIAnnotationKey: hostname,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "secure",
+					TargetPort: intstr.FromInt(32000),
+					NodePort:   32000,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       443,
+				},
+			},
+			Selector: map[string]string{
+				"app": "apiserver",
+			},
+		},
+	}
+}
+
+func newMultiPortSNITestService(namespace, hostnames string) *corev1.Service {
+	service := newSNITestService(namespace, hostnames)
+	service.Name = "openvpn-server"
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "secure",
+			TargetPort: intstr.FromInt(1194),
+			NodePort:   32001,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       1194,
+		},
+		{
+			Name:       "sni-tunnel",
+			TargetPort: intstr.FromInt(8443),
+			NodePort:   32002,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       443,
+		},
+	}
+	return service
+}
+
+func newRestrictedTestService(allowedSourceRanges string) *corev1.Service {
+	service := newSNITestService("cluster-a", "")
+	service.Annotations = map[string]string{
+		exposeAnnotationKey:              "true",
+		allowedSourceRangesAnnotationKey: allowedSourceRanges,
+	}
+	return service
+}
+
+func newSNITestPod(namespace, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "apiserver",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": "apiserver",
+			},
+		},
+		Status: corev1.PodStatus{
+			PodIP: ip,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+}
+
+func newTestEndpoint(ip string, port uint32) *envoyendpointv2.LbEndpoint {
+	return &envoyendpointv2.LbEndpoint{
+		HostIdentifier: &envoyendpointv2.LbEndpoint_Endpoint{
+			Endpoint: &envoyendpointv2.Endpoint{
+				Address: &envoycorev2.Address{
+					Address: &envoycorev2.Address_SocketAddress{
+						SocketAddress: &envoycorev2.SocketAddress{
+							Protocol: envoycorev2.SocketAddress_TCP,
+							Address:  ip,
+							PortSpecifier: &envoycorev2.SocketAddress_PortValue{
+								PortValue: port,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newTestSNIFilterChain(t *testing.T, clusterName string, hostnames ...string) *envoylistenerv2.FilterChain {
+	return &envoylistenerv2.FilterChain{
+		FilterChainMatch: &envoylistenerv2.FilterChainMatch{
+			ServerNames: hostnames,
+		},
+		Filters: []*envoylistenerv2.Filter{
+			{
+				Name: envoywellknown.TCPProxy,
+				ConfigType: &envoylistenerv2.Filter_TypedConfig{
+					TypedConfig: marshalMessage(t, &envoytcpfilterv2.TcpProxy{
+						StatPrefix: "ingress_tcp",
+						ClusterSpecifier: &envoytcpfilterv2.TcpProxy_Cluster{
+							Cluster: clusterName,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
@@ -18,6 +18,10 @@ package main
 
 import (
 	"fmt"
+	"net"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
 
 	envoycorev2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 
@@ -61,3 +65,49 @@ func getMatchingPodPort(servicePort corev1.ServicePort, pod *corev1.Pod) int32 {
 
 	return 0
 }
+
+// getAllowedSourceRanges returns the source IP ranges the service accepts connections from,
+// all sources are accepted if no ranges are returned
+func getAllowedSourceRanges(service *corev1.Service) ([]*envoycorev2.CidrRange, error) {
+	value := service.Annotations[allowedSourceRangesAnnotationKey]
+	if value == "" {
+		return nil, nil
+	}
+
+	var sourceRanges []*envoycorev2.CidrRange
+	for _, sourceRange := range strings.Split(value, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(sourceRange))
+		if err != nil {
+			return nil, fmt.Errorf("invalid source range %q: %v", sourceRange, err)
+		}
+		prefixLen, _ := ipNet.Mask.Size()
+		sourceRanges = append(sourceRanges, &envoycorev2.CidrRange{
+			AddressPrefix: ipNet.IP.String(),
+			PrefixLen:     &wrappers.UInt32Value{Value: uint32(prefixLen)},
+		})
+	}
+
+	return sourceRanges, nil
+}
+
+// getSNIHostnames returns the hostnames from the comma separated list in the SNI annotation of the service
+func getSNIHostnames(service *corev1.Service) []string {
+	var hostnames []string
+	for _, hostname := range strings.Split(service.Annotations[sniAnnotationKey], ",") {
+		if hostname = strings.TrimSpace(hostname); hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+// getSNIServicePort returns the port to which the connections from the SNI listener are routed.
+// This is the port 443 of the service, or its first port if it has none.
+func getSNIServicePort(service *corev1.Service) corev1.ServicePort {
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Port == 443 {
+			return servicePort
+		}
+	}
+	return service.Spec.Ports[0]
+}
@@ -49,14 +49,20 @@ var (
 	listenAddress       string
 	envoyNodeName       string
 	exposeAnnotationKey string
+	sniAnnotationKey    string
 
-	envoyStatsPort int
-	envoyAdminPort int
+	envoyStatsPort       int
+	envoyAdminPort       int
+	envoySNIListenerPort int
 )
 
 const (
 	defaultExposeAnnotationKey = "nodeport-proxy.k8s.io/expose"
-	clusterConnectTimeout      = 1 * time.Second
+	defaultSNIAnnotationKey    = "nodeport-proxy.k8s.io/expose-sni"
+	sniListenerName            = "sni_listener"
+	// allowedSourceRangesAnnotationKey contains the comma separated source IP ranges a service accepts connections from
+	allowedSourceRangesAnnotationKey = "nodeport-proxy.k8s.io/allowed-source-ranges"
+	clusterConnectTimeout            = 1 * time.Second
 )
 
 func main() {
@@ -69,6 +75,8 @@ func main() {
 	flag.IntVar(&envoyStatsPort, "envoy-stats-port", 8002, "Limited port which should be opened on envoy to expose metrics and the health check. Endpoints are: /healthz & /stats")
 	flag.StringVar(&namespace, "namespace", "", "The namespace we should use for pods and services. Leave empty for all namespaces.")
 	flag.StringVar(&exposeAnnotationKey, "expose-annotation-key", defaultExposeAnnotationKey, "The annotation key used to determine if a service should be exposed")
+	flag.StringVar(&sniAnnotationKey, "sni-annotation-key", defaultSNIAnnotationKey, "The annotation key containing the comma separated SNI hostnames by which the connections to the SNI listener are routed to a service")
+	flag.IntVar(&envoySNIListenerPort, "envoy-sni-listener-port", 6443, "Port of the envoy listener which routes TLS connections to services by the SNI hostname")
 	flag.Parse()
 
 	// setup signal handler
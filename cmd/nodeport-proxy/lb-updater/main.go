@@ -45,7 +45,10 @@ import (
 
 const (
 	defaultExposeAnnotationKey = "nodeport-proxy.k8s.io/expose"
+	defaultSNIAnnotationKey    = "nodeport-proxy.k8s.io/expose-sni"
 	healthCheckPort            = 8002
+	sniPortName                = "sni"
+	sniPort                    = 443
 )
 
 var (
@@ -53,6 +56,9 @@ var (
 	lbNamespace         string
 	namespaced          bool
 	exposeAnnotationKey string
+	sniAnnotationKey    string
+
+	envoySNIListenerPort int
 )
 
 func main() {
@@ -63,6 +69,8 @@ func main() {
 	flag.StringVar(&lbNamespace, "lb-namespace", "nodeport-proxy", "namespace of the LoadBalancer service to manage. Needs to exist")
 	flag.BoolVar(&namespaced, "namespaced", false, "Whether this controller should only watch services in the lbNamespace")
 	flag.StringVar(&exposeAnnotationKey, "expose-annotation-key", defaultExposeAnnotationKey, "The annotation key used to determine if a Service should be exposed")
+	flag.StringVar(&sniAnnotationKey, "sni-annotation-key", defaultSNIAnnotationKey, "The annotation key used to determine if a Service is exposed via SNI")
+	flag.IntVar(&envoySNIListenerPort, "envoy-sni-listener-port", 6443, "Port of the envoy listener which routes TLS connections to services by the SNI hostname")
 	flag.Parse()
 
 	// setup signal handler
@@ -157,9 +165,15 @@ func (u *LBUpdater) syncLB(s string) error {
 		Protocol:   corev1.ProtocolTCP,
 	})
 
+	exposeSNI := false
 	for _, service := range services.Items {
 		serviceLog := u.log.With("namespace", service.Namespace).With("name", service.Name)
 
+		// All services exposed via SNI share the same port of the LB
+		if service.Annotations[sniAnnotationKey] != "" {
+			exposeSNI = true
+		}
+
 		if service.Annotations[exposeAnnotationKey] != "true" {
 			serviceLog.Debugw("Skipping service as the annotation is not set to 'true'", "annotation", exposeAnnotationKey)
 			continue
@@ -188,6 +202,15 @@ func (u *LBUpdater) syncLB(s string) error {
 		}
 	}
 
+	if exposeSNI {
+		wantLBPorts = append(wantLBPorts, corev1.ServicePort{
+			Name:       sniPortName,
+			Port:       sniPort,
+			TargetPort: intstr.FromInt(envoySNIListenerPort),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
 	lb := &corev1.Service{}
 	if err := u.client.Get(u.ctx, types.NamespacedName{Namespace: u.lbNamespace, Name: u.lbName}, lb); err != nil {
 		return fmt.Errorf("failed to get service %s/%s from lister: %v", u.lbNamespace, u.lbName, err)
@@ -245,7 +268,7 @@ func setNodePortAndName(portToSet *corev1.ServicePort, lbPorts []corev1.ServiceP
 	// needed because some LB implementations cannot cope with a config change where only the
 	// nodeport differs.
 	// Additionally we have to compare the name directly, because in the case of the healthCheckPort
-	// the NodePort or Port is not part of the name.
+	// and the sniPort the NodePort or Port is not part of the name.
 	oldSchemaName := fmt.Sprintf("%s-%d-%d", portToSet.Name, portToSet.NodePort, portToSet.Port)
 	newSchemaName := fmt.Sprintf("%s-%d", portToSet.Name, portToSet.Port)
 	for _, lbPort := range lbPorts {
@@ -255,7 +278,7 @@ func setNodePortAndName(portToSet *corev1.ServicePort, lbPorts []corev1.ServiceP
 			return
 		}
 	}
-	if portToSet.Name != "healthz" {
+	if portToSet.Name != "healthz" && portToSet.Name != sniPortName {
 		portToSet.Name = fmt.Sprintf("%s-%d", portToSet.Name, portToSet.Port)
 	}
 	// We must reset the NodePort, it is being abused to carry over the port of the target service
@@ -35,6 +35,8 @@ import (
 
 func init() {
 	exposeAnnotationKey = defaultExposeAnnotationKey
+	sniAnnotationKey = defaultSNIAnnotationKey
+	envoySNIListenerPort = 6443
 }
 
 func TestReconciliation(t *testing.T) {
@@ -515,6 +517,100 @@ func TestReconciliation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Service exposed via SNI opens the SNI port",
+			initialServices: []runtime.Object{
+				&corev1.Service{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "Service",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "cluster",
+						Name:      "apiserver",
+						Annotations: map[string]string{
+							"nodeport-proxy.k8s.io/expose":     "true",
+							"nodeport-proxy.k8s.io/expose-sni": "cluster.seed.example.com",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						ClusterIP: "1.2.3.4",
+						Ports: []corev1.ServicePort{{
+							Port:     443,
+							NodePort: 30443,
+						}},
+					},
+				},
+				&corev1.Service{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "Service",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "lb-ns",
+						Name:      "lb",
+					},
+				},
+			},
+			expectedServices: corev1.ServiceList{
+				Items: []corev1.Service{
+					{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "v1",
+							Kind:       "Service",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "cluster",
+							Name:      "apiserver",
+							Annotations: map[string]string{
+								"nodeport-proxy.k8s.io/expose":     "true",
+								"nodeport-proxy.k8s.io/expose-sni": "cluster.seed.example.com",
+							},
+						},
+						Spec: corev1.ServiceSpec{
+							ClusterIP: "1.2.3.4",
+							Ports: []corev1.ServicePort{{
+								Port:     443,
+								NodePort: 30443,
+							}},
+						},
+					},
+					{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "v1",
+							Kind:       "Service",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "lb-ns",
+							Name:            "lb",
+							ResourceVersion: "1",
+						},
+						Spec: corev1.ServiceSpec{
+							Ports: []corev1.ServicePort{
+								{
+									Name:       "cluster-apiserver-30443",
+									Port:       30443,
+									TargetPort: intstr.FromInt(30443),
+									Protocol:   corev1.ProtocolTCP,
+								},
+								{
+									Name:       "healthz",
+									Port:       8002,
+									TargetPort: intstr.FromInt(8002),
+									Protocol:   corev1.ProtocolTCP,
+								},
+								{
+									Name:       "sni",
+									Port:       443,
+									TargetPort: intstr.FromInt(6443),
+									Protocol:   corev1.ProtocolTCP,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -18,6 +18,7 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/urfave/cli"
@@ -34,8 +35,14 @@ func main() {
 	app.Name = "S3 storer"
 	app.Usage = ""
 	app.Version = "v1.0.0"
-	app.Description = "Helper tool to backup files to S3 and maintain a given number of revisions"
+	app.Description = "Helper tool to backup files to S3, a directory or an SFTP server and maintain a given number of revisions"
 
+	backendFlag := cli.StringFlag{
+		Name:   "backend",
+		Value:  storeuploader.BackendS3,
+		EnvVar: "BACKEND",
+		Usage:  fmt.Sprintf("Storage backend to use, one of [%s %s %s]", storeuploader.BackendS3, storeuploader.BackendFilesystem, storeuploader.BackendSFTP),
+	}
 	endpointFlag := cli.StringFlag{
 		Name:  "endpoint, e",
 		Value: "",
@@ -69,17 +76,78 @@ func main() {
 		Usage: "Path to the file to store in S3",
 	}
 	secureFlag := cli.BoolFlag{
-		Name:  "secure",
-		Usage: "Enable tls validation",
+		Name:   "secure",
+		EnvVar: "SECURE",
+		Usage:  "Enable tls validation",
+	}
+	fsRootFlag := cli.StringFlag{
+		Name:   "fs-root",
+		Value:  "",
+		EnvVar: "FS_ROOT",
+		Usage:  "Directory the filesystem backend stores its buckets in",
+	}
+	sftpAddressFlag := cli.StringFlag{
+		Name:   "sftp-address",
+		Value:  "",
+		EnvVar: "SFTP_ADDRESS",
+		Usage:  "host:port of the SFTP server",
+	}
+	sftpUsernameFlag := cli.StringFlag{
+		Name:   "sftp-username",
+		Value:  "",
+		EnvVar: "SFTP_USERNAME",
+		Usage:  "SFTP username",
+	}
+	sftpPasswordFlag := cli.StringFlag{
+		Name:   "sftp-password",
+		Value:  "",
+		EnvVar: "SFTP_PASSWORD",
+		Usage:  "SFTP password",
+	}
+	sftpPrivateKeyFlag := cli.StringFlag{
+		Name:   "sftp-private-key",
+		Value:  "",
+		EnvVar: "SFTP_PRIVATE_KEY",
+		Usage:  "PEM encoded private key to authenticate at the SFTP server",
+	}
+	sftpHostKeyFlag := cli.StringFlag{
+		Name:   "sftp-host-key",
+		Value:  "",
+		EnvVar: "SFTP_HOST_KEY",
+		Usage:  "Public key of the SFTP server in authorized_keys format",
+	}
+	encryptionKeysDirFlag := cli.StringFlag{
+		Name:   "encryption-keys-dir",
+		Value:  "",
+		EnvVar: "ENCRYPTION_KEYS_DIR",
+		Usage:  "Directory holding the base64 encoded 256 bit encryption keys, one file per key named after the key ID. Encrypted backups are decrypted on download",
+	}
+	encryptionKeyIDFlag := cli.StringFlag{
+		Name:   "encryption-key-id",
+		Value:  "",
+		EnvVar: "ENCRYPTION_KEY_ID",
+		Usage:  "ID of the key to encrypt stored files with. Files are stored unencrypted if not set",
 	}
 	createBucketFlag := cli.BoolFlag{
 		Name:  "create-bucket",
 		Usage: "creates the bucket if it does not exist yet",
 	}
+	objectFlag := cli.StringFlag{
+		Name:  "object, o",
+		Value: "",
+		Usage: "Name of the object in S3 to download",
+	}
+	objectNameFileFlag := cli.StringFlag{
+		Name:   "object-name-file",
+		Value:  "",
+		EnvVar: "OBJECT_NAME_FILE",
+		Usage:  "Optional path to a file the name of the stored object is written to",
+	}
 	maxRevisionsFlag := cli.IntFlag{
-		Name:  "max-revisions",
-		Value: 20,
-		Usage: "Maximum number of revisions of the file to keep in S3. Older ones will be deleted",
+		Name:   "max-revisions",
+		Value:  20,
+		Usage:  "Maximum number of revisions of the file to keep in S3. Older ones will be deleted",
+		EnvVar: "MAX_REVISIONS",
 	}
 
 	logDebugFlag := cli.BoolFlag{
@@ -105,14 +173,46 @@ func main() {
 			Usage:  "Stores the given file on S3",
 			Action: store,
 			Flags: []cli.Flag{
+				backendFlag,
 				endpointFlag,
 				secureFlag,
 				accessKeyIDFlag,
 				secretAccessKeyFlag,
+				fsRootFlag,
+				sftpAddressFlag,
+				sftpUsernameFlag,
+				sftpPasswordFlag,
+				sftpPrivateKeyFlag,
+				sftpHostKeyFlag,
 				bucketFlag,
 				prefixFlag,
 				fileFlag,
 				createBucketFlag,
+				objectNameFileFlag,
+				encryptionKeysDirFlag,
+				encryptionKeyIDFlag,
+			},
+		},
+		{
+			Name:   "download",
+			Usage:  "Downloads the given object from S3 to file",
+			Action: download,
+			Flags: []cli.Flag{
+				backendFlag,
+				endpointFlag,
+				secureFlag,
+				accessKeyIDFlag,
+				secretAccessKeyFlag,
+				fsRootFlag,
+				sftpAddressFlag,
+				sftpUsernameFlag,
+				sftpPasswordFlag,
+				sftpPrivateKeyFlag,
+				sftpHostKeyFlag,
+				bucketFlag,
+				objectFlag,
+				fileFlag,
+				encryptionKeysDirFlag,
 			},
 		},
 		{
@@ -120,10 +220,17 @@ func main() {
 			Usage:  "Deletes backups which are older than max-revisions",
 			Action: deleteOldRevisions,
 			Flags: []cli.Flag{
+				backendFlag,
 				endpointFlag,
 				secureFlag,
 				accessKeyIDFlag,
 				secretAccessKeyFlag,
+				fsRootFlag,
+				sftpAddressFlag,
+				sftpUsernameFlag,
+				sftpPasswordFlag,
+				sftpPrivateKeyFlag,
+				sftpHostKeyFlag,
 				bucketFlag,
 				prefixFlag,
 				maxRevisionsFlag,
@@ -135,10 +242,17 @@ func main() {
 			Usage:  "deletes all backups of the filename",
 			Action: deleteAll,
 			Flags: []cli.Flag{
+				backendFlag,
 				endpointFlag,
 				secureFlag,
 				accessKeyIDFlag,
 				secretAccessKeyFlag,
+				fsRootFlag,
+				sftpAddressFlag,
+				sftpUsernameFlag,
+				sftpPasswordFlag,
+				sftpPrivateKeyFlag,
+				sftpHostKeyFlag,
 				bucketFlag,
 				prefixFlag,
 			},
@@ -172,38 +286,94 @@ func main() {
 }
 
 func getUploaderFromCtx(c *cli.Context) (*storeuploader.StoreUploader, error) {
-	uploader, err := storeuploader.New(
-		c.String("endpoint"),
-		c.Bool("secure"),
-		c.String("access-key-id"),
-		c.String("secret-access-key"),
-		logger,
-	)
+	backend, err := storeuploader.NewBackend(storeuploader.Config{
+		Backend:         c.String("backend"),
+		Endpoint:        c.String("endpoint"),
+		Secure:          c.Bool("secure"),
+		AccessKeyID:     c.String("access-key-id"),
+		SecretAccessKey: c.String("secret-access-key"),
+		Root:            c.String("fs-root"),
+		Address:         c.String("sftp-address"),
+		Username:        c.String("sftp-username"),
+		Password:        c.String("sftp-password"),
+		PrivateKey:      c.String("sftp-private-key"),
+		HostKey:         c.String("sftp-host-key"),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create store uploader: %v", err)
 	}
+	uploader := storeuploader.NewWithBackend(backend, logger)
+
+	if err := setEncryptionFromCtx(c, uploader); err != nil {
+		_ = uploader.Close()
+		return nil, err
+	}
 
 	return uploader, nil
 }
 
+func setEncryptionFromCtx(c *cli.Context, uploader *storeuploader.StoreUploader) error {
+	keysDir := c.String("encryption-keys-dir")
+	if keysDir == "" {
+		if c.String("encryption-key-id") != "" {
+			return fmt.Errorf("--encryption-key-id requires --encryption-keys-dir")
+		}
+		return nil
+	}
+
+	keys, err := storeuploader.LoadKeys(keysDir)
+	if err != nil {
+		return fmt.Errorf("failed to load encryption keys: %v", err)
+	}
+	return uploader.SetEncryption(keys, c.String("encryption-key-id"))
+}
+
 func store(c *cli.Context) error {
 	uploader, err := getUploaderFromCtx(c)
 	if err != nil {
 		return err
 	}
+	defer uploader.Close()
 
-	return uploader.Store(
+	objectName, err := uploader.Store(
 		c.String("file"),
 		c.String("bucket"),
 		c.String("prefix"),
 		c.Bool("create-bucket"),
 	)
+	if err != nil {
+		return err
+	}
+
+	if objectNameFile := c.String("object-name-file"); objectNameFile != "" {
+		if err := ioutil.WriteFile(objectNameFile, []byte(objectName), 0644); err != nil {
+			return fmt.Errorf("failed to write object name: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func download(c *cli.Context) error {
+	uploader, err := getUploaderFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer uploader.Close()
+
+	return uploader.Download(
+		c.String("bucket"),
+		c.String("object"),
+		c.String("file"),
+	)
 }
+
 func deleteOldRevisions(c *cli.Context) error {
 	uploader, err := getUploaderFromCtx(c)
 	if err != nil {
 		return err
 	}
+	defer uploader.Close()
 
 	return uploader.DeleteOldBackups(
 		c.String("bucket"),
@@ -216,6 +386,7 @@ func deleteAll(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	defer uploader.Close()
 
 	return uploader.DeleteAll(
 		c.String("bucket"),
@@ -28,6 +28,9 @@ import (
 	backupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/backup"
 	cloudcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/clustercomponentdefaulter"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcddefrag"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdresize"
+	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdrestore"
 	kubernetescontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/monitoring"
 	openshiftcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/openshift"
@@ -60,6 +63,9 @@ var AllControllers = map[string]controllerCreator{
 	seedresourcesuptodatecondition.ControllerName: createSeedConditionUpToDateController,
 	rancher.ControllerName:                        createRancherController,
 	pvwatcher.ControllerName:                      createPvWatcherController,
+	etcdrestore.ControllerName:                    createEtcdRestoreController,
+	etcddefrag.ControllerName:                     createEtcdDefragController,
+	etcdresize.ControllerName:                     createEtcdResizeController,
 }
 
 type controllerCreator func(*controllerContext) error
@@ -110,6 +116,7 @@ func createCloudController(ctrlCtx *controllerContext) error {
 		ctrlCtx.log,
 		ctrlCtx.runOptions.workerCount,
 		ctrlCtx.seedGetter,
+		ctrlCtx.clientProvider,
 		ctrlCtx.runOptions.workerName,
 	); err != nil {
 		return fmt.Errorf("failed to add cloud controller to mgr: %v", err)
@@ -201,6 +208,7 @@ func createBackupController(ctrlCtx *controllerContext) error {
 		*cleanupContainer,
 		backupInterval,
 		ctrlCtx.runOptions.backupContainerImage,
+		ctrlCtx.seedGetter,
 	)
 }
 
@@ -313,3 +321,32 @@ func createPvWatcherController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.workerName)
 
 }
+
+func createEtcdRestoreController(ctrlCtx *controllerContext) error {
+	return etcdrestore.Add(
+		ctrlCtx.log,
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.seedGetter,
+	)
+}
+
+func createEtcdDefragController(ctrlCtx *controllerContext) error {
+	return etcddefrag.Add(
+		ctrlCtx.log,
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.seedGetter,
+	)
+}
+
+func createEtcdResizeController(ctrlCtx *controllerContext) error {
+	return etcdresize.Add(
+		ctrlCtx.log,
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+	)
+}
@@ -72,6 +72,7 @@ type controllerRunOptions struct {
 	namespace                     string
 	clusterURL                    string
 	openvpnServerPort             int
+	sniTunnelAPIServerPort        int
 	overwriteRegistry             string
 	cloudProviderName             string
 	cloudCredentialSecretTemplate string
@@ -101,6 +102,7 @@ func main() {
 	flag.StringVar(&runOp.clusterURL, "cluster-url", "", "Cluster URL")
 	flag.StringVar(&runOp.dnsClusterIP, "dns-cluster-ip", "", "KubeDNS service IP for the cluster")
 	flag.IntVar(&runOp.openvpnServerPort, "openvpn-server-port", 0, "OpenVPN server port")
+	flag.IntVar(&runOp.sniTunnelAPIServerPort, "sni-tunnel-apiserver-port", 0, "Port the apiserver advertises for the kubernetes service. If set, the apiserver and the OpenVPN server are reached through the SNI tunnel agent")
 	flag.StringVar(&runOp.overwriteRegistry, "overwrite-registry", "", "registry to use for all images")
 	flag.StringVar(&runOp.cloudProviderName, "cloud-provider-name", "", "Name of the cloudprovider")
 	flag.StringVar(&runOp.cloudCredentialSecretTemplate, "cloud-credential-secret-template", "", "A serialized Kubernetes secret whose Name and Data fields will be used to create a secret for the openshift cloud credentials operator.")
@@ -222,6 +224,7 @@ func main() {
 		runOp.cloudProviderName,
 		clusterURL,
 		runOp.openvpnServerPort,
+		runOp.sniTunnelAPIServerPort,
 		healthHandler.AddReadinessCheck,
 		cloudCredentialSecretTemplate,
 		runOp.openshiftConsoleCallbackURI,
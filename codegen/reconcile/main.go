@@ -60,6 +60,11 @@ func main() {
 				ImportAlias:  "corev1",
 				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
 			},
+			{
+				ResourceName: "ResourceQuota",
+				ImportAlias:  "corev1",
+				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
+			},
 			{
 				ResourceName:       "StatefulSet",
 				ImportAlias:        "appsv1",
@@ -89,6 +94,12 @@ func main() {
 				ImportAlias:        "autoscalingv1beta2",
 				ResourceImportPath: "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2",
 			},
+			{
+				ResourceName:       "NetworkPolicy",
+				ResourceNamePlural: "NetworkPolicies",
+				ImportAlias:        "networkingv1",
+				ResourceImportPath: "k8s.io/api/networking/v1",
+			},
 			{
 				ResourceName:       "ClusterRoleBinding",
 				ImportAlias:        "rbacv1",
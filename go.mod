@@ -53,6 +53,7 @@ require (
 	github.com/onsi/ginkgo v1.14.0
 	github.com/packethost/packngo v0.1.1-0.20190410075950-a02c426e4888
 	github.com/pkg/errors v0.9.1
+	github.com/pkg/sftp v1.10.1
 	github.com/pmezard/go-difflib v1.0.0
 	github.com/poy/onpar v0.0.0-20200406201722-06f95a1c68e8 // indirect
 	github.com/prometheus/client_golang v1.7.1
@@ -90,6 +90,9 @@ type DatacenterSpec struct {
 	// EnforceAuditLogging enforces audit logging on every cluster within the DC,
 	// ignoring cluster-specific settings.
 	EnforceAuditLogging bool `json:"enforceAuditLogging"`
+	// EnforcedAuditPolicyPreset is the minimum audit policy preset of every cluster within
+	// the DC. Only used if EnforceAuditLogging is set.
+	EnforcedAuditPolicyPreset kubermaticv1.AuditPolicyPreset `json:"enforcedAuditPolicyPreset,omitempty"`
 
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
@@ -487,6 +490,15 @@ type PublicServiceAccountToken struct {
 	// Expiry is a timestamp representing the time when this token will expire.
 	// swagger:strfmt date-time
 	Expiry Time `json:"expiry,omitempty"`
+	// Scopes restricts the API routes the token can be used for, e.g. "GET /api/v1/projects/{project_id}/clusters".
+	// The token can be used for all routes if no scopes are set.
+	Scopes []string `json:"scopes,omitempty"`
+	// LastUsed is a timestamp representing the time when this token was last used.
+	// swagger:strfmt date-time
+	LastUsed *Time `json:"lastUsed,omitempty"`
+	// RevokeAt is a timestamp representing the time when this token will be revoked because it was rotated.
+	// swagger:strfmt date-time
+	RevokeAt *Time `json:"revokeAt,omitempty"`
 }
 
 // ServiceAccountToken represent an API service account token
@@ -497,6 +509,14 @@ type ServiceAccountToken struct {
 	Token string `json:"token,omitempty"`
 }
 
+// ServiceAccountTokenRotation defines how a service account token is rotated
+// swagger:model ServiceAccountTokenRotation
+type ServiceAccountTokenRotation struct {
+	// GracePeriodSeconds is the time the rotated token stays valid after the replacement was issued.
+	// Defaults to one hour.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
 // Project is a top-level container for a set of resources
 // swagger:model Project
 type Project struct {
@@ -599,6 +619,27 @@ type VSphereFolder struct {
 	Path string `json:"path"`
 }
 
+// VSphereResourcePool is the object representing a vsphere resource pool.
+// swagger:model VSphereResourcePool
+type VSphereResourcePool struct {
+	// Path is the path of the resource pool
+	Path string `json:"path"`
+}
+
+// VSphereTagCategory is the object representing a vsphere tag category.
+// swagger:model VSphereTagCategory
+type VSphereTagCategory struct {
+	// ID is the ID of the tag category
+	ID string `json:"id"`
+	// Name is the name of the tag category
+	Name string `json:"name"`
+	// Description is the description of the tag category
+	Description string `json:"description"`
+	// Cardinality is either SINGLE or MULTIPLE and defines how many tags
+	// of the category can be attached to an object
+	Cardinality string `json:"cardinality"`
+}
+
 // AlibabaInstanceTypeList represents an array of Alibaba instance types.
 // swagger:model AlibabaInstanceTypeList
 type AlibabaInstanceTypeList []AlibabaInstanceType
@@ -630,6 +671,23 @@ type MasterVersion struct {
 	// If true, then given version control plane version is not compatible
 	// with one of the kubelets inside cluster and shouldn't be used.
 	RestrictedByKubeletVersion bool `json:"restrictedByKubeletVersion,omitempty"`
+
+	// UpgradeBlockers lists the issues found in the cluster that have to be
+	// resolved before the control plane can be updated to this version.
+	// Resources using a removed API version are only found if they were
+	// applied with kubectl apply, as the version is taken from their
+	// last-applied-configuration annotation. An empty list does not guarantee
+	// that no client uses removed APIs.
+	UpgradeBlockers []UpgradeBlocker `json:"upgradeBlockers,omitempty"`
+}
+
+// UpgradeBlocker describes an issue which prevents a control plane update
+// swagger:model UpgradeBlocker
+type UpgradeBlocker struct {
+	// Check is the name of the pre-flight check which reported the issue
+	Check string `json:"check"`
+	// Message describes the issue
+	Message string `json:"message"`
 }
 
 // CreateClusterSpec is the structure that is used to create cluster with its initial node deployment
@@ -639,6 +697,32 @@ type CreateClusterSpec struct {
 	NodeDeployment *NodeDeployment `json:"nodeDeployment,omitempty"`
 }
 
+// ClusterTemplate represents a template of a cluster and its initial node deployments
+// swagger:model ClusterTemplate
+type ClusterTemplate struct {
+	ObjectMeta `json:",inline"`
+	// Scope is one of user, project or global
+	Scope string `json:"scope"`
+	// ProjectID is empty for global templates
+	ProjectID string `json:"projectID,omitempty"`
+	// User is the email of the template creator
+	User string `json:"user,omitempty"`
+
+	Cluster         *Cluster          `json:"cluster"`
+	NodeDeployments []*NodeDeployment `json:"nodeDeployments,omitempty"`
+}
+
+// ClusterTemplateList represents a list of cluster templates
+// swagger:model ClusterTemplateList
+type ClusterTemplateList []ClusterTemplate
+
+// ClusterTemplateInstances is the structure that is used to create clusters from a template
+// swagger:model ClusterTemplateInstances
+type ClusterTemplateInstances struct {
+	// Replicas is the number of clusters created from the template, at most 10
+	Replicas int32 `json:"replicas"`
+}
+
 const (
 	// OpenShiftClusterType defines the OpenShift cluster type
 	OpenShiftClusterType string = "openshift"
@@ -680,6 +764,9 @@ type ClusterSpec struct {
 	// Configure cluster upgrade window, currently used for coreos node reboots
 	UpdateWindow *kubermaticv1.UpdateWindow `json:"updateWindow,omitempty"`
 
+	// EtcdBackup overrides the etcd backup schedule and retention of the seed
+	EtcdBackup *kubermaticv1.EtcdBackupSettings `json:"etcdBackup,omitempty"`
+
 	// If active the PodSecurityPolicy admission plugin is configured at the apiserver
 	UsePodSecurityPolicyAdmissionPlugin bool `json:"usePodSecurityPolicyAdmissionPlugin,omitempty"`
 
@@ -692,6 +779,14 @@ type ClusterSpec struct {
 	// AuditLogging
 	AuditLogging *kubermaticv1.AuditLoggingSettings `json:"auditLogging,omitempty"`
 
+	// APIServerAllowedIPRanges restricts the source IP ranges in CIDR notation which may reach the
+	// apiserver, all sources are allowed if empty. The node and master egress ranges are always allowed.
+	APIServerAllowedIPRanges []string `json:"apiServerAllowedIPRanges,omitempty"`
+
+	// NodeEgressIPRanges are the source IP ranges in CIDR notation the worker nodes reach the
+	// apiserver from. They are required if APIServerAllowedIPRanges is set.
+	NodeEgressIPRanges []string `json:"nodeEgressIPRanges,omitempty"`
+
 	// Openshift holds all openshift-specific settings
 	Openshift *kubermaticv1.Openshift `json:"openshift,omitempty"`
 }
@@ -705,10 +800,13 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		Version                             ksemver.Semver                         `json:"version"`
 		OIDC                                kubermaticv1.OIDCSettings              `json:"oidc"`
 		UpdateWindow                        *kubermaticv1.UpdateWindow             `json:"updateWindow,omitempty"`
+		EtcdBackup                          *kubermaticv1.EtcdBackupSettings       `json:"etcdBackup,omitempty"`
 		UsePodSecurityPolicyAdmissionPlugin bool                                   `json:"usePodSecurityPolicyAdmissionPlugin,omitempty"`
 		UsePodNodeSelectorAdmissionPlugin   bool                                   `json:"usePodNodeSelectorAdmissionPlugin,omitempty"`
 		AuditLogging                        *kubermaticv1.AuditLoggingSettings     `json:"auditLogging,omitempty"`
 		AdmissionPlugins                    []string                               `json:"admissionPlugins,omitempty"`
+		APIServerAllowedIPRanges            []string                               `json:"apiServerAllowedIPRanges,omitempty"`
+		NodeEgressIPRanges                  []string                               `json:"nodeEgressIPRanges,omitempty"`
 	}{
 		Cloud: PublicCloudSpec{
 			DatacenterName: cs.Cloud.DatacenterName,
@@ -729,10 +827,13 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		MachineNetworks:                     cs.MachineNetworks,
 		OIDC:                                cs.OIDC,
 		UpdateWindow:                        cs.UpdateWindow,
+		EtcdBackup:                          cs.EtcdBackup,
 		UsePodSecurityPolicyAdmissionPlugin: cs.UsePodSecurityPolicyAdmissionPlugin,
 		UsePodNodeSelectorAdmissionPlugin:   cs.UsePodNodeSelectorAdmissionPlugin,
 		AuditLogging:                        cs.AuditLogging,
 		AdmissionPlugins:                    cs.AdmissionPlugins,
+		APIServerAllowedIPRanges:            cs.APIServerAllowedIPRanges,
+		NodeEgressIPRanges:                  cs.NodeEgressIPRanges,
 	})
 
 	return ret, err
@@ -913,6 +1014,85 @@ type ClusterStatus struct {
 
 	// URL specifies the address at which the cluster is available
 	URL string `json:"url"`
+
+	// EtcdBackup contains the outcome of the most recent etcd backups of the cluster
+	EtcdBackup *kubermaticv1.EtcdBackupStatus `json:"etcdBackup,omitempty"`
+
+	// ExternalCluster contains the health and node inventory of an imported cluster
+	ExternalCluster *ExternalClusterStatus `json:"externalCluster,omitempty"`
+}
+
+// ExternalClusterStatus contains the state of an imported cluster as last observed by the
+// external cluster controller
+// swagger:model ExternalClusterStatus
+type ExternalClusterStatus struct {
+	// Reachable is true if the last health check could connect to the cluster
+	Reachable bool `json:"reachable"`
+	// NodeCount is the number of nodes of the cluster
+	NodeCount int `json:"nodeCount"`
+	// Allocatable is the sum of the allocatable resources of all nodes
+	Allocatable NodeResources `json:"allocatable"`
+	// LastError is the error of the last health check
+	LastError string `json:"lastError,omitempty"`
+	// LastCheckTime is the time of the last health check
+	LastCheckTime *Time `json:"lastCheckTime,omitempty"`
+	// LastHeartbeatTime is the time the cluster was reachable for the last time
+	LastHeartbeatTime *Time `json:"lastHeartbeatTime,omitempty"`
+	// KubeconfigUpdate records the last change of the kubeconfig by a user
+	KubeconfigUpdate *ExternalClusterKubeconfigUpdate `json:"kubeconfigUpdate,omitempty"`
+}
+
+// ExternalClusterKubeconfigUpdate records who changed the kubeconfig of an imported cluster and when
+// swagger:model ExternalClusterKubeconfigUpdate
+type ExternalClusterKubeconfigUpdate struct {
+	// User is the email of the user who changed the kubeconfig
+	User string `json:"user"`
+	// Time is the time the kubeconfig was changed
+	Time Time `json:"time"`
+}
+
+// ExternalClusterCloudSpec specifies the managed Kubernetes service an external cluster is imported from.
+// Only one of the providers may be set.
+// swagger:model ExternalClusterCloudSpec
+type ExternalClusterCloudSpec struct {
+	EKS *EKSCloudSpec `json:"eks,omitempty"`
+	GKE *GKECloudSpec `json:"gke,omitempty"`
+	AKS *AKSCloudSpec `json:"aks,omitempty"`
+}
+
+// EKSCloudSpec specifies an Amazon EKS cluster and the credentials to access it
+// swagger:model EKSCloudSpec
+type EKSCloudSpec struct {
+	// Name is the name of the cluster in AWS
+	Name   string `json:"name"`
+	Region string `json:"region"`
+
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+}
+
+// GKECloudSpec specifies a Google GKE cluster and the credentials to access it
+// swagger:model GKECloudSpec
+type GKECloudSpec struct {
+	// Name is the name of the cluster in GCP
+	Name string `json:"name"`
+	Zone string `json:"zone"`
+
+	// ServiceAccount is the base64 encoded service account, the cluster is looked up in its project
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// AKSCloudSpec specifies an Azure AKS cluster and the credentials to access it
+// swagger:model AKSCloudSpec
+type AKSCloudSpec struct {
+	// Name is the name of the cluster in Azure
+	Name          string `json:"name"`
+	ResourceGroup string `json:"resourceGroup"`
+
+	TenantID       string `json:"tenantId,omitempty"`
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+	ClientID       string `json:"clientId,omitempty"`
+	ClientSecret   string `json:"clientSecret,omitempty"`
 }
 
 // ClusterHealth stores health information about the cluster's components.
@@ -1354,6 +1534,20 @@ type NodeDeploymentSpec struct {
 	DynamicConfig *bool `json:"dynamicConfig,omitempty"`
 }
 
+// EtcdBackup is an etcd snapshot of a cluster stored in the backup bucket of its seed.
+// swagger:model EtcdBackup
+type EtcdBackup struct {
+	// Name is the key of the snapshot object in the bucket.
+	Name string `json:"name"`
+
+	// Size of the snapshot in bytes.
+	Size int64 `json:"size"`
+
+	// The time at which the snapshot was stored.
+	// swagger:strfmt date-time
+	Timestamp Time `json:"timestamp"`
+}
+
 // Event is a report of an event somewhere in the cluster.
 // swagger:model Event
 type Event struct {
@@ -1516,6 +1710,13 @@ type AdmissionPlugin struct {
 	FromVersion *ksemver.Semver `json:"fromVersion,omitempty"`
 }
 
+// Preset represents a preset with the credentials of cloud providers
+// swagger:model Preset
+type Preset struct {
+	Name string                  `json:"name"`
+	Spec kubermaticv1.PresetSpec `json:"spec"`
+}
+
 // Seed represents a seed object
 // swagger:model Seed
 type Seed struct {
@@ -18,14 +18,19 @@ package externalcluster
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/semver"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -44,24 +49,50 @@ const (
 	ControllerName = "external_cluster_controller"
 )
 
+// clusterInspector reads the state of an external cluster
+type clusterInspector interface {
+	GetVersion(cluster *kubermaticv1.ExternalCluster) (*semver.Semver, error)
+	ListNodes(cluster *kubermaticv1.ExternalCluster) (*corev1.NodeList, error)
+	GetKubeSystemUID(cluster *kubermaticv1.ExternalCluster) (types.UID, error)
+}
+
+// kubeconfigWriter stores the kubeconfig of an external cluster
+type kubeconfigWriter interface {
+	CreateOrUpdateKubeconfigSecretForCluster(ctx context.Context, cluster *kubermaticv1.ExternalCluster, kubeconfig string) error
+}
+
 // Reconciler is a controller which is responsible for managing clusters
 type Reconciler struct {
 	ctx context.Context
 	ctrlruntimeclient.Client
-	log *zap.SugaredLogger
+	log                 *zap.SugaredLogger
+	inspector           clusterInspector
+	kubeconfigGenerator kubeconfigGenerator
+	kubeconfigWriter    kubeconfigWriter
+	healthCheckInterval time.Duration
 }
 
 // Add creates a cluster controller.
 func Add(
 	ctx context.Context,
 	mgr manager.Manager,
-	log *zap.SugaredLogger) error {
+	log *zap.SugaredLogger,
+	numWorkers int,
+	healthCheckInterval time.Duration) error {
+	externalClusterProvider, err := kubernetes.NewExternalClusterProvider(nil, mgr.GetClient())
+	if err != nil {
+		return err
+	}
 	reconciler := &Reconciler{
-		log:    log.Named(ControllerName),
-		Client: mgr.GetClient(),
-		ctx:    ctx,
+		log:                 log.Named(ControllerName),
+		Client:              mgr.GetClient(),
+		ctx:                 ctx,
+		inspector:           externalClusterProvider,
+		kubeconfigGenerator: &cloudKubeconfigGenerator{client: mgr.GetClient()},
+		kubeconfigWriter:    externalClusterProvider,
+		healthCheckInterval: healthCheckInterval,
 	}
-	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler})
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
 	if err != nil {
 		return err
 	}
@@ -90,18 +121,150 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	if icl.DeletionTimestamp != nil {
 		if kuberneteshelper.HasOnlyFinalizer(icl, kubermaticapiv1.ExternalClusterKubeconfigCleanupFinalizer) {
-			if err := r.cleanUpKubeconfigSecret(icl); err != nil {
+			if err := r.cleanUpSecrets(icl); err != nil {
 				log.Errorf("Could not delete kubeconfig secret, %v", err)
 				return reconcile.Result{}, err
 			}
 		}
+		return reconcile.Result{}, nil
+	}
+
+	// The kubeconfig of clusters imported from a managed Kubernetes service expires, it is
+	// regenerated before the health check. A new kubeconfig is checked immediately.
+	refreshed := false
+	if icl.Spec.CloudSpec != nil && !time.Now().Before(icl.Status.KubeconfigRefreshTime.Time) {
+		var err error
+		if refreshed, err = r.refreshKubeconfig(icl); err != nil {
+			log.Errorf("Could not refresh the kubeconfig, %v", err)
+			return reconcile.Result{}, err
+		}
+	}
+
+	if icl.Spec.KubeconfigReference == nil {
+		return reconcile.Result{RequeueAfter: r.requeueAfter(icl)}, nil
+	}
+
+	// The status update triggers another reconciliation, only check the cluster once per interval.
+	// A kubeconfig replaced by a user is checked immediately.
+	rotated := icl.Status.KubeconfigUpdate != nil && icl.Status.KubeconfigUpdate.Time.After(icl.Status.LastCheckTime.Time)
+	if nextCheck := icl.Status.LastCheckTime.Add(r.healthCheckInterval); !refreshed && !rotated && time.Now().Before(nextCheck) {
+		return reconcile.Result{RequeueAfter: r.requeueAfter(icl)}, nil
+	}
+
+	if err := r.updateStatus(icl); err != nil {
+		log.Errorf("Could not update the cluster status, %v", err)
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: r.requeueAfter(icl)}, nil
+}
+
+// requeueAfter returns the time until the next health check or kubeconfig refresh is due.
+func (r *Reconciler) requeueAfter(cluster *kubermaticv1.ExternalCluster) time.Duration {
+	requeueAfter := time.Until(cluster.Status.LastCheckTime.Add(r.healthCheckInterval))
+	if cluster.Spec.CloudSpec != nil {
+		if untilRefresh := time.Until(cluster.Status.KubeconfigRefreshTime.Time); untilRefresh < requeueAfter {
+			requeueAfter = untilRefresh
+		}
+	}
+	// The check or refresh is due already but was skipped, e.g. because it failed.
+	if requeueAfter <= 0 {
+		return r.healthCheckInterval
 	}
+	return requeueAfter
+}
+
+// refreshKubeconfig generates a new kubeconfig for a cluster imported from a managed Kubernetes service
+// and stores it in the kubeconfig secret of the cluster. Failing to generate the kubeconfig is recorded
+// in the status and retried after the health check interval.
+func (r *Reconciler) refreshKubeconfig(cluster *kubermaticv1.ExternalCluster) (bool, error) {
+	oldCluster := cluster.DeepCopy()
+	now := metav1.Now()
 
-	return reconcile.Result{}, nil
+	refreshed := true
+	if err := r.generateKubeconfig(cluster); err != nil {
+		refreshed = false
+		cluster.Status.Reachable = false
+		cluster.Status.LastError = err.Error()
+		cluster.Status.LastCheckTime = now
+		cluster.Status.KubeconfigRefreshTime = metav1.NewTime(now.Add(r.healthCheckInterval))
+	}
+
+	return refreshed, r.Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
 }
 
-func (r *Reconciler) cleanUpKubeconfigSecret(cluster *kubermaticv1.ExternalCluster) error {
-	if err := r.deleteSecret(cluster); err != nil {
+func (r *Reconciler) generateKubeconfig(cluster *kubermaticv1.ExternalCluster) error {
+	kubeconfig, refreshTime, err := r.kubeconfigGenerator.GenerateKubeconfig(r.ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to generate the kubeconfig: %v", err)
+	}
+	if err := r.kubeconfigWriter.CreateOrUpdateKubeconfigSecretForCluster(r.ctx, cluster, base64.StdEncoding.EncodeToString(kubeconfig)); err != nil {
+		return fmt.Errorf("failed to store the kubeconfig: %v", err)
+	}
+
+	cluster.Status.KubeconfigRefreshTime = metav1.NewTime(refreshTime)
+	return nil
+}
+
+// updateStatus connects to the cluster and stores its health, version and node inventory.
+// Failing to reach the cluster is recorded in the status and not returned as error.
+func (r *Reconciler) updateStatus(cluster *kubermaticv1.ExternalCluster) error {
+	oldCluster := cluster.DeepCopy()
+	now := metav1.Now()
+	status := &cluster.Status
+	status.LastCheckTime = now
+
+	if err := r.inspectCluster(cluster); err != nil {
+		status.Reachable = false
+		status.LastError = err.Error()
+	} else {
+		status.Reachable = true
+		status.LastError = ""
+		status.LastHeartbeatTime = now
+	}
+
+	return r.Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
+}
+
+func (r *Reconciler) inspectCluster(cluster *kubermaticv1.ExternalCluster) error {
+	version, err := r.inspector.GetVersion(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get the version: %v", err)
+	}
+	nodes, err := r.inspector.ListNodes(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to list the nodes: %v", err)
+	}
+
+	allocatable := corev1.ResourceList{}
+	for _, node := range nodes.Items {
+		for name, quantity := range node.Status.Allocatable {
+			sum := allocatable[name]
+			sum.Add(quantity)
+			allocatable[name] = sum
+		}
+	}
+
+	uid, err := r.inspector.GetKubeSystemUID(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to identify the cluster: %v", err)
+	}
+	if cluster.Status.KubeSystemUID != "" && cluster.Status.KubeSystemUID != uid {
+		return errors.New("the kubeconfig belongs to a different cluster")
+	}
+
+	cluster.Status.KubeSystemUID = uid
+	cluster.Status.Version = version
+	cluster.Status.NodeCount = len(nodes.Items)
+	cluster.Status.Allocatable = allocatable
+	return nil
+}
+
+func (r *Reconciler) cleanUpSecrets(cluster *kubermaticv1.ExternalCluster) error {
+	if err := r.deleteSecret(cluster.GetKubeconfigSecretName()); err != nil {
+		return err
+	}
+	if err := r.deleteSecret(cluster.GetCredentialsSecretName()); err != nil {
 		return err
 	}
 
@@ -110,8 +273,7 @@ func (r *Reconciler) cleanUpKubeconfigSecret(cluster *kubermaticv1.ExternalClust
 	return r.Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
 }
 
-func (r *Reconciler) deleteSecret(cluster *kubermaticv1.ExternalCluster) error {
-	secretName := cluster.GetKubeconfigSecretName()
+func (r *Reconciler) deleteSecret(secretName string) error {
 	if secretName == "" {
 		return nil
 	}
@@ -18,21 +18,29 @@ package externalcluster
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"testing"
+	"time"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/semver"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -97,6 +105,330 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileHealthCheck(t *testing.T) {
+	nodes := &corev1.NodeList{
+		Items: []corev1.Node{
+			genNode("node-1", "2", "4Gi"),
+			genNode("node-2", "500m", "1Gi"),
+		},
+	}
+
+	tests := []struct {
+		name              string
+		lastCheckTime     metav1.Time
+		kubeconfigUpdate  *kubermaticv1.ExternalClusterKubeconfigUpdate
+		kubeSystemUID     types.UID
+		inspector         *fakeInspector
+		expectedReachable bool
+		expectedError     string
+		expectedNodeCount int
+		expectedCPU       string
+		expectedMemory    string
+		expectedChecked   bool
+	}{
+		{
+			name:              "scenario 1: the status of a reachable cluster is stored",
+			inspector:         &fakeInspector{version: semver.NewSemverOrDie("v1.19.2"), nodes: nodes},
+			expectedReachable: true,
+			expectedNodeCount: 2,
+			expectedCPU:       "2500m",
+			expectedMemory:    "5Gi",
+			expectedChecked:   true,
+		},
+		{
+			name:            "scenario 2: the error of an unreachable cluster is stored",
+			inspector:       &fakeInspector{err: errors.New("connection refused")},
+			expectedError:   "failed to get the version: connection refused",
+			expectedChecked: true,
+		},
+		{
+			name:            "scenario 3: the cluster is not checked again within the interval",
+			lastCheckTime:   metav1.NewTime(time.Now().Add(-time.Minute)),
+			inspector:       &fakeInspector{version: semver.NewSemverOrDie("v1.19.2"), nodes: nodes},
+			expectedChecked: false,
+		},
+		{
+			name:              "scenario 4: a replaced kubeconfig is checked immediately",
+			lastCheckTime:     metav1.NewTime(time.Now().Add(-time.Minute)),
+			kubeconfigUpdate:  &kubermaticv1.ExternalClusterKubeconfigUpdate{User: "bob@acme.com", Time: metav1.Now()},
+			inspector:         &fakeInspector{version: semver.NewSemverOrDie("v1.19.2"), nodes: nodes, uid: "kube-system-uid"},
+			expectedReachable: true,
+			expectedNodeCount: 2,
+			expectedCPU:       "2500m",
+			expectedMemory:    "5Gi",
+			expectedChecked:   true,
+		},
+		{
+			name:            "scenario 5: a kubeconfig of a different cluster is reported",
+			kubeSystemUID:   "other-cluster",
+			inspector:       &fakeInspector{version: semver.NewSemverOrDie("v1.19.2"), nodes: nodes, uid: "kube-system-uid"},
+			expectedError:   "the kubeconfig belongs to a different cluster",
+			expectedChecked: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := genExternalCluster("test", metav1.Now())
+			cluster.DeletionTimestamp = nil
+			cluster.Status.LastCheckTime = test.lastCheckTime
+			cluster.Status.KubeconfigUpdate = test.kubeconfigUpdate
+			cluster.Status.KubeSystemUID = test.kubeSystemUID
+			kubermaticFakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, cluster)
+
+			target := Reconciler{
+				ctx:                 context.Background(),
+				Client:              kubermaticFakeClient,
+				log:                 kubermaticlog.Logger,
+				inspector:           test.inspector,
+				healthCheckInterval: 5 * time.Minute,
+			}
+
+			result, err := target.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.RequeueAfter <= 0 || result.RequeueAfter > 5*time.Minute {
+				t.Fatalf("expected the cluster to be requeued within the interval, but got %v", result.RequeueAfter)
+			}
+
+			if err := kubermaticFakeClient.Get(context.TODO(), client.ObjectKey{Name: "test"}, cluster); err != nil {
+				t.Fatal(err)
+			}
+			if test.inspector.called != test.expectedChecked {
+				t.Fatalf("expected the cluster to be checked: %t", test.expectedChecked)
+			}
+			if !test.expectedChecked {
+				return
+			}
+
+			status := cluster.Status
+			if status.Reachable != test.expectedReachable {
+				t.Errorf("expected reachable %t, but got %t", test.expectedReachable, status.Reachable)
+			}
+			if status.LastError != test.expectedError {
+				t.Errorf("expected error %q, but got %q", test.expectedError, status.LastError)
+			}
+			if status.LastCheckTime.IsZero() {
+				t.Error("expected the check time to be set")
+			}
+			if !test.expectedReachable {
+				return
+			}
+			if status.Version.String() != "1.19.2" {
+				t.Errorf("expected version 1.19.2, but got %s", status.Version)
+			}
+			if status.NodeCount != test.expectedNodeCount {
+				t.Errorf("expected %d nodes, but got %d", test.expectedNodeCount, status.NodeCount)
+			}
+			if cpu := status.Allocatable[corev1.ResourceCPU]; cpu.String() != test.expectedCPU {
+				t.Errorf("expected allocatable cpu %s, but got %s", test.expectedCPU, cpu.String())
+			}
+			if memory := status.Allocatable[corev1.ResourceMemory]; memory.String() != test.expectedMemory {
+				t.Errorf("expected allocatable memory %s, but got %s", test.expectedMemory, memory.String())
+			}
+		})
+	}
+}
+
+func TestReconcileKubeconfigRefresh(t *testing.T) {
+	refreshTime := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+
+	tests := []struct {
+		name                  string
+		refreshTime           metav1.Time
+		lastCheckTime         metav1.Time
+		generator             *fakeKubeconfigGenerator
+		expectedGenerated     bool
+		expectedChecked       bool
+		expectedError         string
+		expectedRequeueBefore time.Duration
+	}{
+		{
+			name:                  "scenario 1: the kubeconfig of an imported cluster is generated and checked",
+			generator:             &fakeKubeconfigGenerator{refreshTime: refreshTime},
+			expectedGenerated:     true,
+			expectedChecked:       true,
+			expectedRequeueBefore: 5 * time.Minute,
+		},
+		{
+			name:                  "scenario 2: the error of a failed kubeconfig generation is stored",
+			generator:             &fakeKubeconfigGenerator{err: errors.New("access denied")},
+			expectedGenerated:     false,
+			expectedChecked:       false,
+			expectedError:         "failed to generate the kubeconfig: access denied",
+			expectedRequeueBefore: 5 * time.Minute,
+		},
+		{
+			name:                  "scenario 3: a valid kubeconfig is not generated again",
+			refreshTime:           metav1.NewTime(time.Now().Add(time.Minute)),
+			lastCheckTime:         metav1.NewTime(time.Now().Add(-time.Minute)),
+			generator:             &fakeKubeconfigGenerator{refreshTime: refreshTime},
+			expectedGenerated:     false,
+			expectedChecked:       false,
+			expectedRequeueBefore: time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := genExternalCluster("test", metav1.Now())
+			cluster.DeletionTimestamp = nil
+			cluster.Spec.KubeconfigReference = nil
+			cluster.Spec.CloudSpec = &kubermaticv1.ExternalClusterCloudSpec{
+				EKS: &kubermaticv1.ExternalClusterEKSCloudSpec{
+					Name:   "my-cluster",
+					Region: "eu-central-1",
+					CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+						ObjectReference: corev1.ObjectReference{
+							Namespace: resources.KubermaticNamespace,
+							Name:      cluster.GetCredentialsSecretName(),
+						},
+					},
+				},
+			}
+			cluster.Status.KubeconfigRefreshTime = test.refreshTime
+			cluster.Status.LastCheckTime = test.lastCheckTime
+			if !test.refreshTime.IsZero() {
+				cluster.Spec.KubeconfigReference = &providerconfig.GlobalSecretKeySelector{
+					ObjectReference: corev1.ObjectReference{
+						Namespace: resources.KubermaticNamespace,
+						Name:      cluster.GetKubeconfigSecretName(),
+					},
+				}
+			}
+			kubermaticFakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, cluster)
+			kubeconfigWriter, err := kubernetes.NewExternalClusterProvider(nil, kubermaticFakeClient)
+			if err != nil {
+				t.Fatal(err)
+			}
+			inspector := &fakeInspector{version: semver.NewSemverOrDie("v1.18.8"), nodes: &corev1.NodeList{}}
+
+			target := Reconciler{
+				ctx:                 context.Background(),
+				Client:              kubermaticFakeClient,
+				log:                 kubermaticlog.Logger,
+				inspector:           inspector,
+				kubeconfigGenerator: test.generator,
+				kubeconfigWriter:    kubeconfigWriter,
+				healthCheckInterval: 5 * time.Minute,
+			}
+
+			result, err := target.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.RequeueAfter <= 0 || result.RequeueAfter > test.expectedRequeueBefore {
+				t.Fatalf("expected the cluster to be requeued within %v, but got %v", test.expectedRequeueBefore, result.RequeueAfter)
+			}
+			if inspector.called != test.expectedChecked {
+				t.Fatalf("expected the cluster to be checked: %t", test.expectedChecked)
+			}
+
+			if err := kubermaticFakeClient.Get(context.TODO(), client.ObjectKey{Name: "test"}, cluster); err != nil {
+				t.Fatal(err)
+			}
+			if cluster.Status.LastError != test.expectedError {
+				t.Errorf("expected error %q, but got %q", test.expectedError, cluster.Status.LastError)
+			}
+
+			secret := &corev1.Secret{}
+			err = kubermaticFakeClient.Get(context.TODO(), client.ObjectKey{Namespace: resources.KubermaticNamespace, Name: cluster.GetKubeconfigSecretName()}, secret)
+			if !test.expectedGenerated {
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("expected no kubeconfig secret, but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cluster.Spec.KubeconfigReference == nil || cluster.Spec.KubeconfigReference.Name != secret.Name {
+				t.Fatalf("expected the cluster to reference the kubeconfig secret %s", secret.Name)
+			}
+			if !cluster.Status.KubeconfigRefreshTime.Equal(&metav1.Time{Time: refreshTime}) {
+				t.Errorf("expected refresh time %v, but got %v", refreshTime, cluster.Status.KubeconfigRefreshTime)
+			}
+			rawKubeconfig, err := base64.StdEncoding.DecodeString(string(secret.Data[resources.ExternalClusterKubeconfig]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err := clientcmd.Load(rawKubeconfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token := config.AuthInfos[resources.KubeconfigDefaultContextKey].Token; token != "token" {
+				t.Errorf("expected token token, but got %s", token)
+			}
+		})
+	}
+}
+
+type fakeKubeconfigGenerator struct {
+	refreshTime time.Time
+	err         error
+}
+
+func (f *fakeKubeconfigGenerator) GenerateKubeconfig(ctx context.Context, cluster *kubermaticv1.ExternalCluster) ([]byte, time.Time, error) {
+	if f.err != nil {
+		return nil, time.Time{}, f.err
+	}
+	return []byte(testKubeconfig), f.refreshTime, nil
+}
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://my-cluster.eks.amazonaws.com
+  name: my-cluster
+contexts:
+- context:
+    cluster: my-cluster
+    user: default
+  name: default
+current-context: default
+users:
+- name: default
+  user:
+    token: token
+`
+
+type fakeInspector struct {
+	version *semver.Semver
+	nodes   *corev1.NodeList
+	uid     types.UID
+	err     error
+	called  bool
+}
+
+func (f *fakeInspector) GetVersion(cluster *kubermaticv1.ExternalCluster) (*semver.Semver, error) {
+	f.called = true
+	return f.version, f.err
+}
+
+func (f *fakeInspector) ListNodes(cluster *kubermaticv1.ExternalCluster) (*corev1.NodeList, error) {
+	f.called = true
+	return f.nodes, f.err
+}
+
+func (f *fakeInspector) GetKubeSystemUID(cluster *kubermaticv1.ExternalCluster) (types.UID, error) {
+	f.called = true
+	return f.uid, f.err
+}
+
+func genNode(name, cpu, memory string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
 func genExternalCluster(name string, deletionTimestamp metav1.Time) *kubermaticv1.ExternalCluster {
 
 	cluster := &kubermaticv1.ExternalCluster{
@@ -117,3 +449,64 @@ func genExternalCluster(name string, deletionTimestamp metav1.Time) *kubermaticv
 
 	return cluster
 }
+
+func TestGetCredentials(t *testing.T) {
+	cluster := &kubermaticv1.ExternalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"}},
+		Spec: kubermaticv1.ExternalClusterSpec{
+			CloudSpec: &kubermaticv1.ExternalClusterCloudSpec{
+				GKE:        &kubermaticv1.ExternalClusterGKECloudSpec{Name: "my-cluster", Zone: "europe-west3-a"},
+				PresetName: "gcp",
+			},
+		},
+	}
+	disabled := false
+
+	testCases := []struct {
+		name                string
+		preset              *kubermaticv1.Preset
+		expectedCredentials map[string]string
+	}{
+		{
+			name: "credentials are read from the preset",
+			preset: &kubermaticv1.Preset{
+				ObjectMeta: metav1.ObjectMeta{Name: "gcp"},
+				Spec:       kubermaticv1.PresetSpec{GCP: &kubermaticv1.GCP{ServiceAccount: "sa"}},
+			},
+			expectedCredentials: map[string]string{resources.GCPServiceAccount: "sa"},
+		},
+		{
+			name: "disabled credentials can not be used",
+			preset: &kubermaticv1.Preset{
+				ObjectMeta: metav1.ObjectMeta{Name: "gcp"},
+				Spec:       kubermaticv1.PresetSpec{GCP: &kubermaticv1.GCP{ProviderPreset: kubermaticv1.ProviderPreset{Enabled: &disabled}, ServiceAccount: "sa"}},
+			},
+		},
+		{
+			name: "presets restricted to other projects can not be used",
+			preset: &kubermaticv1.Preset{
+				ObjectMeta: metav1.ObjectMeta{Name: "gcp"},
+				Spec:       kubermaticv1.PresetSpec{GCP: &kubermaticv1.GCP{ServiceAccount: "sa"}, Projects: []string{"other-project"}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			generator := &cloudKubeconfigGenerator{client: fake.NewFakeClientWithScheme(scheme.Scheme, test.preset)}
+			credentials, err := generator.getCredentials(context.Background(), cluster)
+			if test.expectedCredentials == nil {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(credentials, test.expectedCredentials) {
+				t.Fatalf("expected credentials %v, got %v", test.expectedCredentials, credentials)
+			}
+		})
+	}
+}
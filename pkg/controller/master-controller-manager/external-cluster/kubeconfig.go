@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/aws"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/gcp"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kubeconfigGenerator generates the kubeconfig of a cluster imported from a managed Kubernetes service.
+// The kubeconfig has to be generated again after the returned time.
+type kubeconfigGenerator interface {
+	GenerateKubeconfig(ctx context.Context, cluster *kubermaticv1.ExternalCluster) ([]byte, time.Time, error)
+}
+
+// cloudKubeconfigGenerator generates kubeconfigs using the cloud credentials referenced by the cluster
+type cloudKubeconfigGenerator struct {
+	client ctrlruntimeclient.Client
+}
+
+func (g *cloudKubeconfigGenerator) GenerateKubeconfig(ctx context.Context, cluster *kubermaticv1.ExternalCluster) ([]byte, time.Time, error) {
+	config, refreshTime, err := g.generateConfig(ctx, cluster)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to encode the kubeconfig: %v", err)
+	}
+	return kubeconfig, refreshTime, nil
+}
+
+func (g *cloudKubeconfigGenerator) generateConfig(ctx context.Context, cluster *kubermaticv1.ExternalCluster) (*clientcmdapi.Config, time.Time, error) {
+	cloud := cluster.Spec.CloudSpec
+	credentials, err := g.getCredentials(ctx, cluster)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	switch {
+	case cloud.EKS != nil:
+		client, err := aws.GetEKSClientSet(credentials[resources.AWSAccessKeyID], credentials[resources.AWSSecretAccessKey], cloud.EKS.Region)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return aws.GetEKSKubeconfig(client, cloud.EKS.Name)
+
+	case cloud.GKE != nil:
+		client, err := gcp.ConnectToContainerService(ctx, credentials[resources.GCPServiceAccount])
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return gcp.GetGKEKubeconfig(ctx, client, cloud.GKE.Zone, cloud.GKE.Name)
+
+	case cloud.AKS != nil:
+		client, err := azure.GetAKSCredentialsLister(azure.Credentials{
+			TenantID:       credentials[resources.AzureTenantID],
+			SubscriptionID: credentials[resources.AzureSubscriptionID],
+			ClientID:       credentials[resources.AzureClientID],
+			ClientSecret:   credentials[resources.AzureClientSecret],
+		})
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return azure.GetAKSKubeconfig(ctx, client, cloud.AKS.ResourceGroup, cloud.AKS.Name)
+	}
+
+	return nil, time.Time{}, errors.New("no managed Kubernetes service specified")
+}
+
+// getCredentials returns the cloud credentials of the cluster, keyed like in the credentials secret.
+// They are read from the preset if the cluster was imported with one, so that changes of the preset
+// are picked up and disabled or restricted presets can no longer be used.
+func (g *cloudKubeconfigGenerator) getCredentials(ctx context.Context, cluster *kubermaticv1.ExternalCluster) (map[string]string, error) {
+	cloud := cluster.Spec.CloudSpec
+	if cloud.PresetName != "" {
+		preset := &kubermaticv1.Preset{}
+		if err := g.client.Get(ctx, types.NamespacedName{Name: cloud.PresetName}, preset); err != nil {
+			return nil, fmt.Errorf("failed to get preset %q: %v", cloud.PresetName, err)
+		}
+		if !preset.Spec.IsAvailableInProject(cluster.Labels[kubermaticv1.ProjectIDLabelKey]) {
+			return nil, fmt.Errorf("the preset %q is not available in the project of the cluster", preset.Name)
+		}
+		return presetCredentials(preset, cloud)
+	}
+
+	var keys []string
+	var ref *providerconfig.GlobalSecretKeySelector
+	switch {
+	case cloud.EKS != nil:
+		keys, ref = []string{resources.AWSAccessKeyID, resources.AWSSecretAccessKey}, cloud.EKS.CredentialsReference
+	case cloud.GKE != nil:
+		keys, ref = []string{resources.GCPServiceAccount}, cloud.GKE.CredentialsReference
+	case cloud.AKS != nil:
+		keys, ref = []string{resources.AzureTenantID, resources.AzureSubscriptionID, resources.AzureClientID, resources.AzureClientSecret}, cloud.AKS.CredentialsReference
+	}
+
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, g.client)
+	credentials := map[string]string{}
+	for _, key := range keys {
+		value, err := secretKeyGetter(ref, key)
+		if err != nil {
+			return nil, err
+		}
+		credentials[key] = value
+	}
+	return credentials, nil
+}
+
+// presetCredentials returns the credentials of the preset for the managed Kubernetes service
+func presetCredentials(preset *kubermaticv1.Preset, cloud *kubermaticv1.ExternalClusterCloudSpec) (map[string]string, error) {
+	switch {
+	case cloud.EKS != nil:
+		if preset.Spec.AWS == nil || !preset.Spec.AWS.IsEnabled() {
+			return nil, fmt.Errorf("the preset %q doesn't contain credentials for AWS", preset.Name)
+		}
+		return map[string]string{
+			resources.AWSAccessKeyID:     preset.Spec.AWS.AccessKeyID,
+			resources.AWSSecretAccessKey: preset.Spec.AWS.SecretAccessKey,
+		}, nil
+	case cloud.GKE != nil:
+		if preset.Spec.GCP == nil || !preset.Spec.GCP.IsEnabled() {
+			return nil, fmt.Errorf("the preset %q doesn't contain credentials for GCP", preset.Name)
+		}
+		return map[string]string{
+			resources.GCPServiceAccount: preset.Spec.GCP.ServiceAccount,
+		}, nil
+	case cloud.AKS != nil:
+		if preset.Spec.Azure == nil || !preset.Spec.Azure.IsEnabled() {
+			return nil, fmt.Errorf("the preset %q doesn't contain credentials for Azure", preset.Name)
+		}
+		return map[string]string{
+			resources.AzureTenantID:       preset.Spec.Azure.TenantID,
+			resources.AzureSubscriptionID: preset.Spec.Azure.SubscriptionID,
+			resources.AzureClientID:       preset.Spec.Azure.ClientID,
+			resources.AzureClientSecret:   preset.Spec.Azure.ClientSecret,
+		}, nil
+	}
+	return nil, errors.New("no managed Kubernetes service specified")
+}
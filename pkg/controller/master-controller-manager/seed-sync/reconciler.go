@@ -102,6 +102,7 @@ func (r *Reconciler) reconcile(seed *kubermaticv1.Seed, client ctrlruntimeclient
 	supportedStrategies := map[corev1.ServiceType]struct{}{
 		corev1.ServiceTypeNodePort:     {},
 		corev1.ServiceTypeLoadBalancer: {},
+		kubermaticv1.ExposeStrategySNI: {},
 	}
 	if seed.Spec.ExposeStrategy != "" {
 		if _, ok := supportedStrategies[seed.Spec.ExposeStrategy]; !ok {
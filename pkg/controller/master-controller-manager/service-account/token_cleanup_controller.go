@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"strings"
+
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const tokenCleanupControllerName = "kubermatic_serviceaccount_token_cleanup_controller"
+
+// AddTokenCleanup adds a controller that deletes the service account tokens
+// whose grace period after a rotation is over
+func AddTokenCleanup(mgr manager.Manager) error {
+	r := &reconcileServiceAccountTokenCleanup{Client: mgr.GetClient(), ctx: context.TODO()}
+	// Create a new controller
+	c, err := controller.New(tokenCleanupControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	isToken := predicateutil.Factory(func(m metav1.Object, _ runtime.Object) bool {
+		return m.GetNamespace() == resources.KubermaticNamespace && strings.HasPrefix(m.GetName(), "sa-token")
+	})
+
+	// Watch for changes to token Secrets
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForObject{}, isToken)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// reconcileServiceAccountTokenCleanup reconciles service account token Secrets
+type reconcileServiceAccountTokenCleanup struct {
+	ctx context.Context
+	client.Client
+}
+
+func (r *reconcileServiceAccountTokenCleanup) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.cleanupRevokedToken(request.NamespacedName)
+	if err != nil {
+		logger := log.Logger.With("controller", tokenCleanupControllerName)
+		logger.Errorw("failed to reconcile in controller", "error", err)
+		return reconcile.Result{}, err
+	}
+	return result, nil
+}
+
+func (r *reconcileServiceAccountTokenCleanup) cleanupRevokedToken(name client.ObjectKey) (reconcile.Result, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(r.ctx, name, secret); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	revokeAt := serviceaccount.RevokeAt(secret)
+	if revokeAt == nil || secret.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// the token was rotated, it is deleted once its grace period is over
+	if remaining := revokeAt.Sub(serviceaccount.Now()); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	if err := r.Delete(r.ctx, secret); err != nil && !kerrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileTokenCleanup(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	serviceaccount.Now = func() time.Time { return now }
+	defer func() { serviceaccount.Now = time.Now }()
+
+	tests := []struct {
+		name            string
+		existingObjects []runtime.Object
+		expectedDeleted bool
+		expectedRequeue time.Duration
+	}{
+		{
+			name:            "scenario 1: a token that was not rotated is kept",
+			existingObjects: []runtime.Object{genTokenSecret(nil)},
+		},
+		{
+			name:            "scenario 2: a rotated token is kept until its grace period is over",
+			existingObjects: []runtime.Object{genTokenSecret(timePtr(now.Add(time.Hour)))},
+			expectedRequeue: time.Hour,
+		},
+		{
+			name:            "scenario 3: a revoked token is deleted",
+			existingObjects: []runtime.Object{genTokenSecret(timePtr(now.Add(-time.Minute)))},
+			expectedDeleted: true,
+		},
+		{
+			name: "scenario 4: a deleted token is ignored",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClient(test.existingObjects...)
+			target := reconcileServiceAccountTokenCleanup{ctx: context.TODO(), Client: fakeClient}
+			name := types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: "sa-token-abcd"}
+
+			result, err := target.Reconcile(reconcile.Request{NamespacedName: name})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.RequeueAfter != test.expectedRequeue {
+				t.Fatalf("expected to requeue after %v, got %v", test.expectedRequeue, result.RequeueAfter)
+			}
+
+			if len(test.existingObjects) == 0 {
+				return
+			}
+			err = fakeClient.Get(context.TODO(), name, &corev1.Secret{})
+			if deleted := kerrors.IsNotFound(err); deleted != test.expectedDeleted {
+				t.Fatalf("expected the token to be deleted: %v, got error: %v", test.expectedDeleted, err)
+			}
+		})
+	}
+}
+
+func genTokenSecret(revokeAt *time.Time) *corev1.Secret {
+	secret := &corev1.Secret{}
+	secret.Name = "sa-token-abcd"
+	secret.Namespace = resources.KubermaticNamespace
+	if revokeAt != nil {
+		secret.Annotations = map[string]string{serviceaccount.RevokeAtAnnotationKey: revokeAt.Format(time.RFC3339)}
+	}
+	return secret
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -647,11 +647,11 @@ command:
 - |
   set -euo pipefail
 
-  endpoint=minio.minio.svc.cluster.local:9000
-  bucket=kubermatic-etcd-backups
+  endpoint=${ENDPOINT:-minio.minio.svc.cluster.local:9000}
+  bucket=${BUCKET:-kubermatic-etcd-backups}
 
   s3-storeuploader store --file /backup/snapshot.db --endpoint "$endpoint" --bucket "$bucket" --create-bucket --prefix $CLUSTER
-  s3-storeuploader delete-old-revisions --max-revisions 20 --endpoint "$endpoint" --bucket "$bucket" --prefix $CLUSTER
+  s3-storeuploader delete-old-revisions --max-revisions "${MAX_REVISIONS:-20}" --endpoint "$endpoint" --bucket "$bucket" --prefix $CLUSTER
 env:
 - name: ACCESS_KEY_ID
   valueFrom:
@@ -677,8 +677,8 @@ command:
 - |
   set -euo pipefail
 
-  endpoint=minio.minio.svc.cluster.local:9000
-  bucket=kubermatic-etcd-backups
+  endpoint=${ENDPOINT:-minio.minio.svc.cluster.local:9000}
+  bucket=${BUCKET:-kubermatic-etcd-backups}
 
   # by default, we keep the most recent backup for every user cluster
   s3-storeuploader delete-old-revisions --max-revisions 1 --endpoint "$endpoint" --bucket "$bucket" --prefix $CLUSTER
@@ -844,12 +844,22 @@ func CreateUpdatesYAML(config *operatorv1alpha1.KubermaticVersionsConfiguration)
 			automaticNodeUpdate := (u.AutomaticNodeUpdate != nil && *u.AutomaticNodeUpdate)
 			automatic := (u.Automatic != nil && *u.Automatic) || automaticNodeUpdate
 
+			var rollout *version.RolloutPolicy
+			if u.Rollout != nil {
+				rollout = &version.RolloutPolicy{
+					MaxConcurrentUpdates: u.Rollout.MaxConcurrentUpdates,
+					CanarySelector:       u.Rollout.CanarySelector,
+					SoakTime:             u.Rollout.SoakTime,
+				}
+			}
+
 			output.Updates = append(output.Updates, &version.Update{
 				From:                u.From,
 				To:                  u.To,
 				Automatic:           automatic,
 				AutomaticNodeUpdate: automaticNodeUpdate,
 				Type:                kind,
+				Rollout:             rollout,
 			})
 		}
 	}
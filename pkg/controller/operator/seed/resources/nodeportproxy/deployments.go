@@ -37,6 +37,9 @@ const (
 	EnvoyDeploymentName   = "nodeport-proxy-envoy"
 	UpdaterDeploymentName = "nodeport-proxy-updater"
 	EnvoyPort             = 8002
+	// EnvoySNIListenerPort is the port of the envoy listener which routes the connections to the
+	// clusters exposed via SNI, the port 443 of the LoadBalancer service is pointing to it.
+	EnvoySNIListenerPort = 6443
 )
 
 func EnvoyDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions) reconciling.NamedDeploymentCreatorGetter {
@@ -99,6 +102,7 @@ func EnvoyDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions) r
 						"-envoy-node-name=kube",
 						"-envoy-admin-port=9001",
 						fmt.Sprintf("-envoy-stats-port=%d", EnvoyPort),
+						fmt.Sprintf("-envoy-sni-listener-port=%d", EnvoySNIListenerPort),
 					},
 					Ports: []corev1.ContainerPort{
 						{
@@ -133,6 +137,11 @@ func EnvoyDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions) r
 							Protocol:      corev1.ProtocolTCP,
 							ContainerPort: EnvoyPort,
 						},
+						{
+							Name:          "sni",
+							Protocol:      corev1.ProtocolTCP,
+							ContainerPort: EnvoySNIListenerPort,
+						},
 					},
 					VolumeMounts: []corev1.VolumeMount{
 						{
@@ -226,6 +235,7 @@ func UpdaterDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions)
 					Args: []string{
 						"-lb-namespace=$(NAMESPACE)",
 						fmt.Sprintf("-lb-name=%s", ServiceName),
+						fmt.Sprintf("-envoy-sni-listener-port=%d", EnvoySNIListenerPort),
 					},
 					Env: []corev1.EnvVar{
 						{
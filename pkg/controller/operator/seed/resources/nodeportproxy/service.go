@@ -42,6 +42,12 @@ func ServiceCreator(seed *kubermaticv1.Seed) reconciling.NamedServiceCreatorGett
 			// must make sure that it exists
 
 			s.Spec.Type = corev1.ServiceTypeLoadBalancer
+			// Envoy only sees the source IPs of the connections with the Local policy, which is
+			// required to enforce the allowed IP ranges of the clusters.
+			s.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+			if seed.Spec.NodeportProxy.ExternalTrafficPolicy != "" {
+				s.Spec.ExternalTrafficPolicy = seed.Spec.NodeportProxy.ExternalTrafficPolicy
+			}
 			s.Spec.Selector = map[string]string{
 				common.NameLabel: EnvoyDeploymentName,
 			}
@@ -19,6 +19,7 @@ package backup
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
@@ -38,6 +40,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -69,8 +72,25 @@ const (
 	cleanupFinalizer = "kubermatic.io/cleanup-backups"
 	// backupCleanupJobLabel defines the label we use on all cleanup jobs
 	backupCleanupJobLabel = "kubermatic-etcd-backup-cleaner"
+	// backupJobLabel defines the label we use on all backup jobs
+	backupJobLabel = "kubermatic-etcd-backup"
 	// clusterEnvVarKey defines the environment variable key for the cluster name
 	clusterEnvVarKey = "CLUSTER"
+	// maxRevisionsEnvVarKey defines the environment variable key for the number of backups to keep.
+	// It is only set if the cluster overrides the retention of the seed.
+	maxRevisionsEnvVarKey = "MAX_REVISIONS"
+	// encryptionKeysVolumeName is the name of the volume the encryption keys are mounted as
+	encryptionKeysVolumeName = "etcd-backup-encryption-keys"
+	// encryptionKeysMountPath is the path the encryption keys are mounted at
+	encryptionKeysMountPath = "/etcd-backup-encryption-keys"
+	// storageVolumeName is the name of the volume the PVC of the filesystem backend is mounted as
+	storageVolumeName = "etcd-backup-storage"
+	// storageMountPath is the path the PVC of the filesystem backend is mounted at
+	storageMountPath = "/etcd-backup-storage"
+	// objectNameFileEnvVarKey defines the environment variable key for the file the store container
+	// writes the name of the uploaded backup to. We point it at the termination log, so the
+	// controller can read it from the pod status.
+	objectNameFileEnvVarKey = "OBJECT_NAME_FILE"
 
 	ControllerName = "kubermatic_backup_controller"
 )
@@ -86,6 +106,12 @@ type Reconciler struct {
 	// backupContainerImage holds the image used for creating the etcd backup
 	// It must be configurable to cover offline use cases
 	backupContainerImage string
+	// seedGetter returns the seed, whose backup storage settings are applied
+	// to the store and cleanup containers
+	seedGetter provider.SeedGetter
+	// storeContainerHonorsRetention is false if the store container does not
+	// reference MAX_REVISIONS, in which case per-cluster retention has no effect
+	storeContainerHonorsRetention bool
 
 	ctrlruntimeclient.Client
 	recorder record.EventRecorder
@@ -102,6 +128,7 @@ func Add(
 	cleanupContainer corev1.Container,
 	backupSchedule time.Duration,
 	backupContainerImage string,
+	seedGetter provider.SeedGetter,
 ) error {
 	log = log.Named(ControllerName)
 	if err := validateStoreContainer(storeContainer); err != nil {
@@ -122,8 +149,11 @@ func Add(
 		cleanupContainer:     cleanupContainer,
 		backupScheduleString: backupScheduleString,
 		backupContainerImage: backupContainerImage,
+		seedGetter:           seedGetter,
 		Client:               mgr.GetClient(),
 		recorder:             mgr.GetEventRecorderFor(ControllerName),
+
+		storeContainerHonorsRetention: usesMaxRevisions(storeContainer),
 	}
 	c, err := controller.New(ControllerName, mgr, controller.Options{
 		Reconciler:              reconciler,
@@ -152,6 +182,20 @@ func Add(
 		return fmt.Errorf("failed to watch CronJobs: %v", err)
 	}
 
+	jobMapFn := &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+		labels := a.Meta.GetLabels()
+		if a.Meta.GetNamespace() != metav1.NamespaceSystem || labels[resources.AppLabelKey] != backupJobLabel {
+			return nil
+		}
+		if clusterName := labels[resources.ClusterLabelKey]; clusterName != "" {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: clusterName}}}
+		}
+		return nil
+	})}
+	if err := c.Watch(&source.Kind{Type: &batchv1.Job{}}, jobMapFn); err != nil {
+		return fmt.Errorf("failed to watch Jobs: %v", err)
+	}
+
 	// Cleanup cleanup jobs...
 	if err := mgr.Add(&runnableWrapper{
 		f: func(stopCh <-chan struct{}) {
@@ -246,11 +290,18 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 }
 
 func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	seed, err := r.seedGetter()
+	if err != nil {
+		return fmt.Errorf("failed to get seed: %v", err)
+	}
+	storage := seed.Spec.EtcdBackupStorage
+	encryption := seed.Spec.EtcdBackupEncryption
+
 	// Cluster got deleted - regardless if the cluster was ever running, we cleanup
 	if cluster.DeletionTimestamp != nil {
 		// Need to cleanup
 		if sets.NewString(cluster.Finalizers...).Has(cleanupFinalizer) {
-			if err := r.Create(ctx, r.cleanupJob(cluster)); err != nil {
+			if err := r.Create(ctx, r.cleanupJob(cluster, storage)); err != nil {
 				// Otherwise we end up in a loop when we are able to create the job but not
 				// remove the finalizer.
 				if !kerrors.IsAlreadyExists(err) {
@@ -281,11 +332,117 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		}
 	}
 
+	if !backupsEnabled(cluster) {
+		return r.deleteCronJob(ctx, cluster)
+	}
+
 	if err := r.ensureCronJobSecret(ctx, cluster); err != nil {
 		return fmt.Errorf("failed to create backup secret: %v", err)
 	}
 
-	return reconciling.ReconcileCronJobs(ctx, []reconciling.NamedCronJobCreatorGetter{r.cronjob(cluster)}, metav1.NamespaceSystem, r.Client)
+	if err := reconciling.ReconcileCronJobs(ctx, []reconciling.NamedCronJobCreatorGetter{r.cronjob(cluster, storage, encryption)}, metav1.NamespaceSystem, r.Client); err != nil {
+		return err
+	}
+
+	if settings := cluster.Spec.EtcdBackup; settings != nil && settings.Retention != nil && !r.storeContainerHonorsRetention {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "RetentionIgnored",
+			"The backup store container of the seed does not use %s, the configured retention of %d backups is not applied", maxRevisionsEnvVarKey, *settings.Retention)
+	}
+
+	return r.updateBackupStatus(ctx, cluster)
+}
+
+// updateBackupStatus records the outcome of the most recent backup jobs of the cluster in its status.
+// Finished jobs get removed by the CronJob controller, so we only ever move the recorded times forward.
+func (r *Reconciler) updateBackupStatus(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs,
+		ctrlruntimeclient.InNamespace(metav1.NamespaceSystem),
+		ctrlruntimeclient.MatchingLabels{resources.AppLabelKey: backupJobLabel, resources.ClusterLabelKey: cluster.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list backup jobs: %v", err)
+	}
+
+	status := &kubermaticv1.EtcdBackupStatus{}
+	if cluster.Status.EtcdBackup != nil {
+		status = cluster.Status.EtcdBackup.DeepCopy()
+	}
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+
+		if job.Status.Succeeded > 0 && job.Status.CompletionTime != nil &&
+			(status.LastSuccessfulBackupTime == nil || job.Status.CompletionTime.After(status.LastSuccessfulBackupTime.Time)) {
+			objectName, err := r.backupObjectName(ctx, job)
+			if err != nil {
+				return err
+			}
+			status.LastSuccessfulBackupTime = job.Status.CompletionTime.DeepCopy()
+			status.LastBackupObject = objectName
+		}
+
+		for _, condition := range job.Status.Conditions {
+			if condition.Type != batchv1.JobFailed || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			if status.LastFailureTime == nil || condition.LastTransitionTime.After(status.LastFailureTime.Time) {
+				status.LastFailureTime = condition.LastTransitionTime.DeepCopy()
+				status.LastFailureReason = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+			}
+		}
+	}
+
+	if equality.Semantic.DeepEqual(status, cluster.Status.EtcdBackup) || equality.Semantic.DeepEqual(*status, kubermaticv1.EtcdBackupStatus{}) {
+		return nil
+	}
+
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.EtcdBackup = status
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update backup status: %v", err)
+	}
+	return nil
+}
+
+// backupObjectName returns the name of the object the given job uploaded, as reported by the
+// termination message of its store container. It returns an empty string if it is unknown.
+func (r *Reconciler) backupObjectName(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		ctrlruntimeclient.InNamespace(job.Namespace),
+		ctrlruntimeclient.MatchingLabels{"job-name": job.Name},
+	); err != nil {
+		return "", fmt.Errorf("failed to list pods of backup job %q: %v", job.Name, err)
+	}
+
+	for _, pod := range pods.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != r.storeContainer.Name {
+				continue
+			}
+			if terminated := containerStatus.State.Terminated; terminated != nil && terminated.ExitCode == 0 {
+				return strings.TrimSpace(terminated.Message), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// deleteCronJob removes the backup CronJob of a cluster whose backups got disabled. Existing
+// backups are kept, they are only removed by the cleanup job once the cluster is deleted.
+func (r *Reconciler) deleteCronJob(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	name, _ := r.cronjob(cluster, nil, nil)()
+	cronJob := &batchv1beta1.CronJob{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, cronJob); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get backup CronJob: %v", err)
+	}
+	if err := r.Delete(ctx, cronJob); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete backup CronJob: %v", err)
+	}
+	return nil
 }
 
 func (r *Reconciler) getEtcdSecretName(cluster *kubermaticv1.Cluster) string {
@@ -322,14 +479,14 @@ func (r *Reconciler) ensureCronJobSecret(ctx context.Context, cluster *kubermati
 	return nil
 }
 
-func (r *Reconciler) cleanupJob(cluster *kubermaticv1.Cluster) *batchv1.Job {
+func (r *Reconciler) cleanupJob(cluster *kubermaticv1.Cluster, storage *kubermaticv1.EtcdBackupStorage) *batchv1.Job {
 	cleanupContainer := r.cleanupContainer.DeepCopy()
 	cleanupContainer.Env = append(cleanupContainer.Env, corev1.EnvVar{
 		Name:  clusterEnvVarKey,
 		Value: cluster.Name,
 	})
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("remove-cluster-backups-%s", cluster.Name),
 			Namespace: metav1.NamespaceSystem,
@@ -360,9 +517,12 @@ func (r *Reconciler) cleanupJob(cluster *kubermaticv1.Cluster) *batchv1.Job {
 			},
 		},
 	}
+	applyStorage(&job.Spec.Template.Spec, &job.Spec.Template.Spec.Containers[0], storage)
+
+	return job
 }
 
-func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCronJobCreatorGetter {
+func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster, storage *kubermaticv1.EtcdBackupStorage, encryption *kubermaticv1.EtcdBackupEncryption) reconciling.NamedCronJobCreatorGetter {
 	return func() (string, reconciling.CronJobCreator) {
 		return fmt.Sprintf("%s-%s", cronJobPrefix, cluster.Name), func(cronJob *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
 			gv := kubermaticv1.SchemeGroupVersion
@@ -371,10 +531,18 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 			}
 
 			// Spec
-			cronJob.Spec.Schedule = r.backupScheduleString
+			cronJob.Spec.Schedule = r.backupSchedule(cluster)
 			cronJob.Spec.ConcurrencyPolicy = batchv1beta1.ForbidConcurrent
 			cronJob.Spec.Suspend = utilpointer.BoolPtr(false)
-			cronJob.Spec.SuccessfulJobsHistoryLimit = utilpointer.Int32Ptr(0)
+			// Keep the last successful job around, so its outcome can be recorded in the cluster status
+			cronJob.Spec.SuccessfulJobsHistoryLimit = utilpointer.Int32Ptr(1)
+
+			jobLabels := map[string]string{
+				resources.AppLabelKey:     backupJobLabel,
+				resources.ClusterLabelKey: cluster.Name,
+			}
+			cronJob.Spec.JobTemplate.Labels = jobLabels
+			cronJob.Spec.JobTemplate.Spec.Template.Labels = jobLabels
 
 			endpoints := etcd.GetClientEndpoints(cluster.Status.NamespaceName)
 			image := r.backupContainerImage
@@ -425,7 +593,16 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 			storeContainer.Env = append(storeContainer.Env, corev1.EnvVar{
 				Name:  clusterEnvVarKey,
 				Value: cluster.Name,
+			}, corev1.EnvVar{
+				Name:  objectNameFileEnvVarKey,
+				Value: corev1.TerminationMessagePathDefault,
 			})
+			if settings := cluster.Spec.EtcdBackup; settings != nil && settings.Retention != nil {
+				storeContainer.Env = append(storeContainer.Env, corev1.EnvVar{
+					Name:  maxRevisionsEnvVarKey,
+					Value: strconv.Itoa(*settings.Retention),
+				})
+			}
 
 			cronJob.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{*storeContainer}
@@ -445,6 +622,9 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 					},
 				},
 			}
+			podSpec := &cronJob.Spec.JobTemplate.Spec.Template.Spec
+			applyStorage(podSpec, &podSpec.Containers[0], storage)
+			applyEncryption(podSpec, &podSpec.Containers[0], encryption)
 
 			return cronJob, nil
 		}
@@ -452,6 +632,124 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 
 }
 
+// applyStorage configures the given store or cleanup container to use the backup storage of the
+// seed. Settings of the configured container are overwritten.
+func applyStorage(podSpec *corev1.PodSpec, container *corev1.Container, storage *kubermaticv1.EtcdBackupStorage) {
+	if storage == nil {
+		return
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "BACKEND", Value: string(storage.Backend)},
+		{Name: "BUCKET", Value: storage.Bucket},
+	}
+	var credentialKeys []string
+
+	switch storage.Backend {
+	case kubermaticv1.EtcdBackupStorageBackendS3:
+		if storage.S3 != nil {
+			env = append(env,
+				corev1.EnvVar{Name: "ENDPOINT", Value: storage.S3.Endpoint},
+				corev1.EnvVar{Name: "SECURE", Value: strconv.FormatBool(storage.S3.Secure)},
+			)
+		}
+		credentialKeys = []string{"ACCESS_KEY_ID", "SECRET_ACCESS_KEY"}
+
+	case kubermaticv1.EtcdBackupStorageBackendFilesystem:
+		env = append(env, corev1.EnvVar{Name: "FS_ROOT", Value: storageMountPath})
+		if storage.Filesystem != nil {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: storageVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: storage.Filesystem.PersistentVolumeClaim,
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      storageVolumeName,
+				MountPath: storageMountPath,
+				SubPath:   storage.Filesystem.Path,
+			})
+		}
+
+	case kubermaticv1.EtcdBackupStorageBackendSFTP:
+		if storage.SFTP != nil {
+			env = append(env,
+				corev1.EnvVar{Name: "SFTP_ADDRESS", Value: storage.SFTP.Address},
+				corev1.EnvVar{Name: "SFTP_HOST_KEY", Value: storage.SFTP.HostKey},
+			)
+		}
+		credentialKeys = []string{"SFTP_USERNAME", "SFTP_PASSWORD", "SFTP_PRIVATE_KEY"}
+	}
+
+	if storage.CredentialsSecret != "" {
+		for _, key := range credentialKeys {
+			env = append(env, corev1.EnvVar{
+				Name: key,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: storage.CredentialsSecret},
+						Key:                  key,
+						Optional:             utilpointer.BoolPtr(true),
+					},
+				},
+			})
+		}
+	}
+
+	for _, envVar := range env {
+		setEnvVar(container, envVar)
+	}
+}
+
+// applyEncryption configures the given store container to encrypt the backups with the active key of the seed
+func applyEncryption(podSpec *corev1.PodSpec, container *corev1.Container, encryption *kubermaticv1.EtcdBackupEncryption) {
+	if encryption == nil {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: encryptionKeysVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: encryption.KeySecret,
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      encryptionKeysVolumeName,
+		MountPath: encryptionKeysMountPath,
+		ReadOnly:  true,
+	})
+	setEnvVar(container, corev1.EnvVar{Name: "ENCRYPTION_KEYS_DIR", Value: encryptionKeysMountPath})
+	setEnvVar(container, corev1.EnvVar{Name: "ENCRYPTION_KEY_ID", Value: encryption.ActiveKeyID})
+}
+
+// setEnvVar sets the given environment variable, replacing an existing one with the same name
+func setEnvVar(container *corev1.Container, envVar corev1.EnvVar) {
+	for i := range container.Env {
+		if container.Env[i].Name == envVar.Name {
+			container.Env[i] = envVar
+			return
+		}
+	}
+	container.Env = append(container.Env, envVar)
+}
+
+// backupSchedule returns the cron schedule of the cluster, falling back to the seed-wide schedule.
+func (r *Reconciler) backupSchedule(cluster *kubermaticv1.Cluster) string {
+	if settings := cluster.Spec.EtcdBackup; settings != nil && settings.Schedule != "" {
+		return settings.Schedule
+	}
+	return r.backupScheduleString
+}
+
+func backupsEnabled(cluster *kubermaticv1.Cluster) bool {
+	settings := cluster.Spec.EtcdBackup
+	return settings == nil || settings.Enabled == nil || *settings.Enabled
+}
+
 func parseDuration(interval time.Duration) (string, error) {
 	scheduleString := fmt.Sprintf("@every %vm", interval.Round(time.Minute).Minutes())
 	// We verify the validity of the scheduleString here, because the cronjob controller
@@ -476,6 +774,21 @@ func validateStoreContainer(storeContainer corev1.Container) error {
 	return fmt.Errorf("storeContainer does not have a mount for the shared volume %s", SharedVolumeName)
 }
 
+// usesMaxRevisions returns whether the command, args or env of the store container
+// reference MAX_REVISIONS, which is how the per-cluster retention is passed to it.
+func usesMaxRevisions(storeContainer corev1.Container) bool {
+	values := append(append([]string{}, storeContainer.Command...), storeContainer.Args...)
+	for _, env := range storeContainer.Env {
+		values = append(values, env.Value)
+	}
+	for _, value := range values {
+		if strings.Contains(value, maxRevisionsEnvVarKey) {
+			return true
+		}
+	}
+	return false
+}
+
 func snapshotCommand(etcdEndpoints []string) []string {
 	cmd := []string{
 		"/bin/sh",
@@ -19,6 +19,7 @@ package backup
 import (
 	"context"
 	"testing"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
@@ -26,11 +27,13 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/kubermatic/v2/pkg/semver"
 
+	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	certutil "k8s.io/client-go/util/cert"
+	utilpointer "k8s.io/utils/pointer"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -45,6 +48,10 @@ var (
 	}
 )
 
+func testSeedGetter() (*kubermaticv1.Seed, error) {
+	return &kubermaticv1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "test-seed"}}, nil
+}
+
 func TestEnsureBackupCronJob(t *testing.T) {
 	cluster := &kubermaticv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -87,6 +94,7 @@ func TestEnsureBackupCronJob(t *testing.T) {
 		storeContainer:       testStoreContainer,
 		cleanupContainer:     testCleanupContainer,
 		backupContainerImage: DefaultBackupContainerImage,
+		seedGetter:           testSeedGetter,
 		Client:               ctrlruntimefakeclient.NewFakeClient(caSecret, cluster),
 	}
 
@@ -103,8 +111,8 @@ func TestEnsureBackupCronJob(t *testing.T) {
 		t.Fatalf("Expected exactly one cronjob, got %v", len(cronJobs.Items))
 	}
 
-	if *cronJobs.Items[0].Spec.SuccessfulJobsHistoryLimit != 0 {
-		t.Errorf("Expected spec.SuccessfulJobsHistoryLimit to be 0 but was %v",
+	if *cronJobs.Items[0].Spec.SuccessfulJobsHistoryLimit != 1 {
+		t.Errorf("Expected spec.SuccessfulJobsHistoryLimit to be 1 but was %v",
 			*cronJobs.Items[0].Spec.SuccessfulJobsHistoryLimit)
 	}
 
@@ -168,7 +176,7 @@ func TestCleanupJobSpec(t *testing.T) {
 		cleanupContainer: testCleanupContainer,
 	}
 
-	cleanupJob := reconciler.cleanupJob(&kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}})
+	cleanupJob := reconciler.cleanupJob(&kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}}, nil)
 
 	if cleanupJob.Namespace != metav1.NamespaceSystem {
 		t.Errorf("expected cleanup jobs Namespace to be %q but was %q", metav1.NamespaceSystem, cleanupJob.Namespace)
@@ -178,3 +186,338 @@ func TestCleanupJobSpec(t *testing.T) {
 		t.Errorf("expected cleanup job to have exactly one container, got %d", containerLen)
 	}
 }
+
+func TestCronJobEtcdBackupSettings(t *testing.T) {
+	retention := 720
+	testCases := []struct {
+		name                 string
+		settings             *kubermaticv1.EtcdBackupSettings
+		expectedSchedule     string
+		expectedMaxRevisions string
+	}{
+		{
+			name:             "seed defaults",
+			expectedSchedule: "@every 20m",
+		},
+		{
+			name: "cluster overrides",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				Schedule:  "0 * * * *",
+				Retention: &retention,
+			},
+			expectedSchedule:     "0 * * * *",
+			expectedMaxRevisions: "720",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reconciler := &Reconciler{
+				storeContainer:       testStoreContainer,
+				backupScheduleString: "@every 20m",
+				backupContainerImage: DefaultBackupContainerImage,
+			}
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				Spec: kubermaticv1.ClusterSpec{
+					Version:    *semver.NewSemverOrDie("1.16.3"),
+					EtcdBackup: tc.settings,
+				},
+			}
+
+			_, creator := reconciler.cronjob(cluster, nil, nil)()
+			cronJob, err := creator(&batchv1beta1.CronJob{})
+			if err != nil {
+				t.Fatalf("failed to create cronjob: %v", err)
+			}
+
+			if cronJob.Spec.Schedule != tc.expectedSchedule {
+				t.Errorf("expected schedule %q but got %q", tc.expectedSchedule, cronJob.Spec.Schedule)
+			}
+
+			var maxRevisions string
+			for _, env := range cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env {
+				if env.Name == maxRevisionsEnvVarKey {
+					maxRevisions = env.Value
+				}
+			}
+			if maxRevisions != tc.expectedMaxRevisions {
+				t.Errorf("expected %s to be %q but got %q", maxRevisionsEnvVarKey, tc.expectedMaxRevisions, maxRevisions)
+			}
+		})
+	}
+}
+
+func TestUsesMaxRevisions(t *testing.T) {
+	testCases := []struct {
+		name      string
+		container corev1.Container
+		expected  bool
+	}{
+		{
+			name:      "container without retention",
+			container: testStoreContainer,
+		},
+		{
+			name: "retention in command",
+			container: corev1.Container{
+				Command: []string{"/bin/sh", "-c", `s3-storeuploader delete-old-revisions --max-revisions "${MAX_REVISIONS:-20}"`},
+			},
+			expected: true,
+		},
+		{
+			name: "retention in args",
+			container: corev1.Container{
+				Args: []string{"--max-revisions=$(MAX_REVISIONS)"},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := usesMaxRevisions(tc.container); got != tc.expected {
+				t.Errorf("expected %t but got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCronJobEtcdBackupStorageAndEncryption(t *testing.T) {
+	reconciler := &Reconciler{
+		storeContainer:       testStoreContainer,
+		backupScheduleString: "@every 20m",
+		backupContainerImage: DefaultBackupContainerImage,
+	}
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie("1.16.3"),
+		},
+	}
+	storage := &kubermaticv1.EtcdBackupStorage{
+		Backend:           kubermaticv1.EtcdBackupStorageBackendSFTP,
+		Bucket:            "etcd-backups",
+		CredentialsSecret: "sftp-credentials",
+		SFTP: &kubermaticv1.EtcdBackupSFTPStorage{
+			Address: "backups.example.com:22",
+			HostKey: "ssh-ed25519 AAAA",
+		},
+	}
+
+	encryption := &kubermaticv1.EtcdBackupEncryption{
+		KeySecret:   "etcd-backup-keys",
+		ActiveKeyID: "2020-09",
+	}
+
+	_, creator := reconciler.cronjob(cluster, storage, encryption)()
+	cronJob, err := creator(&batchv1beta1.CronJob{})
+	if err != nil {
+		t.Fatalf("failed to create cronjob: %v", err)
+	}
+
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	env := map[string]string{}
+	secretRefs := map[string]string{}
+	for _, envVar := range podSpec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+		if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+			secretRefs[envVar.Name] = envVar.ValueFrom.SecretKeyRef.Name
+		}
+	}
+	expectedEnv := map[string]string{
+		"BACKEND":             "sftp",
+		"BUCKET":              "etcd-backups",
+		"SFTP_ADDRESS":        "backups.example.com:22",
+		"SFTP_HOST_KEY":       "ssh-ed25519 AAAA",
+		"ENCRYPTION_KEYS_DIR": encryptionKeysMountPath,
+		"ENCRYPTION_KEY_ID":   "2020-09",
+	}
+	for name, value := range expectedEnv {
+		if env[name] != value {
+			t.Errorf("expected env var %s to be %q but got %q", name, value, env[name])
+		}
+	}
+
+	for _, name := range []string{"SFTP_USERNAME", "SFTP_PASSWORD", "SFTP_PRIVATE_KEY"} {
+		if secretRefs[name] != "sftp-credentials" {
+			t.Errorf("expected env var %s to reference secret %q but got %q", name, "sftp-credentials", secretRefs[name])
+		}
+	}
+}
+
+func TestCleanupJobEtcdBackupFilesystemStorage(t *testing.T) {
+	reconciler := &Reconciler{
+		cleanupContainer: testCleanupContainer,
+	}
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+	}
+	storage := &kubermaticv1.EtcdBackupStorage{
+		Backend: kubermaticv1.EtcdBackupStorageBackendFilesystem,
+		Bucket:  "etcd-backups",
+		Filesystem: &kubermaticv1.EtcdBackupFilesystemStorage{
+			PersistentVolumeClaim: "backups",
+			Path:                  "kubermatic",
+		},
+	}
+
+	podSpec := reconciler.cleanupJob(cluster, storage).Spec.Template.Spec
+
+	var fsRoot string
+	for _, envVar := range podSpec.Containers[0].Env {
+		if envVar.Name == "FS_ROOT" {
+			fsRoot = envVar.Value
+		}
+	}
+	if fsRoot != storageMountPath {
+		t.Errorf("expected env var FS_ROOT to be %q but got %q", storageMountPath, fsRoot)
+	}
+
+	var claimName string
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == storageVolumeName && volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if claimName != "backups" {
+		t.Errorf("expected the %q PVC to be mounted, got %q", "backups", claimName)
+	}
+
+	var subPath string
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if mount.Name == storageVolumeName {
+			subPath = mount.SubPath
+		}
+	}
+	if subPath != "kubermatic" {
+		t.Errorf("expected the PVC to be mounted with sub path %q, got %q", "kubermatic", subPath)
+	}
+}
+
+func TestDisabledBackupsDeleteCronJob(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-cluster",
+			Finalizers: []string{cleanupFinalizer},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie("1.16.3"),
+			EtcdBackup: &kubermaticv1.EtcdBackupSettings{
+				Enabled: utilpointer.BoolPtr(false),
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "testnamespace",
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Etcd: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceSystem,
+			Name:      "etcd-backup-test-cluster",
+		},
+	}
+
+	reconciler := &Reconciler{
+		log:                  kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		storeContainer:       testStoreContainer,
+		cleanupContainer:     testCleanupContainer,
+		backupContainerImage: DefaultBackupContainerImage,
+		seedGetter:           testSeedGetter,
+		Client:               ctrlruntimefakeclient.NewFakeClient(cluster, cronJob),
+	}
+
+	if _, err := reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}}); err != nil {
+		t.Fatalf("Error syncing cluster: %v", err)
+	}
+
+	cronJobs := &batchv1beta1.CronJobList{}
+	if err := reconciler.List(context.Background(), cronJobs); err != nil {
+		t.Fatalf("Error listing cronjobs: %v", err)
+	}
+	if len(cronJobs.Items) != 0 {
+		t.Errorf("Expected the cronjob to be deleted, got %d cronjobs", len(cronJobs.Items))
+	}
+}
+
+func TestUpdateBackupStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+	jobLabels := map[string]string{
+		resources.AppLabelKey:     backupJobLabel,
+		resources.ClusterLabelKey: "test-cluster",
+	}
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Status: kubermaticv1.ClusterStatus{
+			EtcdBackup: &kubermaticv1.EtcdBackupStatus{
+				LastSuccessfulBackupTime: &earlier,
+				LastBackupObject:         "test-cluster-storeuploader-old",
+			},
+		},
+	}
+	succeededJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "succeeded", Labels: jobLabels},
+		Status: batchv1.JobStatus{
+			Succeeded:      1,
+			CompletionTime: &later,
+		},
+	}
+	succeededPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "succeeded-abcde", Labels: map[string]string{"job-name": "succeeded"}},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: testStoreContainer.Name,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Message: "test-cluster-storeuploader-new\n",
+				}},
+			}},
+		},
+	}
+	failedJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "failed", Labels: jobLabels},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{
+				Type:               batchv1.JobFailed,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: earlier,
+				Reason:             "BackoffLimitExceeded",
+				Message:            "Job has reached the specified backoff limit",
+			}},
+		},
+	}
+
+	reconciler := &Reconciler{
+		storeContainer: testStoreContainer,
+		Client:         ctrlruntimefakeclient.NewFakeClient(cluster, succeededJob, succeededPod, failedJob),
+	}
+
+	if err := reconciler.updateBackupStatus(context.Background(), cluster); err != nil {
+		t.Fatalf("failed to update backup status: %v", err)
+	}
+
+	updated := &kubermaticv1.Cluster{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: cluster.Name}, updated); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	status := updated.Status.EtcdBackup
+	if status == nil {
+		t.Fatal("expected the backup status to be set")
+	}
+	if !status.LastSuccessfulBackupTime.Equal(&later) {
+		t.Errorf("expected last successful backup at %v, got %v", later, status.LastSuccessfulBackupTime)
+	}
+	if status.LastBackupObject != "test-cluster-storeuploader-new" {
+		t.Errorf("expected last backup object to be %q, got %q", "test-cluster-storeuploader-new", status.LastBackupObject)
+	}
+	if status.LastFailureTime == nil || !status.LastFailureTime.Equal(&earlier) {
+		t.Errorf("expected last failure at %v, got %v", earlier, status.LastFailureTime)
+	}
+	if expected := "BackoffLimitExceeded: Job has reached the specified backoff limit"; status.LastFailureReason != expected {
+		t.Errorf("expected last failure reason to be %q, got %q", expected, status.LastFailureReason)
+	}
+}
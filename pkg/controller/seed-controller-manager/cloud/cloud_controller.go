@@ -26,6 +26,7 @@ import (
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -58,18 +59,27 @@ const (
 	// currentMigrationRevision describes the current migration revision. If this is set on the
 	// cluster, certain migrations wont get executed. This must never be decremented.
 	CurrentMigrationRevision = awsHarcodedAZMigrationRevision
+	// periodicReconcileInterval is the interval in which cloud providers implementing the
+	// provider.PeriodicReconciler interface are reconciled.
+	periodicReconcileInterval = 5 * time.Minute
 )
 
 // Check if the Reconciler fullfills the interface
 // at compile time
 var _ reconcile.Reconciler = &Reconciler{}
 
+// userClusterConnectionProvider offers functions to retrieve clients for the given user clusters
+type userClusterConnectionProvider interface {
+	GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (client.Client, error)
+}
+
 type Reconciler struct {
 	client.Client
-	log        *zap.SugaredLogger
-	recorder   record.EventRecorder
-	seedGetter provider.SeedGetter
-	workerName string
+	log                           *zap.SugaredLogger
+	recorder                      record.EventRecorder
+	seedGetter                    provider.SeedGetter
+	userClusterConnectionProvider userClusterConnectionProvider
+	workerName                    string
 }
 
 func Add(
@@ -77,14 +87,16 @@ func Add(
 	log *zap.SugaredLogger,
 	numWorkers int,
 	seedGetter provider.SeedGetter,
+	userClusterConnectionProvider userClusterConnectionProvider,
 	workerName string,
 ) error {
 	reconciler := &Reconciler{
-		Client:     mgr.GetClient(),
-		log:        log.Named(ControllerName),
-		recorder:   mgr.GetEventRecorderFor(ControllerName),
-		seedGetter: seedGetter,
-		workerName: workerName,
+		Client:                        mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		seedGetter:                    seedGetter,
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		workerName:                    workerName,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
@@ -174,17 +186,31 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		}
 	}
 
-	if _, err := prov.InitializeCloudProvider(cluster, r.updateCluster); err != nil {
+	initializedCluster, err := prov.InitializeCloudProvider(cluster, r.updateCluster)
+	if err != nil {
 		return nil, fmt.Errorf("failed cloud provider init: %v", err)
 	}
 
+	var result *reconcile.Result
+	if prov, ok := prov.(provider.PeriodicReconciler); ok {
+		if initializedCluster, err = prov.ReconcileCloudProvider(ctx, r.Client, r.userClusterClient, initializedCluster, r.updateCluster); err != nil {
+			return nil, fmt.Errorf("failed cloud provider reconciling: %v", err)
+		}
+		// Changes of the managed resources do not trigger a reconcile.
+		result = &reconcile.Result{RequeueAfter: periodicReconcileInterval}
+	}
+
 	if _, err := r.updateCluster(cluster.Name, func(c *kubermaticv1.Cluster) {
 		c.Status.ExtendedHealth.CloudProviderInfrastructure = kubermaticv1.HealthStatusUp
 	}); err != nil {
 		return nil, fmt.Errorf("failed to set cluster health: %v", err)
 	}
 
-	return nil, nil
+	return result, nil
+}
+
+func (r *Reconciler) userClusterClient(cluster *kubermaticv1.Cluster) (client.Client, error) {
+	return r.userClusterConnectionProvider.GetClient(cluster)
 }
 
 func (r *Reconciler) migrateICMP(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, cloudProvider provider.CloudProvider) error {
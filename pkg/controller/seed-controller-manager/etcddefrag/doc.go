@@ -0,0 +1,26 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package etcddefrag contains a controller that keeps the etcd databases of user clusters below their backend quota.
+
+The controller periodically checks the database size of every etcd member. Once a member exceeds the threshold
+configured in the seed, the members get defragmented one at a time, the followers first and the leader last.
+The keyspace is not compacted by the controller, etcd compacts it itself according to its auto compaction
+retention. The database sizes and the results are exposed as metrics and as the
+EtcdDatabaseSizeHealthy condition of the cluster.
+*/
+package etcddefrag
@@ -0,0 +1,47 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcddefrag
+
+import (
+	"context"
+
+	"go.etcd.io/etcd/v3/clientv3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// etcdClient is the subset of the etcd client used by the controller
+type etcdClient interface {
+	MemberList(ctx context.Context) (*clientv3.MemberListResponse, error)
+	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
+	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
+	AlarmDisarm(ctx context.Context, alarm *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
+	Close() error
+}
+
+// etcdClientFactory returns a client for the etcd of the given cluster
+type etcdClientFactory func(ctx context.Context, cluster *kubermaticv1.Cluster) (etcdClient, error)
+
+func newEtcdClientFactory(client ctrlruntimeclient.Client) etcdClientFactory {
+	return func(ctx context.Context, cluster *kubermaticv1.Cluster) (etcdClient, error) {
+		return etcd.NewClient(ctx, client, cluster)
+	}
+}
@@ -0,0 +1,448 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcddefrag
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.etcd.io/etcd/v3/clientv3"
+	"go.etcd.io/etcd/v3/etcdserver/etcdserverpb"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_etcd_defragmentation_controller"
+
+	// defaultQuotaBackendBytes is the backend quota of etcd if --quota-backend-bytes is not set,
+	// which is the case for the etcd of user clusters
+	defaultQuotaBackendBytes int64 = 2 * 1024 * 1024 * 1024
+
+	defaultDBSizeThresholdPercent        = 60
+	defaultFragmentationThresholdPercent = 25
+	defaultInterval                      = 15 * time.Minute
+
+	// requestTimeout is used for all requests to etcd except the defragmentation
+	requestTimeout = 10 * time.Second
+	// defragmentationTimeout limits how long a single member may be defragmented. The member
+	// does not serve any requests while it is being defragmented.
+	defragmentationTimeout = 5 * time.Minute
+	// memberHealthTimeout is how long we wait for a member to respond again after it got
+	// defragmented, before moving on to the next one
+	memberHealthTimeout = time.Minute
+)
+
+var (
+	dbSizeMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "etcd",
+		Name:      "database_size_bytes",
+		Help:      "Physically allocated size of the database of the etcd member",
+	}, []string{"cluster", "member"})
+	dbSizeInUseMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "etcd",
+		Name:      "database_size_in_use_bytes",
+		Help:      "Logically used size of the database of the etcd member",
+	}, []string{"cluster", "member"})
+	defragmentationsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubermatic",
+		Subsystem: "etcd",
+		Name:      "defragmentations_total",
+		Help:      "Number of defragmentations of the etcd member by result",
+	}, []string{"cluster", "member", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(dbSizeMetric, dbSizeInUseMetric, defragmentationsMetric)
+}
+
+type Reconciler struct {
+	log        *zap.SugaredLogger
+	workerName string
+	ctrlruntimeclient.Client
+	recorder record.EventRecorder
+	// seedGetter returns the seed, whose defragmentation settings are used
+	seedGetter    provider.SeedGetter
+	newEtcdClient etcdClientFactory
+	// members holds the names of the members we exposed metrics for, keyed by cluster name,
+	// so the metrics can be removed once the cluster is gone
+	members sync.Map
+}
+
+// Add creates a new etcd defragmentation controller that is responsible for keeping the
+// etcd databases of user clusters below their quota
+func Add(
+	log *zap.SugaredLogger,
+	mgr manager.Manager,
+	numWorkers int,
+	workerName string,
+	seedGetter provider.SeedGetter,
+) error {
+	log = log.Named(ControllerName)
+	reconciler := &Reconciler{
+		log:           log,
+		workerName:    workerName,
+		Client:        mgr.GetClient(),
+		recorder:      mgr.GetEventRecorderFor(ControllerName),
+		seedGetter:    seedGetter,
+		newEtcdClient: newEtcdClientFactory(mgr.GetClient()),
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	// Clusters are checked periodically, so we only react to updates that decide whether
+	// they are checked at all
+	clusterPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old := e.ObjectOld.(*kubermaticv1.Cluster)
+			new := e.ObjectNew.(*kubermaticv1.Cluster)
+			return old.Spec.Pause != new.Spec.Pause ||
+				old.Labels[kubermaticv1.WorkerNameLabelKey] != new.Labels[kubermaticv1.WorkerNameLabelKey] ||
+				old.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) !=
+					new.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue)
+		},
+	}
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}, clusterPredicate); err != nil {
+		return fmt.Errorf("failed to create watch for Clusters: %v", err)
+	}
+	return nil
+}
+
+// settings are the defragmentation settings of the seed with all defaults applied
+type settings struct {
+	disabled                      bool
+	dbSizeThreshold               int64
+	fragmentationThresholdPercent int64
+	interval                      time.Duration
+}
+
+func settingsFromSeed(seed *kubermaticv1.Seed) (*settings, error) {
+	s := &settings{
+		dbSizeThreshold:               defaultQuotaBackendBytes * defaultDBSizeThresholdPercent / 100,
+		fragmentationThresholdPercent: defaultFragmentationThresholdPercent,
+		interval:                      defaultInterval,
+	}
+	config := seed.Spec.EtcdDefragmentation
+	if config == nil {
+		return s, nil
+	}
+	s.disabled = config.Disabled
+	if config.DBSizeThresholdPercent > 0 {
+		s.dbSizeThreshold = defaultQuotaBackendBytes * int64(config.DBSizeThresholdPercent) / 100
+	}
+	if config.FragmentationThresholdPercent > 0 {
+		s.fragmentationThresholdPercent = int64(config.FragmentationThresholdPercent)
+	}
+	if config.Interval != "" {
+		interval, err := time.ParseDuration(config.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid etcd defragmentation interval %q: %v", config.Interval, err)
+		}
+		s.interval = interval
+	}
+	return s, nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.deleteMetrics(request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
+		return reconcile.Result{}, nil
+	}
+	if cluster.DeletionTimestamp != nil {
+		r.deleteMetrics(cluster.Name)
+		return reconcile.Result{}, nil
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get seed: %v", err)
+	}
+	settings, err := settingsFromSeed(seed)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	result := reconcile.Result{RequeueAfter: settings.interval}
+
+	// Etcd is not touched while the cluster is paused, e.g. because it is being restored
+	if cluster.Spec.Pause || cluster.Status.NamespaceName == "" ||
+		!cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) {
+		return result, nil
+	}
+
+	if err := r.reconcile(ctx, log, cluster, settings); err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "EtcdDefragmentationError", err.Error())
+		return result, err
+	}
+	return result, nil
+}
+
+// memberStatus is the status of a single etcd member
+type memberStatus struct {
+	name        string
+	endpoint    string
+	leader      bool
+	dbSize      int64
+	dbSizeInUse int64
+}
+
+// fragmented returns whether at least the given percentage of the database is unused
+func (m *memberStatus) fragmented(thresholdPercent int64) bool {
+	return (m.dbSize-m.dbSizeInUse)*100 >= m.dbSize*thresholdPercent
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, settings *settings) error {
+	client, err := r.newEtcdClient(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %v", err)
+	}
+	defer client.Close()
+
+	members, err := r.memberStatuses(ctx, client, cluster)
+	if err != nil {
+		return err
+	}
+
+	exceeding := membersExceeding(members, settings.dbSizeThreshold)
+	if len(exceeding) == 0 {
+		if err := r.disarmNoSpaceAlarms(ctx, log, client); err != nil {
+			return err
+		}
+		return r.setCondition(ctx, cluster, corev1.ConditionTrue, kubermaticv1.ReasonEtcdDatabaseSizeBelowThreshold, "")
+	}
+
+	if settings.disabled {
+		return r.setCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonEtcdDefragmentationDisabled,
+			exceedingMessage(exceeding, settings.dbSizeThreshold))
+	}
+
+	leader := members[len(members)-1]
+	if !leader.leader {
+		return fmt.Errorf("etcd has no leader")
+	}
+
+	// The keyspace is compacted by etcd itself, which keeps the revisions of the auto compaction
+	// retention. Defragmenting returns the space freed by the compaction to the filesystem.
+	defragmented := false
+	for _, member := range members {
+		if !member.fragmented(settings.fragmentationThresholdPercent) {
+			continue
+		}
+		if err := r.defragment(ctx, log, client, cluster, member); err != nil {
+			if conditionErr := r.setCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonEtcdDefragmentationFailed,
+				fmt.Sprintf("Failed to defragment member %s: %v", member.name, err)); conditionErr != nil {
+				log.Errorw("Failed to set condition", zap.Error(conditionErr))
+			}
+			return err
+		}
+		defragmented = true
+	}
+
+	if defragmented {
+		if members, err = r.memberStatuses(ctx, client, cluster); err != nil {
+			return err
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "EtcdDefragmented", "All fragmented etcd members were defragmented")
+	}
+	if exceeding = membersExceeding(members, settings.dbSizeThreshold); len(exceeding) > 0 {
+		// The members mostly consist of data that is in use, so defragmenting them again would not help
+		return r.setCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonEtcdDatabaseNotFragmented,
+			exceedingMessage(exceeding, settings.dbSizeThreshold))
+	}
+	if err := r.disarmNoSpaceAlarms(ctx, log, client); err != nil {
+		return err
+	}
+	return r.setCondition(ctx, cluster, corev1.ConditionTrue, kubermaticv1.ReasonEtcdDefragmented, "")
+}
+
+// memberStatuses returns the status of all members, sorted by name with the leader last. The
+// statuses are exposed as metrics. It fails if any member is not responding, as we must not
+// defragment a member while the quorum depends on it.
+func (r *Reconciler) memberStatuses(ctx context.Context, client etcdClient, cluster *kubermaticv1.Cluster) ([]*memberStatus, error) {
+	listCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	memberList, err := client.MemberList(listCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members: %v", err)
+	}
+
+	var members []*memberStatus
+	var names []string
+	for _, member := range memberList.Members {
+		status := &memberStatus{
+			name:     member.Name,
+			endpoint: etcd.MemberEndpoint(cluster, member.Name),
+		}
+		resp, err := r.memberStatus(ctx, client, status.endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status of etcd member %s: %v", member.Name, err)
+		}
+		status.leader = resp.Header.MemberId == resp.Leader
+		status.dbSize = resp.DbSize
+		status.dbSizeInUse = resp.DbSizeInUse
+
+		dbSizeMetric.WithLabelValues(cluster.Name, member.Name).Set(float64(status.dbSize))
+		dbSizeInUseMetric.WithLabelValues(cluster.Name, member.Name).Set(float64(status.dbSizeInUse))
+		members = append(members, status)
+		names = append(names, member.Name)
+	}
+	r.members.Store(cluster.Name, names)
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].leader != members[j].leader {
+			return members[j].leader
+		}
+		return members[i].name < members[j].name
+	})
+	return members, nil
+}
+
+func (r *Reconciler) memberStatus(ctx context.Context, client etcdClient, endpoint string) (*clientv3.StatusResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	return client.Status(ctx, endpoint)
+}
+
+// defragment defragments a single member and waits for it to respond again
+func (r *Reconciler) defragment(ctx context.Context, log *zap.SugaredLogger, client etcdClient, cluster *kubermaticv1.Cluster, member *memberStatus) error {
+	log = log.With("member", member.name, "leader", member.leader)
+	log.Infow("Defragmenting etcd member", "db-size", member.dbSize, "db-size-in-use", member.dbSizeInUse)
+
+	defragCtx, cancel := context.WithTimeout(ctx, defragmentationTimeout)
+	_, err := client.Defragment(defragCtx, member.endpoint)
+	cancel()
+	if err != nil {
+		defragmentationsMetric.WithLabelValues(cluster.Name, member.name, "failure").Inc()
+		return err
+	}
+	defragmentationsMetric.WithLabelValues(cluster.Name, member.name, "success").Inc()
+
+	return wait.PollImmediate(time.Second, memberHealthTimeout, func() (bool, error) {
+		_, err := r.memberStatus(ctx, client, member.endpoint)
+		return err == nil, nil
+	})
+}
+
+// disarmNoSpaceAlarms clears the alarms raised by members that exceeded the quota. Etcd
+// only accepts writes again once they are cleared, even if the database got smaller.
+func (r *Reconciler) disarmNoSpaceAlarms(ctx context.Context, log *zap.SugaredLogger, client etcdClient) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	alarms, err := client.AlarmList(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list etcd alarms: %v", err)
+	}
+	for _, alarm := range alarms.Alarms {
+		if alarm.Alarm != etcdserverpb.AlarmType_NOSPACE {
+			continue
+		}
+		log.Infow("Disarming etcd NOSPACE alarm", "member-id", alarm.MemberID)
+		if _, err := client.AlarmDisarm(ctx, (*clientv3.AlarmMember)(alarm)); err != nil {
+			return fmt.Errorf("failed to disarm NOSPACE alarm of etcd member %x: %v", alarm.MemberID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) setCondition(ctx context.Context, cluster *kubermaticv1.Cluster, status corev1.ConditionStatus, reason, message string) error {
+	oldCluster := cluster.DeepCopy()
+	kubermaticv1helper.SetClusterCondition(cluster, kubermaticv1.ClusterConditionEtcdDatabaseSizeHealthy, status, reason, message)
+	if reflect.DeepEqual(oldCluster, cluster) {
+		return nil
+	}
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update cluster condition: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteMetrics(clusterName string) {
+	names, ok := r.members.Load(clusterName)
+	if !ok {
+		return
+	}
+	for _, name := range names.([]string) {
+		dbSizeMetric.DeleteLabelValues(clusterName, name)
+		dbSizeInUseMetric.DeleteLabelValues(clusterName, name)
+		defragmentationsMetric.DeleteLabelValues(clusterName, name, "success")
+		defragmentationsMetric.DeleteLabelValues(clusterName, name, "failure")
+	}
+	r.members.Delete(clusterName)
+}
+
+func membersExceeding(members []*memberStatus, threshold int64) []*memberStatus {
+	var exceeding []*memberStatus
+	for _, member := range members {
+		if member.dbSize >= threshold {
+			exceeding = append(exceeding, member)
+		}
+	}
+	return exceeding
+}
+
+// exceedingMessage lists the members above the threshold. The sizes are only exposed as metrics,
+// as they change constantly.
+func exceedingMessage(members []*memberStatus, threshold int64) string {
+	var names []string
+	for _, member := range members {
+		names = append(names, member.name)
+	}
+	return fmt.Sprintf("The database of %s exceeds %dMiB", strings.Join(names, ", "), threshold/1024/1024)
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcddefrag
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.etcd.io/etcd/v3/clientv3"
+	"go.etcd.io/etcd/v3/etcdserver/etcdserverpb"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testClusterName = "test-cluster"
+	mib             = 1024 * 1024
+)
+
+type fakeMember struct {
+	id          uint64
+	name        string
+	dbSize      int64
+	dbSizeInUse int64
+}
+
+// fakeEtcdClient simulates an etcd cluster, whose defragmentation shrinks the database
+// of a member to the size in use
+type fakeEtcdClient struct {
+	members       []*fakeMember
+	leader        uint64
+	alarms        []*etcdserverpb.AlarmMember
+	defragErr     error
+	defragmented  []string
+	disarmedAlarm bool
+}
+
+func (f *fakeEtcdClient) member(endpoint string) (*fakeMember, error) {
+	for _, m := range f.members {
+		if endpoint == etcd.MemberEndpoint(testCluster(), m.name) {
+			return m, nil
+		}
+	}
+	return nil, errors.New("unknown endpoint")
+}
+
+func (f *fakeEtcdClient) MemberList(ctx context.Context) (*clientv3.MemberListResponse, error) {
+	resp := &clientv3.MemberListResponse{}
+	for _, m := range f.members {
+		resp.Members = append(resp.Members, &etcdserverpb.Member{ID: m.id, Name: m.name})
+	}
+	return resp, nil
+}
+
+func (f *fakeEtcdClient) Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error) {
+	m, err := f.member(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &clientv3.StatusResponse{
+		Header:      &etcdserverpb.ResponseHeader{MemberId: m.id},
+		Leader:      f.leader,
+		DbSize:      m.dbSize,
+		DbSizeInUse: m.dbSizeInUse,
+	}, nil
+}
+
+func (f *fakeEtcdClient) Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error) {
+	if f.defragErr != nil {
+		return nil, f.defragErr
+	}
+	m, err := f.member(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	m.dbSize = m.dbSizeInUse
+	f.defragmented = append(f.defragmented, m.name)
+	return &clientv3.DefragmentResponse{}, nil
+}
+
+func (f *fakeEtcdClient) AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error) {
+	return &clientv3.AlarmResponse{Alarms: f.alarms}, nil
+}
+
+func (f *fakeEtcdClient) AlarmDisarm(ctx context.Context, alarm *clientv3.AlarmMember) (*clientv3.AlarmResponse, error) {
+	f.alarms = nil
+	f.disarmedAlarm = true
+	return &clientv3.AlarmResponse{}, nil
+}
+
+func (f *fakeEtcdClient) Close() error {
+	return nil
+}
+
+func testCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterName},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + testClusterName,
+			Conditions: []kubermaticv1.ClusterCondition{
+				{
+					Type:   kubermaticv1.ClusterConditionEtcdClusterInitialized,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		seedSettings         *kubermaticv1.EtcdDefragmentationSettings
+		pauseCluster         bool
+		etcd                 *fakeEtcdClient
+		expectedDefragmented []string
+		expectedCondition    corev1.ConditionStatus
+		expectedReason       string
+		expectErr            bool
+	}{
+		{
+			name: "Databases below threshold are left alone",
+			etcd: &fakeEtcdClient{
+				leader: 1,
+				members: []*fakeMember{
+					{id: 1, name: "etcd-0", dbSize: 100 * mib, dbSizeInUse: 10 * mib},
+					{id: 2, name: "etcd-1", dbSize: 100 * mib, dbSizeInUse: 10 * mib},
+					{id: 3, name: "etcd-2", dbSize: 100 * mib, dbSizeInUse: 10 * mib},
+				},
+			},
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    kubermaticv1.ReasonEtcdDatabaseSizeBelowThreshold,
+		},
+		{
+			name: "Members are defragmented one at a time with the leader last",
+			etcd: &fakeEtcdClient{
+				leader: 1,
+				members: []*fakeMember{
+					{id: 1, name: "etcd-0", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+					{id: 2, name: "etcd-1", dbSize: 1400 * mib, dbSizeInUse: 100 * mib},
+					{id: 3, name: "etcd-2", dbSize: 1400 * mib, dbSizeInUse: 100 * mib},
+				},
+				alarms: []*etcdserverpb.AlarmMember{{MemberID: 1, Alarm: etcdserverpb.AlarmType_NOSPACE}},
+			},
+			expectedDefragmented: []string{"etcd-1", "etcd-2", "etcd-0"},
+			expectedCondition:    corev1.ConditionTrue,
+			expectedReason:       kubermaticv1.ReasonEtcdDefragmented,
+		},
+		{
+			name:         "Threshold is taken from the seed",
+			seedSettings: &kubermaticv1.EtcdDefragmentationSettings{DBSizeThresholdPercent: 10},
+			etcd: &fakeEtcdClient{
+				leader: 2,
+				members: []*fakeMember{
+					{id: 1, name: "etcd-0", dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+					{id: 2, name: "etcd-1", dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+					{id: 3, name: "etcd-2", dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+				},
+			},
+			expectedDefragmented: []string{"etcd-0", "etcd-2", "etcd-1"},
+			expectedCondition:    corev1.ConditionTrue,
+			expectedReason:       kubermaticv1.ReasonEtcdDefragmented,
+		},
+		{
+			name: "Databases that are in use are not defragmented",
+			etcd: &fakeEtcdClient{
+				leader: 1,
+				members: []*fakeMember{
+					{id: 1, name: "etcd-0", dbSize: 1500 * mib, dbSizeInUse: 1400 * mib},
+					{id: 2, name: "etcd-1", dbSize: 1500 * mib, dbSizeInUse: 1400 * mib},
+					{id: 3, name: "etcd-2", dbSize: 1500 * mib, dbSizeInUse: 1400 * mib},
+				},
+			},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdDatabaseNotFragmented,
+		},
+		{
+			name:         "Disabled defragmentation only reports the size",
+			seedSettings: &kubermaticv1.EtcdDefragmentationSettings{Disabled: true},
+			etcd: &fakeEtcdClient{
+				leader: 1,
+				members: []*fakeMember{
+					{id: 1, name: "etcd-0", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+					{id: 2, name: "etcd-1", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+					{id: 3, name: "etcd-2", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+				},
+			},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdDefragmentationDisabled,
+		},
+		{
+			name: "Failed defragmentation is reported",
+			etcd: &fakeEtcdClient{
+				leader:    1,
+				defragErr: errors.New("timeout"),
+				members: []*fakeMember{
+					{id: 1, name: "etcd-0", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+					{id: 2, name: "etcd-1", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+					{id: 3, name: "etcd-2", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+				},
+			},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdDefragmentationFailed,
+			expectErr:         true,
+		},
+		{
+			name:         "Paused clusters are skipped",
+			pauseCluster: true,
+			etcd: &fakeEtcdClient{
+				leader: 1,
+				members: []*fakeMember{
+					{id: 1, name: "etcd-0", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+					{id: 2, name: "etcd-1", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+					{id: 3, name: "etcd-2", dbSize: 1500 * mib, dbSizeInUse: 100 * mib},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := testCluster()
+			cluster.Spec.Pause = tc.pauseCluster
+			seed := &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{EtcdDefragmentation: tc.seedSettings},
+			}
+
+			ctx := context.Background()
+			r := &Reconciler{
+				log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client:   ctrlruntimefakeclient.NewFakeClient(cluster),
+				recorder: record.NewFakeRecorder(10),
+				seedGetter: func() (*kubermaticv1.Seed, error) {
+					return seed, nil
+				},
+				newEtcdClient: func(context.Context, *kubermaticv1.Cluster) (etcdClient, error) {
+					return tc.etcd, nil
+				},
+			}
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testClusterName}})
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+			if result.RequeueAfter != defaultInterval {
+				t.Errorf("expected cluster to be checked again after %v, got %v", defaultInterval, result.RequeueAfter)
+			}
+
+			if err := r.Get(ctx, types.NamespacedName{Name: testClusterName}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if len(tc.etcd.defragmented) != len(tc.expectedDefragmented) {
+				t.Fatalf("expected members %v to be defragmented, got %v", tc.expectedDefragmented, tc.etcd.defragmented)
+			}
+			for i := range tc.expectedDefragmented {
+				if tc.etcd.defragmented[i] != tc.expectedDefragmented[i] {
+					t.Fatalf("expected members %v to be defragmented, got %v", tc.expectedDefragmented, tc.etcd.defragmented)
+				}
+			}
+			if len(tc.etcd.alarms) > 0 {
+				t.Errorf("expected NOSPACE alarms to be disarmed, got %v", tc.etcd.alarms)
+			}
+
+			_, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionEtcdDatabaseSizeHealthy)
+			if tc.expectedCondition == "" {
+				if condition != nil {
+					t.Fatalf("expected no condition, got %+v", condition)
+				}
+				return
+			}
+			if condition == nil {
+				t.Fatal("expected condition to be set")
+			}
+			if condition.Status != tc.expectedCondition || condition.Reason != tc.expectedReason {
+				t.Errorf("expected condition %s with reason %s, got %s with reason %s", tc.expectedCondition, tc.expectedReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
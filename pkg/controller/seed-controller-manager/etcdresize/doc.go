@@ -0,0 +1,28 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package etcdresize contains a controller that resizes the etcd of user clusters to the configured number of members.
+
+New members are added by the StatefulSet reconciling of the cluster controller, one at a time and only while etcd is
+healthy. The etcd launcher of a new member adds it as learner and promotes it once it caught up with the leader.
+Removing members is done by this controller: the member with the highest ordinal is removed from etcd while all
+other members are healthy and only then the StatefulSet is scaled in, so the quorum never depends on a deleted pod.
+The etcd launchers read the size from the cluster, so a resize only changes the replicas of the StatefulSet and
+does not restart the existing members.
+The progress is reported in the EtcdClusterSizeReconciled condition of the cluster.
+*/
+package etcdresize
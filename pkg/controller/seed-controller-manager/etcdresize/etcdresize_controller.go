@@ -0,0 +1,296 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdresize
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/v3/clientv3"
+	"go.etcd.io/etcd/v3/etcdserver/etcdserverpb"
+	"go.uber.org/zap"
+
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_etcd_resize_controller"
+
+	// requeueInterval is used to check the progress of a resize
+	requeueInterval = 10 * time.Second
+	// requestTimeout is used for all requests to etcd
+	requestTimeout = 10 * time.Second
+)
+
+// etcdClient is the subset of the etcd client used by the controller
+type etcdClient interface {
+	MemberList(ctx context.Context) (*clientv3.MemberListResponse, error)
+	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
+	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
+	Close() error
+}
+
+type Reconciler struct {
+	log        *zap.SugaredLogger
+	workerName string
+	ctrlruntimeclient.Client
+	recorder      record.EventRecorder
+	newEtcdClient func(ctx context.Context, cluster *kubermaticv1.Cluster) (etcdClient, error)
+}
+
+// Add creates a new etcd resize controller that is responsible for safely changing the
+// number of etcd members of user clusters
+func Add(
+	log *zap.SugaredLogger,
+	mgr manager.Manager,
+	numWorkers int,
+	workerName string,
+) error {
+	log = log.Named(ControllerName)
+	client := mgr.GetClient()
+	reconciler := &Reconciler{
+		log:        log,
+		workerName: workerName,
+		Client:     client,
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		newEtcdClient: func(ctx context.Context, cluster *kubermaticv1.Cluster) (etcdClient, error) {
+			return etcd.NewClient(ctx, client, cluster)
+		},
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	// Only react to cluster updates that affect the size of etcd or whether it may be touched
+	clusterPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old := e.ObjectOld.(*kubermaticv1.Cluster)
+			new := e.ObjectNew.(*kubermaticv1.Cluster)
+			return old.Spec.ComponentsOverride.Etcd.ClusterSize != new.Spec.ComponentsOverride.Etcd.ClusterSize ||
+				old.Spec.Pause != new.Spec.Pause ||
+				old.Labels[kubermaticv1.WorkerNameLabelKey] != new.Labels[kubermaticv1.WorkerNameLabelKey] ||
+				old.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) !=
+					new.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue)
+		},
+	}
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}, clusterPredicate); err != nil {
+		return fmt.Errorf("failed to create watch for Clusters: %v", err)
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &appsv1.StatefulSet{}},
+		controllerutil.EnqueueClusterForNamespacedObject(client),
+		predicateutil.ByName(resources.EtcdStatefulSetName),
+	); err != nil {
+		return fmt.Errorf("failed to create watch for StatefulSets: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
+		return reconcile.Result{}, nil
+	}
+	// Etcd is not touched while the cluster is paused, e.g. because it is being restored
+	if cluster.DeletionTimestamp != nil || cluster.Spec.Pause || cluster.Status.NamespaceName == "" ||
+		!cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, cluster)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "EtcdResizeError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	size, err := etcd.GetClusterSize(cluster)
+	if err != nil {
+		return nil, r.setCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonEtcdInvalidClusterSize, err.Error())
+	}
+
+	set := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}, set); err != nil {
+		return nil, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+	if set.Spec.Replicas == nil {
+		return nil, nil
+	}
+	replicas := int(*set.Spec.Replicas)
+
+	client, err := r.newEtcdClient(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create etcd client: %v", err)
+	}
+	defer client.Close()
+
+	listCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	memberList, err := client.MemberList(listCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members: %v", err)
+	}
+	members := memberList.Members
+	learners := 0
+	for _, member := range members {
+		if member.IsLearner {
+			learners++
+		}
+	}
+
+	if size < replicas {
+		return r.scaleIn(ctx, log, cluster, client, set, members, size)
+	}
+	if size == replicas && len(members) == size && learners == 0 {
+		return nil, r.setCondition(ctx, cluster, corev1.ConditionTrue, kubermaticv1.ReasonEtcdClusterSizeReached, "")
+	}
+
+	// Members are added by the StatefulSet reconciling and the etcd launcher, we only report the progress
+	message := fmt.Sprintf("Scaling out to %d members, %d voting members and %d learners have joined", size, len(members)-learners, learners)
+	if err := r.setCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonEtcdScalingOut, message); err != nil {
+		return nil, err
+	}
+	return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// scaleIn removes the member with the highest ordinal from etcd and scales in the StatefulSet afterwards
+func (r *Reconciler) scaleIn(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	cluster *kubermaticv1.Cluster,
+	client etcdClient,
+	set *appsv1.StatefulSet,
+	members []*etcdserverpb.Member,
+	size int,
+) (*reconcile.Result, error) {
+	replicas := int(*set.Spec.Replicas)
+	departing := fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, replicas-1)
+	log = log.With("member", departing)
+
+	message := fmt.Sprintf("Scaling in to %d members, removing member %s", size, departing)
+	if err := r.setCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonEtcdScalingIn, message); err != nil {
+		return nil, err
+	}
+
+	var departingMember *etcdserverpb.Member
+	for _, member := range members {
+		if memberName(member) == departing {
+			departingMember = member
+		}
+	}
+
+	if departingMember != nil {
+		// Removing a member only keeps the quorum if all others are healthy
+		for _, member := range members {
+			if member == departingMember {
+				continue
+			}
+			if member.IsLearner {
+				log.Infow("Waiting for learner to be promoted before removing member", "learner", memberName(member))
+				return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+			}
+			statusCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+			_, err := client.Status(statusCtx, etcd.MemberEndpoint(cluster, memberName(member)))
+			cancel()
+			if err != nil {
+				log.Infow("Waiting for member to become healthy before removing member", "unhealthy-member", memberName(member), zap.Error(err))
+				return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+			}
+		}
+
+		log.Info("Removing etcd member")
+		removeCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		if _, err := client.MemberRemove(removeCtx, departingMember.ID); err != nil {
+			return nil, fmt.Errorf("failed to remove etcd member %s: %v", departing, err)
+		}
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "EtcdMemberRemoved", "Removed etcd member %s", departing)
+	}
+
+	// The member is gone, so its pod can be deleted
+	oldSet := set.DeepCopy()
+	set.Spec.Replicas = resources.Int32(int32(replicas - 1))
+	if err := r.Patch(ctx, set, ctrlruntimeclient.MergeFrom(oldSet)); err != nil {
+		return nil, fmt.Errorf("failed to scale in etcd StatefulSet: %v", err)
+	}
+	return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// memberName returns the name of the member. Members that have not been started yet have no
+// name, so it is taken from their peer URL.
+func memberName(member *etcdserverpb.Member) string {
+	if member.Name != "" || len(member.PeerURLs) == 0 {
+		return member.Name
+	}
+	host := strings.TrimPrefix(member.PeerURLs[0], "http://")
+	return strings.SplitN(host, ".", 2)[0]
+}
+
+func (r *Reconciler) setCondition(ctx context.Context, cluster *kubermaticv1.Cluster, status corev1.ConditionStatus, reason, message string) error {
+	oldCluster := cluster.DeepCopy()
+	kubermaticv1helper.SetClusterCondition(cluster, kubermaticv1.ClusterConditionEtcdClusterSizeReconciled, status, reason, message)
+	if reflect.DeepEqual(oldCluster, cluster) {
+		return nil
+	}
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update cluster condition: %v", err)
+	}
+	return nil
+}
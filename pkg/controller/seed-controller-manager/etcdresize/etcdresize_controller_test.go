@@ -0,0 +1,246 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdresize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.etcd.io/etcd/v3/clientv3"
+	"go.etcd.io/etcd/v3/etcdserver/etcdserverpb"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testClusterName = "test-cluster"
+	testNamespace   = "cluster-test-cluster"
+)
+
+type fakeEtcdClient struct {
+	members   []*etcdserverpb.Member
+	unhealthy map[string]bool
+	removed   []uint64
+}
+
+func (f *fakeEtcdClient) MemberList(ctx context.Context) (*clientv3.MemberListResponse, error) {
+	return &clientv3.MemberListResponse{Members: f.members}, nil
+}
+
+func (f *fakeEtcdClient) MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error) {
+	f.removed = append(f.removed, id)
+	return &clientv3.MemberRemoveResponse{}, nil
+}
+
+func (f *fakeEtcdClient) Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error) {
+	for _, m := range f.members {
+		if endpoint == etcd.MemberEndpoint(testCluster(0), m.Name) {
+			if f.unhealthy[m.Name] {
+				return nil, errors.New("context deadline exceeded")
+			}
+			return &clientv3.StatusResponse{}, nil
+		}
+	}
+	return nil, errors.New("unknown endpoint")
+}
+
+func (f *fakeEtcdClient) Close() error {
+	return nil
+}
+
+func testCluster(size int) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterName},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: testNamespace,
+			Conditions: []kubermaticv1.ClusterCondition{
+				{
+					Type:   kubermaticv1.ClusterConditionEtcdClusterInitialized,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	cluster.Spec.ComponentsOverride.Etcd.ClusterSize = size
+	return cluster
+}
+
+func testMembers(n int, learners ...int) []*etcdserverpb.Member {
+	var members []*etcdserverpb.Member
+	for i := 0; i < n; i++ {
+		members = append(members, &etcdserverpb.Member{
+			ID:       uint64(i + 1),
+			Name:     fmt.Sprintf("etcd-%d", i),
+			PeerURLs: []string{fmt.Sprintf("http://etcd-%d.etcd.%s.svc.cluster.local:2380", i, testNamespace)},
+		})
+	}
+	for _, i := range learners {
+		// learners that have not been started yet have no name
+		members[i].Name = ""
+		members[i].IsLearner = true
+	}
+	return members
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name              string
+		clusterSize       int
+		replicas          int32
+		etcd              *fakeEtcdClient
+		expectedReplicas  int32
+		expectedRemoved   []uint64
+		expectedCondition corev1.ConditionStatus
+		expectedReason    string
+	}{
+		{
+			name:              "Cluster at its size",
+			clusterSize:       3,
+			replicas:          3,
+			etcd:              &fakeEtcdClient{members: testMembers(3)},
+			expectedReplicas:  3,
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    kubermaticv1.ReasonEtcdClusterSizeReached,
+		},
+		{
+			name:              "Clusters without size have the default size",
+			replicas:          3,
+			etcd:              &fakeEtcdClient{members: testMembers(3)},
+			expectedReplicas:  3,
+			expectedCondition: corev1.ConditionTrue,
+			expectedReason:    kubermaticv1.ReasonEtcdClusterSizeReached,
+		},
+		{
+			name:              "Scale out is reported until the learner got promoted",
+			clusterSize:       5,
+			replicas:          4,
+			etcd:              &fakeEtcdClient{members: testMembers(4, 3)},
+			expectedReplicas:  4,
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdScalingOut,
+		},
+		{
+			name:              "Member is removed before the StatefulSet is scaled in",
+			clusterSize:       3,
+			replicas:          5,
+			etcd:              &fakeEtcdClient{members: testMembers(5)},
+			expectedReplicas:  4,
+			expectedRemoved:   []uint64{5},
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdScalingIn,
+		},
+		{
+			name:              "Already removed member is only scaled in",
+			clusterSize:       3,
+			replicas:          4,
+			etcd:              &fakeEtcdClient{members: testMembers(3)},
+			expectedReplicas:  3,
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdScalingIn,
+		},
+		{
+			name:        "Member is not removed while another one is unhealthy",
+			clusterSize: 3,
+			replicas:    5,
+			etcd: &fakeEtcdClient{
+				members:   testMembers(5),
+				unhealthy: map[string]bool{"etcd-1": true},
+			},
+			expectedReplicas:  5,
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdScalingIn,
+		},
+		{
+			name:              "Invalid sizes are reported",
+			clusterSize:       4,
+			replicas:          3,
+			etcd:              &fakeEtcdClient{members: testMembers(3)},
+			expectedReplicas:  3,
+			expectedCondition: corev1.ConditionFalse,
+			expectedReason:    kubermaticv1.ReasonEtcdInvalidClusterSize,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := testCluster(tc.clusterSize)
+			set := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: resources.EtcdStatefulSetName},
+				Spec:       appsv1.StatefulSetSpec{Replicas: resources.Int32(tc.replicas)},
+			}
+
+			ctx := context.Background()
+			r := &Reconciler{
+				log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client:   ctrlruntimefakeclient.NewFakeClient(cluster, set),
+				recorder: record.NewFakeRecorder(10),
+				newEtcdClient: func(context.Context, *kubermaticv1.Cluster) (etcdClient, error) {
+					return tc.etcd, nil
+				},
+			}
+
+			if _, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testClusterName}}); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+
+			if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.EtcdStatefulSetName}, set); err != nil {
+				t.Fatalf("failed to get StatefulSet: %v", err)
+			}
+			if *set.Spec.Replicas != tc.expectedReplicas {
+				t.Errorf("expected %d replicas, got %d", tc.expectedReplicas, *set.Spec.Replicas)
+			}
+			if fmt.Sprint(tc.etcd.removed) != fmt.Sprint(tc.expectedRemoved) {
+				t.Errorf("expected members %v to be removed, got %v", tc.expectedRemoved, tc.etcd.removed)
+			}
+
+			if err := r.Get(ctx, types.NamespacedName{Name: testClusterName}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			_, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionEtcdClusterSizeReconciled)
+			if condition == nil {
+				t.Fatal("expected condition to be set")
+			}
+			if condition.Status != tc.expectedCondition || condition.Reason != tc.expectedReason {
+				t.Errorf("expected condition %s with reason %s, got %s with reason %s", tc.expectedCondition, tc.expectedReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestMemberName(t *testing.T) {
+	members := testMembers(4, 3)
+	if name := memberName(members[3]); name != "etcd-3" {
+		t.Errorf("expected unstarted member to be named etcd-3, got %q", name)
+	}
+	if name := memberName(members[0]); name != "etcd-0" {
+		t.Errorf("expected member to be named etcd-0, got %q", name)
+	}
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package etcdrestore contains a controller that restores the etcd of user clusters from a backup.
+
+A restore is requested by creating an EtcdRestore in the namespace of the cluster. The controller then
+pauses the cluster, scales down its apiserver and removes the etcd StatefulSet including its volumes.
+Once the cluster is unpaused again, the etcd StatefulSet gets recreated and the etcd launcher of every
+member bootstraps its data dir from the backup before starting etcd.
+*/
+package etcdrestore
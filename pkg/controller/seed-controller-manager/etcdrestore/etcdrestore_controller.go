@@ -0,0 +1,480 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdrestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_etcd_restore_controller"
+
+	// pauseReason is set as PauseReason on the cluster while its etcd is being restored
+	pauseReason = "etcd restore in progress"
+	// requeueInterval is used to check again whether a step of the restore has finished
+	requeueInterval = 10 * time.Second
+)
+
+type Reconciler struct {
+	log        *zap.SugaredLogger
+	workerName string
+	ctrlruntimeclient.Client
+	recorder record.EventRecorder
+	// seedGetter returns the seed, whose backup encryption keys are provided to the etcd launcher
+	seedGetter provider.SeedGetter
+}
+
+// Add creates a new etcd restore controller that is responsible for restoring the
+// etcd of user clusters from backups
+func Add(
+	log *zap.SugaredLogger,
+	mgr manager.Manager,
+	numWorkers int,
+	workerName string,
+	seedGetter provider.SeedGetter,
+) error {
+	log = log.Named(ControllerName)
+	reconciler := &Reconciler{
+		log:        log,
+		workerName: workerName,
+		Client:     mgr.GetClient(),
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		seedGetter: seedGetter,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.EtcdRestore{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch for EtcdRestores: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	restore := &kubermaticv1.EtcdRestore{}
+	if err := r.Get(ctx, request.NamespacedName, restore); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: restore.Spec.Cluster.Name}, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Debug("Skipping because the cluster is gone")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	// The cluster is paused by this controller during the restore, so we must
+	// not skip paused clusters here.
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, restore, cluster)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(restore, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "ReconcilingError", "failed to reconcile etcd restore %s: %v", restore.Name, err)
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if restore.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	// The etcd launcher only looks for restores in its own namespace
+	if restore.Namespace != cluster.Status.NamespaceName {
+		return nil, fmt.Errorf("etcd restore must be created in the cluster namespace %q", cluster.Status.NamespaceName)
+	}
+
+	switch restore.Status.Phase {
+	case "":
+		return r.startRestore(ctx, log, restore, cluster)
+	case kubermaticv1.EtcdRestorePhaseStarted:
+		return r.removeEtcdData(ctx, log, restore, cluster)
+	case kubermaticv1.EtcdRestorePhaseStsRebuilding:
+		return r.waitForEtcd(ctx, log, restore, cluster)
+	}
+	return nil, nil
+}
+
+// startRestore pauses the cluster, so no controller recreates the resources we are about to remove.
+func (r *Reconciler) startRestore(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if restore.Spec.BackupName == "" {
+		return nil, fmt.Errorf("backupName must not be empty")
+	}
+	credentials := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: restore.Spec.BackupDownloadCredentialsSecret}, credentials); err != nil {
+		return nil, fmt.Errorf("failed to get backup download credentials secret %q: %v", restore.Spec.BackupDownloadCredentialsSecret, err)
+	}
+
+	restores := &kubermaticv1.EtcdRestoreList{}
+	if err := r.List(ctx, restores, ctrlruntimeclient.InNamespace(restore.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list etcd restores: %v", err)
+	}
+	for _, other := range restores.Items {
+		if other.Name != restore.Name && other.Status.Phase != "" && other.Status.Phase != kubermaticv1.EtcdRestorePhaseCompleted {
+			log.Infow("Waiting for other etcd restore to finish", "restore", other.Name)
+			return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+		}
+	}
+
+	// The pause state is recorded before the cluster is paused, so a cluster paused by an
+	// admin stays paused after the restore.
+	if restore.Status.ClusterPause == nil {
+		if err := r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+			restore.Status.ClusterPause = &kubermaticv1.EtcdRestoreClusterPause{
+				Paused: cluster.Spec.Pause,
+				Reason: cluster.Spec.PauseReason,
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if !cluster.Spec.Pause {
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Pause = true
+		cluster.Spec.PauseReason = pauseReason
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to pause cluster: %v", err)
+		}
+	}
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "EtcdRestoreStarted", "Restoring etcd from backup %q", restore.Spec.BackupName)
+
+	return nil, r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+		restore.Status.Phase = kubermaticv1.EtcdRestorePhaseStarted
+		setRestoreCondition(restore, kubermaticv1.EtcdRestoreConditionClusterPaused, corev1.ConditionTrue, "", "")
+	})
+}
+
+// removeEtcdData scales down the apiserver and removes the etcd StatefulSet including its volumes. Once
+// that is done, the credentials for the etcd launcher are provided and the cluster is unpaused, which
+// recreates the etcd StatefulSet.
+func (r *Reconciler) removeEtcdData(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	ns := cluster.Status.NamespaceName
+
+	apiserverStopped, err := r.scaleDownAPIServer(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	if !apiserverStopped {
+		log.Debug("Waiting for the apiserver pods to stop")
+		return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+	if err := r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+		setRestoreCondition(restore, kubermaticv1.EtcdRestoreConditionAPIServerScaledDown, corev1.ConditionTrue, "", "")
+	}); err != nil {
+		return nil, err
+	}
+
+	etcdRemoved, err := r.deleteEtcd(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	if !etcdRemoved {
+		log.Debug("Waiting for the etcd pods and volumes to be removed")
+		return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	if err := r.copyCredentials(ctx, restore, ns); err != nil {
+		return nil, err
+	}
+
+	// The phase must be set before unpausing the cluster, the etcd launcher only restores
+	// from the backup while the restore is in the StsRebuilding phase.
+	return nil, r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+		restore.Status.Phase = kubermaticv1.EtcdRestorePhaseStsRebuilding
+		setRestoreCondition(restore, kubermaticv1.EtcdRestoreConditionEtcdDataRemoved, corev1.ConditionTrue, "", "")
+	})
+}
+
+// waitForEtcd unpauses the cluster and waits until the recreated etcd is healthy. A cluster that
+// was paused before the restore is paused again afterwards.
+func (r *Reconciler) waitForEtcd(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.Spec.Pause {
+		oldCluster := cluster.DeepCopy()
+		// The etcd launcher must start the restored members as a new etcd cluster. The condition
+		// is set again by the cluster controller once etcd is healthy.
+		kubermaticv1helper.SetClusterCondition(cluster, kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionFalse, "", "Etcd is being restored from a backup")
+		cluster.Spec.Pause = false
+		cluster.Spec.PauseReason = ""
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to unpause cluster: %v", err)
+		}
+		if err := r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+			setRestoreCondition(restore, kubermaticv1.EtcdRestoreConditionClusterPaused, corev1.ConditionFalse, "", "")
+		}); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) {
+		log.Debug("Waiting for the restored etcd to become healthy")
+		return &reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	credentials := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdRestoreCredentialsSecretName}, credentials); err == nil {
+		if err := r.Delete(ctx, credentials); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to delete backup download credentials: %v", err)
+		}
+	} else if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get backup download credentials: %v", err)
+	}
+
+	if pause := restore.Status.ClusterPause; pause != nil && pause.Paused {
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Pause = true
+		cluster.Spec.PauseReason = pause.Reason
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to pause cluster again: %v", err)
+		}
+	}
+
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "EtcdRestoreCompleted", "Restored etcd from backup %q", restore.Spec.BackupName)
+	return nil, r.updateRestore(ctx, restore, func(restore *kubermaticv1.EtcdRestore) {
+		now := metav1.Now()
+		restore.Status.Phase = kubermaticv1.EtcdRestorePhaseCompleted
+		restore.Status.RestoreTime = &now
+		setRestoreCondition(restore, kubermaticv1.EtcdRestoreConditionEtcdRestored, corev1.ConditionTrue, "", "")
+	})
+}
+
+// scaleDownAPIServer scales the apiserver Deployment to zero replicas and returns whether all its pods are gone.
+func (r *Reconciler) scaleDownAPIServer(ctx context.Context, namespace string) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.ApiserverDeploymentName}, deployment); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get apiserver Deployment: %v", err)
+		}
+	} else if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+		oldDeployment := deployment.DeepCopy()
+		deployment.Spec.Replicas = resources.Int32(0)
+		if err := r.Patch(ctx, deployment, ctrlruntimeclient.MergeFrom(oldDeployment)); err != nil {
+			return false, fmt.Errorf("failed to scale down apiserver: %v", err)
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, ctrlruntimeclient.InNamespace(namespace), ctrlruntimeclient.MatchingLabels(resources.BaseAppLabels(resources.ApiserverDeploymentName, nil))); err != nil {
+		return false, fmt.Errorf("failed to list apiserver pods: %v", err)
+	}
+	return len(pods.Items) == 0, nil
+}
+
+// deleteEtcd deletes the etcd StatefulSet and, once all its pods are gone, its volumes. It returns
+// whether all of them are gone.
+func (r *Reconciler) deleteEtcd(ctx context.Context, namespace string) (bool, error) {
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: resources.EtcdStatefulSetName}}
+	if err := r.Delete(ctx, sts); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete etcd StatefulSet: %v", err)
+	}
+
+	etcdLabels := ctrlruntimeclient.MatchingLabels(resources.BaseAppLabels(resources.EtcdStatefulSetName, nil))
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, ctrlruntimeclient.InNamespace(namespace), etcdLabels); err != nil {
+		return false, fmt.Errorf("failed to list etcd pods: %v", err)
+	}
+	if len(pods.Items) > 0 {
+		return false, nil
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, pvcs, ctrlruntimeclient.InNamespace(namespace), etcdLabels); err != nil {
+		return false, fmt.Errorf("failed to list etcd PersistentVolumeClaims: %v", err)
+	}
+	for i := range pvcs.Items {
+		if err := r.Delete(ctx, &pvcs.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to delete etcd PersistentVolumeClaim %s: %v", pvcs.Items[i].Name, err)
+		}
+	}
+	return len(pvcs.Items) == 0, nil
+}
+
+// copyCredentials copies the backup download credentials into the cluster namespace, where
+// the etcd launcher can read them. If the seed encrypts backups, its encryption keys are
+// added as well, so the launcher can decrypt the backup.
+func (r *Reconciler) copyCredentials(ctx context.Context, restore *kubermaticv1.EtcdRestore, namespace string) error {
+	credentials := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: restore.Spec.BackupDownloadCredentialsSecret}, credentials); err != nil {
+		return fmt.Errorf("failed to get backup download credentials secret %q: %v", restore.Spec.BackupDownloadCredentialsSecret, err)
+	}
+
+	data := map[string][]byte{}
+	for key, value := range credentials.Data {
+		data[key] = value
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return fmt.Errorf("failed to get seed: %v", err)
+	}
+	// the location of the backups is taken from the seed, unless the secret sets it
+	if storage := seed.Spec.EtcdBackupStorage; storage != nil && storage.Backend == kubermaticv1.EtcdBackupStorageBackendFilesystem && len(data["BACKEND"]) == 0 {
+		return fmt.Errorf("backups of the filesystem storage can not be restored, the backup download credentials secret %q must set the BACKEND of a copy of the backup", restore.Spec.BackupDownloadCredentialsSecret)
+	}
+	for key, value := range storageLocation(seed.Spec.EtcdBackupStorage) {
+		if _, ok := data[key]; !ok {
+			data[key] = []byte(value)
+		}
+	}
+	if encryption := seed.Spec.EtcdBackupEncryption; encryption != nil {
+		keys := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: encryption.KeySecret}, keys); err != nil {
+			return fmt.Errorf("failed to get backup encryption keys secret %q: %v", encryption.KeySecret, err)
+		}
+		for id, key := range keys.Data {
+			data[resources.EtcdRestoreEncryptionKeyPrefix+id] = key
+		}
+	}
+
+	gvk := kubermaticv1.SchemeGroupVersion.WithKind(kubermaticv1.EtcdRestoreKindName)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            resources.EtcdRestoreCredentialsSecretName,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(restore, gvk)},
+		},
+		Data: data,
+	}
+	if err := r.Create(ctx, secret); err != nil {
+		if !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create backup download credentials: %v", err)
+		}
+		existing := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.EtcdRestoreCredentialsSecretName}, existing); err != nil {
+			return fmt.Errorf("failed to get backup download credentials: %v", err)
+		}
+		oldExisting := existing.DeepCopy()
+		existing.OwnerReferences = secret.OwnerReferences
+		existing.Data = secret.Data
+		if err := r.Patch(ctx, existing, ctrlruntimeclient.MergeFrom(oldExisting)); err != nil {
+			return fmt.Errorf("failed to update backup download credentials: %v", err)
+		}
+	}
+	return nil
+}
+
+// storageLocation returns the settings of the given backup storage, keyed like the environment of
+// the store container.
+func storageLocation(storage *kubermaticv1.EtcdBackupStorage) map[string]string {
+	if storage == nil {
+		return nil
+	}
+
+	location := map[string]string{
+		"BACKEND": string(storage.Backend),
+		"BUCKET":  storage.Bucket,
+	}
+	switch storage.Backend {
+	case kubermaticv1.EtcdBackupStorageBackendS3:
+		if storage.S3 != nil {
+			location["ENDPOINT"] = storage.S3.Endpoint
+			location["SECURE"] = strconv.FormatBool(storage.S3.Secure)
+		}
+	case kubermaticv1.EtcdBackupStorageBackendSFTP:
+		if storage.SFTP != nil {
+			location["SFTP_ADDRESS"] = storage.SFTP.Address
+			location["SFTP_HOST_KEY"] = storage.SFTP.HostKey
+		}
+	}
+	return location
+}
+
+func (r *Reconciler) updateRestore(ctx context.Context, restore *kubermaticv1.EtcdRestore, modify func(*kubermaticv1.EtcdRestore)) error {
+	oldRestore := restore.DeepCopy()
+	modify(restore)
+	if err := r.Patch(ctx, restore, ctrlruntimeclient.MergeFrom(oldRestore)); err != nil {
+		return fmt.Errorf("failed to update etcd restore: %v", err)
+	}
+	return nil
+}
+
+// setRestoreCondition sets a condition on the given restore using the provided type, status, reason and message.
+func setRestoreCondition(restore *kubermaticv1.EtcdRestore, conditionType kubermaticv1.EtcdRestoreConditionType, status corev1.ConditionStatus, reason, message string) {
+	newCondition := kubermaticv1.EtcdRestoreCondition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+	for i, condition := range restore.Status.Conditions {
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status == status && condition.Reason == reason && condition.Message == message {
+			return
+		}
+		newCondition.LastTransitionTime = condition.LastTransitionTime
+		if condition.Status != status {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		restore.Status.Conditions[i] = newCondition
+		return
+	}
+
+	newCondition.LastTransitionTime = metav1.Now()
+	restore.Status.Conditions = append(restore.Status.Conditions, newCondition)
+	// Has to be sorted, otherwise we may end up creating patches that just re-arrange them.
+	sort.SliceStable(restore.Status.Conditions, func(i, j int) bool {
+		return restore.Status.Conditions[i].Type < restore.Status.Conditions[j].Type
+	})
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdrestore
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testClusterName = "test-cluster"
+	testNamespace   = "cluster-test-cluster"
+)
+
+func TestReconcile(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterName},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: testNamespace,
+		},
+	}
+	restore := &kubermaticv1.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "restore"},
+		Spec: kubermaticv1.EtcdRestoreSpec{
+			Cluster:                         corev1.ObjectReference{Name: testClusterName},
+			BackupName:                      "test-cluster-storeuploader-2020-09-01T10:00:00-snapshot.db",
+			BackupDownloadCredentialsSecret: "s3-credentials",
+		},
+	}
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "s3-credentials"},
+		Data:       map[string][]byte{"SFTP_USERNAME": []byte("backup"), "BUCKET": []byte("restore-backups")},
+	}
+	encryptionKeys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "etcd-backup-keys"},
+		Data:       map[string][]byte{"2020-09": []byte("c2VjcmV0")},
+	}
+	seed := &kubermaticv1.Seed{
+		Spec: kubermaticv1.SeedSpec{
+			EtcdBackupStorage: &kubermaticv1.EtcdBackupStorage{
+				Backend: kubermaticv1.EtcdBackupStorageBackendSFTP,
+				Bucket:  "etcd-backups",
+				SFTP: &kubermaticv1.EtcdBackupSFTPStorage{
+					Address: "backups.example.com:22",
+					HostKey: "ssh-ed25519 AAAA",
+				},
+			},
+			EtcdBackupEncryption: &kubermaticv1.EtcdBackupEncryption{
+				KeySecret:   encryptionKeys.Name,
+				ActiveKeyID: "2020-09",
+			},
+		},
+	}
+	apiserver := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: resources.ApiserverDeploymentName},
+		Spec:       appsv1.DeploymentSpec{Replicas: resources.Int32(2)},
+	}
+	etcd := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: resources.EtcdStatefulSetName},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "data-etcd-0",
+			Labels:    resources.BaseAppLabels(resources.EtcdStatefulSetName, nil),
+		},
+	}
+
+	ctx := context.Background()
+	r := &Reconciler{
+		log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client:   ctrlruntimefakeclient.NewFakeClient(cluster, restore, credentials, encryptionKeys, apiserver, etcd, pvc),
+		recorder: record.NewFakeRecorder(10),
+		seedGetter: func() (*kubermaticv1.Seed, error) {
+			return seed, nil
+		},
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: restore.Name}}
+
+	reconcileAndGet := func() {
+		t.Helper()
+		if _, err := r.Reconcile(request); err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+		if err := r.Get(ctx, types.NamespacedName{Name: testClusterName}, cluster); err != nil {
+			t.Fatalf("failed to get cluster: %v", err)
+		}
+		if err := r.Get(ctx, request.NamespacedName, restore); err != nil {
+			t.Fatalf("failed to get restore: %v", err)
+		}
+	}
+
+	reconcileAndGet()
+	if restore.Status.Phase != kubermaticv1.EtcdRestorePhaseStarted {
+		t.Fatalf("expected phase %q, got %q", kubermaticv1.EtcdRestorePhaseStarted, restore.Status.Phase)
+	}
+	if !cluster.Spec.Pause {
+		t.Fatal("expected cluster to be paused")
+	}
+
+	// The first run deletes the StatefulSet and PVCs, the second one notices they are gone
+	reconcileAndGet()
+	reconcileAndGet()
+	if restore.Status.Phase != kubermaticv1.EtcdRestorePhaseStsRebuilding {
+		t.Fatalf("expected phase %q, got %q", kubermaticv1.EtcdRestorePhaseStsRebuilding, restore.Status.Phase)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver deployment: %v", err)
+	}
+	if *apiserver.Spec.Replicas != 0 {
+		t.Errorf("expected apiserver to be scaled down, has %d replicas", *apiserver.Spec.Replicas)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.EtcdStatefulSetName}, etcd); !kerrors.IsNotFound(err) {
+		t.Errorf("expected etcd StatefulSet to be deleted, got %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: pvc.Name}, pvc); !kerrors.IsNotFound(err) {
+		t.Errorf("expected etcd PVC to be deleted, got %v", err)
+	}
+	copied := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.EtcdRestoreCredentialsSecretName}, copied); err != nil {
+		t.Fatalf("failed to get copied credentials: %v", err)
+	}
+	if string(copied.Data["SFTP_USERNAME"]) != "backup" {
+		t.Errorf("expected copied credentials to match, got %v", copied.Data)
+	}
+	expectedLocation := map[string]string{
+		"BACKEND":       "sftp",
+		"BUCKET":        "restore-backups",
+		"SFTP_ADDRESS":  "backups.example.com:22",
+		"SFTP_HOST_KEY": "ssh-ed25519 AAAA",
+	}
+	for key, value := range expectedLocation {
+		if string(copied.Data[key]) != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, copied.Data[key])
+		}
+	}
+	if string(copied.Data[resources.EtcdRestoreEncryptionKeyPrefix+"2020-09"]) != "c2VjcmV0" {
+		t.Errorf("expected the encryption keys of the seed to be copied, got %v", copied.Data)
+	}
+
+	reconcileAndGet()
+	if cluster.Spec.Pause {
+		t.Fatal("expected cluster to be unpaused")
+	}
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionFalse) {
+		t.Fatalf("expected %s condition to be reset", kubermaticv1.ClusterConditionEtcdClusterInitialized)
+	}
+
+	// Still waiting for etcd
+	reconcileAndGet()
+	if restore.Status.Phase != kubermaticv1.EtcdRestorePhaseStsRebuilding {
+		t.Fatalf("expected phase %q, got %q", kubermaticv1.EtcdRestorePhaseStsRebuilding, restore.Status.Phase)
+	}
+
+	cluster.Status.Conditions[0].Status = corev1.ConditionTrue
+	if err := r.Update(ctx, cluster); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+	reconcileAndGet()
+	if restore.Status.Phase != kubermaticv1.EtcdRestorePhaseCompleted {
+		t.Fatalf("expected phase %q, got %q", kubermaticv1.EtcdRestorePhaseCompleted, restore.Status.Phase)
+	}
+	if restore.Status.RestoreTime == nil {
+		t.Error("expected restore time to be set")
+	}
+	if cluster.Spec.Pause {
+		t.Error("expected cluster to stay unpaused, as it was not paused before the restore")
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.EtcdRestoreCredentialsSecretName}, copied); !kerrors.IsNotFound(err) {
+		t.Errorf("expected copied credentials to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileRejectsForeignNamespace(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterName},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: testNamespace},
+	}
+	restore := &kubermaticv1.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "restore"},
+		Spec: kubermaticv1.EtcdRestoreSpec{
+			Cluster:    corev1.ObjectReference{Name: testClusterName},
+			BackupName: "backup",
+		},
+	}
+	r := &Reconciler{
+		log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client:   ctrlruntimefakeclient.NewFakeClient(cluster, restore),
+		recorder: record.NewFakeRecorder(10),
+	}
+
+	if _, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "kube-system", Name: restore.Name}}); err == nil {
+		t.Fatal("expected an error for a restore outside of the cluster namespace")
+	}
+}
+
+func TestReconcileKeepsPausedCluster(t *testing.T) {
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterName},
+		Spec: kubermaticv1.ClusterSpec{
+			Pause:       true,
+			PauseReason: "maintenance",
+		},
+		Status: kubermaticv1.ClusterStatus{NamespaceName: testNamespace},
+	}
+	restore := &kubermaticv1.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "restore"},
+		Spec: kubermaticv1.EtcdRestoreSpec{
+			Cluster:                         corev1.ObjectReference{Name: testClusterName},
+			BackupName:                      "backup",
+			BackupDownloadCredentialsSecret: "s3-credentials",
+		},
+	}
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "s3-credentials"},
+	}
+	r := &Reconciler{
+		log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client:   ctrlruntimefakeclient.NewFakeClient(cluster, restore, credentials),
+		recorder: record.NewFakeRecorder(10),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: restore.Name}}
+
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if err := r.Get(ctx, request.NamespacedName, restore); err != nil {
+		t.Fatalf("failed to get restore: %v", err)
+	}
+	if pause := restore.Status.ClusterPause; pause == nil || !pause.Paused || pause.Reason != "maintenance" {
+		t.Fatalf("expected the pause state of the cluster to be recorded, got %v", pause)
+	}
+
+	// Skip to the point where the restored etcd became healthy in the unpaused cluster
+	if err := r.Get(ctx, types.NamespacedName{Name: testClusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	cluster.Spec.Pause = false
+	cluster.Spec.PauseReason = ""
+	cluster.Status.Conditions = []kubermaticv1.ClusterCondition{
+		{Type: kubermaticv1.ClusterConditionEtcdClusterInitialized, Status: corev1.ConditionTrue},
+	}
+	if err := r.Update(ctx, cluster); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+	restore.Status.Phase = kubermaticv1.EtcdRestorePhaseStsRebuilding
+	if err := r.Update(ctx, restore); err != nil {
+		t.Fatalf("failed to update restore: %v", err)
+	}
+
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: testClusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if !cluster.Spec.Pause || cluster.Spec.PauseReason != "maintenance" {
+		t.Errorf("expected cluster to be paused again with its previous reason, got pause = %v, reason = %q", cluster.Spec.Pause, cluster.Spec.PauseReason)
+	}
+}
@@ -640,9 +640,9 @@ func (r *Reconciler) networkDefaults(ctx context.Context, cluster *kubermaticv1.
 // GetServiceCreators returns all service creators that are currently in use
 func getAllServiceCreators(osData *openshiftData) []reconciling.NamedServiceCreatorGetter {
 	creators := []reconciling.NamedServiceCreatorGetter{
-		apiserver.ServiceCreator(osData.Cluster().Spec.ExposeStrategy),
+		apiserver.ServiceCreator(osData.Cluster().Spec.ExposeStrategy, osData.Cluster().Address.ExternalName, resources.APIServerAllowedIPRanges(osData.Cluster(), osData.Seed())),
 		openshiftresources.OpenshiftAPIServiceCreator,
-		openvpn.ServiceCreator(osData.Cluster().Spec.ExposeStrategy),
+		openvpn.ServiceCreator(osData.Cluster().Spec.ExposeStrategy, osData.Cluster().Address.ExternalName),
 		etcd.ServiceCreator(osData),
 		dns.ServiceCreator(),
 		machinecontroller.ServiceCreator(),
@@ -651,7 +651,7 @@ func getAllServiceCreators(osData *openshiftData) []reconciling.NamedServiceCrea
 	}
 
 	if osData.Cluster().Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
-		creators = append(creators, nodeportproxy.FrontLoadBalancerServiceCreator())
+		creators = append(creators, nodeportproxy.FrontLoadBalancerServiceCreator(osData))
 	}
 
 	return creators
@@ -682,6 +682,7 @@ func (r *Reconciler) ensureServiceAccounts(ctx context.Context, c *kubermaticv1.
 func (r *Reconciler) ensureRoles(ctx context.Context, c *kubermaticv1.Cluster) error {
 	namedRoleCreatorGetters := []reconciling.NamedRoleCreatorGetter{
 		usercluster.RoleCreator,
+		etcd.RoleCreator,
 	}
 	if err := reconciling.ReconcileRoles(ctx, namedRoleCreatorGetters, c.Status.NamespaceName, r.Client); err != nil {
 		return fmt.Errorf("failed to ensure Roles: %v", err)
@@ -693,6 +694,7 @@ func (r *Reconciler) ensureRoles(ctx context.Context, c *kubermaticv1.Cluster) e
 func (r *Reconciler) ensureRoleBindings(ctx context.Context, c *kubermaticv1.Cluster) error {
 	namedRoleBindingCreatorGetters := []reconciling.NamedRoleBindingCreatorGetter{
 		usercluster.RoleBindingCreator,
+		etcd.RoleBindingCreator,
 	}
 	if err := reconciling.ReconcileRoleBindings(ctx, namedRoleBindingCreatorGetters, c.Status.NamespaceName, r.Client); err != nil {
 		return fmt.Errorf("failed to ensure RoleBindings: %v", err)
@@ -25,6 +25,7 @@ import (
 	"github.com/Masterminds/sprig"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/etcd"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
@@ -104,6 +105,10 @@ func OpenshiftKubeAPIServerConfigMapCreator(data masterConfigData) reconciling.N
 				AdvertiseAddress: data.Cluster().Address.IP,
 				CloudProvider:    data.GetKubernetesCloudProviderName(),
 			}
+			// When exposed via SNI, the apiserver is only reachable through the SNI tunnel agent
+			if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+				templateInput.AdvertiseAddress = resources.SNITunnelAgentIP
+			}
 			if err := openshiftKubeAPIServerTemplate.Execute(&apiServerConfigBuffer, templateInput); err != nil {
 				return nil, fmt.Errorf("failed to execute template: %v", err)
 			}
@@ -202,7 +202,7 @@ func OauthServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedSer
 			if se.Annotations == nil {
 				se.Annotations = map[string]string{}
 			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
+			if exposeStrategy != corev1.ServiceTypeLoadBalancer {
 				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
 			} else {
@@ -280,6 +280,17 @@ func (r *Reconciler) getRancherServerURL(ctx context.Context, namespace string)
 	if err := r.Get(ctx, types.NamespacedName{Name: resources.RancherServerServiceName, Namespace: namespace}, service); err != nil {
 		return "", err
 	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: strings.ReplaceAll(service.Namespace, "cluster-", "")}, cluster); err != nil {
+		return "", fmt.Errorf("failed to get cluster: %v", err)
+	}
+
+	// The SNI listener of the NodePort proxy routes to the https port by the hostname
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+		return fmt.Sprintf("https://%s", resources.RancherServerSNIHostname(cluster.Address.ExternalName)), nil
+	}
+
 	var port int32
 	for _, svcPort := range service.Spec.Ports {
 		if svcPort.Name == "https" {
@@ -291,11 +302,6 @@ func (r *Reconciler) getRancherServerURL(ctx context.Context, namespace string)
 		return "", fmt.Errorf("Can't find rancher server service nodeport")
 	}
 
-	cluster := &kubermaticv1.Cluster{}
-	if err := r.Get(ctx, types.NamespacedName{Name: strings.ReplaceAll(service.Namespace, "cluster-", "")}, cluster); err != nil {
-		return "", fmt.Errorf("failed to get cluster: %v", err)
-	}
-
 	return fmt.Sprintf("https://%s:%d", cluster.Address.ExternalName, port), nil
 }
 
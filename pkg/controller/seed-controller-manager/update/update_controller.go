@@ -19,8 +19,12 @@ package update
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/coreos/locksmith/pkg/timeutil"
 	"go.uber.org/zap"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
@@ -28,11 +32,18 @@ import (
 	"k8c.io/kubermatic/v2/pkg/cluster/client"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
 	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
 	"k8c.io/kubermatic/v2/pkg/version"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -44,15 +55,36 @@ import (
 
 const (
 	ControllerName = "kubermatic_update_controller"
+
+	// rolloutRequeueInterval is used to check again whether a staged rollout allows updating a cluster
+	rolloutRequeueInterval = time.Minute
+	// minCanaryRecoveryTime is the minimum time canaries get to become healthy again after an update
+	// before the rollout is halted
+	minCanaryRecoveryTime = 15 * time.Minute
+	// rollbackTimeout is the time the control plane gets to become healthy after an update before
+	// the update is rolled back. Canaries must not be rolled back before they could halt the rollout.
+	rollbackTimeout = minCanaryRecoveryTime
+	// minUpdateSoakTime is the minimum time after an update before it is considered successful, so
+	// the health of the cluster was determined after the control plane was changed
+	minUpdateSoakTime = 2 * time.Minute
+	// updateCheckInterval is used to check again whether the control plane was rolled out
+	updateCheckInterval = 30 * time.Second
 )
 
 type Reconciler struct {
 	workerName    string
 	updateManager *version.Manager
 	ctrlruntimeclient.Client
+	// apiReader reads the clusters of a rollout from the API server, as the cache may not have
+	// seen the updates claimed by other workers yet
+	apiReader                     ctrlruntimeclient.Reader
 	recorder                      record.EventRecorder
 	userClusterConnectionProvider *client.Provider
 	log                           *zap.SugaredLogger
+	now                           func() time.Time
+	// rolloutLock serializes the decision whether a staged rollout allows updating a cluster
+	// with claiming the update, so concurrent workers don't exceed the rollout limits
+	rolloutLock sync.Mutex
 }
 
 // Add creates a new update controller
@@ -62,9 +94,11 @@ func Add(mgr manager.Manager, numWorkers int, workerName string, updateManager *
 		workerName:                    workerName,
 		updateManager:                 updateManager,
 		Client:                        mgr.GetClient(),
+		apiReader:                     mgr.GetAPIReader(),
 		recorder:                      mgr.GetEventRecorderFor(ControllerName),
 		userClusterConnectionProvider: userClusterConnectionProvider,
 		log:                           log,
+		now:                           time.Now,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{
@@ -94,6 +128,10 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{}, err
 	}
 
+	// Waiting for the update window or the rollout is not a reconciling failure, so
+	// the requeue for it is added outside of the wrapper to keep the condition healthy
+	var requeueAfter time.Duration
+
 	// Add a wrapping here so we can emit an event on error
 	result, err := kubermaticv1helper.ClusterReconcileWrapper(
 		ctx,
@@ -102,7 +140,10 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		cluster,
 		kubermaticv1.ClusterConditionUpdateControllerReconcilingSuccess,
 		func() (*reconcile.Result, error) {
-			return r.reconcile(ctx, cluster)
+			var result *reconcile.Result
+			var err error
+			result, requeueAfter, err = r.reconcile(ctx, cluster)
+			return result, err
 		},
 	)
 	if err != nil {
@@ -112,52 +153,470 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	if result == nil {
 		result = &reconcile.Result{}
 	}
+	if err == nil && !result.Requeue && result.RequeueAfter == 0 && requeueAfter > 0 {
+		result.RequeueAfter = requeueAfter
+	}
 	return *result, err
 }
 
-func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+// reconcile applies pending automatic updates. If the update window or the rollout does not allow
+// updating the cluster yet, the duration until the next check is returned instead.
+func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, time.Duration, error) {
+
+	if result, err := r.reconcileUpdateHealth(ctx, cluster); err != nil || result != nil {
+		return result, 0, err
+	}
 
 	if !cluster.Status.ExtendedHealth.AllHealthy() {
 		// Cluster not healthy yet. Nothing to do.
 		// If it gets healthy we'll get notified by the event. No need to requeue
-		return nil, nil
+		return nil, 0, nil
 	}
 
-	clusterType := v1.KubernetesClusterType
-	if cluster.IsOpenshift() {
-		clusterType = v1.OpenShiftClusterType
+	clusterType := clusterTypeOf(cluster)
+
+	rolledBack, err := r.isRolledBackUpdatePending(cluster, clusterType)
+	if err != nil {
+		return nil, 0, err
+	}
+	if rolledBack {
+		status := cluster.Status.AutomaticUpdate
+		msg := fmt.Sprintf("The automatic update to version %s was rolled back and is not retried until status.automaticUpdate is removed", status.To)
+		return nil, 0, r.setAutomaticUpdatesAllowedCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonUpdateRolledBack, msg)
+	}
+
+	waitFor, err := durationToUpdateWindow(cluster.Spec.UpdateWindow, r.now())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get update window: %v", err)
+	}
+	if waitFor > 0 {
+		pending, err := r.hasPendingUpdates(ctx, cluster, clusterType)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to check for pending updates: %v", err)
+		}
+		if !pending {
+			return nil, 0, r.setAutomaticUpdatesAllowedCondition(ctx, cluster, corev1.ConditionTrue, "", "")
+		}
+
+		nextUpdate := r.now().Add(waitFor).UTC().Format(time.RFC3339)
+		msg := fmt.Sprintf("Automatic updates are pending and will be applied in the next update window starting at %s", nextUpdate)
+		if err := r.setAutomaticUpdatesAllowedCondition(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonOutsideUpdateWindow, msg); err != nil {
+			return nil, 0, err
+		}
+		return nil, waitFor, nil
 	}
 
 	// NodeUpdate may need the controlplane to be updated first
-	updated, err := r.controlPlaneUpgrade(ctx, cluster, clusterType)
+	waitFor, updated, err := r.startControlPlaneUpdate(ctx, cluster, clusterType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update the controlplane: %v", err)
+		return nil, 0, err
+	}
+	if waitFor > 0 {
+		return nil, waitFor, nil
 	}
 	// Give the controller time to do the update
 	// TODO: This is not really safe. We should add a `Version` to the status
 	// that gets incremented when the controller does this. Combined with a
 	// `SeedResourcesUpToDate` condition, that should do the trick
 	if updated {
-		return &reconcile.Result{RequeueAfter: time.Minute}, nil
+		return &reconcile.Result{RequeueAfter: time.Minute}, 0, nil
 	}
 
 	if err := r.nodeUpdate(ctx, cluster, clusterType); err != nil {
-		return nil, fmt.Errorf("failed to update machineDeployments: %v", err)
+		return nil, 0, fmt.Errorf("failed to update machineDeployments: %v", err)
+	}
+
+	return nil, 0, nil
+}
+
+// startControlPlaneUpdate starts a pending controlplane update if the rollout allows it. The rollout
+// lock is only held while counting the clusters that are being updated and claiming the update in the
+// cluster status, both of which only talk to the seed. If the cluster must wait, the duration until
+// the next check is returned.
+func (r *Reconciler) startControlPlaneUpdate(ctx context.Context, cluster *kubermaticv1.Cluster, clusterType string) (time.Duration, bool, error) {
+	r.rolloutLock.Lock()
+	defer r.rolloutLock.Unlock()
+
+	waitFor, reason, msg, err := r.rolloutDelay(ctx, cluster, clusterType)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to check the rollout of the controlplane update: %v", err)
+	}
+	if waitFor > 0 {
+		if err := r.setAutomaticUpdatesAllowedCondition(ctx, cluster, corev1.ConditionFalse, reason, msg); err != nil {
+			return 0, false, err
+		}
+		return waitFor, false, nil
+	}
+
+	if err := r.setAutomaticUpdatesAllowedCondition(ctx, cluster, corev1.ConditionTrue, "", ""); err != nil {
+		return 0, false, err
+	}
+
+	updated, err := r.controlPlaneUpgrade(ctx, cluster, clusterType)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to update the controlplane: %v", err)
+	}
+	return 0, updated, nil
+}
+
+// rolloutDelay checks whether the rollout policy allows updating the cluster now. Canaries go first; all
+// other clusters wait for them to be updated, healthy and soaked, and a canary that does not recover halts
+// the rollout. If the cluster must wait, the duration until the next check is returned with a reason.
+func (r *Reconciler) rolloutDelay(ctx context.Context, cluster *kubermaticv1.Cluster, clusterType string) (time.Duration, string, string, error) {
+	update, err := r.updateManager.AutomaticControlplaneUpdate(cluster.Spec.Version.String(), clusterType)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("failed to get automatic update for cluster for version %s: %v", cluster.Spec.Version.String(), err)
+	}
+	if update == nil {
+		return 0, "", "", nil
+	}
+	policy, err := r.updateManager.AutomaticControlplaneUpdateRollout(cluster.Spec.Version.String(), clusterType)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("failed to get rollout policy for version %s: %v", cluster.Spec.Version.String(), err)
+	}
+	if policy == nil {
+		return 0, "", "", nil
+	}
+	canarySelector, err := canarySelector(policy)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	selector, err := workerlabel.LabelSelector(r.workerName)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("failed to create worker label selector: %v", err)
+	}
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.apiReader.List(ctx, clusters, &ctrlruntimeclient.ListOptions{LabelSelector: selector}); err != nil {
+		return 0, "", "", fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	target := update.Version.String()
+	now := r.now()
+	recoveryTime := policy.SoakTime.Duration
+	if recoveryTime < minCanaryRecoveryTime {
+		recoveryTime = minCanaryRecoveryTime
+	}
+
+	var updating, pendingCanaries int
+	var unhealthyCanaries []string
+	var lastCanaryStart time.Time
+	for i := range clusters.Items {
+		other := &clusters.Items[i]
+		if other.Name == cluster.Name || other.DeletionTimestamp != nil || other.Spec.Pause {
+			continue
+		}
+		healthy := other.Status.ExtendedHealth.AllHealthy()
+
+		if status := other.Status.AutomaticUpdate; status != nil && status.To == target && status.Failed {
+			return rolloutRequeueInterval, kubermaticv1.ReasonRolloutHalted, fmt.Sprintf("The rollout of version %s is halted, because canary cluster %s did not become healthy after its update", target, other.Name), nil
+		}
+
+		// clusters that have already been updated as part of this rollout
+		if status := other.Status.AutomaticUpdate; status != nil && status.To == target && other.Spec.Version.String() == target {
+			if status.InProgress {
+				updating++
+			}
+			if !status.Canary {
+				continue
+			}
+			if status.StartTime.After(lastCanaryStart) {
+				lastCanaryStart = status.StartTime.Time
+			}
+			if status.InProgress || !healthy {
+				if now.Sub(status.StartTime.Time) > recoveryTime {
+					if err := r.haltRollout(ctx, other); err != nil {
+						return 0, "", "", err
+					}
+					return rolloutRequeueInterval, kubermaticv1.ReasonRolloutHalted, fmt.Sprintf("The rollout of version %s is halted, because canary cluster %s did not become healthy after its update", target, other.Name), nil
+				}
+				unhealthyCanaries = append(unhealthyCanaries, other.Name)
+			}
+			continue
+		}
+
+		// canaries that still wait for this update
+		if canarySelector.Matches(labels.Set(other.Labels)) {
+			otherUpdate, err := r.updateManager.AutomaticControlplaneUpdate(other.Spec.Version.String(), clusterTypeOf(other))
+			if err != nil {
+				return 0, "", "", fmt.Errorf("failed to get automatic update for cluster %s: %v", other.Name, err)
+			}
+			if otherUpdate != nil && otherUpdate.Version.Equal(update.Version) {
+				pendingCanaries++
+			}
+		}
+	}
+
+	if policy.MaxConcurrentUpdates > 0 && updating >= policy.MaxConcurrentUpdates {
+		return rolloutRequeueInterval, kubermaticv1.ReasonRolloutInProgress, fmt.Sprintf("Waiting for %d clusters to finish their update to version %s", updating, target), nil
+	}
+	if canarySelector.Matches(labels.Set(cluster.Labels)) {
+		return 0, "", "", nil
+	}
+	if pendingCanaries > 0 {
+		return rolloutRequeueInterval, kubermaticv1.ReasonRolloutInProgress, fmt.Sprintf("Waiting for %d canary clusters to be updated to version %s", pendingCanaries, target), nil
+	}
+	if len(unhealthyCanaries) > 0 {
+		return rolloutRequeueInterval, kubermaticv1.ReasonRolloutInProgress, fmt.Sprintf("Waiting for canary clusters %v to become healthy after their update to version %s", unhealthyCanaries, target), nil
+	}
+	if soakEnd := lastCanaryStart.Add(policy.SoakTime.Duration); now.Before(soakEnd) {
+		return soakEnd.Sub(now), kubermaticv1.ReasonRolloutInProgress, fmt.Sprintf("Waiting for the soak time of the canary clusters to pass at %s before updating to version %s", soakEnd.UTC().Format(time.RFC3339), target), nil
+	}
+	return 0, "", "", nil
+}
+
+// reconcileUpdateHealth watches an applied control plane update until the cluster is healthy again, and
+// rolls it back if that does not happen before the deadline. A non-nil result stops the reconciliation.
+func (r *Reconciler) reconcileUpdateHealth(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	status := cluster.Status.AutomaticUpdate
+	if status == nil || (status.RollbackDeadline == nil && !status.InProgress) {
+		return nil, nil
 	}
 
+	oldCluster := cluster.DeepCopy()
+	switch {
+	case cluster.Spec.Version.String() != status.To:
+		// The version was changed by someone else since the update, so it must not be rolled back anymore
+		status.RollbackDeadline = nil
+		status.InProgress = false
+	case cluster.Status.ExtendedHealth.AllHealthy():
+		// The health in the status may still stem from before the update
+		rolledOut, err := r.apiserverRolledOut(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if soakEnd := status.StartTime.Add(minUpdateSoakTime); !rolledOut || r.now().Before(soakEnd) {
+			return &reconcile.Result{RequeueAfter: updateCheckInterval}, nil
+		}
+		status.RollbackDeadline = nil
+		status.InProgress = false
+		kubermaticv1helper.SetClusterCondition(cluster, kubermaticv1.ClusterConditionAutomaticUpdateHealthy, corev1.ConditionTrue, kubermaticv1.ReasonClusterUpdateSuccessful, "")
+	case status.RollbackDeadline == nil:
+		// Without a deadline the update is only tracked until the cluster is healthy again
+		return nil, nil
+	default:
+		if now := r.now(); now.Before(status.RollbackDeadline.Time) {
+			return &reconcile.Result{RequeueAfter: status.RollbackDeadline.Sub(now)}, nil
+		}
+		if err := r.rollback(ctx, cluster); err != nil {
+			return nil, err
+		}
+		// Give the controller time to do the rollback
+		return &reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return nil, fmt.Errorf("failed to update automatic update status: %v", err)
+	}
 	return nil, nil
 }
 
-func (r *Reconciler) nodeUpdate(ctx context.Context, cluster *kubermaticv1.Cluster, clusterType string) error {
+// apiserverRolledOut returns true once all replicas of the apiserver Deployment were updated to its
+// current spec and are ready. For Kubernetes clusters the Deployment must also run the cluster version.
+func (r *Reconciler) apiserverRolledOut(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, deployment); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get apiserver deployment: %v", err)
+	}
+
+	if !cluster.IsOpenshift() {
+		image := ""
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if container.Name == resources.ApiserverDeploymentName {
+				image = container.Image
+			}
+		}
+		if !strings.HasSuffix(image, ":v"+cluster.Spec.Version.String()) {
+			return false, nil
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas == replicas &&
+		deployment.Status.Replicas == replicas, nil
+}
+
+// rollback reverts the control plane of a cluster that did not become healthy after its automatic
+// update to the previous version. If the etcd data of the new version cannot be used by the previous
+// version, the cluster is left as is and only the condition is set.
+func (r *Reconciler) rollback(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	oldCluster := cluster.DeepCopy()
+	status := cluster.Status.AutomaticUpdate
+	status.RollbackDeadline = nil
+	// A canary that has to be rolled back halts the rollout for all other clusters
+	if status.Canary {
+		status.Failed = true
+	}
+
+	from, err := semver.NewSemver(status.From)
+	if err != nil {
+		return fmt.Errorf("failed to parse previous version %q: %v", status.From, err)
+	}
+	previous := cluster.DeepCopy()
+	previous.Spec.Version = *from
+
+	if etcd.ImageTag(previous) != etcd.ImageTag(cluster) {
+		msg := fmt.Sprintf("Cluster did not become healthy after its update from version %s to %s and cannot be rolled back, because the etcd version changed from %s to %s", status.From, status.To, etcd.ImageTag(previous), etcd.ImageTag(cluster))
+		kubermaticv1helper.SetClusterCondition(cluster, kubermaticv1.ClusterConditionAutomaticUpdateHealthy, corev1.ConditionFalse, kubermaticv1.ReasonRollbackImpossible, msg)
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return fmt.Errorf("failed to update automatic update status: %v", err)
+		}
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "AutoUpdateRollbackImpossible", msg)
+		return nil
+	}
+
+	msg := fmt.Sprintf("Cluster did not become healthy within %v after its update from version %s to %s, rolled back to version %s", rollbackTimeout, status.From, status.To, status.From)
+	status.RolledBack = true
+	status.InProgress = false
+	cluster.Spec.Version = *from
+	kubermaticv1helper.SetClusterCondition(cluster, kubermaticv1.ClusterConditionAutomaticUpdateHealthy, corev1.ConditionFalse, kubermaticv1.ReasonUpdateRolledBack, msg)
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to roll back cluster: %v", err)
+	}
+	r.recorder.Event(cluster, corev1.EventTypeWarning, "AutoUpdateRolledBack", msg)
+	return nil
+}
+
+// isRolledBackUpdatePending returns true if the pending automatic controlplane update of the cluster
+// is the one that was rolled back before.
+func (r *Reconciler) isRolledBackUpdatePending(cluster *kubermaticv1.Cluster, clusterType string) (bool, error) {
+	status := cluster.Status.AutomaticUpdate
+	if status == nil || !status.RolledBack || cluster.Spec.Version.String() != status.From {
+		return false, nil
+	}
+	update, err := r.updateManager.AutomaticControlplaneUpdate(cluster.Spec.Version.String(), clusterType)
+	if err != nil {
+		return false, fmt.Errorf("failed to get automatic update for cluster for version %s: %v", cluster.Spec.Version.String(), err)
+	}
+	return update != nil && update.Version.String() == status.To, nil
+}
+
+// haltRollout marks the update of the given canary cluster as failed, which stops the rollout
+func (r *Reconciler) haltRollout(ctx context.Context, canary *kubermaticv1.Cluster) error {
+	oldCanary := canary.DeepCopy()
+	canary.Status.AutomaticUpdate.Failed = true
+	if err := r.Patch(ctx, canary, ctrlruntimeclient.MergeFrom(oldCanary)); err != nil {
+		return fmt.Errorf("failed to mark update of canary cluster %s as failed: %v", canary.Name, err)
+	}
+	r.recorder.Eventf(canary, corev1.EventTypeWarning, "AutoUpdateRolloutHalted", "Cluster did not become healthy after its update to version %s, halting the rollout", canary.Status.AutomaticUpdate.To)
+	return nil
+}
+
+// canarySelector returns the selector for the canary clusters of the given policy, which selects
+// nothing if the policy has no canaries
+func canarySelector(policy *version.RolloutPolicy) (labels.Selector, error) {
+	if policy == nil || policy.CanarySelector == nil {
+		return labels.Nothing(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.CanarySelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid canary selector: %v", err)
+	}
+	return selector, nil
+}
+
+func clusterTypeOf(cluster *kubermaticv1.Cluster) string {
+	if cluster.IsOpenshift() {
+		return v1.OpenShiftClusterType
+	}
+	return v1.KubernetesClusterType
+}
+
+// durationToUpdateWindow returns how long it takes until the given update window starts. It returns
+// zero if no window is configured or if now is within the window.
+func durationToUpdateWindow(window *kubermaticv1.UpdateWindow, now time.Time) (time.Duration, error) {
+	if window == nil || window.Start == "" || window.Length == "" {
+		return 0, nil
+	}
+
+	periodic, err := timeutil.ParsePeriodic(window.Start, window.Length)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse update window: %v", err)
+	}
+
+	// The update window is specified in UTC
+	if waitFor := periodic.DurationToStart(now.UTC()); waitFor > 0 {
+		return waitFor, nil
+	}
+	return 0, nil
+}
+
+// setAutomaticUpdatesAllowedCondition sets the AutomaticUpdatesAllowed condition and emits an event
+// whenever updates get deferred to a new update window.
+func (r *Reconciler) setAutomaticUpdatesAllowedCondition(ctx context.Context, cluster *kubermaticv1.Cluster, status corev1.ConditionStatus, reason, message string) error {
+	oldCluster := cluster.DeepCopy()
+	kubermaticv1helper.SetClusterCondition(cluster, kubermaticv1.ClusterConditionAutomaticUpdatesAllowed, status, reason, message)
+	if reflect.DeepEqual(oldCluster, cluster) {
+		return nil
+	}
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to set %s condition: %v", kubermaticv1.ClusterConditionAutomaticUpdatesAllowed, err)
+	}
+	if status == corev1.ConditionFalse {
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "AutoUpdateDeferred", message)
+	}
+	return nil
+}
+
+// hasPendingUpdates returns true if there is an automatic update for either the controlplane
+// or any of the MachineDeployments of the cluster.
+func (r *Reconciler) hasPendingUpdates(ctx context.Context, cluster *kubermaticv1.Cluster, clusterType string) (bool, error) {
+	update, err := r.updateManager.AutomaticControlplaneUpdate(cluster.Spec.Version.String(), clusterType)
+	if err != nil {
+		return false, fmt.Errorf("failed to get automatic update for cluster for version %s: %v", cluster.Spec.Version.String(), err)
+	}
+	if update != nil {
+		return true, nil
+	}
+
+	machineDeployments, err := r.machineDeployments(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+	for _, md := range machineDeployments.Items {
+		targetVersion, err := r.updateManager.AutomaticNodeUpdate(md.Spec.Template.Spec.Versions.Kubelet, clusterType, cluster.Spec.Version.String())
+		if err != nil {
+			return false, fmt.Errorf("failed to get automatic update for machinedeployment %s/%s that has version %q: %v", md.Namespace, md.Name, md.Spec.Template.Spec.Versions.Kubelet, err)
+		}
+		if targetVersion != nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *Reconciler) machineDeployments(ctx context.Context, cluster *kubermaticv1.Cluster) (*clusterv1alpha1.MachineDeploymentList, error) {
 	c, err := r.userClusterConnectionProvider.GetClient(cluster)
 	if err != nil {
-		return fmt.Errorf("failed to get usercluster client: %v", err)
+		return nil, fmt.Errorf("failed to get usercluster client: %v", err)
 	}
 
 	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
 	// Kubermatic only creates MachineDeployments in the kube-system namespace, everything else is essentially unsupported
 	if err := c.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace("kube-system")); err != nil {
-		return fmt.Errorf("failed to list MachineDeployments: %v", err)
+		return nil, fmt.Errorf("failed to list MachineDeployments: %v", err)
+	}
+	return machineDeployments, nil
+}
+
+func (r *Reconciler) nodeUpdate(ctx context.Context, cluster *kubermaticv1.Cluster, clusterType string) error {
+	c, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get usercluster client: %v", err)
+	}
+
+	machineDeployments, err := r.machineDeployments(ctx, cluster)
+	if err != nil {
+		return err
 	}
 
 	for _, md := range machineDeployments.Items {
@@ -187,8 +646,25 @@ func (r *Reconciler) controlPlaneUpgrade(ctx context.Context, cluster *kubermati
 	if update == nil {
 		return false, nil
 	}
+	policy, err := r.updateManager.AutomaticControlplaneUpdateRollout(cluster.Spec.Version.String(), clusterType)
+	if err != nil {
+		return false, fmt.Errorf("failed to get rollout policy for version %s: %v", cluster.Spec.Version.String(), err)
+	}
+	canarySelector, err := canarySelector(policy)
+	if err != nil {
+		return false, err
+	}
 	oldCluster := cluster.DeepCopy()
 
+	deadline := metav1.NewTime(r.now().Add(rollbackTimeout))
+	cluster.Status.AutomaticUpdate = &kubermaticv1.AutomaticUpdateStatus{
+		From:             cluster.Spec.Version.String(),
+		To:               update.Version.String(),
+		StartTime:        metav1.NewTime(r.now()),
+		Canary:           canarySelector.Matches(labels.Set(cluster.Labels)),
+		InProgress:       true,
+		RollbackDeadline: &deadline,
+	}
 	cluster.Spec.Version = *semver.NewSemverOrDie(update.Version.String())
 	// Invalidating the health to prevent automatic updates directly on the next processing.
 	cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
@@ -0,0 +1,491 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	mastermindssemver "github.com/Masterminds/semver"
+
+	v1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/version"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDurationToUpdateWindow(t *testing.T) {
+	// Thursday
+	now := time.Date(2020, time.September, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		updateWindow *kubermaticv1.UpdateWindow
+		expected     time.Duration
+		expectErr    bool
+	}{
+		{
+			name:     "no update window",
+			expected: 0,
+		},
+		{
+			name:         "incomplete update window",
+			updateWindow: &kubermaticv1.UpdateWindow{Start: "04:00"},
+			expected:     0,
+		},
+		{
+			name:         "within daily update window",
+			updateWindow: &kubermaticv1.UpdateWindow{Start: "09:00", Length: "2h"},
+			expected:     0,
+		},
+		{
+			name:         "before daily update window",
+			updateWindow: &kubermaticv1.UpdateWindow{Start: "12:30", Length: "1h"},
+			expected:     2*time.Hour + 30*time.Minute,
+		},
+		{
+			name:         "after daily update window",
+			updateWindow: &kubermaticv1.UpdateWindow{Start: "04:00", Length: "1h"},
+			expected:     18 * time.Hour,
+		},
+		{
+			name:         "weekly update window next week",
+			updateWindow: &kubermaticv1.UpdateWindow{Start: "Wed 10:00", Length: "1h"},
+			expected:     6 * 24 * time.Hour,
+		},
+		{
+			name:         "within weekly update window",
+			updateWindow: &kubermaticv1.UpdateWindow{Start: "Wed 22:00", Length: "14h"},
+			expected:     0,
+		},
+		{
+			name:         "invalid update window",
+			updateWindow: &kubermaticv1.UpdateWindow{Start: "invalid", Length: "1h"},
+			expectErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			waitFor, err := durationToUpdateWindow(test.updateWindow, now)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("expected err=%v, got %v", test.expectErr, err)
+			}
+			if waitFor != test.expected {
+				t.Errorf("expected to wait %v, got %v", test.expected, waitFor)
+			}
+		})
+	}
+}
+
+func TestRolloutDelay(t *testing.T) {
+	now := time.Date(2020, time.September, 3, 10, 0, 0, 0, time.UTC)
+
+	updateManager := version.New(
+		[]*version.Version{
+			{Version: mastermindssemver.MustParse("1.18.5"), Type: v1.KubernetesClusterType},
+			{Version: mastermindssemver.MustParse("1.18.6"), Type: v1.KubernetesClusterType},
+		},
+		[]*version.Update{{
+			From:      "1.18.5",
+			To:        "1.18.6",
+			Automatic: true,
+			Type:      v1.KubernetesClusterType,
+			Rollout: &version.RolloutPolicy{
+				MaxConcurrentUpdates: 2,
+				CanarySelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				SoakTime:             metav1.Duration{Duration: time.Hour},
+			},
+		}},
+	)
+
+	updatedAgo := func(ago time.Duration, canary bool) *kubermaticv1.AutomaticUpdateStatus {
+		return &kubermaticv1.AutomaticUpdateStatus{
+			From:      "1.18.5",
+			To:        "1.18.6",
+			StartTime: metav1.NewTime(now.Add(-ago)),
+			Canary:    canary,
+		}
+	}
+	inProgress := func(status *kubermaticv1.AutomaticUpdateStatus) *kubermaticv1.AutomaticUpdateStatus {
+		status.InProgress = true
+		return status
+	}
+
+	tests := []struct {
+		name           string
+		cluster        *kubermaticv1.Cluster
+		others         []runtime.Object
+		expectedWait   time.Duration
+		expectedReason string
+		expectedFailed string
+	}{
+		{
+			name:    "canaries are updated first",
+			cluster: testCluster("canary-a", "1.18.5", true, true, nil),
+			others: []runtime.Object{
+				testCluster("other", "1.18.5", false, true, nil),
+			},
+		},
+		{
+			name:    "clusters wait for pending canaries",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.5", true, true, nil),
+			},
+			expectedWait:   rolloutRequeueInterval,
+			expectedReason: kubermaticv1.ReasonRolloutInProgress,
+		},
+		{
+			name:    "clusters wait for unhealthy canaries",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, false, updatedAgo(10*time.Minute, true)),
+			},
+			expectedWait:   rolloutRequeueInterval,
+			expectedReason: kubermaticv1.ReasonRolloutInProgress,
+		},
+		{
+			name:    "clusters wait for the soak time",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, true, updatedAgo(40*time.Minute, true)),
+				testCluster("canary-b", "1.18.6", true, true, updatedAgo(50*time.Minute, true)),
+			},
+			expectedWait:   20 * time.Minute,
+			expectedReason: kubermaticv1.ReasonRolloutInProgress,
+		},
+		{
+			name:    "clusters follow once the soak time has passed",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, true, updatedAgo(2*time.Hour, true)),
+			},
+		},
+		{
+			name:    "concurrent updates are limited",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, true, updatedAgo(2*time.Hour, true)),
+				testCluster("other-a", "1.18.6", false, false, inProgress(updatedAgo(5*time.Minute, false))),
+				testCluster("other-b", "1.18.6", false, false, inProgress(updatedAgo(5*time.Minute, false))),
+			},
+			expectedWait:   rolloutRequeueInterval,
+			expectedReason: kubermaticv1.ReasonRolloutInProgress,
+		},
+		{
+			name:    "updates in progress count even if the cluster reports to be healthy",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, true, updatedAgo(2*time.Hour, true)),
+				testCluster("other-a", "1.18.6", false, true, inProgress(updatedAgo(time.Second, false))),
+				testCluster("other-b", "1.18.6", false, true, inProgress(updatedAgo(time.Second, false))),
+			},
+			expectedWait:   rolloutRequeueInterval,
+			expectedReason: kubermaticv1.ReasonRolloutInProgress,
+		},
+		{
+			name:    "completed updates do not count against concurrent updates",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, true, updatedAgo(2*time.Hour, true)),
+				testCluster("other-a", "1.18.6", false, false, updatedAgo(time.Hour, false)),
+				testCluster("other-b", "1.18.6", false, false, updatedAgo(time.Hour, false)),
+			},
+		},
+		{
+			name:    "canaries that do not recover halt the rollout",
+			cluster: testCluster("canary-b", "1.18.5", true, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, false, updatedAgo(2*time.Hour, true)),
+			},
+			expectedWait:   rolloutRequeueInterval,
+			expectedReason: kubermaticv1.ReasonRolloutHalted,
+			expectedFailed: "canary-a",
+		},
+		{
+			name:    "failed canaries keep the rollout halted",
+			cluster: testCluster("other", "1.18.5", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.6", true, true, func() *kubermaticv1.AutomaticUpdateStatus {
+					status := updatedAgo(3*time.Hour, true)
+					status.Failed = true
+					return status
+				}()),
+			},
+			expectedWait:   rolloutRequeueInterval,
+			expectedReason: kubermaticv1.ReasonRolloutHalted,
+			expectedFailed: "canary-a",
+		},
+		{
+			name:    "clusters without pending update are not delayed",
+			cluster: testCluster("other", "1.18.6", false, true, nil),
+			others: []runtime.Object{
+				testCluster("canary-a", "1.18.5", true, true, nil),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := ctrlruntimefakeclient.NewFakeClient(append(test.others, test.cluster)...)
+			r := &Reconciler{
+				updateManager: updateManager,
+				Client:        client,
+				apiReader:     client,
+				recorder:      record.NewFakeRecorder(10),
+				now:           func() time.Time { return now },
+			}
+
+			waitFor, reason, _, err := r.rolloutDelay(ctx, test.cluster, v1.KubernetesClusterType)
+			if err != nil {
+				t.Fatalf("failed to check rollout: %v", err)
+			}
+			if waitFor != test.expectedWait {
+				t.Errorf("expected to wait %v, got %v", test.expectedWait, waitFor)
+			}
+			if reason != test.expectedReason {
+				t.Errorf("expected reason %q, got %q", test.expectedReason, reason)
+			}
+
+			if test.expectedFailed != "" {
+				canary := &kubermaticv1.Cluster{}
+				if err := r.Get(ctx, types.NamespacedName{Name: test.expectedFailed}, canary); err != nil {
+					t.Fatalf("failed to get cluster: %v", err)
+				}
+				if canary.Status.AutomaticUpdate == nil || !canary.Status.AutomaticUpdate.Failed {
+					t.Errorf("expected the update of cluster %s to be marked as failed", test.expectedFailed)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileUpdateHealth(t *testing.T) {
+	now := time.Date(2020, time.September, 3, 10, 0, 0, 0, time.UTC)
+
+	withDeadline := func(from, to string, in time.Duration) *kubermaticv1.AutomaticUpdateStatus {
+		deadline := metav1.NewTime(now.Add(in))
+		return &kubermaticv1.AutomaticUpdateStatus{
+			From:             from,
+			To:               to,
+			StartTime:        metav1.NewTime(now.Add(in - rollbackTimeout)),
+			Canary:           true,
+			InProgress:       true,
+			RollbackDeadline: &deadline,
+		}
+	}
+
+	apiserver := func(version string, updatedReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-a", Name: resources.ApiserverDeploymentName, Generation: 2},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: resources.Int32(2),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  resources.ApiserverDeploymentName,
+							Image: "k8s.gcr.io/kube-apiserver:v" + version,
+						}},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           2,
+				UpdatedReplicas:    updatedReplicas,
+				ReadyReplicas:      2,
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		cluster            *kubermaticv1.Cluster
+		apiserver          *appsv1.Deployment
+		expectedResult     *reconcile.Result
+		expectedVersion    string
+		expectedDeadline   bool
+		expectedInProgress bool
+		expectedRolledBack bool
+		expectedFailed     bool
+		expectedReason     string
+	}{
+		{
+			name:            "clusters without deadline are ignored",
+			cluster:         testCluster("a", "1.18.6", false, false, nil),
+			expectedVersion: "1.18.6",
+		},
+		{
+			name:               "unhealthy clusters wait for the deadline",
+			cluster:            testCluster("a", "1.18.6", true, false, withDeadline("1.18.5", "1.18.6", 5*time.Minute)),
+			expectedResult:     &reconcile.Result{RequeueAfter: 5 * time.Minute},
+			expectedVersion:    "1.18.6",
+			expectedDeadline:   true,
+			expectedInProgress: true,
+		},
+		{
+			name:            "healthy clusters complete the update",
+			cluster:         testCluster("a", "1.18.6", true, true, withDeadline("1.18.5", "1.18.6", 5*time.Minute)),
+			apiserver:       apiserver("1.18.6", 2),
+			expectedVersion: "1.18.6",
+			expectedReason:  kubermaticv1.ReasonClusterUpdateSuccessful,
+		},
+		{
+			name:               "a stale healthy status waits for the new apiserver",
+			cluster:            testCluster("a", "1.18.6", true, true, withDeadline("1.18.5", "1.18.6", 5*time.Minute)),
+			apiserver:          apiserver("1.18.5", 2),
+			expectedResult:     &reconcile.Result{RequeueAfter: updateCheckInterval},
+			expectedVersion:    "1.18.6",
+			expectedDeadline:   true,
+			expectedInProgress: true,
+		},
+		{
+			name:               "healthy clusters wait for all apiserver replicas to be updated",
+			cluster:            testCluster("a", "1.18.6", true, true, withDeadline("1.18.5", "1.18.6", 5*time.Minute)),
+			apiserver:          apiserver("1.18.6", 1),
+			expectedResult:     &reconcile.Result{RequeueAfter: updateCheckInterval},
+			expectedVersion:    "1.18.6",
+			expectedDeadline:   true,
+			expectedInProgress: true,
+		},
+		{
+			name:               "healthy clusters wait for the minimum soak time",
+			cluster:            testCluster("a", "1.18.6", true, true, withDeadline("1.18.5", "1.18.6", rollbackTimeout-30*time.Second)),
+			apiserver:          apiserver("1.18.6", 2),
+			expectedResult:     &reconcile.Result{RequeueAfter: updateCheckInterval},
+			expectedVersion:    "1.18.6",
+			expectedDeadline:   true,
+			expectedInProgress: true,
+		},
+		{
+			name:               "unhealthy clusters are rolled back after the deadline",
+			cluster:            testCluster("a", "1.18.6", true, false, withDeadline("1.18.5", "1.18.6", -time.Minute)),
+			expectedResult:     &reconcile.Result{RequeueAfter: time.Minute},
+			expectedVersion:    "1.18.5",
+			expectedRolledBack: true,
+			expectedFailed:     true,
+			expectedReason:     kubermaticv1.ReasonUpdateRolledBack,
+		},
+		{
+			name:               "clusters are not rolled back if the etcd version changed",
+			cluster:            testCluster("a", "1.17.0", true, false, withDeadline("1.16.9", "1.17.0", -time.Minute)),
+			expectedResult:     &reconcile.Result{RequeueAfter: time.Minute},
+			expectedVersion:    "1.17.0",
+			expectedInProgress: true,
+			expectedFailed:     true,
+			expectedReason:     kubermaticv1.ReasonRollbackImpossible,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			test.cluster.Status.NamespaceName = "cluster-a"
+			objects := []runtime.Object{test.cluster}
+			if test.apiserver != nil {
+				objects = append(objects, test.apiserver)
+			}
+			r := &Reconciler{
+				Client:   ctrlruntimefakeclient.NewFakeClient(objects...),
+				recorder: record.NewFakeRecorder(10),
+				now:      func() time.Time { return now },
+			}
+
+			result, err := r.reconcileUpdateHealth(ctx, test.cluster)
+			if err != nil {
+				t.Fatalf("failed to reconcile update health: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expectedResult) {
+				t.Errorf("expected result %v, got %v", test.expectedResult, result)
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := r.Get(ctx, types.NamespacedName{Name: test.cluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if v := cluster.Spec.Version.String(); v != test.expectedVersion {
+				t.Errorf("expected version %s, got %s", test.expectedVersion, v)
+			}
+			if status := cluster.Status.AutomaticUpdate; status != nil {
+				if (status.RollbackDeadline != nil) != test.expectedDeadline {
+					t.Errorf("expected rollback deadline to be set: %v, got %v", test.expectedDeadline, status.RollbackDeadline)
+				}
+				if status.InProgress != test.expectedInProgress {
+					t.Errorf("expected in progress to be %v", test.expectedInProgress)
+				}
+				if status.RolledBack != test.expectedRolledBack {
+					t.Errorf("expected rolled back to be %v", test.expectedRolledBack)
+				}
+				if status.Failed != test.expectedFailed {
+					t.Errorf("expected failed to be %v", test.expectedFailed)
+				}
+			}
+			var reason string
+			for _, condition := range cluster.Status.Conditions {
+				if condition.Type == kubermaticv1.ClusterConditionAutomaticUpdateHealthy {
+					reason = condition.Reason
+				}
+			}
+			if reason != test.expectedReason {
+				t.Errorf("expected condition reason %q, got %q", test.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func testCluster(name, clusterVersion string, canary, healthy bool, status *kubermaticv1.AutomaticUpdateStatus) *kubermaticv1.Cluster {
+	health := kubermaticv1.HealthStatusUp
+	if !healthy {
+		health = kubermaticv1.HealthStatusDown
+	}
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie(clusterVersion),
+		},
+		Status: kubermaticv1.ClusterStatus{
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver:                    health,
+				Scheduler:                    health,
+				Controller:                   health,
+				MachineController:            health,
+				Etcd:                         health,
+				CloudProviderInfrastructure:  health,
+				UserClusterControllerManager: health,
+			},
+			AutomaticUpdate: status,
+		},
+	}
+	if canary {
+		cluster.Labels = map[string]string{"canary": "true"}
+	}
+	return cluster
+}
@@ -67,6 +67,7 @@ func Add(
 	cloudProviderName string,
 	clusterURL *url.URL,
 	openvpnServerPort int,
+	sniTunnelAPIServerPort int,
 	registerReconciledCheck func(name string, check healthcheck.Check),
 	cloudCredentialSecretTemplate *corev1.Secret,
 	openshiftConsoleCallbackURI string,
@@ -79,6 +80,7 @@ func Add(
 		namespace:                     namespace,
 		clusterURL:                    clusterURL,
 		openvpnServerPort:             openvpnServerPort,
+		sniTunnelAPIServerPort:        sniTunnelAPIServerPort,
 		cloudCredentialSecretTemplate: cloudCredentialSecretTemplate,
 		log:                           log,
 		platform:                      cloudProviderName,
@@ -219,6 +221,7 @@ type reconciler struct {
 	namespace                     string
 	clusterURL                    *url.URL
 	openvpnServerPort             int
+	sniTunnelAPIServerPort        int
 	platform                      string
 	cloudCredentialSecretTemplate *corev1.Secret
 	openshiftConsoleCallbackURI   string
@@ -35,6 +35,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/openvpn"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/prometheus"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/scheduler"
+	snitunnelagent "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/sni-tunnel-agent"
 	systembasicuser "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/system-basic-user"
 	userauth "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/user-auth"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/usersshkeys"
@@ -452,8 +453,15 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 		return fmt.Errorf("failed to reconcile ConfigMaps in kube-public namespace: %v", err)
 	}
 
-	creators = []reconciling.NamedConfigMapCreatorGetter{
-		openvpn.ClientConfigConfigMapCreator(r.clusterURL.Hostname(), r.openvpnServerPort),
+	creators = []reconciling.NamedConfigMapCreatorGetter{}
+	if r.sniTunnelAPIServerPort != 0 {
+		// The OpenVPN client connects to the SNI tunnel agent on its node
+		creators = append(creators,
+			openvpn.ClientConfigConfigMapCreator(resources.SNITunnelAgentIP, snitunnelagent.OpenVPNPort),
+			snitunnelagent.ConfigMapCreator(r.clusterURL.Hostname(), r.sniTunnelAPIServerPort),
+		)
+	} else {
+		creators = append(creators, openvpn.ClientConfigConfigMapCreator(r.clusterURL.Hostname(), r.openvpnServerPort))
 	}
 	if r.openshift {
 		creators = append(creators, openshift.ControlplaneConfigCreator(r.platform))
@@ -516,6 +524,9 @@ func (r *reconciler) reconcileDaemonSet(ctx context.Context) error {
 	if !r.openshift {
 		dsCreators = append(dsCreators, nodelocaldns.DaemonSetCreator())
 	}
+	if r.sniTunnelAPIServerPort != 0 {
+		dsCreators = append(dsCreators, snitunnelagent.DaemonSetCreator(r.sniTunnelAPIServerPort))
+	}
 
 	if err := reconciling.ReconcileDaemonSets(ctx, dsCreators, metav1.NamespaceSystem, r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile the DaemonSet: %v", err)
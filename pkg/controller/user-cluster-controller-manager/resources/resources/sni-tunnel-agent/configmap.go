@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snitunnelagent
+
+import (
+	"bytes"
+	"text/template"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	addonManagerModeKey = "addonmanager.kubernetes.io/mode"
+	reconcileModeValue  = "Reconcile"
+
+	// OpenVPNPort is the port on which the agent accepts the connections of the OpenVPN client
+	OpenVPNPort = 1194
+
+	certsMountPath = "/etc/sni-tunnel-agent/certs"
+)
+
+type tunnel struct {
+	Name     string
+	Port     int
+	Hostname string
+}
+
+// ConfigMapCreator returns a ConfigMap containing the envoy config of the SNI tunnel agent. The agent
+// listens on the SNITunnelAgentIP for connections to the apiserver and to the OpenVPN server and
+// tunnels them through the SNI listener of the NodePort proxy.
+func ConfigMapCreator(externalName string, apiserverPort int) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.SNITunnelAgentConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			if cm.Labels == nil {
+				cm.Labels = map[string]string{}
+			}
+			cm.Labels[addonManagerModeKey] = reconcileModeValue
+
+			t, err := template.New("config").Parse(configTemplate)
+			if err != nil {
+				return nil, err
+			}
+			configBuf := bytes.Buffer{}
+			if err := t.Execute(&configBuf, struct {
+				ListenAddress string
+				ExternalName  string
+				CertsPath     string
+				Tunnels       []tunnel
+			}{
+				ListenAddress: resources.SNITunnelAgentIP,
+				ExternalName:  externalName,
+				CertsPath:     certsMountPath,
+				Tunnels: []tunnel{
+					{
+						Name:     "apiserver",
+						Port:     apiserverPort,
+						Hostname: resources.ApiserverTunnelSNIHostname(externalName),
+					},
+					{
+						Name:     "openvpn",
+						Port:     OpenVPNPort,
+						Hostname: resources.OpenVPNSNIHostname(externalName),
+					},
+				},
+			}); err != nil {
+				return nil, err
+			}
+
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+
+			cm.Data["envoy.yaml"] = configBuf.String()
+			return cm, nil
+		}
+	}
+}
+
+const (
+	configTemplate = `static_resources:
+  listeners:
+{{- range .Tunnels }}
+  - name: {{ .Name }}
+    address:
+      socket_address:
+        address: {{ $.ListenAddress }}
+        port_value: {{ .Port }}
+    filter_chains:
+    - filters:
+      - name: envoy.tcp_proxy
+        typed_config:
+          "@type": type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+          stat_prefix: {{ .Name }}
+          cluster: {{ .Name }}
+{{- end }}
+  clusters:
+{{- range .Tunnels }}
+  - name: {{ .Name }}
+    connect_timeout: 5s
+    type: STRICT_DNS
+    dns_lookup_family: V4_ONLY
+    load_assignment:
+      cluster_name: {{ .Name }}
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: {{ $.ExternalName }}
+                port_value: 443
+    transport_socket:
+      name: envoy.transport_sockets.tls
+      typed_config:
+        "@type": type.googleapis.com/envoy.api.v2.auth.UpstreamTlsContext
+        sni: {{ .Hostname }}
+        common_tls_context:
+          tls_certificates:
+          - certificate_chain:
+              filename: {{ $.CertsPath }}/client.crt
+            private_key:
+              filename: {{ $.CertsPath }}/client.key
+          validation_context:
+            trusted_ca:
+              filename: {{ $.CertsPath }}/ca.crt
+{{- end }}
+`
+)
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snitunnelagent
+
+import (
+	"fmt"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+// DaemonSetCreator returns the SNI tunnel agent, which runs on every node in the host network.
+// The SNITunnelAgentIP gets assigned to a dummy interface, so the nodes and the pods can reach it.
+func DaemonSetCreator(apiserverPort int) reconciling.NamedDaemonSetCreatorGetter {
+	return func() (string, reconciling.DaemonSetCreator) {
+		return resources.SNITunnelAgentDaemonSetName, func(ds *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+			maxUnavailable := intstr.FromString("10%")
+			ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &maxUnavailable,
+				},
+			}
+			labels := resources.BaseAppLabels(resources.SNITunnelAgentDaemonSetName, nil)
+			if ds.Labels == nil {
+				ds.Labels = labels
+			}
+			ds.Labels[addonManagerModeKey] = reconcileModeValue
+
+			if ds.Spec.Selector == nil {
+				ds.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+			}
+			if ds.Spec.Template.ObjectMeta.Labels == nil {
+				ds.Spec.Template.ObjectMeta.Labels = labels
+			}
+
+			ds.Spec.Template.Spec.PriorityClassName = "system-node-critical"
+			ds.Spec.Template.Spec.HostNetwork = true
+			// The cluster DNS needs the apiserver, which is only reachable once the agent runs
+			ds.Spec.Template.Spec.DNSPolicy = corev1.DNSDefault
+			ds.Spec.Template.Spec.AutomountServiceAccountToken = pointer.BoolPtr(false)
+			ds.Spec.Template.Spec.TerminationGracePeriodSeconds = pointer.Int64Ptr(0)
+			// All nodes and pods need the apiserver, so the agent must run on all nodes
+			ds.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			}
+
+			ds.Spec.Template.Spec.InitContainers = []corev1.Container{
+				{
+					Name:    "interface-init",
+					Image:   fmt.Sprintf("%s/kubermatic/openvpn:v2.4.8-r1", resources.RegistryQuay),
+					Command: []string{"/bin/sh"},
+					Args: []string{
+						"-ec",
+						fmt.Sprintf(`ip link add sni-tunnel type dummy 2>/dev/null || true
+ip addr replace %s/32 dev sni-tunnel
+ip link set sni-tunnel up`, resources.SNITunnelAgentIP),
+					},
+					SecurityContext: &corev1.SecurityContext{
+						Capabilities: &corev1.Capabilities{
+							Add: []corev1.Capability{"NET_ADMIN"},
+						},
+					},
+				},
+			}
+
+			ds.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:    "envoy",
+					Image:   fmt.Sprintf("%s/envoyproxy/envoy-alpine:v1.13.0", resources.RegistryDocker),
+					Command: []string{"/usr/local/bin/envoy"},
+					Args: []string{
+						"-c",
+						"/etc/envoy/envoy.yaml",
+					},
+					ReadinessProbe: &corev1.Probe{
+						Handler: corev1.Handler{
+							TCPSocket: &corev1.TCPSocketAction{
+								Host: resources.SNITunnelAgentIP,
+								Port: intstr.FromInt(apiserverPort),
+							},
+						},
+						PeriodSeconds:    10,
+						SuccessThreshold: 1,
+						FailureThreshold: 3,
+						TimeoutSeconds:   1,
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("16Mi"),
+							corev1.ResourceCPU:    resource.MustParse("5m"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "config",
+							MountPath: "/etc/envoy",
+							ReadOnly:  true,
+						},
+						{
+							Name:      "certs",
+							MountPath: certsMountPath,
+							ReadOnly:  true,
+						},
+					},
+				},
+			}
+
+			ds.Spec.Template.Spec.Volumes = []corev1.Volume{
+				{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: resources.SNITunnelAgentConfigMapName,
+							},
+						},
+					},
+				},
+				{
+					Name: "certs",
+					VolumeSource: corev1.VolumeSource{
+						// The agent authenticates with the certificate of the OpenVPN client
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: resources.OpenVPNClientCertificatesSecretName,
+						},
+					},
+				},
+			}
+
+			return ds, nil
+		}
+	}
+}
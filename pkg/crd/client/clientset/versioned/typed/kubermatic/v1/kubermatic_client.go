@@ -13,6 +13,7 @@ type KubermaticV1Interface interface {
 	AddonsGetter
 	AddonConfigsGetter
 	ClustersGetter
+	ClusterTemplatesGetter
 	ExternalClustersGetter
 	KubermaticSettingsGetter
 	ProjectsGetter
@@ -38,6 +39,10 @@ func (c *KubermaticV1Client) Clusters() ClusterInterface {
 	return newClusters(c)
 }
 
+func (c *KubermaticV1Client) ClusterTemplates() ClusterTemplateInterface {
+	return newClusterTemplates(c)
+}
+
 func (c *KubermaticV1Client) ExternalClusters() ExternalClusterInterface {
 	return newExternalClusters(c)
 }
@@ -14,6 +14,8 @@ type Interface interface {
 	AddonConfigs() AddonConfigInformer
 	// Clusters returns a ClusterInformer.
 	Clusters() ClusterInformer
+	// ClusterTemplates returns a ClusterTemplateInformer.
+	ClusterTemplates() ClusterTemplateInformer
 	// ExternalClusters returns a ExternalClusterInformer.
 	ExternalClusters() ExternalClusterInformer
 	// KubermaticSettings returns a KubermaticSettingInformer.
@@ -54,6 +56,11 @@ func (v *version) Clusters() ClusterInformer {
 	return &clusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterTemplates returns a ClusterTemplateInformer.
+func (v *version) ClusterTemplates() ClusterTemplateInformer {
+	return &clusterTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ExternalClusters returns a ExternalClusterInformer.
 func (v *version) ExternalClusters() ExternalClusterInformer {
 	return &externalClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
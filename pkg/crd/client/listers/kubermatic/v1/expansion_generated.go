@@ -18,6 +18,10 @@ type AddonConfigListerExpansion interface{}
 // ClusterLister.
 type ClusterListerExpansion interface{}
 
+// ClusterTemplateListerExpansion allows custom methods to be added to
+// ClusterTemplateLister.
+type ClusterTemplateListerExpansion interface{}
+
 // ExternalClusterListerExpansion allows custom methods to be added to
 // ExternalClusterLister.
 type ExternalClusterListerExpansion interface{}
@@ -52,6 +52,7 @@ const (
 	UpdatedByVPALabelKey = "updated-by-vpa"
 
 	DefaultEtcdClusterSize = 3
+	MaxEtcdClusterSize     = 7
 )
 
 // ProtectedClusterLabels is a set of labels that must not be set by users on clusters,
@@ -96,10 +97,18 @@ type ClusterSpec struct {
 	// HumanReadableName is the cluster name provided by the user
 	HumanReadableName string `json:"humanReadableName"`
 
-	// ExposeStrategy is the approach we use to expose this cluster, either via NodePort
-	// or via a dedicated LoadBalancer
+	// ExposeStrategy is the approach we use to expose this cluster, either via NodePort,
+	// via a dedicated LoadBalancer or via SNI on the port 443 of the NodePort proxy
 	ExposeStrategy corev1.ServiceType `json:"exposeStrategy"`
 
+	// APIServerAllowedIPRanges restricts the source IP ranges which may reach the apiserver of the
+	// cluster, an empty list allows all sources. The NodeEgressIPRanges and the master egress ranges
+	// of the seed are always allowed in addition.
+	APIServerAllowedIPRanges []string `json:"apiServerAllowedIPRanges,omitempty"`
+	// NodeEgressIPRanges are the source IP ranges the worker nodes reach the apiserver from. They
+	// must be set if APIServerAllowedIPRanges is set, as the nodes use the same address.
+	NodeEgressIPRanges []string `json:"nodeEgressIPRanges,omitempty"`
+
 	// Pause tells that this cluster is currently not managed by the controller.
 	// It indicates that the user needs to do some action to resolve the pause.
 	Pause bool `json:"pause"`
@@ -126,8 +135,17 @@ type ClusterSpec struct {
 	AdmissionPlugins                    []string `json:"admissionPlugins,omitempty"`
 
 	AuditLogging *AuditLoggingSettings `json:"auditLogging,omitempty"`
+
+	// EtcdBackup overrides the seed-wide etcd backup settings for this cluster.
+	EtcdBackup *EtcdBackupSettings `json:"etcdBackup,omitempty"`
 }
 
+// ExposeStrategySNI exposes the apiserver, the OpenVPN server and the rancher server of the cluster
+// only on the port 443 of the NodePort proxy, which routes the connections by their SNI hostname.
+// Clients within the cluster, which reach the apiserver by IP, and the OpenVPN client use the SNI
+// tunnel agent on their node, which tunnels their connections through TLS with a SNI hostname.
+const ExposeStrategySNI corev1.ServiceType = "SNI"
+
 const (
 	// ClusterFeatureExternalCloudProvider describes the external cloud provider feature. It is
 	// only supported on a limited set of providers for a specific set of Kube versions. It must
@@ -144,11 +162,26 @@ const (
 // the `AllClusterConditionTypes` variable.
 type ClusterConditionType string
 
+// UpdateWindow restricts automatic updates of the cluster to a recurring maintenance window.
 type UpdateWindow struct {
-	Start  string `json:"start,omitempty"`
+	// Start is the UTC time of day, optionally prefixed by a weekday, at which the window opens, e.g. "Tue 02:00".
+	Start string `json:"start,omitempty"`
+	// Length is the duration of the window, e.g. "3h".
 	Length string `json:"length,omitempty"`
 }
 
+// EtcdBackupSettings configures the periodic etcd backups of a cluster. Unset fields fall back
+// to the defaults of the seed.
+type EtcdBackupSettings struct {
+	// Enabled toggles the etcd backups of the cluster. Backups are enabled if unset.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Schedule is the cron expression at which backups are created, e.g. "0 * * * *".
+	Schedule string `json:"schedule,omitempty"`
+	// Retention is the number of backups to keep. Older backups are deleted. It is passed to the
+	// store container as MAX_REVISIONS and is ignored by custom store containers that do not use it.
+	Retention *int `json:"retention,omitempty"`
+}
+
 const (
 	// ClusterConditionSeedResourcesUpToDate indicates that all controllers have finished setting up the
 	// resources for a user clusters that run inside the seed cluster, i.e. this ignores
@@ -171,8 +204,42 @@ const (
 
 	ClusterConditionEtcdClusterInitialized ClusterConditionType = "EtcdClusterInitialized"
 
+	// ClusterConditionAutomaticUpdatesAllowed indicates whether pending automatic updates may be
+	// applied right now. It is false if the cluster is outside of its configured update window
+	// or has to wait for a staged rollout.
+	ClusterConditionAutomaticUpdatesAllowed ClusterConditionType = "AutomaticUpdatesAllowed"
+
+	// ClusterConditionAutomaticUpdateHealthy indicates whether the control plane became healthy
+	// after its last automatic update. It is false if the update had to be rolled back or
+	// could not be rolled back.
+	ClusterConditionAutomaticUpdateHealthy ClusterConditionType = "AutomaticUpdateHealthy"
+
+	// ClusterConditionEtcdDatabaseSizeHealthy indicates whether the databases of all etcd members are
+	// below the defragmentation threshold of the seed, either on their own or after they got defragmented.
+	ClusterConditionEtcdDatabaseSizeHealthy ClusterConditionType = "EtcdDatabaseSizeHealthy"
+
+	// ClusterConditionEtcdClusterSizeReconciled indicates whether etcd consists of the number of members
+	// configured for the cluster. It is false while members are being added or removed.
+	ClusterConditionEtcdClusterSizeReconciled ClusterConditionType = "EtcdClusterSizeReconciled"
+
 	ReasonClusterUpdateSuccessful = "ClusterUpdateSuccessful"
 	ReasonClusterUpdateInProgress = "ClusterUpdateInProgress"
+	ReasonOutsideUpdateWindow     = "OutsideUpdateWindow"
+	ReasonRolloutInProgress       = "RolloutInProgress"
+	ReasonRolloutHalted           = "RolloutHalted"
+	ReasonUpdateRolledBack        = "UpdateRolledBack"
+	ReasonRollbackImpossible      = "RollbackImpossible"
+
+	ReasonEtcdDatabaseSizeBelowThreshold = "DatabaseSizeBelowThreshold"
+	ReasonEtcdDefragmented               = "Defragmented"
+	ReasonEtcdDefragmentationFailed      = "DefragmentationFailed"
+	ReasonEtcdDefragmentationDisabled    = "DefragmentationDisabled"
+	ReasonEtcdDatabaseNotFragmented      = "DatabaseNotFragmented"
+
+	ReasonEtcdClusterSizeReached = "ClusterSizeReached"
+	ReasonEtcdScalingOut         = "ScalingOut"
+	ReasonEtcdScalingIn          = "ScalingIn"
+	ReasonEtcdInvalidClusterSize = "InvalidClusterSize"
 )
 
 var AllClusterConditionTypes = []ClusterConditionType{
@@ -249,6 +316,50 @@ type ClusterStatus struct {
 
 	// InheritedLabels are labels the cluster inherited from the project. They are read-only for users.
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
+
+	// EtcdBackup contains the outcome of the most recent etcd backups of the cluster.
+	EtcdBackup *EtcdBackupStatus `json:"etcdBackup,omitempty"`
+
+	// AutomaticUpdate describes the most recent automatic control plane update of the cluster.
+	AutomaticUpdate *AutomaticUpdateStatus `json:"automaticUpdate,omitempty"`
+}
+
+// AutomaticUpdateStatus describes an automatic control plane update of a cluster.
+type AutomaticUpdateStatus struct {
+	// From is the version the cluster was updated from.
+	From string `json:"from"`
+	// To is the version the cluster was updated to.
+	To string `json:"to"`
+	// StartTime is the time at which the update was started.
+	StartTime metav1.Time `json:"startTime"`
+	// Canary is true if the cluster was updated as a canary of a staged rollout.
+	Canary bool `json:"canary,omitempty"`
+	// InProgress is set when the update is started and unset once the control plane became
+	// healthy again. Clusters with an update in progress count against the concurrent updates
+	// of the rollout.
+	InProgress bool `json:"inProgress,omitempty"`
+	// Failed is set if the cluster was a canary and did not become healthy again in time.
+	// This halts the rollout of the update in the seed until it is unset again.
+	Failed bool `json:"failed,omitempty"`
+	// RollbackDeadline is the time until which the control plane must become healthy after
+	// the update. Otherwise it is rolled back to the previous version. It is unset once the
+	// apiserver was rolled out with the new version and the cluster became healthy.
+	RollbackDeadline *metav1.Time `json:"rollbackDeadline,omitempty"`
+	// RolledBack is set if the update was rolled back. The update is not retried automatically
+	// until the status is removed.
+	RolledBack bool `json:"rolledBack,omitempty"`
+}
+
+// EtcdBackupStatus describes the most recent etcd backups of a cluster.
+type EtcdBackupStatus struct {
+	// LastSuccessfulBackupTime is the time at which the last successful backup finished.
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+	// LastBackupObject is the key of the object the last successful backup was stored as.
+	LastBackupObject string `json:"lastBackupObject,omitempty"`
+	// LastFailureTime is the time at which the last backup failed.
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// LastFailureReason describes why the last failed backup failed.
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
 }
 
 // HasConditionValue returns true if the cluster status has the given condition with the given status.
@@ -286,8 +397,89 @@ type OIDCSettings struct {
 	ExtraScopes   string `json:"extraScopes,omitempty"`
 }
 
+// AuditPolicyPreset selects one of the audit policies shipped with Kubermatic.
+type AuditPolicyPreset string
+
+const (
+	// AuditPolicyMetadata logs the metadata of every request, but no request or response bodies.
+	AuditPolicyMetadata AuditPolicyPreset = "metadata"
+	// AuditPolicyRecommended logs request and response bodies of security relevant resources,
+	// like RBAC objects or pod exec sessions, and only the metadata of all other requests.
+	AuditPolicyRecommended AuditPolicyPreset = "recommended"
+	// AuditPolicyCustom uses the policy from the ConfigMap referenced in the CustomPolicy field.
+	AuditPolicyCustom AuditPolicyPreset = "custom"
+)
+
 type AuditLoggingSettings struct {
 	Enabled bool `json:"enabled,omitempty"`
+
+	// PolicyPreset selects the audit policy of the cluster. If unset, the "metadata" policy
+	// is used and manual changes to the audit ConfigMap of the cluster are kept.
+	PolicyPreset AuditPolicyPreset `json:"policyPreset,omitempty"`
+	// CustomPolicy references the ConfigMap containing the audit policy. Must be set if the
+	// "custom" preset is selected.
+	CustomPolicy *AuditPolicyConfigMapRef `json:"customPolicy,omitempty"`
+
+	// LogRotation configures the rotation of the audit log file inside the apiserver pod.
+	LogRotation *AuditLogRotationSettings `json:"logRotation,omitempty"`
+
+	// Sinks configures where the audit log is shipped to. The audit log is written to stdout
+	// of the audit-logs sidecar if no sinks are configured.
+	Sinks []AuditSink `json:"sinks,omitempty"`
+}
+
+// AuditPolicyConfigMapRef references a ConfigMap containing an audit policy. The ConfigMap
+// must live in the namespace of the Seed resource, so it can only be provided by admins.
+type AuditPolicyConfigMapRef struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+	// Key is the key of the policy inside the ConfigMap, defaults to "policy.yaml".
+	Key string `json:"key,omitempty"`
+}
+
+// AuditLogRotationSettings configures the rotation of the audit log file. Unset fields fall
+// back to the defaults.
+type AuditLogRotationSettings struct {
+	// MaxAge is the number of days to keep old audit log files, defaults to 30.
+	MaxAge int `json:"maxAge,omitempty"`
+	// MaxBackup is the number of old audit log files to keep, defaults to 3.
+	MaxBackup int `json:"maxBackup,omitempty"`
+	// MaxSize is the size in megabytes at which the audit log file is rotated, defaults to 100.
+	MaxSize int `json:"maxSize,omitempty"`
+}
+
+// AuditSink configures a single destination for the audit log. Exactly one of its fields
+// must be set.
+type AuditSink struct {
+	Webhook *AuditWebhookSink `json:"webhook,omitempty"`
+	Syslog  *AuditSyslogSink  `json:"syslog,omitempty"`
+	File    *AuditFileSink    `json:"file,omitempty"`
+}
+
+// AuditWebhookSink posts the audit events as JSON lines to an HTTP endpoint.
+type AuditWebhookSink struct {
+	// URL is the http or https endpoint the audit events are sent to.
+	URL string `json:"url"`
+	// InsecureSkipVerify disables the verification of the serving certificate of the endpoint.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// AuditSyslogSink forwards the audit events to a syslog server.
+type AuditSyslogSink struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// Mode is the transport protocol, one of "udp", "tcp" or "tls". Defaults to "udp".
+	Mode string `json:"mode,omitempty"`
+	// Format is the syslog message format, one of "rfc5424" or "rfc3164". Defaults to "rfc5424".
+	Format string `json:"format,omitempty"`
+}
+
+// AuditFileSink makes the apiserver write the audit log to the given file on the audit log
+// volume instead of audit.log. The file is rotated according to LogRotation. At most one file sink
+// can be configured.
+type AuditFileSink struct {
+	// Name is the name of the file.
+	Name string `json:"name"`
 }
 
 type ComponentSettings struct {
@@ -314,6 +506,8 @@ type StatefulSetSettings struct {
 }
 
 type EtcdStatefulSetSettings struct {
+	// ClusterSize is the number of etcd members, one of 3, 5 or 7. Changing it on a running
+	// cluster adds or removes one member at a time.
 	ClusterSize int                          `json:"clusterSize,omitempty"`
 	Resources   *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
@@ -415,6 +609,12 @@ type HetznerCloudSpec struct {
 	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference,omitempty"`
 
 	Token string `json:"token,omitempty"` // Token is used to authenticate with the Hetzner cloud API.
+
+	// Network is the name of the private network the nodes are attached to.
+	// If empty, a network is created for the cluster and deleted together with it.
+	// It must have a server subnet in the network zone of the datacenter and must not
+	// overlap with the pod and service CIDRs. No firewall is created for the nodes.
+	Network string `json:"network,omitempty"`
 }
 
 // AzureCloudSpec specifies acceess credentials to Azure cloud.
@@ -468,6 +668,25 @@ type VSphereCloudSpec struct {
 	// machines, it is mutually exclusive with Datastore.
 	// +optional
 	DatastoreCluster string `json:"datastoreCluster,omitempty"`
+	// ResourcePool is the path of the resource pool the virtual machines are
+	// placed in. Defaults to the root resource pool of the vSphere cluster.
+	// +optional
+	ResourcePool string `json:"resourcePool,omitempty"`
+
+	// TagCategoryID is the ID of the tag category in which a tag is created
+	// for the cluster. The tag is periodically attached to the virtual machines
+	// in the folder of the cluster, if the folder was created for it, and is
+	// deleted together with the cluster. No tags are managed if it is empty.
+	// +optional
+	TagCategoryID string `json:"tagCategoryID,omitempty"`
+	// Tags is a list of IDs of existing tags, which are attached to the
+	// virtual machines of the cluster in addition to the cluster tag.
+	// Tags can be added, but not removed once the cluster tag was created.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// ClusterTagID is the ID of the tag that was created for the cluster.
+	// +optional
+	ClusterTagID string `json:"clusterTagID,omitempty"`
 
 	// This user will be used for everything except cloud provider functionality
 	InfraManagementUser VSphereCredentials `json:"infraManagementUser"`
@@ -488,6 +707,15 @@ type AWSCloudSpec struct {
 	RouteTableID        string `json:"routeTableId"`
 	InstanceProfileName string `json:"instanceProfileName"`
 	SecurityGroupID     string `json:"securityGroupID"`
+	// SubnetIDs maps availability zones to the subnets the nodes in that zone are placed in.
+	// When set, nodes can only be created in the listed availability zones. Requires the VPCID to be set.
+	// +optional
+	SubnetIDs map[string]string `json:"subnetIDs,omitempty"`
+	// PrivateNodes disables public IPs for all nodes of the cluster. The nodes then reach the internet
+	// through a NAT gateway, which must be routed to in the route tables of the subnets of the
+	// nodes, or in the route table of the cluster if no subnets are set.
+	// +optional
+	PrivateNodes bool `json:"privateNodes,omitempty"`
 
 	// DEPRECATED. Don't care for the role name. We only require the ControlPlaneRoleARN to be set so the control plane
 	// can perform the assume-role.
@@ -545,6 +773,14 @@ type KubevirtCloudSpec struct {
 	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference,omitempty"`
 
 	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	// InfraNamespace is the namespace on the KubeVirt infra cluster that holds all
+	// virtual machines of this cluster. It is created by the cloud provider.
+	InfraNamespace string `json:"infraNamespace,omitempty"`
+	// ServiceAccountCredentialsReference references the kubeconfig of a service account
+	// that is only allowed to manage virtual machines inside InfraNamespace. It is handed
+	// to machine-controller instead of the admin kubeconfig.
+	ServiceAccountCredentialsReference *providerconfig.GlobalSecretKeySelector `json:"serviceAccountCredentialsReference,omitempty"`
 }
 
 // AlibabaCloudSpec specifies the access data to Alibaba.
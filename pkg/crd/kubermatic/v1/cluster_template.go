@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ClusterTemplateResourceName represents "Resource" defined in Kubernetes
+	ClusterTemplateResourceName = "clustertemplates"
+
+	// ClusterTemplateKind represents "Kind" defined in Kubernetes
+	ClusterTemplateKind = "ClusterTemplate"
+
+	// ClusterTemplateScopeLabelKey is the label which holds the scope of a cluster template
+	ClusterTemplateScopeLabelKey = "kubermatic.io/template-scope"
+	// ClusterTemplateUserAnnotationKey is the annotation which holds the email of the template creator
+	ClusterTemplateUserAnnotationKey = "kubermatic.io/template-user"
+
+	// UserClusterTemplateScope marks templates which are only visible to their creator
+	UserClusterTemplateScope = "user"
+	// ProjectClusterTemplateScope marks templates which are visible to all members of a project
+	ProjectClusterTemplateScope = "project"
+	// GlobalClusterTemplateScope marks templates which are visible to all users
+	GlobalClusterTemplateScope = "global"
+)
+
+//+genclient
+//+genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTemplate is the object representing a template for user clusters.
+type ClusterTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterTemplateSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTemplateList specifies a list of cluster templates
+type ClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterTemplate `json:"items"`
+}
+
+// ClusterTemplateSpec specifies the cluster and the initial node deployments created from a template.
+type ClusterTemplateSpec struct {
+	// HumanReadableName is the template name provided by the user
+	HumanReadableName string `json:"humanReadableName"`
+
+	// ClusterType is the type of the clusters created from the template, either kubernetes or openshift
+	ClusterType string `json:"clusterType,omitempty"`
+	// ClusterLabels are the labels set on the clusters created from the template
+	ClusterLabels map[string]string `json:"clusterLabels,omitempty"`
+	// Credential is the name of the preset used to fill in the cloud credentials. Templates
+	// never contain credentials themselves.
+	Credential string `json:"credential,omitempty"`
+
+	Cluster ClusterSpec `json:"cluster"`
+
+	// NodeDeployments holds the initial node deployments of the clusters in their API representation
+	NodeDeployments []runtime.RawExtension `json:"nodeDeployments,omitempty"`
+}
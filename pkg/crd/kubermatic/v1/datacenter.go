@@ -86,6 +86,102 @@ type SeedSpec struct {
 	ProxySettings *ProxySettings `json:"proxy_settings,omitempty"`
 	// Optional: ExposeStrategy explicitly sets the expose strategy for this seed cluster, if not set, the default provided by the master is used.
 	ExposeStrategy corev1.ServiceType `json:"expose_strategy,omitempty"`
+	// Optional: MasterEgressIPRanges are the source IP ranges in CIDR notation the master and this
+	// seed reach the apiservers of the user clusters from. They are always allowed by clusters which
+	// restrict the source IP ranges of their apiserver, and must be set for clusters to do so.
+	MasterEgressIPRanges []string `json:"master_egress_ip_ranges,omitempty"`
+	// Optional: EtcdBackupStorage configures where the etcd backups of the user clusters in this seed
+	// are stored. If not set, the store and cleanup containers are used as configured.
+	EtcdBackupStorage *EtcdBackupStorage `json:"etcd_backup_storage,omitempty"`
+	// Optional: EtcdBackupEncryption enables client-side encryption of the etcd backups of the user
+	// clusters in this seed. Backups are decrypted transparently when restoring them.
+	EtcdBackupEncryption *EtcdBackupEncryption `json:"etcd_backup_encryption,omitempty"`
+	// Optional: EtcdDefragmentation configures the automatic defragmentation of the
+	// etcd members of the user clusters in this seed. It is enabled with the defaults if not set.
+	EtcdDefragmentation *EtcdDefragmentationSettings `json:"etcd_defragmentation,omitempty"`
+}
+
+// EtcdDefragmentationSettings configures when the etcd members of user clusters get defragmented
+// to keep their databases below the backend quota.
+type EtcdDefragmentationSettings struct {
+	// Disabled turns off the automatic defragmentation. The database sizes are still monitored.
+	Disabled bool `json:"disabled,omitempty"`
+	// Optional: DBSizeThresholdPercent is the size of the database of a member, in percent of the
+	// backend quota, from which on the etcd of a cluster gets defragmented. Defaults to 60.
+	DBSizeThresholdPercent int `json:"db_size_threshold_percent,omitempty"`
+	// Optional: FragmentationThresholdPercent is the share of the database of a member, in percent,
+	// that must be unused for the member to get defragmented. Defragmenting a
+	// database that is mostly in use does not reclaim any space. Defaults to 25.
+	FragmentationThresholdPercent int `json:"fragmentation_threshold_percent,omitempty"`
+	// Optional: Interval is the duration between two checks of the database sizes, e.g. "10m".
+	// Defaults to 15m.
+	Interval string `json:"interval,omitempty"`
+}
+
+// EtcdBackupStorageBackend is the type of storage etcd backups are kept in.
+type EtcdBackupStorageBackend string
+
+const (
+	EtcdBackupStorageBackendS3         EtcdBackupStorageBackend = "s3"
+	EtcdBackupStorageBackendFilesystem EtcdBackupStorageBackend = "filesystem"
+	EtcdBackupStorageBackendSFTP       EtcdBackupStorageBackend = "sftp"
+)
+
+// EtcdBackupStorage configures the storage backend for etcd backups. Exactly the settings
+// for the selected backend must be set.
+type EtcdBackupStorage struct {
+	// Backend is one of "s3", "filesystem" or "sftp".
+	Backend EtcdBackupStorageBackend `json:"backend"`
+	// Bucket is the S3 bucket, or the directory below the root of the filesystem and SFTP
+	// backends, the backups are stored in.
+	Bucket string `json:"bucket"`
+	// Optional: CredentialsSecret is the name of a secret in the kube-system namespace of the
+	// seed. For S3 it must contain ACCESS_KEY_ID and SECRET_ACCESS_KEY, for SFTP SFTP_USERNAME
+	// and either SFTP_PASSWORD or SFTP_PRIVATE_KEY.
+	CredentialsSecret string `json:"credentials_secret,omitempty"`
+
+	S3         *EtcdBackupS3Storage         `json:"s3,omitempty"`
+	Filesystem *EtcdBackupFilesystemStorage `json:"filesystem,omitempty"`
+	SFTP       *EtcdBackupSFTPStorage       `json:"sftp,omitempty"`
+}
+
+// EtcdBackupS3Storage configures an S3 compatible object storage.
+type EtcdBackupS3Storage struct {
+	// Endpoint is the address of the S3 endpoint, e.g. "s3.amazonaws.com".
+	Endpoint string `json:"endpoint"`
+	// Secure enables TLS for the connection to the endpoint.
+	Secure bool `json:"secure,omitempty"`
+}
+
+// EtcdBackupFilesystemStorage configures a volume the backups are written to. The volume is only
+// mounted into the backup jobs, so these backups can neither be listed through the API nor be
+// restored by an EtcdRestore without copying them to an S3 or SFTP storage first.
+type EtcdBackupFilesystemStorage struct {
+	// PersistentVolumeClaim is the name of a ReadWriteMany PVC in the kube-system namespace of the seed.
+	PersistentVolumeClaim string `json:"persistent_volume_claim"`
+	// Optional: Path is the directory inside the volume that is used as the root of the backups.
+	// Defaults to the root of the volume.
+	Path string `json:"path,omitempty"`
+}
+
+// EtcdBackupSFTPStorage configures an SFTP server.
+type EtcdBackupSFTPStorage struct {
+	// Address is the host:port of the SFTP server.
+	Address string `json:"address"`
+	// HostKey is the public key of the server in authorized_keys format, e.g. "ssh-ed25519 AAAA...".
+	HostKey string `json:"host_key"`
+}
+
+// EtcdBackupEncryption configures the keys etcd backups are encrypted with. Every backup is
+// encrypted with a random data key, which is stored alongside the backup, encrypted with the
+// active key. The ID of the active key is recorded in the object metadata.
+type EtcdBackupEncryption struct {
+	// KeySecret is the name of a Secret in the kube-system namespace, which holds the base64
+	// encoded 256 bit keys, keyed by their ID. To rotate the key, add a new one and make it
+	// the active key. Old keys must be kept as long as backups encrypted with them exist.
+	KeySecret string `json:"key_secret"`
+	// ActiveKeyID is the ID of the key in KeySecret new backups are encrypted with.
+	ActiveKeyID string `json:"active_key_id"`
 }
 
 type NodeportProxyConfig struct {
@@ -96,6 +192,10 @@ type NodeportProxyConfig struct {
 	// Annotations are used to further tweak the LoadBalancer integration with the
 	// cloud provider where the seed cluster is running.
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// Optional: ExternalTrafficPolicy of the LoadBalancer service. Clusters exposed via NodePort
+	// or SNI can only restrict the source IP ranges of their apiserver if it is "Local", as envoy
+	// must see the source IPs of the connections. Defaults to "Cluster".
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"external_traffic_policy,omitempty"`
 	// Envoy configures the Envoy application itself.
 	Envoy NodeportProxyComponent `json:"envoy,omitempty"`
 	// EnvoyManager configures the Kubermatic-internal Envoy manager.
@@ -159,6 +259,9 @@ type DatacenterSpec struct {
 	// EnforceAuditLogging enforces audit logging on every cluster within the DC,
 	// ignoring cluster-specific settings.
 	EnforceAuditLogging bool `json:"enforceAuditLogging"`
+	// EnforcedAuditPolicyPreset is the minimum audit policy preset of every cluster within
+	// the DC. Only used if EnforceAuditLogging is set.
+	EnforcedAuditPolicyPreset AuditPolicyPreset `json:"enforcedAuditPolicyPreset,omitempty"`
 
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
@@ -299,6 +402,10 @@ type DatacenterSpecFake struct {
 
 // DatacenterSpecKubevirt describes a kubevirt datacenter.
 type DatacenterSpecKubevirt struct {
+	// Optional: ResourceQuota is applied to the namespace that is created for
+	// every user cluster on the KubeVirt infra cluster. If empty, the quota
+	// only tracks resource usage.
+	ResourceQuota corev1.ResourceList `json:"resourceQuota,omitempty"`
 }
 
 // DatacenterSpecAlibaba describes a alibaba datacenter.
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// EtcdRestoreResourceName represents "Resource" defined in Kubernetes
+	EtcdRestoreResourceName = "etcdrestores"
+
+	// EtcdRestoreKindName represents "Kind" defined in Kubernetes
+	EtcdRestoreKindName = "EtcdRestore"
+
+	// EtcdRestorePhaseStarted means the cluster has been paused and its apiserver is being scaled down.
+	EtcdRestorePhaseStarted EtcdRestorePhase = "Started"
+	// EtcdRestorePhaseStsRebuilding means the etcd data has been removed and the etcd members are
+	// bootstrapping from the snapshot.
+	EtcdRestorePhaseStsRebuilding EtcdRestorePhase = "StsRebuilding"
+	// EtcdRestorePhaseCompleted means the etcd cluster has been restored and is healthy again.
+	EtcdRestorePhaseCompleted EtcdRestorePhase = "Completed"
+
+	// EtcdRestoreConditionClusterPaused indicates that the cluster is paused for the restore.
+	EtcdRestoreConditionClusterPaused EtcdRestoreConditionType = "ClusterPaused"
+	// EtcdRestoreConditionAPIServerScaledDown indicates that no apiserver is running anymore.
+	EtcdRestoreConditionAPIServerScaledDown EtcdRestoreConditionType = "APIServerScaledDown"
+	// EtcdRestoreConditionEtcdDataRemoved indicates that the etcd StatefulSet and its volumes are deleted.
+	EtcdRestoreConditionEtcdDataRemoved EtcdRestoreConditionType = "EtcdDataRemoved"
+	// EtcdRestoreConditionEtcdRestored indicates that the etcd cluster is healthy with the restored data.
+	EtcdRestoreConditionEtcdRestored EtcdRestoreConditionType = "EtcdRestored"
+)
+
+// EtcdRestore specifies a restore of the etcd of a user cluster from a backup
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type EtcdRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdRestoreSpec   `json:"spec"`
+	Status EtcdRestoreStatus `json:"status,omitempty"`
+}
+
+// EtcdRestoreSpec specifies details of an etcd restore
+type EtcdRestoreSpec struct {
+	// Cluster is the reference to the cluster whose etcd will be restored
+	Cluster corev1.ObjectReference `json:"cluster"`
+	// BackupName is the name of the backup object in the backup bucket to restore from
+	BackupName string `json:"backupName"`
+	// BackupDownloadCredentialsSecret is the name of a Secret in the kube-system namespace which contains
+	// the credentials used to download the backup: ACCESS_KEY_ID and SECRET_ACCESS_KEY for S3, or
+	// SFTP_USERNAME and SFTP_PASSWORD or SFTP_PRIVATE_KEY for SFTP. The location of the backup is taken
+	// from the backup storage of the seed, unless the Secret sets BACKEND, BUCKET, ENDPOINT and SECURE,
+	// or SFTP_ADDRESS and SFTP_HOST_KEY itself.
+	BackupDownloadCredentialsSecret string `json:"backupDownloadCredentialsSecret"`
+}
+
+// EtcdRestoreList is a list of etcd restores
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type EtcdRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EtcdRestore `json:"items"`
+}
+
+type EtcdRestorePhase string
+
+type EtcdRestoreStatus struct {
+	Phase EtcdRestorePhase `json:"phase,omitempty"`
+	// RestoreTime is the time the etcd cluster became healthy with the restored data
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+	// ClusterPause is the pause state the cluster had before the restore. It is applied to
+	// the cluster again once the restore is completed.
+	// +optional
+	ClusterPause *EtcdRestoreClusterPause `json:"clusterPause,omitempty"`
+	Conditions   []EtcdRestoreCondition   `json:"conditions,omitempty"`
+}
+
+type EtcdRestoreClusterPause struct {
+	Paused bool   `json:"paused"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type EtcdRestoreConditionType string
+
+type EtcdRestoreCondition struct {
+	// Type of etcd restore condition.
+	Type EtcdRestoreConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transit from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -20,7 +20,11 @@ import (
 	"fmt"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -41,7 +45,8 @@ type ExternalCluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ExternalClusterSpec `json:"spec"`
+	Spec   ExternalClusterSpec   `json:"spec"`
+	Status ExternalClusterStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -61,8 +66,88 @@ type ExternalClusterSpec struct {
 	HumanReadableName string `json:"humanReadableName"`
 
 	KubeconfigReference *providerconfig.GlobalSecretKeySelector `json:"kubeconfigReference,omitempty"`
+
+	// CloudSpec is set for clusters imported from a managed Kubernetes service. The kubeconfig
+	// of such clusters is generated and refreshed by the external cluster controller.
+	CloudSpec *ExternalClusterCloudSpec `json:"cloudSpec,omitempty"`
+}
+
+// ExternalClusterCloudSpec specifies the managed Kubernetes service the cluster is imported from.
+// Only one of the fields may be set.
+type ExternalClusterCloudSpec struct {
+	EKS *ExternalClusterEKSCloudSpec `json:"eks,omitempty"`
+	GKE *ExternalClusterGKECloudSpec `json:"gke,omitempty"`
+	AKS *ExternalClusterAKSCloudSpec `json:"aks,omitempty"`
+
+	// PresetName is the name of the Preset containing the credentials. The credentials are
+	// read from the Preset whenever they are needed, instead of the CredentialsReference of the
+	// provider, so they are never copied. Only presets stored as Preset resources can be used.
+	PresetName string `json:"presetName,omitempty"`
+}
+
+// ExternalClusterEKSCloudSpec specifies an Amazon EKS cluster.
+type ExternalClusterEKSCloudSpec struct {
+	Name   string `json:"name"`
+	Region string `json:"region"`
+
+	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference"`
+}
+
+// ExternalClusterGKECloudSpec specifies a Google GKE cluster.
+type ExternalClusterGKECloudSpec struct {
+	Name string `json:"name"`
+	Zone string `json:"zone"`
+
+	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference"`
+}
+
+// ExternalClusterAKSCloudSpec specifies an Azure AKS cluster.
+type ExternalClusterAKSCloudSpec struct {
+	Name          string `json:"name"`
+	ResourceGroup string `json:"resourceGroup"`
+
+	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference"`
+}
+
+// ExternalClusterStatus contains the state of the external cluster as last observed by the
+// external cluster controller.
+type ExternalClusterStatus struct {
+	// Reachable is true if the last health check could connect to the cluster
+	Reachable bool `json:"reachable"`
+	// Version is the Kubernetes version of the cluster
+	Version *semver.Semver `json:"version,omitempty"`
+	// NodeCount is the number of nodes of the cluster
+	NodeCount int `json:"nodeCount"`
+	// Allocatable is the sum of the allocatable resources of all nodes
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+	// LastError is the error of the last health check, it is empty if the check succeeded
+	LastError string `json:"lastError,omitempty"`
+	// LastCheckTime is the time of the last health check
+	LastCheckTime metav1.Time `json:"lastCheckTime,omitempty"`
+	// LastHeartbeatTime is the time the cluster was reachable for the last time
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty"`
+	// KubeconfigRefreshTime is the time after which the generated kubeconfig of a cluster
+	// imported from a managed Kubernetes service has to be refreshed
+	KubeconfigRefreshTime metav1.Time `json:"kubeconfigRefreshTime,omitempty"`
+	// KubeconfigUpdate records the last change of the kubeconfig by a user
+	KubeconfigUpdate *ExternalClusterKubeconfigUpdate `json:"kubeconfigUpdate,omitempty"`
+	// KubeSystemUID is the UID of the kube-system namespace of the cluster. It identifies the
+	// cluster, a new kubeconfig must connect to the same cluster.
+	KubeSystemUID types.UID `json:"kubeSystemUID,omitempty"`
+}
+
+// ExternalClusterKubeconfigUpdate records who changed the kubeconfig of an external cluster and when.
+type ExternalClusterKubeconfigUpdate struct {
+	// User is the email of the user who changed the kubeconfig
+	User string `json:"user"`
+	// Time is the time the kubeconfig was changed
+	Time metav1.Time `json:"time"`
 }
 
 func (i *ExternalCluster) GetKubeconfigSecretName() string {
 	return fmt.Sprintf("kubeconfig-external-cluster-%s", i.Name)
 }
+
+func (i *ExternalCluster) GetCredentialsSecretName() string {
+	return fmt.Sprintf("credential-external-cluster-%s", i.Name)
+}
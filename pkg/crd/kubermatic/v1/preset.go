@@ -60,9 +60,40 @@ type PresetSpec struct {
 
 	Fake                *Fake  `json:"fake,omitempty"`
 	RequiredEmailDomain string `json:"requiredEmailDomain,omitempty"`
+
+	// Projects restricts the preset to the projects with the given IDs. The preset can
+	// be used in all projects if no projects are set.
+	Projects []string `json:"projects,omitempty"`
+}
+
+// IsAvailableInProject returns true if the preset can be used in the given project
+func (s *PresetSpec) IsAvailableInProject(projectID string) bool {
+	if len(s.Projects) == 0 {
+		return true
+	}
+	for _, project := range s.Projects {
+		if project == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// ProviderPreset contains the settings shared by the credentials of all providers
+type ProviderPreset struct {
+	// Enabled allows to disable the credentials of a single provider without removing
+	// them from the preset. The credentials are enabled if the field is not set.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled returns true if the credentials of the provider can be used
+func (p ProviderPreset) IsEnabled() bool {
+	return p.Enabled == nil || *p.Enabled
 }
 
 type Digitalocean struct {
+	ProviderPreset `json:",inline"`
+
 	// Token is used to authenticate with the DigitalOcean API.
 	Token string `json:"token"`
 
@@ -70,6 +101,8 @@ type Digitalocean struct {
 }
 
 type Hetzner struct {
+	ProviderPreset `json:",inline"`
+
 	// Token is used to authenticate with the Hetzner API.
 	Token string `json:"token"`
 
@@ -77,6 +110,8 @@ type Hetzner struct {
 }
 
 type Azure struct {
+	ProviderPreset `json:",inline"`
+
 	TenantID       string `json:"tenantId"`
 	SubscriptionID string `json:"subscriptionId"`
 	ClientID       string `json:"clientId"`
@@ -92,6 +127,8 @@ type Azure struct {
 }
 
 type VSphere struct {
+	ProviderPreset `json:",inline"`
+
 	Username string `json:"username"`
 	Password string `json:"password"`
 
@@ -102,6 +139,8 @@ type VSphere struct {
 }
 
 type AWS struct {
+	ProviderPreset `json:",inline"`
+
 	AccessKeyID     string `json:"accessKeyId"`
 	SecretAccessKey string `json:"secretAccessKey"`
 
@@ -115,6 +154,8 @@ type AWS struct {
 }
 
 type Openstack struct {
+	ProviderPreset `json:",inline"`
+
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Tenant   string `json:"tenant"`
@@ -131,6 +172,8 @@ type Openstack struct {
 }
 
 type Packet struct {
+	ProviderPreset `json:",inline"`
+
 	APIKey    string `json:"apiKey"`
 	ProjectID string `json:"projectId"`
 
@@ -140,6 +183,8 @@ type Packet struct {
 }
 
 type GCP struct {
+	ProviderPreset `json:",inline"`
+
 	ServiceAccount string `json:"serviceAccount"`
 
 	Network    string `json:"network,omitempty"`
@@ -149,18 +194,24 @@ type GCP struct {
 }
 
 type Fake struct {
+	ProviderPreset `json:",inline"`
+
 	Token string `json:"token"`
 
 	Datacenter string `json:"datacenter,omitempty"`
 }
 
 type Kubevirt struct {
+	ProviderPreset `json:",inline"`
+
 	Kubeconfig string `json:"kubeconfig"`
 
 	Datacenter string `json:"datacenter,omitempty"`
 }
 
 type Alibaba struct {
+	ProviderPreset `json:",inline"`
+
 	AccessKeyID     string `json:"accessKeyId"`
 	AccessKeySecret string `json:"accessKeySecret"`
 
@@ -75,6 +75,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AdmissionPluginList{},
 		&ExternalCluster{},
 		&ExternalClusterList{},
+		&EtcdRestore{},
+		&EtcdRestoreList{},
+		&ClusterTemplate{},
+		&ClusterTemplateList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
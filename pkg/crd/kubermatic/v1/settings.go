@@ -54,6 +54,9 @@ type SettingSpec struct {
 	EnableOIDCKubeconfig    bool           `json:"enableOIDCKubeconfig"`
 	UserProjectsLimit       int64          `json:"userProjectsLimit"`
 	RestrictProjectCreation bool           `json:"restrictProjectCreation"`
+	// ServiceAccountTokenMaxExpiryHours caps the validity of newly issued service account
+	// tokens. Zero means that tokens can be valid for up to three years.
+	ServiceAccountTokenMaxExpiryHours int64 `json:"serviceAccountTokenMaxExpiryHours"`
 
 	// TODO: Datacenters, presets, user management, Google Analytics and default addons.
 }
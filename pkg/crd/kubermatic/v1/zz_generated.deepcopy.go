@@ -36,6 +36,7 @@ func (in *APIServerSettings) DeepCopy() *APIServerSettings {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWS) DeepCopyInto(out *AWS) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -57,6 +58,13 @@ func (in *AWSCloudSpec) DeepCopyInto(out *AWSCloudSpec) {
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -376,6 +384,7 @@ func (in *AdmissionPluginSpec) DeepCopy() *AdmissionPluginSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Alibaba) DeepCopyInto(out *Alibaba) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -410,9 +419,58 @@ func (in *AlibabaCloudSpec) DeepCopy() *AlibabaCloudSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditFileSink) DeepCopyInto(out *AuditFileSink) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditFileSink.
+func (in *AuditFileSink) DeepCopy() *AuditFileSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditFileSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogRotationSettings) DeepCopyInto(out *AuditLogRotationSettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogRotationSettings.
+func (in *AuditLogRotationSettings) DeepCopy() *AuditLogRotationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogRotationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuditLoggingSettings) DeepCopyInto(out *AuditLoggingSettings) {
 	*out = *in
+	if in.CustomPolicy != nil {
+		in, out := &in.CustomPolicy, &out.CustomPolicy
+		*out = new(AuditPolicyConfigMapRef)
+		**out = **in
+	}
+	if in.LogRotation != nil {
+		in, out := &in.LogRotation, &out.LogRotation
+		*out = new(AuditLogRotationSettings)
+		**out = **in
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]AuditSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -426,9 +484,110 @@ func (in *AuditLoggingSettings) DeepCopy() *AuditLoggingSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditPolicyConfigMapRef) DeepCopyInto(out *AuditPolicyConfigMapRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditPolicyConfigMapRef.
+func (in *AuditPolicyConfigMapRef) DeepCopy() *AuditPolicyConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditPolicyConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditSink) DeepCopyInto(out *AuditSink) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AuditWebhookSink)
+		**out = **in
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(AuditSyslogSink)
+		**out = **in
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(AuditFileSink)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSink.
+func (in *AuditSink) DeepCopy() *AuditSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditSyslogSink) DeepCopyInto(out *AuditSyslogSink) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSyslogSink.
+func (in *AuditSyslogSink) DeepCopy() *AuditSyslogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditSyslogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditWebhookSink) DeepCopyInto(out *AuditWebhookSink) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditWebhookSink.
+func (in *AuditWebhookSink) DeepCopy() *AuditWebhookSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditWebhookSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomaticUpdateStatus) DeepCopyInto(out *AutomaticUpdateStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.RollbackDeadline != nil {
+		in, out := &in.RollbackDeadline, &out.RollbackDeadline
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticUpdateStatus.
+func (in *AutomaticUpdateStatus) DeepCopy() *AutomaticUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomaticUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Azure) DeepCopyInto(out *Azure) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -718,6 +877,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		}
 	}
 	out.Version = in.Version.DeepCopy()
+	if in.APIServerAllowedIPRanges != nil {
+		in, out := &in.APIServerAllowedIPRanges, &out.APIServerAllowedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeEgressIPRanges != nil {
+		in, out := &in.NodeEgressIPRanges, &out.NodeEgressIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.ComponentsOverride.DeepCopyInto(&out.ComponentsOverride)
 	out.OIDC = in.OIDC
 	if in.Features != nil {
@@ -745,7 +914,12 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	if in.AuditLogging != nil {
 		in, out := &in.AuditLogging, &out.AuditLogging
 		*out = new(AuditLoggingSettings)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EtcdBackup != nil {
+		in, out := &in.EtcdBackup, &out.EtcdBackup
+		*out = new(EtcdBackupSettings)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -814,6 +988,16 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.EtcdBackup != nil {
+		in, out := &in.EtcdBackup, &out.EtcdBackup
+		*out = new(EtcdBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutomaticUpdate != nil {
+		in, out := &in.AutomaticUpdate, &out.AutomaticUpdate
+		*out = new(AutomaticUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -827,6 +1011,97 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplate.
+func (in *ClusterTemplate) DeepCopy() *ClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateList) DeepCopyInto(out *ClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateList.
+func (in *ClusterTemplateList) DeepCopy() *ClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateSpec) DeepCopyInto(out *ClusterTemplateSpec) {
+	*out = *in
+	if in.ClusterLabels != nil {
+		in, out := &in.ClusterLabels, &out.ClusterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	if in.NodeDeployments != nil {
+		in, out := &in.NodeDeployments, &out.NodeDeployments
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateSpec.
+func (in *ClusterTemplateSpec) DeepCopy() *ClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentSettings) DeepCopyInto(out *ComponentSettings) {
 	*out = *in
@@ -953,7 +1228,7 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 	if in.Kubevirt != nil {
 		in, out := &in.Kubevirt, &out.Kubevirt
 		*out = new(DatacenterSpecKubevirt)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Alibaba != nil {
 		in, out := &in.Alibaba, &out.Alibaba
@@ -1108,133 +1383,503 @@ func (in *DatacenterSpecGCP) DeepCopy() *DatacenterSpecGCP {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatacenterSpecHetzner) DeepCopyInto(out *DatacenterSpecHetzner) {
+func (in *DatacenterSpecHetzner) DeepCopyInto(out *DatacenterSpecHetzner) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecHetzner.
+func (in *DatacenterSpecHetzner) DeepCopy() *DatacenterSpecHetzner {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterSpecHetzner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterSpecKubevirt) DeepCopyInto(out *DatacenterSpecKubevirt) {
+	*out = *in
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecKubevirt.
+func (in *DatacenterSpecKubevirt) DeepCopy() *DatacenterSpecKubevirt {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterSpecKubevirt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterSpecOpenstack) DeepCopyInto(out *DatacenterSpecOpenstack) {
+	*out = *in
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(ImageList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ManageSecurityGroups != nil {
+		in, out := &in.ManageSecurityGroups, &out.ManageSecurityGroups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrustDevicePath != nil {
+		in, out := &in.TrustDevicePath, &out.TrustDevicePath
+		*out = new(bool)
+		**out = **in
+	}
+	out.NodeSizeRequirements = in.NodeSizeRequirements
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecOpenstack.
+func (in *DatacenterSpecOpenstack) DeepCopy() *DatacenterSpecOpenstack {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterSpecOpenstack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterSpecPacket) DeepCopyInto(out *DatacenterSpecPacket) {
+	*out = *in
+	if in.Facilities != nil {
+		in, out := &in.Facilities, &out.Facilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecPacket.
+func (in *DatacenterSpecPacket) DeepCopy() *DatacenterSpecPacket {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterSpecPacket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterSpecVSphere) DeepCopyInto(out *DatacenterSpecVSphere) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make(ImageList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InfraManagementUser != nil {
+		in, out := &in.InfraManagementUser, &out.InfraManagementUser
+		*out = new(VSphereCredentials)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecVSphere.
+func (in *DatacenterSpecVSphere) DeepCopy() *DatacenterSpecVSphere {
+	if in == nil {
+		return nil
+	}
+	out := new(DatacenterSpecVSphere)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentSettings) DeepCopyInto(out *DeploymentSettings) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSettings.
+func (in *DeploymentSettings) DeepCopy() *DeploymentSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Digitalocean) DeepCopyInto(out *Digitalocean) {
+	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Digitalocean.
+func (in *Digitalocean) DeepCopy() *Digitalocean {
+	if in == nil {
+		return nil
+	}
+	out := new(Digitalocean)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DigitaloceanCloudSpec) DeepCopyInto(out *DigitaloceanCloudSpec) {
+	*out = *in
+	if in.CredentialsReference != nil {
+		in, out := &in.CredentialsReference, &out.CredentialsReference
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigitaloceanCloudSpec.
+func (in *DigitaloceanCloudSpec) DeepCopy() *DigitaloceanCloudSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DigitaloceanCloudSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupEncryption) DeepCopyInto(out *EtcdBackupEncryption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupEncryption.
+func (in *EtcdBackupEncryption) DeepCopy() *EtcdBackupEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupFilesystemStorage) DeepCopyInto(out *EtcdBackupFilesystemStorage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupFilesystemStorage.
+func (in *EtcdBackupFilesystemStorage) DeepCopy() *EtcdBackupFilesystemStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupFilesystemStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupS3Storage) DeepCopyInto(out *EtcdBackupS3Storage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupS3Storage.
+func (in *EtcdBackupS3Storage) DeepCopy() *EtcdBackupS3Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupS3Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupSFTPStorage) DeepCopyInto(out *EtcdBackupSFTPStorage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupSFTPStorage.
+func (in *EtcdBackupSFTPStorage) DeepCopy() *EtcdBackupSFTPStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupSFTPStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupSettings) DeepCopyInto(out *EtcdBackupSettings) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupSettings.
+func (in *EtcdBackupSettings) DeepCopy() *EtcdBackupSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupStatus) DeepCopyInto(out *EtcdBackupStatus) {
+	*out = *in
+	if in.LastSuccessfulBackupTime != nil {
+		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupStatus.
+func (in *EtcdBackupStatus) DeepCopy() *EtcdBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupStorage) DeepCopyInto(out *EtcdBackupStorage) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(EtcdBackupS3Storage)
+		**out = **in
+	}
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(EtcdBackupFilesystemStorage)
+		**out = **in
+	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(EtcdBackupSFTPStorage)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupStorage.
+func (in *EtcdBackupStorage) DeepCopy() *EtcdBackupStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDefragmentationSettings) DeepCopyInto(out *EtcdDefragmentationSettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDefragmentationSettings.
+func (in *EtcdDefragmentationSettings) DeepCopy() *EtcdDefragmentationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDefragmentationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestore) DeepCopyInto(out *EtcdRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestore.
+func (in *EtcdRestore) DeepCopy() *EtcdRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestoreClusterPause) DeepCopyInto(out *EtcdRestoreClusterPause) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecHetzner.
-func (in *DatacenterSpecHetzner) DeepCopy() *DatacenterSpecHetzner {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreClusterPause.
+func (in *EtcdRestoreClusterPause) DeepCopy() *EtcdRestoreClusterPause {
 	if in == nil {
 		return nil
 	}
-	out := new(DatacenterSpecHetzner)
+	out := new(EtcdRestoreClusterPause)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatacenterSpecKubevirt) DeepCopyInto(out *DatacenterSpecKubevirt) {
+func (in *EtcdRestoreCondition) DeepCopyInto(out *EtcdRestoreCondition) {
 	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecKubevirt.
-func (in *DatacenterSpecKubevirt) DeepCopy() *DatacenterSpecKubevirt {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreCondition.
+func (in *EtcdRestoreCondition) DeepCopy() *EtcdRestoreCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(DatacenterSpecKubevirt)
+	out := new(EtcdRestoreCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatacenterSpecOpenstack) DeepCopyInto(out *DatacenterSpecOpenstack) {
+func (in *EtcdRestoreList) DeepCopyInto(out *EtcdRestoreList) {
 	*out = *in
-	if in.DNSServers != nil {
-		in, out := &in.DNSServers, &out.DNSServers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Images != nil {
-		in, out := &in.Images, &out.Images
-		*out = make(ImageList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ManageSecurityGroups != nil {
-		in, out := &in.ManageSecurityGroups, &out.ManageSecurityGroups
-		*out = new(bool)
-		**out = **in
-	}
-	if in.TrustDevicePath != nil {
-		in, out := &in.TrustDevicePath, &out.TrustDevicePath
-		*out = new(bool)
-		**out = **in
-	}
-	out.NodeSizeRequirements = in.NodeSizeRequirements
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecOpenstack.
-func (in *DatacenterSpecOpenstack) DeepCopy() *DatacenterSpecOpenstack {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreList.
+func (in *EtcdRestoreList) DeepCopy() *EtcdRestoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(DatacenterSpecOpenstack)
+	out := new(EtcdRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatacenterSpecPacket) DeepCopyInto(out *DatacenterSpecPacket) {
+func (in *EtcdRestoreSpec) DeepCopyInto(out *EtcdRestoreSpec) {
 	*out = *in
-	if in.Facilities != nil {
-		in, out := &in.Facilities, &out.Facilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.Cluster = in.Cluster
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecPacket.
-func (in *DatacenterSpecPacket) DeepCopy() *DatacenterSpecPacket {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreSpec.
+func (in *EtcdRestoreSpec) DeepCopy() *EtcdRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DatacenterSpecPacket)
+	out := new(EtcdRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatacenterSpecVSphere) DeepCopyInto(out *DatacenterSpecVSphere) {
+func (in *EtcdRestoreStatus) DeepCopyInto(out *EtcdRestoreStatus) {
 	*out = *in
-	if in.Templates != nil {
-		in, out := &in.Templates, &out.Templates
-		*out = make(ImageList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
 	}
-	if in.InfraManagementUser != nil {
-		in, out := &in.InfraManagementUser, &out.InfraManagementUser
-		*out = new(VSphereCredentials)
+	if in.ClusterPause != nil {
+		in, out := &in.ClusterPause, &out.ClusterPause
+		*out = new(EtcdRestoreClusterPause)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]EtcdRestoreCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecVSphere.
-func (in *DatacenterSpecVSphere) DeepCopy() *DatacenterSpecVSphere {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreStatus.
+func (in *EtcdRestoreStatus) DeepCopy() *EtcdRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DatacenterSpecVSphere)
+	out := new(EtcdRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentSettings) DeepCopyInto(out *DeploymentSettings) {
+func (in *EtcdStatefulSetSettings) DeepCopyInto(out *EtcdStatefulSetSettings) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(corev1.ResourceRequirements)
@@ -1243,34 +1888,62 @@ func (in *DeploymentSettings) DeepCopyInto(out *DeploymentSettings) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSettings.
-func (in *DeploymentSettings) DeepCopy() *DeploymentSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdStatefulSetSettings.
+func (in *EtcdStatefulSetSettings) DeepCopy() *EtcdStatefulSetSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentSettings)
+	out := new(EtcdStatefulSetSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Digitalocean) DeepCopyInto(out *Digitalocean) {
+func (in *ExtendedClusterHealth) DeepCopyInto(out *ExtendedClusterHealth) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Digitalocean.
-func (in *Digitalocean) DeepCopy() *Digitalocean {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedClusterHealth.
+func (in *ExtendedClusterHealth) DeepCopy() *ExtendedClusterHealth {
 	if in == nil {
 		return nil
 	}
-	out := new(Digitalocean)
+	out := new(ExtendedClusterHealth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DigitaloceanCloudSpec) DeepCopyInto(out *DigitaloceanCloudSpec) {
+func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCluster.
+func (in *ExternalCluster) DeepCopy() *ExternalCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExternalCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterAKSCloudSpec) DeepCopyInto(out *ExternalClusterAKSCloudSpec) {
 	*out = *in
 	if in.CredentialsReference != nil {
 		in, out := &in.CredentialsReference, &out.CredentialsReference
@@ -1280,78 +1953,104 @@ func (in *DigitaloceanCloudSpec) DeepCopyInto(out *DigitaloceanCloudSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigitaloceanCloudSpec.
-func (in *DigitaloceanCloudSpec) DeepCopy() *DigitaloceanCloudSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterAKSCloudSpec.
+func (in *ExternalClusterAKSCloudSpec) DeepCopy() *ExternalClusterAKSCloudSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DigitaloceanCloudSpec)
+	out := new(ExternalClusterAKSCloudSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EtcdStatefulSetSettings) DeepCopyInto(out *EtcdStatefulSetSettings) {
+func (in *ExternalClusterCloudSpec) DeepCopyInto(out *ExternalClusterCloudSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+	if in.EKS != nil {
+		in, out := &in.EKS, &out.EKS
+		*out = new(ExternalClusterEKSCloudSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GKE != nil {
+		in, out := &in.GKE, &out.GKE
+		*out = new(ExternalClusterGKECloudSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AKS != nil {
+		in, out := &in.AKS, &out.AKS
+		*out = new(ExternalClusterAKSCloudSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdStatefulSetSettings.
-func (in *EtcdStatefulSetSettings) DeepCopy() *EtcdStatefulSetSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterCloudSpec.
+func (in *ExternalClusterCloudSpec) DeepCopy() *ExternalClusterCloudSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EtcdStatefulSetSettings)
+	out := new(ExternalClusterCloudSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExtendedClusterHealth) DeepCopyInto(out *ExtendedClusterHealth) {
+func (in *ExternalClusterEKSCloudSpec) DeepCopyInto(out *ExternalClusterEKSCloudSpec) {
 	*out = *in
+	if in.CredentialsReference != nil {
+		in, out := &in.CredentialsReference, &out.CredentialsReference
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedClusterHealth.
-func (in *ExtendedClusterHealth) DeepCopy() *ExtendedClusterHealth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterEKSCloudSpec.
+func (in *ExternalClusterEKSCloudSpec) DeepCopy() *ExternalClusterEKSCloudSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExtendedClusterHealth)
+	out := new(ExternalClusterEKSCloudSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
+func (in *ExternalClusterGKECloudSpec) DeepCopyInto(out *ExternalClusterGKECloudSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.CredentialsReference != nil {
+		in, out := &in.CredentialsReference, &out.CredentialsReference
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCluster.
-func (in *ExternalCluster) DeepCopy() *ExternalCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterGKECloudSpec.
+func (in *ExternalClusterGKECloudSpec) DeepCopy() *ExternalClusterGKECloudSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalCluster)
+	out := new(ExternalClusterGKECloudSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ExternalCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterKubeconfigUpdate) DeepCopyInto(out *ExternalClusterKubeconfigUpdate) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterKubeconfigUpdate.
+func (in *ExternalClusterKubeconfigUpdate) DeepCopy() *ExternalClusterKubeconfigUpdate {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ExternalClusterKubeconfigUpdate)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -1395,6 +2094,11 @@ func (in *ExternalClusterSpec) DeepCopyInto(out *ExternalClusterSpec) {
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.CloudSpec != nil {
+		in, out := &in.CloudSpec, &out.CloudSpec
+		*out = new(ExternalClusterCloudSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1408,9 +2112,46 @@ func (in *ExternalClusterSpec) DeepCopy() *ExternalClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterStatus) DeepCopyInto(out *ExternalClusterStatus) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	in.KubeconfigRefreshTime.DeepCopyInto(&out.KubeconfigRefreshTime)
+	if in.KubeconfigUpdate != nil {
+		in, out := &in.KubeconfigUpdate, &out.KubeconfigUpdate
+		*out = new(ExternalClusterKubeconfigUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterStatus.
+func (in *ExternalClusterStatus) DeepCopy() *ExternalClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fake) DeepCopyInto(out *Fake) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -1443,6 +2184,7 @@ func (in *FakeCloudSpec) DeepCopy() *FakeCloudSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCP) DeepCopyInto(out *GCP) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -1480,6 +2222,7 @@ func (in *GCPCloudSpec) DeepCopy() *GCPCloudSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Hetzner) DeepCopyInto(out *Hetzner) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -1625,6 +2368,7 @@ func (in *KubermaticSettingList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kubevirt) DeepCopyInto(out *Kubevirt) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -1646,6 +2390,11 @@ func (in *KubevirtCloudSpec) DeepCopyInto(out *KubevirtCloudSpec) {
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.ServiceAccountCredentialsReference != nil {
+		in, out := &in.ServiceAccountCredentialsReference, &out.ServiceAccountCredentialsReference
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
+	}
 	return
 }
 
@@ -1801,6 +2550,7 @@ func (in *Openshift) DeepCopy() *Openshift {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Openstack) DeepCopyInto(out *Openstack) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -1854,6 +2604,7 @@ func (in *OpenstackNodeSizeRequirements) DeepCopy() *OpenstackNodeSizeRequiremen
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Packet) DeepCopyInto(out *Packet) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -1954,57 +2705,62 @@ func (in *PresetSpec) DeepCopyInto(out *PresetSpec) {
 	if in.Digitalocean != nil {
 		in, out := &in.Digitalocean, &out.Digitalocean
 		*out = new(Digitalocean)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Hetzner != nil {
 		in, out := &in.Hetzner, &out.Hetzner
 		*out = new(Hetzner)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(Azure)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.VSphere != nil {
 		in, out := &in.VSphere, &out.VSphere
 		*out = new(VSphere)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
 		*out = new(AWS)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Openstack != nil {
 		in, out := &in.Openstack, &out.Openstack
 		*out = new(Openstack)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Packet != nil {
 		in, out := &in.Packet, &out.Packet
 		*out = new(Packet)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
 		*out = new(GCP)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Kubevirt != nil {
 		in, out := &in.Kubevirt, &out.Kubevirt
 		*out = new(Kubevirt)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Alibaba != nil {
 		in, out := &in.Alibaba, &out.Alibaba
 		*out = new(Alibaba)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Fake != nil {
 		in, out := &in.Fake, &out.Fake
 		*out = new(Fake)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
@@ -2128,6 +2884,27 @@ func (in *ProjectStatus) DeepCopy() *ProjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderPreset) DeepCopyInto(out *ProviderPreset) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderPreset.
+func (in *ProviderPreset) DeepCopy() *ProviderPreset {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderPreset)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxySettings) DeepCopyInto(out *ProxySettings) {
 	*out = *in
@@ -2278,6 +3055,26 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = new(ProxySettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MasterEgressIPRanges != nil {
+		in, out := &in.MasterEgressIPRanges, &out.MasterEgressIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EtcdBackupStorage != nil {
+		in, out := &in.EtcdBackupStorage, &out.EtcdBackupStorage
+		*out = new(EtcdBackupStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EtcdBackupEncryption != nil {
+		in, out := &in.EtcdBackupEncryption, &out.EtcdBackupEncryption
+		*out = new(EtcdBackupEncryption)
+		**out = **in
+	}
+	if in.EtcdDefragmentation != nil {
+		in, out := &in.EtcdDefragmentation, &out.EtcdDefragmentation
+		*out = new(EtcdDefragmentationSettings)
+		**out = **in
+	}
 	return
 }
 
@@ -2591,6 +3388,7 @@ func (in *UserSpec) DeepCopy() *UserSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphere) DeepCopyInto(out *VSphere) {
 	*out = *in
+	in.ProviderPreset.DeepCopyInto(&out.ProviderPreset)
 	return
 }
 
@@ -2612,6 +3410,11 @@ func (in *VSphereCloudSpec) DeepCopyInto(out *VSphereCloudSpec) {
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.InfraManagementUser = in.InfraManagementUser
 	return
 }
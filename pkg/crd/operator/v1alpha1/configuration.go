@@ -33,6 +33,9 @@ const (
 	NodePortStrategy ExposeStrategy = "NodePort"
 	// LoadBalancerStrategy creates a LoadBalancer service per cluster.
 	LoadBalancerStrategy ExposeStrategy = "LoadBalancer"
+	// SNIStrategy exposes all clusters only on the port 443 of the central NodePort proxy, which
+	// routes the connections by their SNI hostname.
+	SNIStrategy ExposeStrategy = "SNI"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -300,6 +303,27 @@ type Update struct {
 	//nolint:staticcheck
 	//lint:ignore SA5008 omitgenyaml is used by the example-yaml-generator
 	AutomaticNodeUpdate *bool `json:"automaticNodeUpdate,omitempty,omitgenyaml"`
+	// Rollout controls how an automatic update is rolled out to the matching user
+	// clusters of a seed. If not set, all matching clusters are updated at once.
+	// ---
+	//nolint:staticcheck
+	//lint:ignore SA5008 omitgenyaml is used by the example-yaml-generator
+	Rollout *UpdateRolloutPolicy `json:"rollout,omitempty,omitgenyaml"`
+}
+
+// UpdateRolloutPolicy configures a staged rollout of an automatic control plane update.
+type UpdateRolloutPolicy struct {
+	// MaxConcurrentUpdates is the maximum number of clusters per seed whose control plane
+	// is updated at the same time. Zero means no limit.
+	MaxConcurrentUpdates int `json:"maxConcurrentUpdates,omitempty"`
+	// CanarySelector selects the canary clusters, which are updated first. All other
+	// clusters are only updated once all canaries are healthy and the soak time has passed.
+	// If a canary does not become healthy again within the soak time (but at least 15
+	// minutes), the rollout is halted.
+	CanarySelector *metav1.LabelSelector `json:"canarySelector,omitempty"`
+	// SoakTime is the time to wait after the last canary was updated before the remaining
+	// clusters are updated, e.g. "2h".
+	SoakTime metav1.Duration `json:"soakTime,omitempty"`
 }
 
 // KubermaticVPAConfiguration configures the Kubernetes VPA.
@@ -6,6 +6,7 @@ package v1alpha1
 
 import (
 	semver "github.com/Masterminds/semver"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	sets "k8s.io/apimachinery/pkg/util/sets"
 )
@@ -461,6 +462,11 @@ func (in *Update) DeepCopyInto(out *Update) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(UpdateRolloutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -473,3 +479,25 @@ func (in *Update) DeepCopy() *Update {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateRolloutPolicy) DeepCopyInto(out *UpdateRolloutPolicy) {
+	*out = *in
+	if in.CanarySelector != nil {
+		in, out := &in.CanarySelector, &out.CanarySelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.SoakTime = in.SoakTime
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateRolloutPolicy.
+func (in *UpdateRolloutPolicy) DeepCopy() *UpdateRolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateRolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
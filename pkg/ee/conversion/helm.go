@@ -188,7 +188,7 @@ func convertKubermaticConfiguration(values *helmValues, targetNamespace string)
 	config.Spec.Ingress.CertificateIssuer.Kind = certmanagerv1alpha2.ClusterIssuerKind
 
 	if values.Kubermatic.ExposeStrategy != "" && values.Kubermatic.ExposeStrategy != string(common.DefaultExposeStrategy) {
-		allowed := sets.NewString(string(operatorv1alpha1.NodePortStrategy), string(operatorv1alpha1.LoadBalancerStrategy))
+		allowed := sets.NewString(string(operatorv1alpha1.NodePortStrategy), string(operatorv1alpha1.LoadBalancerStrategy), string(operatorv1alpha1.SNIStrategy))
 
 		if !allowed.Has(values.Kubermatic.ExposeStrategy) {
 			return nil, fmt.Errorf("invalid expose strategy '%s', choose one of %v", values.Kubermatic.ExposeStrategy, allowed.List())
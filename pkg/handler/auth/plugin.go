@@ -32,6 +32,8 @@ type TokenClaims struct {
 	Subject string
 	Groups  []string
 	Expiry  apiv1.Time
+	// Scopes restricts the API routes the token can be used for, all routes are allowed if empty
+	Scopes []string
 }
 
 // TokenExtractorVerifier combines TokenVerifier and TokenExtractor interfaces
@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
@@ -69,10 +71,32 @@ func (s *ServiceAccountAuthClient) Verify(ctx context.Context, token string) (To
 	if string(tokenFromDB) != token {
 		return TokenClaims{}, fmt.Errorf("sa: the token %s has been revoked for %s", customClaims.TokenID, customClaims.Email)
 	}
+	if serviceaccount.IsRevoked(rawToken) {
+		return TokenClaims{}, fmt.Errorf("sa: the token %s has been rotated and revoked for %s", customClaims.TokenID, customClaims.Email)
+	}
+
+	s.updateLastUsed(rawToken)
 
 	return TokenClaims{
 		Name:    customClaims.TokenID,
 		Email:   customClaims.Email,
 		Subject: customClaims.Email,
+		Scopes:  customClaims.Scopes,
 	}, nil
 }
+
+// updateLastUsed records the time the token was used. The update is best effort, a failed
+// update must not fail the authentication of the request.
+func (s *ServiceAccountAuthClient) updateLastUsed(token *corev1.Secret) {
+	now := serviceaccount.Now()
+	if lastUsed := serviceaccount.LastUsed(token); lastUsed != nil && now.Sub(*lastUsed) < serviceaccount.LastUsedUpdateInterval {
+		return
+	}
+
+	updatedToken := token.DeepCopy()
+	if updatedToken.Annotations == nil {
+		updatedToken.Annotations = map[string]string{}
+	}
+	updatedToken.Annotations[serviceaccount.LastUsedAnnotationKey] = now.UTC().Format(time.RFC3339)
+	_, _ = s.saTokenProvider.UpdateUnsecured(updatedToken)
+}
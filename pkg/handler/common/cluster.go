@@ -21,11 +21,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 	"time"
 
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
+	"github.com/Masterminds/semver"
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -44,6 +46,7 @@ import (
 	machineresource "k8c.io/kubermatic/v2/pkg/resources/machine"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/validation"
+	"k8c.io/kubermatic/v2/pkg/validation/upgradecheck"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -81,6 +84,18 @@ type patchCluster struct {
 func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClusterSpec, sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
 	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
 	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter) (interface{}, error) {
+	var nodeDeployments []*apiv1.NodeDeployment
+	if body.NodeDeployment != nil {
+		nodeDeployments = append(nodeDeployments, body.NodeDeployment)
+	}
+	return CreateClusterWithNodeDeployments(ctx, projectID, body.Cluster, nodeDeployments, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter)
+}
+
+// CreateClusterWithNodeDeployments creates the given cluster in the project. The node deployments are
+// created in the background once the cluster is up.
+func CreateClusterWithNodeDeployments(ctx context.Context, projectID string, apiCluster apiv1.Cluster, nodeDeployments []*apiv1.NodeDeployment, sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
+	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter) (*apiv1.Cluster, error) {
 
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
@@ -94,23 +109,23 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 	}
 	k8sClient := privilegedClusterProvider.GetSeedClusterAdminClient()
 
-	seed, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, body.Cluster.Spec.Cloud.DatacenterName)
+	seed, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, apiCluster.Spec.Cloud.DatacenterName)
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	credentialName := body.Cluster.Credential
+	credentialName := apiCluster.Credential
 	if len(credentialName) > 0 {
-		cloudSpec, err := credentialManager.SetCloudCredentials(adminUserInfo, credentialName, body.Cluster.Spec.Cloud, dc)
+		cloudSpec, err := credentialManager.SetCloudCredentials(adminUserInfo, project.Name, credentialName, apiCluster.Spec.Cloud, dc)
 		if err != nil {
 			return nil, errors.NewBadRequest("invalid credentials: %v", err)
 		}
-		body.Cluster.Spec.Cloud = *cloudSpec
+		apiCluster.Spec.Cloud = *cloudSpec
 	}
 
 	// Create the cluster.
 	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
-	spec, err := cluster.Spec(body.Cluster, dc, secretKeyGetter)
+	spec, err := cluster.Spec(apiCluster, dc, secretKeyGetter)
 	if err != nil {
 		return nil, errors.NewBadRequest("invalid cluster: %v", err)
 	}
@@ -133,8 +148,20 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 	if err = validation.ValidateUpdateWindow(spec.UpdateWindow); err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
+	if err = validation.ValidateEtcdBackupSettings(spec.EtcdBackup); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err = validation.ValidateAuditLoggingSettings(spec.AuditLogging); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err = validateAuditPolicyChange(adminUserInfo, nil, spec.AuditLogging); err != nil {
+		return nil, err
+	}
+	if err = validation.ValidateAPIServerAllowedIPRanges(spec, seed); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
 	partialCluster := &kubermaticv1.Cluster{}
-	partialCluster.Labels = body.Cluster.Labels
+	partialCluster.Labels = apiCluster.Labels
 	if partialCluster.Labels == nil {
 		partialCluster.Labels = make(map[string]string)
 	}
@@ -142,8 +169,8 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 	// for example the credentials secret.
 	partialCluster.Labels[kubermaticv1.ProjectIDLabelKey] = projectID
 	partialCluster.Spec = *spec
-	if body.Cluster.Type == "openshift" {
-		if body.Cluster.Spec.Openshift == nil || body.Cluster.Spec.Openshift.ImagePullSecret == "" {
+	if apiCluster.Type == "openshift" {
+		if apiCluster.Spec.Openshift == nil || apiCluster.Spec.Openshift.ImagePullSecret == "" {
 			return nil, errors.NewBadRequest("openshift clusters must be configured with an imagePullSecret")
 		}
 		partialCluster.Annotations = map[string]string{
@@ -153,9 +180,7 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 
 	// Enforce audit logging
 	if dc.Spec.EnforceAuditLogging {
-		partialCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-			Enabled: true,
-		}
+		partialCluster.Spec.AuditLogging = enforceAuditLogging(partialCluster.Spec.AuditLogging, dc.Spec.EnforcedAuditPolicyPreset)
 	}
 
 	// Enforce PodSecurityPolicy
@@ -180,8 +205,14 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	// Create the initial node deployment in the background.
-	if body.NodeDeployment != nil && body.NodeDeployment.Spec.Replicas > 0 {
+	// Create the initial node deployments in the background.
+	var initialNodeDeployments []*apiv1.NodeDeployment
+	for _, nd := range nodeDeployments {
+		if nd != nil && nd.Spec.Replicas > 0 {
+			initialNodeDeployments = append(initialNodeDeployments, nd)
+		}
+	}
+	if len(initialNodeDeployments) > 0 {
 		// for BringYourOwn provider we don't create ND
 		isBYO, err := common.IsBringYourOwnProvider(spec.Cloud)
 		if err != nil {
@@ -190,16 +221,18 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		if !isBYO {
 			go func() {
 				defer utilruntime.HandleCrash()
-				ndName := getNodeDeploymentDisplayName(body.NodeDeployment)
-				eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationStart), "Started creation of initial node deployment %s", ndName)
-				err := createInitialNodeDeploymentWithRetries(ctx, body.NodeDeployment, newCluster, project, sshKeyProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter)
-				if err != nil {
-					eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeWarning, string(nodeDeploymentCreationFail), "Failed to create initial node deployment %s: %v", ndName, err)
-					klog.Errorf("failed to create initial node deployment for cluster %s: %v", newCluster.Name, err)
-					initNodeDeploymentFailures.With(prometheus.Labels{"cluster": newCluster.Name, "datacenter": body.Cluster.Spec.Cloud.DatacenterName}).Add(1)
-				} else {
-					eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationSuccess), "Successfully created initial node deployment %s", ndName)
-					klog.V(5).Infof("created initial node deployment for cluster %s", newCluster.Name)
+				for _, nodeDeployment := range initialNodeDeployments {
+					ndName := getNodeDeploymentDisplayName(nodeDeployment)
+					eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationStart), "Started creation of initial node deployment %s", ndName)
+					err := createInitialNodeDeploymentWithRetries(ctx, nodeDeployment, newCluster, project, sshKeyProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter)
+					if err != nil {
+						eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeWarning, string(nodeDeploymentCreationFail), "Failed to create initial node deployment %s: %v", ndName, err)
+						klog.Errorf("failed to create initial node deployment for cluster %s: %v", newCluster.Name, err)
+						initNodeDeploymentFailures.With(prometheus.Labels{"cluster": newCluster.Name, "datacenter": apiCluster.Spec.Cloud.DatacenterName}).Add(1)
+					} else {
+						eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationSuccess), "Successfully created initial node deployment %s", ndName)
+						klog.V(5).Infof("created initial node deployment for cluster %s", newCluster.Name)
+					}
 				}
 			}()
 		} else {
@@ -306,7 +339,7 @@ func DeleteEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	return nil, updateAndDeleteCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, existingCluster)
 }
 
-func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, patch json.RawMessage, seedsGetter provider.SeedsGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, patch json.RawMessage, seedsGetter provider.SeedsGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, admissionPluginProvider provider.AdmissionPluginsProvider) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
 
@@ -362,6 +395,9 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	newInternalCluster.Spec.AuditLogging = patchedCluster.Spec.AuditLogging
 	newInternalCluster.Spec.Openshift = patchedCluster.Spec.Openshift
 	newInternalCluster.Spec.UpdateWindow = patchedCluster.Spec.UpdateWindow
+	newInternalCluster.Spec.EtcdBackup = patchedCluster.Spec.EtcdBackup
+	newInternalCluster.Spec.APIServerAllowedIPRanges = patchedCluster.Spec.APIServerAllowedIPRanges
+	newInternalCluster.Spec.NodeEgressIPRanges = patchedCluster.Spec.NodeEgressIPRanges
 
 	incompatibleKubelets, err := common.CheckClusterVersionSkew(ctx, userInfoGetter, clusterProvider, newInternalCluster, projectID)
 	if err != nil {
@@ -371,24 +407,36 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		return nil, errors.NewBadRequest("Cluster contains nodes running the following incompatible kubelet versions: %v. Upgrade your nodes before you upgrade the cluster.", incompatibleKubelets)
 	}
 
+	if !newInternalCluster.IsOpenshift() && !newInternalCluster.Spec.Version.Equal(&oldInternalCluster.Spec.Version) {
+		blockers, err := checkControlPlaneUpgrade(ctx, userInfoGetter, clusterProvider, admissionPluginProvider, oldInternalCluster, newInternalCluster, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(blockers) > 0 {
+			return nil, errors.NewBadRequest("cluster cannot be updated to version %s: %s", newInternalCluster.Spec.Version.String(), strings.Join(blockers, "; "))
+		}
+	}
+
 	userInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
 		return nil, errors.New(http.StatusInternalServerError, err.Error())
 	}
-	_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, newInternalCluster.Spec.Cloud.DatacenterName)
+	seed, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, newInternalCluster.Spec.Cloud.DatacenterName)
 	if err != nil {
 		return nil, fmt.Errorf("error getting dc: %v", err)
 	}
 
+	if err := validateAuditPolicyChange(userInfo, oldInternalCluster.Spec.AuditLogging, newInternalCluster.Spec.AuditLogging); err != nil {
+		return nil, err
+	}
+
 	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), newInternalCluster); err != nil {
 		return nil, err
 	}
 
 	// Enforce audit logging
 	if dc.Spec.EnforceAuditLogging {
-		newInternalCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-			Enabled: true,
-		}
+		newInternalCluster.Spec.AuditLogging = enforceAuditLogging(newInternalCluster.Spec.AuditLogging, dc.Spec.EnforcedAuditPolicyPreset)
 	}
 
 	// Enforce PodSecurityPolicy
@@ -406,6 +454,15 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	if err = validation.ValidateUpdateWindow(newInternalCluster.Spec.UpdateWindow); err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
+	if err = validation.ValidateEtcdBackupSettings(newInternalCluster.Spec.EtcdBackup); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err = validation.ValidateAuditLoggingSettings(newInternalCluster.Spec.AuditLogging); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err = validation.ValidateAPIServerAllowedIPRanges(&newInternalCluster.Spec, seed); err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
 
 	updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, newInternalCluster)
 	if err != nil {
@@ -415,6 +472,44 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	return convertInternalClusterToExternal(updatedCluster, true), nil
 }
 
+// checkControlPlaneUpgrade runs the pre-flight checks for updating the control plane
+// from the version of oldCluster to the version of newCluster. The checks that need
+// the user cluster are advisory: if it can't be reached, e.g. because the update is
+// meant to fix its control plane, they are skipped.
+func checkControlPlaneUpgrade(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, admissionPluginProvider provider.AdmissionPluginsProvider, oldCluster, newCluster *kubermaticv1.Cluster, projectID string) ([]string, error) {
+	// The admission plugins are taken from the new cluster, as they can be changed
+	// together with the version.
+	cluster := newCluster.DeepCopy()
+	cluster.Spec.Version = oldCluster.Spec.Version
+	target := newCluster.Spec.Version.Semver()
+
+	var blockers []upgradecheck.Blocker
+	state, err := gatherUpgradeState(ctx, userInfoGetter, clusterProvider, cluster, projectID, target)
+	if err != nil {
+		kubermaticlog.Logger.With("cluster", cluster.Name).Warnw("Skipping the pre-flight checks of the user cluster", zap.Error(err))
+		blockers, err = upgradecheck.UnavailableAdmissionPlugins(admissionPluginProvider, cluster, target)
+	} else {
+		blockers, err = state.Check(admissionPluginProvider, target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to run pre-flight checks: %v", err)
+	}
+
+	messages := make([]string, 0, len(blockers))
+	for _, blocker := range blockers {
+		messages = append(messages, blocker.String())
+	}
+	return messages, nil
+}
+
+func gatherUpgradeState(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster, projectID string, target *semver.Version) (*upgradecheck.ClusterState, error) {
+	client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return upgradecheck.GatherClusterState(ctx, client, cluster, target)
+}
+
 func UpdateClusterSSHKey(ctx context.Context, userInfoGetter provider.UserInfoGetter, sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, clusterSSHKey *kubermaticv1.UserSSHKey, projectID string) error {
 	adminUserInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
@@ -657,14 +752,18 @@ func convertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, fil
 			MachineNetworks:                     internalCluster.Spec.MachineNetworks,
 			OIDC:                                internalCluster.Spec.OIDC,
 			UpdateWindow:                        internalCluster.Spec.UpdateWindow,
+			EtcdBackup:                          internalCluster.Spec.EtcdBackup,
 			AuditLogging:                        internalCluster.Spec.AuditLogging,
 			UsePodSecurityPolicyAdmissionPlugin: internalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
 			UsePodNodeSelectorAdmissionPlugin:   internalCluster.Spec.UsePodNodeSelectorAdmissionPlugin,
 			AdmissionPlugins:                    internalCluster.Spec.AdmissionPlugins,
+			APIServerAllowedIPRanges:            internalCluster.Spec.APIServerAllowedIPRanges,
+			NodeEgressIPRanges:                  internalCluster.Spec.NodeEgressIPRanges,
 		},
 		Status: apiv1.ClusterStatus{
-			Version: internalCluster.Spec.Version,
-			URL:     internalCluster.Address.URL,
+			Version:    internalCluster.Spec.Version,
+			URL:        internalCluster.Address.URL,
+			EtcdBackup: internalCluster.Status.EtcdBackup,
 		},
 		Type: apiv1.KubernetesClusterType,
 	}
@@ -757,3 +856,41 @@ func ConvertClusterMetrics(podMetrics *v1beta1.PodMetricsList, nodeMetrics []v1b
 
 	return clusterMetrics, nil
 }
+
+// auditPolicyPresetRanks orders the audit policy presets by the amount of details they log.
+var auditPolicyPresetRanks = map[kubermaticv1.AuditPolicyPreset]int{
+	"":                                  0,
+	kubermaticv1.AuditPolicyMetadata:    0,
+	kubermaticv1.AuditPolicyRecommended: 1,
+}
+
+// validateAuditPolicyChange makes sure that only admins can select a custom audit policy or
+// change the referenced policy. Regular users can keep a custom policy an admin has set up.
+func validateAuditPolicyChange(userInfo *provider.UserInfo, oldSettings, newSettings *kubermaticv1.AuditLoggingSettings) error {
+	if userInfo.IsAdmin || newSettings == nil || newSettings.PolicyPreset != kubermaticv1.AuditPolicyCustom {
+		return nil
+	}
+	if oldSettings != nil && oldSettings.PolicyPreset == kubermaticv1.AuditPolicyCustom && reflect.DeepEqual(oldSettings.CustomPolicy, newSettings.CustomPolicy) {
+		return nil
+	}
+	return errors.New(http.StatusForbidden, "only admins can select a custom audit policy")
+}
+
+// enforceAuditLogging enables audit logging and raises the policy preset to the given minimum.
+// Custom policies can only be selected by admins, see validateAuditPolicyChange, and are
+// therefore kept as they are.
+func enforceAuditLogging(settings *kubermaticv1.AuditLoggingSettings, minimumPreset kubermaticv1.AuditPolicyPreset) *kubermaticv1.AuditLoggingSettings {
+	enforced := &kubermaticv1.AuditLoggingSettings{}
+	if settings != nil {
+		enforced = settings.DeepCopy()
+	}
+	enforced.Enabled = true
+
+	if enforced.PolicyPreset == kubermaticv1.AuditPolicyCustom {
+		return enforced
+	}
+	if auditPolicyPresetRanks[enforced.PolicyPreset] < auditPolicyPresetRanks[minimumPreset] {
+		enforced.PolicyPreset = minimumPreset
+	}
+	return enforced
+}
@@ -24,12 +24,14 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	transporthttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 	kubermaticcontext "k8c.io/kubermatic/v2/pkg/util/context"
 	k8cerrors "k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/util/hash"
@@ -50,6 +52,9 @@ const (
 	// noTokenFoundKey key under which an error is kept when no suitable token has been found in a request
 	noTokenFoundKey kubermaticcontext.Key = "no-token-found"
 
+	// requestRouteKey key under which the method and the path template of the matched route are kept in the ctx
+	requestRouteKey kubermaticcontext.Key = "request-route"
+
 	// ClusterProviderContextKey key under which the current ClusterProvider is kept in the ctx
 	ClusterProviderContextKey kubermaticcontext.Key = "cluster-provider"
 
@@ -224,6 +229,13 @@ func TokenVerifier(tokenVerifier auth.TokenVerifier, userProvider provider.UserP
 				return nil, err
 			}
 
+			if len(claims.Scopes) > 0 {
+				route, _ := ctx.Value(requestRouteKey).(requestRoute)
+				if !serviceaccount.ScopesAllow(claims.Scopes, route.method, route.pathTemplate) {
+					return nil, k8cerrors.New(http.StatusForbidden, "forbidden: the token is not allowed to access this endpoint")
+				}
+			}
+
 			ctx = context.WithValue(ctx, TokenExpiryContextKey, claims.Expiry)
 			return next(context.WithValue(ctx, AuthenticatedUserContextKey, user), request)
 		}
@@ -276,9 +288,21 @@ func getAddonProvider(addonProviderGetter provider.AddonProviderGetter, seedsGet
 	return addonProviderGetter(seed)
 }
 
+// requestRoute identifies the route of a request, it is used to check the scopes of a token
+type requestRoute struct {
+	method       string
+	pathTemplate string
+}
+
 // TokenExtractor knows how to extract a token from the incoming request
 func TokenExtractor(o auth.TokenExtractor) transporthttp.RequestFunc {
 	return func(ctx context.Context, r *http.Request) context.Context {
+		route := requestRoute{method: r.Method}
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			route.pathTemplate, _ = currentRoute.GetPathTemplate()
+		}
+		ctx = context.WithValue(ctx, requestRouteKey, route)
+
 		token, err := o.Extract(r)
 		if err != nil {
 			return context.WithValue(ctx, noTokenFoundKey, err)
@@ -205,6 +205,14 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/providers/vsphere/folders").
 		Handler(r.listVSphereFolders())
 
+	mux.Methods(http.MethodGet).
+		Path("/providers/vsphere/resourcepools").
+		Handler(r.listVSphereResourcePools())
+
+	mux.Methods(http.MethodGet).
+		Path("/providers/vsphere/tagcategories").
+		Handler(r.listVSphereTagCategories())
+
 	mux.Methods(http.MethodGet).
 		Path("/providers/packet/sizes").
 		Handler(r.listPacketSizes())
@@ -303,6 +311,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/health").
 		Handler(r.getClusterHealth())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/backups").
+		Handler(r.listClusterBackups())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/upgrades").
 		Handler(r.getClusterUpgrades())
@@ -639,6 +651,9 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 	mux.Methods(http.MethodPatch).
 		Path("/projects/{project_id}/serviceaccounts/{serviceaccount_id}/tokens/{token_id}").
 		Handler(r.patchServiceAccountToken())
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/serviceaccounts/{serviceaccount_id}/tokens/{token_id}/rotate").
+		Handler(r.rotateServiceAccountToken())
 	mux.Methods(http.MethodDelete).
 		Path("/projects/{project_id}/serviceaccounts/{serviceaccount_id}/tokens/{token_id}").
 		Handler(r.deleteServiceAccountToken())
@@ -1088,6 +1103,50 @@ func (r Routing) listVSphereFolders() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v1/providers/vsphere/resourcepools vsphere listVSphereResourcePools
+//
+// Lists resource pools from vsphere datacenter
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []VSphereResourcePool
+func (r Routing) listVSphereResourcePools() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(provider.VsphereResourcePoolsEndpoint(r.seedsGetter, r.presetsProvider, r.userInfoGetter)),
+		provider.DecodeVSphereResourcePoolsReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/providers/vsphere/tagcategories vsphere listVSphereTagCategories
+//
+// Lists tag categories from vsphere datacenter
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []VSphereTagCategory
+func (r Routing) listVSphereTagCategories() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(provider.VsphereTagCategoriesEndpoint(r.seedsGetter, r.presetsProvider, r.userInfoGetter)),
+		provider.DecodeVSphereTagCategoriesReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/providers/packet/sizes packet listPacketSizes
 //
 // Lists sizes from packet
@@ -1868,7 +1927,7 @@ func (r Routing) patchCluster() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(cluster.PatchEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		)(cluster.PatchEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.admissionPluginProvider)),
 		cluster.DecodePatchReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2009,6 +2068,32 @@ func (r Routing) getClusterHealth() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/backups project listClusterBackups
+//
+//     Lists the etcd backups of the cluster that are stored in the backup bucket of the seed
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []EtcdBackup
+//       401: empty
+//       403: empty
+func (r Routing) listClusterBackups() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.ListBackupsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route PUT /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/sshkeys/{key_id} project assignSSHKeyToCluster
 //
 //     Assigns an existing ssh key to the given cluster
@@ -2168,7 +2253,7 @@ func (r Routing) getClusterUpgrades() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(cluster.GetUpgradesEndpoint(r.updateManager, r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		)(cluster.GetUpgradesEndpoint(r.updateManager, r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.admissionPluginProvider)),
 		common.DecodeGetClusterReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2552,7 +2637,7 @@ func (r Routing) addTokenToServiceAccount() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(serviceaccount.CreateTokenEndpoint(r.projectProvider, r.privilegedProjectProvider, r.serviceAccountProvider, r.privilegedServiceAccountProvider, r.serviceAccountTokenProvider, r.privilegedServiceAccountTokenProvider, r.saTokenAuthenticator, r.saTokenGenerator, r.userInfoGetter)),
+		)(serviceaccount.CreateTokenEndpoint(r.projectProvider, r.privilegedProjectProvider, r.serviceAccountProvider, r.privilegedServiceAccountProvider, r.serviceAccountTokenProvider, r.privilegedServiceAccountTokenProvider, r.saTokenAuthenticator, r.saTokenGenerator, r.settingsProvider, r.userInfoGetter)),
 		serviceaccount.DecodeAddTokenReq,
 		SetStatusCreatedHeader(EncodeJSON),
 		r.defaultServerOptions()...,
@@ -2603,7 +2688,7 @@ func (r Routing) updateServiceAccountToken() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(serviceaccount.UpdateTokenEndpoint(r.projectProvider, r.privilegedProjectProvider, r.serviceAccountProvider, r.privilegedServiceAccountProvider, r.serviceAccountTokenProvider, r.privilegedServiceAccountTokenProvider, r.saTokenAuthenticator, r.saTokenGenerator, r.userInfoGetter)),
+		)(serviceaccount.UpdateTokenEndpoint(r.projectProvider, r.privilegedProjectProvider, r.serviceAccountProvider, r.privilegedServiceAccountProvider, r.serviceAccountTokenProvider, r.privilegedServiceAccountTokenProvider, r.saTokenAuthenticator, r.saTokenGenerator, r.settingsProvider, r.userInfoGetter)),
 		serviceaccount.DecodeUpdateTokenReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -2630,13 +2715,40 @@ func (r Routing) patchServiceAccountToken() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(serviceaccount.PatchTokenEndpoint(r.projectProvider, r.privilegedProjectProvider, r.serviceAccountProvider, r.privilegedServiceAccountProvider, r.serviceAccountTokenProvider, r.privilegedServiceAccountTokenProvider, r.saTokenAuthenticator, r.saTokenGenerator, r.userInfoGetter)),
+		)(serviceaccount.PatchTokenEndpoint(r.projectProvider, r.privilegedProjectProvider, r.serviceAccountProvider, r.privilegedServiceAccountProvider, r.serviceAccountTokenProvider, r.privilegedServiceAccountTokenProvider, r.saTokenAuthenticator, r.saTokenGenerator, r.settingsProvider, r.userInfoGetter)),
 		serviceaccount.DecodePatchTokenReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
+// swagger:route POST /api/v1/projects/{project_id}/serviceaccounts/{serviceaccount_id}/tokens/{token_id}/rotate tokens rotateServiceAccountToken
+//
+//     Issues a replacement for the token. The old token is revoked after the grace period.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ServiceAccountToken
+//       401: empty
+//       403: empty
+func (r Routing) rotateServiceAccountToken() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(serviceaccount.RotateTokenEndpoint(r.projectProvider, r.privilegedProjectProvider, r.serviceAccountProvider, r.privilegedServiceAccountProvider, r.serviceAccountTokenProvider, r.privilegedServiceAccountTokenProvider, r.saTokenAuthenticator, r.saTokenGenerator, r.settingsProvider, r.userInfoGetter)),
+		serviceaccount.DecodeRotateTokenReq,
+		SetStatusCreatedHeader(EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route DELETE /api/v1/projects/{project_id}/serviceaccounts/{serviceaccount_id}/tokens/{token_id} tokens deleteServiceAccountToken
 //
 //     Deletes the token
@@ -68,6 +68,27 @@ func (r Routing) RegisterV1Admin(mux *mux.Router) {
 		Path("/admin/admission/plugins/{name}").
 		Handler(r.updateAdmissionPlugin())
 
+	// Defines a set of HTTP endpoints for the presets
+	mux.Methods(http.MethodGet).
+		Path("/admin/presets").
+		Handler(r.listPresets())
+
+	mux.Methods(http.MethodPost).
+		Path("/admin/presets").
+		Handler(r.createPreset())
+
+	mux.Methods(http.MethodGet).
+		Path("/admin/presets/{preset_name}").
+		Handler(r.getPreset())
+
+	mux.Methods(http.MethodPut).
+		Path("/admin/presets/{preset_name}").
+		Handler(r.updatePreset())
+
+	mux.Methods(http.MethodDelete).
+		Path("/admin/presets/{preset_name}").
+		Handler(r.deletePreset())
+
 	// Defines a set of HTTP endpoints for the seeds
 	mux.Methods(http.MethodGet).
 		Path("/admin/seeds").
@@ -306,6 +327,132 @@ func (r Routing) updateAdmissionPlugin() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v1/admin/presets admin listPresets
+//
+//     Returns all presets, including the ones restricted to projects.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []Preset
+//       401: empty
+//       403: empty
+func (r Routing) listPresets() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.ListPresetsEndpoint(r.userInfoGetter, r.presetsProvider)),
+		decodeEmptyReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/admin/presets admin createPreset
+//
+//     Creates the preset.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: Preset
+//       401: empty
+//       403: empty
+func (r Routing) createPreset() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.CreatePresetEndpoint(r.userInfoGetter, r.presetsProvider)),
+		admin.DecodeCreatePresetReq,
+		SetStatusCreatedHeader(EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/admin/presets/{preset_name} admin getPreset
+//
+//     Gets the preset.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Preset
+//       401: empty
+//       403: empty
+func (r Routing) getPreset() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.GetPresetEndpoint(r.userInfoGetter, r.presetsProvider)),
+		admin.DecodePresetReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PUT /api/v1/admin/presets/{preset_name} admin updatePreset
+//
+//     Updates the preset.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Preset
+//       401: empty
+//       403: empty
+func (r Routing) updatePreset() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.UpdatePresetEndpoint(r.userInfoGetter, r.presetsProvider)),
+		admin.DecodeUpdatePresetReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v1/admin/presets/{preset_name} admin deletePreset
+//
+//     Deletes the preset.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deletePreset() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.DeletePresetEndpoint(r.userInfoGetter, r.presetsProvider)),
+		admin.DecodePresetReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/admin/seeds admin listSeeds
 //
 //     Returns all seeds from the CRDs.
@@ -175,6 +175,10 @@ func (p *FakeExternalClusterProvider) CreateOrUpdateKubeconfigSecretForCluster(c
 	return p.Provider.CreateOrUpdateKubeconfigSecretForCluster(ctx, cluster, kubeconfig)
 }
 
+func (p *FakeExternalClusterProvider) CreateOrUpdateCredentialSecretForCluster(ctx context.Context, cluster *kubermaticapiv1.ExternalCluster, secretData map[string][]byte) error {
+	return p.Provider.CreateOrUpdateCredentialSecretForCluster(ctx, cluster, secretData)
+}
+
 func (p *FakeExternalClusterProvider) New(userInfo *provider.UserInfo, project *kubermaticapiv1.Project, cluster *kubermaticapiv1.ExternalCluster) (*kubermaticapiv1.ExternalCluster, error) {
 	return p.Provider.New(userInfo, project, cluster)
 }
@@ -0,0 +1,237 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	k8cerrors "k8c.io/kubermatic/v2/pkg/util/errors"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ListPresetsEndpoint returns all presets, including the ones restricted to projects and disabled providers
+func ListPresetsEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		presets, err := presetsProvider.GetAllPresets(userInfo)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		resultList := []apiv1.Preset{}
+		for _, preset := range presets {
+			resultList = append(resultList, convertPreset(preset))
+		}
+		return resultList, nil
+	}
+}
+
+// GetPresetEndpoint returns the preset
+func GetPresetEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(presetReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		presets, err := presetsProvider.GetAllPresets(userInfo)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		for _, preset := range presets {
+			if preset.Name == req.PresetName {
+				return convertPreset(preset), nil
+			}
+		}
+
+		return nil, k8cerrors.NewNotFound("Preset", req.PresetName)
+	}
+}
+
+// CreatePresetEndpoint creates the preset
+func CreatePresetEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createPresetReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		if err := validatePreset(req.Body); err != nil {
+			return nil, k8cerrors.NewBadRequest(err.Error())
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		preset, err := presetsProvider.CreatePreset(userInfo, &kubermaticv1.Preset{
+			ObjectMeta: v1.ObjectMeta{Name: req.Body.Name},
+			Spec:       req.Body.Spec,
+		})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertPreset(*preset), nil
+	}
+}
+
+// UpdatePresetEndpoint replaces the spec of the preset
+func UpdatePresetEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(updatePresetReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		if err := req.Validate(); err != nil {
+			return nil, k8cerrors.NewBadRequest(err.Error())
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		preset, err := presetsProvider.UpdatePreset(userInfo, &kubermaticv1.Preset{
+			ObjectMeta: v1.ObjectMeta{Name: req.PresetName},
+			Spec:       req.Body.Spec,
+		})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertPreset(*preset), nil
+	}
+}
+
+// DeletePresetEndpoint deletes the preset
+func DeletePresetEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(presetReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if err := presetsProvider.DeletePreset(userInfo, req.PresetName); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+// presetReq defines HTTP request for getPreset and deletePreset
+// swagger:parameters getPreset deletePreset
+type presetReq struct {
+	// in: path
+	// required: true
+	PresetName string `json:"preset_name"`
+}
+
+// createPresetReq defines HTTP request for createPreset
+// swagger:parameters createPreset
+type createPresetReq struct {
+	// in: body
+	Body apiv1.Preset
+}
+
+// updatePresetReq defines HTTP request for updatePreset
+// swagger:parameters updatePreset
+type updatePresetReq struct {
+	presetReq
+	// in: body
+	Body apiv1.Preset
+}
+
+// Validate validates UpdatePresetEndpoint request
+func (r updatePresetReq) Validate() error {
+	if r.PresetName != r.Body.Name {
+		return fmt.Errorf("preset name mismatch, you requested to update Preset = %s but body contains Preset = %s", r.PresetName, r.Body.Name)
+	}
+	return validatePreset(r.Body)
+}
+
+func validatePreset(preset apiv1.Preset) error {
+	if errs := validation.IsDNS1123Subdomain(preset.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid preset name %q: %v", preset.Name, errs)
+	}
+	for _, project := range preset.Spec.Projects {
+		if project == "" {
+			return fmt.Errorf("the project IDs of the preset cannot be empty")
+		}
+	}
+	return nil
+}
+
+func DecodePresetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req presetReq
+	name := mux.Vars(r)["preset_name"]
+	if name == "" {
+		return nil, fmt.Errorf("'preset_name' parameter is required but was not provided")
+	}
+	req.PresetName = name
+
+	return req, nil
+}
+
+func DecodeCreatePresetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createPresetReq
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
+
+func DecodeUpdatePresetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req updatePresetReq
+	nameReq, err := DecodePresetReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.presetReq = nameReq.(presetReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
+
+func convertPreset(preset kubermaticv1.Preset) apiv1.Preset {
+	return apiv1.Preset{
+		Name: preset.Name,
+		Spec: preset.Spec,
+	}
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestListPresetsEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: not authorized user gets presets",
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:             "scenario 2: authorized user gets presets restricted to projects and disabled providers",
+			expectedResponse: `[{"name":"first","spec":{"fake":{"enabled":false,"token":"abc"}}},{"name":"second","spec":{"fake":{"token":"def"},"projects":["my-project"]}}]`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true),
+				genPreset("first", genFake("abc", false)),
+				genPreset("second", genFake("def", true), "my-project"),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/admin/presets", strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestGetPresetEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		preset                 string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:             "scenario 1: authorized user gets the preset",
+			preset:           "first",
+			expectedResponse: `{"name":"first","spec":{"fake":{"token":"abc"},"projects":["my-project"]}}`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true),
+				genPreset("first", genFake("abc", true), "my-project"),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:             "scenario 2: authorized user gets a preset which doesn't exist",
+			preset:           "second",
+			expectedResponse: `{"error":{"code":404,"message":"Preset \"second\" not found"}}`,
+			httpStatus:       http.StatusNotFound,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true),
+				genPreset("first", genFake("abc", true)),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/admin/presets/%s", tc.preset), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestCreatePresetEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		body                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: not authorized user creates the preset",
+			body:                   `{"name":"first","spec":{"fake":{"token":"abc"}}}`,
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:                   "scenario 2: authorized user creates the preset",
+			body:                   `{"name":"first","spec":{"fake":{"token":"abc"},"projects":["my-project"]}}`,
+			expectedResponse:       `{"name":"first","spec":{"fake":{"token":"abc"},"projects":["my-project"]}}`,
+			httpStatus:             http.StatusCreated,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 3
+		{
+			name:                   "scenario 3: authorized user creates the preset with an invalid name",
+			body:                   `{"name":"First_Preset","spec":{"fake":{"token":"abc"}}}`,
+			expectedResponse:       `{"error":{"code":400,"message":"invalid preset name \"First_Preset\": [a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')]"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/admin/presets", strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestUpdatePresetEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		preset                 string
+		body                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:             "scenario 1: authorized user disables the provider of the preset",
+			preset:           "first",
+			body:             `{"name":"first","spec":{"fake":{"enabled":false,"token":"abc"}}}`,
+			expectedResponse: `{"name":"first","spec":{"fake":{"enabled":false,"token":"abc"}}}`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true),
+				genPreset("first", genFake("abc", true), "my-project"),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:             "scenario 2: authorized user updates the preset with a different name",
+			preset:           "first",
+			body:             `{"name":"second","spec":{"fake":{"token":"abc"}}}`,
+			expectedResponse: `{"error":{"code":400,"message":"preset name mismatch, you requested to update Preset = first but body contains Preset = second"}}`,
+			httpStatus:       http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true),
+				genPreset("first", genFake("abc", true)),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/api/v1/admin/presets/%s", tc.preset), strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestDeletePresetEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		preset                 string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:       "scenario 1: not authorized user deletes the preset",
+			preset:     "first",
+			httpStatus: http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{
+				genPreset("first", genFake("abc", true)),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:       "scenario 2: authorized user deletes the preset",
+			preset:     "first",
+			httpStatus: http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true),
+				genPreset("first", genFake("abc", true)),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/admin/presets/%s", tc.preset), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			err = clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: tc.preset}, &kubermaticv1.Preset{})
+			if deleted := kerrors.IsNotFound(err); deleted != (tc.httpStatus == http.StatusOK) {
+				t.Fatalf("Expected preset to be deleted: %t, but got err %v", tc.httpStatus == http.StatusOK, err)
+			}
+		})
+	}
+}
+
+func genPreset(name string, fake *kubermaticv1.Fake, projects ...string) *kubermaticv1.Preset {
+	return &kubermaticv1.Preset{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.PresetSpec{
+			Fake:     fake,
+			Projects: projects,
+		},
+	}
+}
+
+func genFake(token string, enabled bool) *kubermaticv1.Fake {
+	fake := &kubermaticv1.Fake{Token: token}
+	if !enabled {
+		fake.Enabled = &enabled
+	}
+	return fake
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/storeuploader"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ListBackupsEndpoint lists the etcd snapshots of the cluster that are stored in the backup storage of the seed
+func ListBackupsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(common.GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		cluster, err := handlercommon.GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		seeds, err := seedsGetter()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		seed, ok := seeds[req.DC]
+		if !ok {
+			return nil, errors.NewNotFound("seed", req.DC)
+		}
+
+		var config storeuploader.Config
+		var bucket string
+		if storage := seed.Spec.EtcdBackupStorage; storage != nil {
+			config, err = backupStorageConfig(ctx, privilegedClusterProvider, storage)
+			bucket = storage.Bucket
+		} else {
+			config, bucket, err = legacyBackupStorageConfig(ctx, privilegedClusterProvider)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The storage is usually only reachable from within the seed, e.g. a minio
+		// running next to the user cluster control planes.
+		if namespace, name, port, ok := seedService(config); ok {
+			endpoint, closeForwarder, err := forwardToSeedService(ctx, privilegedClusterProvider, namespace, name, port)
+			if err != nil {
+				return nil, err
+			}
+			defer closeForwarder()
+			config.Endpoint = endpoint
+		}
+
+		backend, err := storeuploader.NewBackend(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to backup storage: %v", err)
+		}
+		defer backend.Close()
+
+		objects, err := storeuploader.NewWithBackend(backend, log.Logger).List(bucket, cluster.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %v", err)
+		}
+
+		return convertBackupsToExternal(objects), nil
+	}
+}
+
+// backupStorageConfig returns the settings to access the backup storage configured in the seed
+func backupStorageConfig(ctx context.Context, privilegedClusterProvider provider.PrivilegedClusterProvider, storage *kubermaticv1.EtcdBackupStorage) (storeuploader.Config, error) {
+	config := storeuploader.Config{Backend: string(storage.Backend)}
+
+	credentials := &corev1.Secret{}
+	if storage.CredentialsSecret != "" {
+		key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: storage.CredentialsSecret}
+		if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().Get(ctx, key, credentials); err != nil {
+			return config, common.KubernetesErrorToHTTPError(err)
+		}
+	}
+
+	switch storage.Backend {
+	case kubermaticv1.EtcdBackupStorageBackendS3:
+		if storage.S3 == nil {
+			return config, errors.New(http.StatusNotImplemented, "the S3 backup storage of the seed is not configured")
+		}
+		config.Endpoint = storage.S3.Endpoint
+		config.Secure = storage.S3.Secure
+		config.AccessKeyID = string(credentials.Data["ACCESS_KEY_ID"])
+		config.SecretAccessKey = string(credentials.Data["SECRET_ACCESS_KEY"])
+	case kubermaticv1.EtcdBackupStorageBackendSFTP:
+		if storage.SFTP == nil {
+			return config, errors.New(http.StatusNotImplemented, "the SFTP backup storage of the seed is not configured")
+		}
+		config.Address = storage.SFTP.Address
+		config.HostKey = storage.SFTP.HostKey
+		config.Username = string(credentials.Data["SFTP_USERNAME"])
+		config.Password = string(credentials.Data["SFTP_PASSWORD"])
+		config.PrivateKey = string(credentials.Data["SFTP_PRIVATE_KEY"])
+	default:
+		// filesystem backups live on a volume that is only mounted into the backup jobs
+		return config, errors.New(http.StatusNotImplemented, fmt.Sprintf("listing backups is not supported for the %s backup storage", storage.Backend))
+	}
+
+	return config, nil
+}
+
+// legacyBackupStorageConfig returns the settings to access the S3 backup storage configured
+// through the s3-credentials secret, which is used if the seed does not configure a storage.
+// The location falls back to the one the default store container uses.
+func legacyBackupStorageConfig(ctx context.Context, privilegedClusterProvider provider.PrivilegedClusterProvider) (storeuploader.Config, string, error) {
+	credentials := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: resources.EtcdBackupCredentialsSecretName}
+	if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().Get(ctx, key, credentials); err != nil {
+		if kerrors.IsNotFound(err) {
+			return storeuploader.Config{}, "", errors.New(http.StatusNotImplemented, "etcd backups are not configured for this seed")
+		}
+		return storeuploader.Config{}, "", common.KubernetesErrorToHTTPError(err)
+	}
+
+	endpoint, bucket := string(credentials.Data["ENDPOINT"]), string(credentials.Data["BUCKET"])
+	if endpoint == "" {
+		endpoint = resources.DefaultEtcdBackupEndpoint
+	}
+	if bucket == "" {
+		bucket = resources.DefaultEtcdBackupBucket
+	}
+
+	return storeuploader.Config{
+		Backend:         storeuploader.BackendS3,
+		Endpoint:        endpoint,
+		Secure:          string(credentials.Data["SECURE"]) == "true",
+		AccessKeyID:     string(credentials.Data["ACCESS_KEY_ID"]),
+		SecretAccessKey: string(credentials.Data["SECRET_ACCESS_KEY"]),
+	}, bucket, nil
+}
+
+// seedService returns the service of the seed cluster the S3 endpoint of the given config
+// points to, if it uses a cluster-local service address like "minio.minio.svc.cluster.local:9000".
+func seedService(config storeuploader.Config) (namespace, name string, port int, ok bool) {
+	if config.Backend != storeuploader.BackendS3 {
+		return "", "", 0, false
+	}
+	host, rawPort, err := net.SplitHostPort(config.Endpoint)
+	if err != nil {
+		return "", "", 0, false
+	}
+	port, err = strconv.Atoi(rawPort)
+	if err != nil {
+		return "", "", 0, false
+	}
+	parts := strings.Split(host, ".")
+	if len(parts) < 3 || parts[2] != "svc" {
+		return "", "", 0, false
+	}
+	return parts[1], parts[0], port, true
+}
+
+// forwardToSeedService forwards a local port to a ready pod of the given service of the seed
+// cluster. It returns the local endpoint and a function to stop the forwarding.
+func forwardToSeedService(ctx context.Context, privilegedClusterProvider provider.PrivilegedClusterProvider, namespace, name string, port int) (string, func(), error) {
+	clusterProvider, ok := privilegedClusterProvider.(*kubernetesprovider.ClusterProvider)
+	if !ok {
+		return "", nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
+	}
+
+	service := &corev1.Service{}
+	if err := clusterProvider.GetSeedClusterAdminRuntimeClient().Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, service); err != nil {
+		return "", nil, common.KubernetesErrorToHTTPError(err)
+	}
+	targetPort := port
+	for _, servicePort := range service.Spec.Ports {
+		if int(servicePort.Port) == port && servicePort.TargetPort.Type == intstr.Int && servicePort.TargetPort.IntVal != 0 {
+			targetPort = int(servicePort.TargetPort.IntVal)
+		}
+	}
+
+	portforwarder, closeChan, err := common.GetPortForwarder(
+		clusterProvider.GetSeedClusterAdminClient().CoreV1(),
+		clusterProvider.SeedAdminConfig(),
+		namespace,
+		labels.SelectorFromSet(service.Spec.Selector).String(),
+		targetPort)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get portforwarder for backup storage: %v", err)
+	}
+	closeForwarder := func() {
+		portforwarder.Close()
+		close(closeChan)
+	}
+
+	if err := common.ForwardPort(log.Logger, portforwarder); err != nil {
+		closeForwarder()
+		return "", nil, err
+	}
+	ports, err := portforwarder.GetPorts()
+	if err != nil {
+		closeForwarder()
+		return "", nil, fmt.Errorf("failed to get backup storage port: %v", err)
+	}
+	if len(ports) != 1 {
+		closeForwarder()
+		return "", nil, fmt.Errorf("didn't get exactly one port but %d", len(ports))
+	}
+
+	return fmt.Sprintf("127.0.0.1:%d", ports[0].Local), closeForwarder, nil
+}
+
+// convertBackupsToExternal converts the given snapshot objects to API backups, newest first
+func convertBackupsToExternal(objects []storeuploader.Object) []apiv1.EtcdBackup {
+	backups := make([]apiv1.EtcdBackup, 0, len(objects))
+	for _, object := range objects {
+		backups = append(backups, apiv1.EtcdBackup{
+			Name:      object.Key,
+			Size:      object.Size,
+			Timestamp: apiv1.NewTime(object.LastModified),
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Timestamp.After(backups[j].Timestamp.Time)
+	})
+	return backups
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/storeuploader"
+)
+
+func TestConvertBackupsToExternal(t *testing.T) {
+	older := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		objects  []storeuploader.Object
+		expected []apiv1.EtcdBackup
+	}{
+		{
+			name:     "no backups",
+			expected: []apiv1.EtcdBackup{},
+		},
+		{
+			name: "newest backup first",
+			objects: []storeuploader.Object{
+				{Key: "older", Size: 1, LastModified: older},
+				{Key: "newer", Size: 2, LastModified: newer},
+			},
+			expected: []apiv1.EtcdBackup{
+				{Name: "newer", Size: 2, Timestamp: apiv1.NewTime(newer)},
+				{Name: "older", Size: 1, Timestamp: apiv1.NewTime(older)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := deep.Equal(convertBackupsToExternal(test.objects), test.expected); diff != nil {
+				t.Errorf("Got unexpected backups: %v", diff)
+			}
+		})
+	}
+}
+
+func TestSeedService(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            storeuploader.Config
+		expectedNamespace string
+		expectedName      string
+		expectedPort      int
+		expectedOK        bool
+	}{
+		{
+			name:              "default minio",
+			config:            storeuploader.Config{Backend: storeuploader.BackendS3, Endpoint: "minio.minio.svc.cluster.local:9000"},
+			expectedNamespace: "minio",
+			expectedName:      "minio",
+			expectedPort:      9000,
+			expectedOK:        true,
+		},
+		{
+			name:              "short service address",
+			config:            storeuploader.Config{Backend: storeuploader.BackendS3, Endpoint: "s3.storage.svc:443"},
+			expectedNamespace: "storage",
+			expectedName:      "s3",
+			expectedPort:      443,
+			expectedOK:        true,
+		},
+		{
+			name:   "external endpoint",
+			config: storeuploader.Config{Backend: storeuploader.BackendS3, Endpoint: "s3.amazonaws.com:443"},
+		},
+		{
+			name:   "endpoint without port",
+			config: storeuploader.Config{Backend: storeuploader.BackendS3, Endpoint: "minio.minio.svc.cluster.local"},
+		},
+		{
+			name:   "sftp",
+			config: storeuploader.Config{Backend: storeuploader.BackendSFTP, Address: "sftp.backup.svc.cluster.local:22"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespace, name, port, ok := seedService(test.config)
+			if namespace != test.expectedNamespace || name != test.expectedName || port != test.expectedPort || ok != test.expectedOK {
+				t.Errorf("expected %s/%s:%d (%v), got %s/%s:%d (%v)", test.expectedNamespace, test.expectedName, test.expectedPort, test.expectedOK, namespace, name, port, ok)
+			}
+		})
+	}
+}
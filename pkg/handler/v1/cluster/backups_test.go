@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newFakeS3 serves the bucket listing of a single bucket with the given objects.
+func newFakeS3(t *testing.T, bucket string, objects []string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/"+bucket) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, ok := r.URL.Query()["location"]; ok {
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`)
+			return
+		}
+
+		prefix := r.URL.Query().Get("prefix")
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>%s</Name><IsTruncated>false</IsTruncated>`, bucket)
+		for _, object := range objects {
+			if strings.HasPrefix(object, "<Contents><Key>"+prefix) {
+				fmt.Fprint(w, object)
+			}
+		}
+		fmt.Fprint(w, `</ListBucketResult>`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func s3Object(key string, size int, lastModified string) string {
+	return fmt.Sprintf("<Contents><Key>%s</Key><Size>%d</Size><LastModified>%s</LastModified></Contents>", key, size, lastModified)
+}
+
+func TestListBackupsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cluster := test.GenDefaultCluster()
+	s3 := newFakeS3(t, "etcd-backups", []string{
+		s3Object(cluster.Name+"-storeuploader-2020-01-01T00:00:00-snapshot.db", 1024, "2020-01-01T00:00:10.000Z"),
+		s3Object(cluster.Name+"-storeuploader-2020-01-03T00:00:00-snapshot.db", 2048, "2020-01-03T00:00:10.000Z"),
+		s3Object(cluster.Name+"-storeuploader-2020-01-02T00:00:00-snapshot.db", 1536, "2020-01-02T00:00:10.000Z"),
+		s3Object("other-cluster-storeuploader-2020-01-02T00:00:00-snapshot.db", 1536, "2020-01-02T00:00:10.000Z"),
+	})
+	s3URL, err := url.Parse(s3.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "backup-credentials"},
+		Data: map[string][]byte{
+			"ACCESS_KEY_ID":     []byte("access-key"),
+			"SECRET_ACCESS_KEY": []byte("secret-key"),
+		},
+	}
+
+	testcases := []struct {
+		name             string
+		storage          *kubermaticv1.EtcdBackupStorage
+		existingKubeObjs []runtime.Object
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "backups of the cluster, newest first",
+			storage: &kubermaticv1.EtcdBackupStorage{
+				Backend:           kubermaticv1.EtcdBackupStorageBackendS3,
+				Bucket:            "etcd-backups",
+				CredentialsSecret: credentials.Name,
+				S3:                &kubermaticv1.EtcdBackupS3Storage{Endpoint: s3URL.Host},
+			},
+			existingKubeObjs: []runtime.Object{credentials},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `[{"name":"defClusterID-storeuploader-2020-01-03T00:00:00-snapshot.db","size":2048,"timestamp":"2020-01-03T00:00:10Z"},{"name":"defClusterID-storeuploader-2020-01-02T00:00:00-snapshot.db","size":1536,"timestamp":"2020-01-02T00:00:10Z"},{"name":"defClusterID-storeuploader-2020-01-01T00:00:00-snapshot.db","size":1024,"timestamp":"2020-01-01T00:00:10Z"}]`,
+		},
+		{
+			name: "missing bucket",
+			storage: &kubermaticv1.EtcdBackupStorage{
+				Backend:           kubermaticv1.EtcdBackupStorageBackendS3,
+				Bucket:            "other-backups",
+				CredentialsSecret: credentials.Name,
+				S3:                &kubermaticv1.EtcdBackupS3Storage{Endpoint: s3URL.Host},
+			},
+			existingKubeObjs: []runtime.Object{credentials},
+			expectedCode:     http.StatusInternalServerError,
+		},
+		{
+			name: "filesystem storage can not be listed",
+			storage: &kubermaticv1.EtcdBackupStorage{
+				Backend:    kubermaticv1.EtcdBackupStorageBackendFilesystem,
+				Bucket:     "etcd-backups",
+				Filesystem: &kubermaticv1.EtcdBackupFilesystemStorage{PersistentVolumeClaim: "backups"},
+			},
+			expectedCode: http.StatusNotImplemented,
+		},
+		{
+			name:         "backups are not configured",
+			expectedCode: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+				seed := test.GenTestSeed()
+				seed.Spec.EtcdBackupStorage = tc.storage
+				return map[string]*kubermaticv1.Seed{seed.Name: seed}, nil
+			}
+
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/backups", test.GenDefaultProject().Name, cluster.Name), nil)
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), provider.SeedsGetter(seedsGetter), tc.existingKubeObjs, nil, test.GenDefaultKubermaticObjects(test.GenDefaultCluster()), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint: %v", err)
+			}
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.expectedCode {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.expectedCode, res.Code, res.Body.String())
+			}
+			if tc.expectedResponse != "" {
+				test.CompareWithResult(t, res, tc.expectedResponse)
+			}
+		})
+	}
+}
@@ -917,6 +917,36 @@ func TestCreateClusterEndpoint(t *testing.T) {
 			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
 			ProjectToSync:          test.GenDefaultProject().Name,
 			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		}, // scenario 15
+		{
+			Name:                   "scenario 15: create a cluster in audit-logging-enforced datacenter, keeping the audit policy and sinks",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"audited-dc"},"auditLogging":{"policyPreset":"recommended","sinks":[{"syslog":{"host":"10.0.0.1","port":514}}]}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"audited-dc","fake":{}},"version":"1.15.0","oidc":{},"auditLogging":{"enabled":true,"policyPreset":"recommended","sinks":[{"syslog":{"host":"10.0.0.1","port":514}}]}},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID:       true,
+			HTTPStatus:             http.StatusCreated,
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 16
+		{
+			Name:                   "scenario 16: a cluster with an invalid audit sink",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"},"auditLogging":{"enabled":true,"sinks":[{"webhook":{"url":"ftp://audit.example.com"}}]}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid cluster: invalid audit sink 0: webhook URL must use http or https, got \"ftp\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 17
+		{
+			Name:                   "scenario 17: regular users can not select a custom audit policy",
+			Body:                   `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"audited-dc"},"auditLogging":{"enabled":true,"policyPreset":"custom","customPolicy":{"name":"audit-policy"}}}}}`,
+			ExpectedResponse:       `{"error":{"code":403,"message":"only admins can select a custom audit policy"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ProjectToSync:          test.GenDefaultProject().Name,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
 		},
 	}
 
@@ -1223,6 +1253,43 @@ func TestPatchCluster(t *testing.T) {
 					return cluster
 				}(), genUser("John", "john@acme.com", false)),
 		},
+		// scenario 8
+		{
+			Name:             "scenario 8: tried to update cluster with an admission plugin unavailable in the new version",
+			Body:             `{"spec":{"version":"9.10.0"}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"cluster cannot be updated to version 9.10.0: AdmissionPlugins: admission plugin EventRateLimit is not available in version 9.10.0"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Spec.AdmissionPlugins = []string{"EventRateLimit"}
+					return cluster
+				}()),
+		},		// scenario 9
+		{
+			Name:             "scenario 9: the regular user can not change the custom audit policy set by an admin",
+			Body:             `{"spec":{"auditLogging":{"customPolicy":{"name":"other-policy"}}}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"only admins can select a custom audit policy"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusForbidden,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
+						Enabled:      true,
+						PolicyPreset: kubermaticv1.AuditPolicyCustom,
+						CustomPolicy: &kubermaticv1.AuditPolicyConfigMapRef{Name: "audit-policy"},
+					}
+					return cluster
+				}()),
+		},
 	}
 
 	for _, tc := range testcases {
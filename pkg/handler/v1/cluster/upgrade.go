@@ -33,6 +33,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/validation/nodeupdate"
+	"k8c.io/kubermatic/v2/pkg/validation/upgradecheck"
 	"k8c.io/kubermatic/v2/pkg/version"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -40,7 +41,7 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func GetUpgradesEndpoint(updateManager common.UpdateManager, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func GetUpgradesEndpoint(updateManager common.UpdateManager, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, admissionPluginProvider provider.AdmissionPluginsProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 		req, ok := request.(common.GetClusterReq)
@@ -76,19 +77,34 @@ func GetUpgradesEndpoint(updateManager common.UpdateManager, projectProvider pro
 			return nil, err
 		}
 
+		// The resources of the cluster are gathered once and checked against every version
+		var state *upgradecheck.ClusterState
+		if clusterType == apiv1.KubernetesClusterType && len(versions) > 0 {
+			state, err = upgradecheck.GatherClusterState(ctx, client, cluster, latestVersion(versions))
+			if err != nil {
+				return nil, fmt.Errorf("failed to run pre-flight checks: %v", err)
+			}
+		}
+
 		upgrades := make([]*apiv1.MasterVersion, 0)
 		for _, v := range versions {
 			isRestricted := false
-			if clusterType == apiv1.KubernetesClusterType {
+			var blockers []upgradecheck.Blocker
+			if state != nil {
 				isRestricted, err = isRestrictedByKubeletVersions(v, machineDeployments.Items)
 				if err != nil {
 					return nil, err
 				}
+				blockers, err = state.Check(admissionPluginProvider, v.Version)
+				if err != nil {
+					return nil, fmt.Errorf("failed to run pre-flight checks for version %s: %v", v.Version, err)
+				}
 			}
 
 			upgrades = append(upgrades, &apiv1.MasterVersion{
 				Version:                    v.Version,
 				RestrictedByKubeletVersion: isRestricted,
+				UpgradeBlockers:            convertUpgradeBlockersToExternal(blockers),
 			})
 		}
 
@@ -96,6 +112,30 @@ func GetUpgradesEndpoint(updateManager common.UpdateManager, projectProvider pro
 	}
 }
 
+func latestVersion(versions []*version.Version) *semver.Version {
+	latest := versions[0].Version
+	for _, v := range versions[1:] {
+		if v.Version.GreaterThan(latest) {
+			latest = v.Version
+		}
+	}
+	return latest
+}
+
+func convertUpgradeBlockersToExternal(blockers []upgradecheck.Blocker) []apiv1.UpgradeBlocker {
+	if len(blockers) == 0 {
+		return nil
+	}
+	result := make([]apiv1.UpgradeBlocker, 0, len(blockers))
+	for _, blocker := range blockers {
+		result = append(result, apiv1.UpgradeBlocker{
+			Check:   blocker.Check,
+			Message: blocker.Message,
+		})
+	}
+	return result
+}
+
 func isRestrictedByKubeletVersions(controlPlaneVersion *version.Version, mds []clusterv1alpha1.MachineDeployment) (bool, error) {
 	for _, md := range mds {
 		kubeletVersion, err := semver.NewVersion(md.Spec.Template.Spec.Versions.Kubelet)
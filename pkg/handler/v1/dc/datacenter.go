@@ -643,27 +643,28 @@ func ConvertInternalDCToExternalSpec(dc *kubermaticv1.Datacenter, seedName strin
 		return nil, err
 	}
 	return &apiv1.DatacenterSpec{
-		Seed:                     seedName,
-		Location:                 dc.Location,
-		Country:                  dc.Country,
-		Provider:                 p,
-		Node:                     dc.Node,
-		Digitalocean:             dc.Spec.Digitalocean,
-		AWS:                      dc.Spec.AWS,
-		BringYourOwn:             dc.Spec.BringYourOwn,
-		Openstack:                dc.Spec.Openstack,
-		Hetzner:                  dc.Spec.Hetzner,
-		VSphere:                  dc.Spec.VSphere,
-		Azure:                    dc.Spec.Azure,
-		Packet:                   dc.Spec.Packet,
-		GCP:                      dc.Spec.GCP,
-		Kubevirt:                 dc.Spec.Kubevirt,
-		Alibaba:                  dc.Spec.Alibaba,
-		Fake:                     dc.Spec.Fake,
-		RequiredEmailDomain:      dc.Spec.RequiredEmailDomain,
-		RequiredEmailDomains:     dc.Spec.RequiredEmailDomains,
-		EnforceAuditLogging:      dc.Spec.EnforceAuditLogging,
-		EnforcePodSecurityPolicy: dc.Spec.EnforcePodSecurityPolicy,
+		Seed:                      seedName,
+		Location:                  dc.Location,
+		Country:                   dc.Country,
+		Provider:                  p,
+		Node:                      dc.Node,
+		Digitalocean:              dc.Spec.Digitalocean,
+		AWS:                       dc.Spec.AWS,
+		BringYourOwn:              dc.Spec.BringYourOwn,
+		Openstack:                 dc.Spec.Openstack,
+		Hetzner:                   dc.Spec.Hetzner,
+		VSphere:                   dc.Spec.VSphere,
+		Azure:                     dc.Spec.Azure,
+		Packet:                    dc.Spec.Packet,
+		GCP:                       dc.Spec.GCP,
+		Kubevirt:                  dc.Spec.Kubevirt,
+		Alibaba:                   dc.Spec.Alibaba,
+		Fake:                      dc.Spec.Fake,
+		RequiredEmailDomain:       dc.Spec.RequiredEmailDomain,
+		RequiredEmailDomains:      dc.Spec.RequiredEmailDomains,
+		EnforceAuditLogging:       dc.Spec.EnforceAuditLogging,
+		EnforcedAuditPolicyPreset: dc.Spec.EnforcedAuditPolicyPreset,
+		EnforcePodSecurityPolicy:  dc.Spec.EnforcePodSecurityPolicy,
 	}, nil
 }
 
@@ -673,22 +674,23 @@ func convertExternalDCToInternal(datacenter *apiv1.DatacenterSpec) kubermaticv1.
 		Location: datacenter.Location,
 		Node:     datacenter.Node,
 		Spec: kubermaticv1.DatacenterSpec{
-			Digitalocean:             datacenter.Digitalocean,
-			BringYourOwn:             datacenter.BringYourOwn,
-			AWS:                      datacenter.AWS,
-			Azure:                    datacenter.Azure,
-			Openstack:                datacenter.Openstack,
-			Packet:                   datacenter.Packet,
-			Hetzner:                  datacenter.Hetzner,
-			VSphere:                  datacenter.VSphere,
-			GCP:                      datacenter.GCP,
-			Kubevirt:                 datacenter.Kubevirt,
-			Alibaba:                  datacenter.Alibaba,
-			Fake:                     datacenter.Fake,
-			RequiredEmailDomain:      datacenter.RequiredEmailDomain,
-			RequiredEmailDomains:     datacenter.RequiredEmailDomains,
-			EnforceAuditLogging:      datacenter.EnforceAuditLogging,
-			EnforcePodSecurityPolicy: datacenter.EnforcePodSecurityPolicy,
+			Digitalocean:              datacenter.Digitalocean,
+			BringYourOwn:              datacenter.BringYourOwn,
+			AWS:                       datacenter.AWS,
+			Azure:                     datacenter.Azure,
+			Openstack:                 datacenter.Openstack,
+			Packet:                    datacenter.Packet,
+			Hetzner:                   datacenter.Hetzner,
+			VSphere:                   datacenter.VSphere,
+			GCP:                       datacenter.GCP,
+			Kubevirt:                  datacenter.Kubevirt,
+			Alibaba:                   datacenter.Alibaba,
+			Fake:                      datacenter.Fake,
+			RequiredEmailDomain:       datacenter.RequiredEmailDomain,
+			RequiredEmailDomains:      datacenter.RequiredEmailDomains,
+			EnforceAuditLogging:       datacenter.EnforceAuditLogging,
+			EnforcedAuditPolicyPreset: datacenter.EnforcedAuditPolicyPreset,
+			EnforcePodSecurityPolicy:  datacenter.EnforcePodSecurityPolicy,
 		},
 	}
 }
@@ -828,6 +830,12 @@ func (req createDCReq) validate() error {
 		return fmt.Errorf("path seed %q and request seed %q not equal", req.Seed, req.Body.Spec.Seed)
 	}
 
+	switch req.Body.Spec.EnforcedAuditPolicyPreset {
+	case "", kubermaticv1.AuditPolicyMetadata, kubermaticv1.AuditPolicyRecommended:
+	default:
+		return fmt.Errorf("audit policy preset %q can not be enforced", req.Body.Spec.EnforcedAuditPolicyPreset)
+	}
+
 	return nil
 }
 
@@ -53,6 +53,9 @@ type providerReq struct {
 	ProviderName string `json:"provider_name"`
 	// in: query
 	Datacenter string `json:"datacenter,omitempty"`
+	// in: query
+	// ProjectID limits the credentials to the presets available in the given project
+	ProjectID string `json:"project_id,omitempty"`
 }
 
 // CredentialEndpoint returns custom credential list name for the provider
@@ -67,7 +70,7 @@ func CredentialEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.ProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -82,6 +85,9 @@ func CredentialEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 		}
 
 		for _, preset := range presets {
+			if req.ProjectID != "" && !preset.Spec.IsAvailableInProject(req.ProjectID) {
+				continue
+			}
 			// get specific provider by name from the Preset spec struct:
 			// type PresetSpec struct {
 			//	Digitalocean Digitalocean
@@ -134,6 +140,7 @@ func DecodeProviderReq(c context.Context, r *http.Request) (interface{}, error)
 	return providerReq{
 		ProviderName: mux.Vars(r)["provider_name"],
 		Datacenter:   r.URL.Query().Get("datacenter"),
+		ProjectID:    r.URL.Query().Get("project_id"),
 	}, nil
 }
 
@@ -389,6 +389,79 @@ func TestCredentialEndpoint(t *testing.T) {
 	}
 }
 
+func TestCredentialEndpointForProject(t *testing.T) {
+	t.Parallel()
+	genPreset := func(name string, projects ...string) *kubermaticv1.Preset {
+		return &kubermaticv1.Preset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: kubermaticv1.PresetSpec{
+				RequiredEmailDomain: test.RequiredEmailDomain,
+				Projects:            projects,
+				AWS: &kubermaticv1.AWS{
+					AccessKeyID: "a",
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name             string
+		projectID        string
+		credentials      []runtime.Object
+		httpStatus       int
+		expectedResponse string
+	}{
+		{
+			name: "test list of credential names restricted to the projects of the user",
+			credentials: []runtime.Object{
+				genPreset("first"),
+				genPreset("second", test.ProjectName),
+				genPreset("third", "other-project-ID"),
+			},
+			httpStatus:       http.StatusOK,
+			expectedResponse: `{"names":["first", "second"]}`,
+		},
+		{
+			name:      "test list of credential names for the specific project",
+			projectID: test.ProjectName,
+			credentials: []runtime.Object{
+				genPreset("first"),
+				genPreset("second", test.ProjectName, "other-project-ID"),
+				genPreset("third", "other-project-ID"),
+			},
+			httpStatus:       http.StatusOK,
+			expectedResponse: `{"names":["first", "second"]}`,
+		},
+		{
+			name:       "test list of credential names for a project the user is not a member of",
+			projectID:  "other-project-ID",
+			httpStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/providers/aws/presets/credentials?project_id=%s", tc.projectID), strings.NewReader(""))
+			res := httptest.NewRecorder()
+
+			apiUser := test.GenDefaultAPIUser()
+			router, err := test.CreateTestEndpoint(*apiUser, nil, test.GenDefaultKubermaticObjects(tc.credentials...), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v\n", err)
+			}
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.httpStatus, res.Code)
+
+			if res.Code == http.StatusOK {
+				compareJSON(t, res, tc.expectedResponse)
+			}
+		})
+	}
+}
+
 func compareJSON(t *testing.T, res *httptest.ResponseRecorder, expectedResponseString string) {
 	t.Helper()
 	var actualResponse interface{}
@@ -207,6 +207,93 @@ func getVsphereFolders(userInfo *provider.UserInfo, seedsGetter provider.SeedsGe
 	return apiFolders, nil
 }
 
+func VsphereResourcePoolsEndpoint(seedsGetter provider.SeedsGetter, presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(VSphereResourcePoolsReq)
+		if !ok {
+			return nil, fmt.Errorf("incorrect type of request, expected = VSphereResourcePoolsReq, got = %T", request)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		username, password, err := getVsphereCredentials(presetsProvider, userInfo, req.Username, req.Password, req.Credential)
+		if err != nil {
+			return nil, err
+		}
+
+		_, datacenter, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, req.DatacenterName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find Datacenter %q: %v", req.DatacenterName, err)
+		}
+
+		pools, err := vsphere.GetResourcePools(datacenter.Spec.VSphere, username, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource pools: %v", err)
+		}
+
+		var apiPools []apiv1.VSphereResourcePool
+		for _, pool := range pools {
+			apiPools = append(apiPools, apiv1.VSphereResourcePool{Path: pool.Path})
+		}
+
+		return apiPools, nil
+	}
+}
+
+func VsphereTagCategoriesEndpoint(seedsGetter provider.SeedsGetter, presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(VSphereTagCategoriesReq)
+		if !ok {
+			return nil, fmt.Errorf("incorrect type of request, expected = VSphereTagCategoriesReq, got = %T", request)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		username, password, err := getVsphereCredentials(presetsProvider, userInfo, req.Username, req.Password, req.Credential)
+		if err != nil {
+			return nil, err
+		}
+
+		_, datacenter, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, req.DatacenterName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find Datacenter %q: %v", req.DatacenterName, err)
+		}
+
+		categories, err := vsphere.GetTagCategories(datacenter.Spec.VSphere, username, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tag categories: %v", err)
+		}
+
+		var apiCategories []apiv1.VSphereTagCategory
+		for _, category := range categories {
+			apiCategories = append(apiCategories, apiv1.VSphereTagCategory{
+				ID:          category.ID,
+				Name:        category.Name,
+				Description: category.Description,
+				Cardinality: category.Cardinality,
+			})
+		}
+
+		return apiCategories, nil
+	}
+}
+
+// getVsphereCredentials returns the passed credentials, unless a preset is requested.
+func getVsphereCredentials(presetsProvider provider.PresetProvider, userInfo *provider.UserInfo, username, password, credential string) (string, string, error) {
+	if len(credential) > 0 {
+		preset, err := presetsProvider.GetPreset(userInfo, credential)
+		if err != nil {
+			return "", "", errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", credential, userInfo.Email))
+		}
+		if credentials := preset.Spec.VSphere; credentials != nil {
+			return credentials.Username, credentials.Password, nil
+		}
+	}
+	return username, password, nil
+}
+
 // VSphereNetworksReq represent a request for vsphere networks
 // swagger:parameters listVSphereNetworks
 type VSphereNetworksReq struct {
@@ -288,3 +375,53 @@ func DecodeVSphereFoldersNoCredentialsReq(c context.Context, r *http.Request) (i
 	req.GetClusterReq = lr.(common.GetClusterReq)
 	return req, nil
 }
+
+// VSphereResourcePoolsReq represent a request for vsphere resource pools
+// swagger:parameters listVSphereResourcePools
+type VSphereResourcePoolsReq struct {
+	// in: header
+	Username string
+	// in: header
+	Password string
+	// in: header
+	DatacenterName string
+	// in: header
+	// Credential predefined Kubermatic credential name from the presets
+	Credential string
+}
+
+func DecodeVSphereResourcePoolsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req VSphereResourcePoolsReq
+
+	req.Username = r.Header.Get("Username")
+	req.Password = r.Header.Get("Password")
+	req.DatacenterName = r.Header.Get("DatacenterName")
+	req.Credential = r.Header.Get("Credential")
+
+	return req, nil
+}
+
+// VSphereTagCategoriesReq represent a request for vsphere tag categories
+// swagger:parameters listVSphereTagCategories
+type VSphereTagCategoriesReq struct {
+	// in: header
+	Username string
+	// in: header
+	Password string
+	// in: header
+	DatacenterName string
+	// in: header
+	// Credential predefined Kubermatic credential name from the presets
+	Credential string
+}
+
+func DecodeVSphereTagCategoriesReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req VSphereTagCategoriesReq
+
+	req.Username = r.Header.Get("Username")
+	req.Password = r.Header.Get("Password")
+	req.DatacenterName = r.Header.Get("DatacenterName")
+	req.Credential = r.Header.Get("Credential")
+
+	return req, nil
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 	"unicode/utf8"
 
 	"github.com/go-kit/kit/endpoint"
@@ -40,8 +42,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 )
 
+// defaultRotationGracePeriod is the time a rotated token stays valid if no grace period was requested
+const defaultRotationGracePeriod = time.Hour
+
+// maxRotationGracePeriod is the longest time a rotated token can stay valid
+const maxRotationGracePeriod = 7 * 24 * time.Hour
+
 // CreateTokenEndpoint creates a token for the given service account
-func CreateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func CreateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, settingsProvider provider.SettingsProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(addTokenReq)
 		err := req.Validate()
@@ -67,9 +75,27 @@ func CreateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 			return nil, errors.NewAlreadyExists("token", req.Body.Name)
 		}
 
+		maxExpiry, err := getTokenMaxExpiry(settingsProvider)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		options := serviceaccount.TokenOptions{
+			Expiry: maxExpiry,
+			Scopes: req.Body.Scopes,
+		}
+		if !req.Body.Expiry.IsZero() {
+			if !req.Body.Expiry.After(serviceaccount.Now()) {
+				return nil, errors.NewBadRequest("the expiry of the token must be in the future")
+			}
+			if req.Body.Expiry.After(maxExpiry) {
+				return nil, errors.NewBadRequest(fmt.Sprintf("the expiry of the token can not be later than %s", maxExpiry.UTC().Format(time.RFC3339)))
+			}
+			options.Expiry = req.Body.Expiry.Time
+		}
+
 		tokenID := rand.String(10)
 
-		token, err := tokenGenerator.Generate(serviceaccount.Claims(sa.Spec.Email, project.Name, tokenID))
+		token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions(sa.Spec.Email, project.Name, tokenID, options))
 		if err != nil {
 			return nil, errors.New(http.StatusInternalServerError, "can not generate token data")
 		}
@@ -88,6 +114,47 @@ func CreateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 	}
 }
 
+// getTokenMaxExpiry returns the latest expiry of a token issued now, as configured by the admin
+func getTokenMaxExpiry(settingsProvider provider.SettingsProvider) (time.Time, error) {
+	settings, err := settingsProvider.GetGlobalSettings()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if hours := settings.Spec.ServiceAccountTokenMaxExpiryHours; hours > 0 {
+		return serviceaccount.Now().Add(time.Duration(hours) * time.Hour), nil
+	}
+	return serviceaccount.DefaultTokenExpiry(), nil
+}
+
+// renewedTokenOptions returns the options of a token which replaces the given one. The new token
+// keeps the scopes and the validity period of the old token, capped by the current maximum expiry.
+func renewedTokenOptions(secret *v1.Secret, tokenAuthenticator serviceaccount.TokenAuthenticator, settingsProvider provider.SettingsProvider) (serviceaccount.TokenOptions, error) {
+	token, ok := secret.Data["token"]
+	if !ok {
+		return serviceaccount.TokenOptions{}, fmt.Errorf("can not find token data in secret %s", secret.Name)
+	}
+	publicClaim, customClaim, err := tokenAuthenticator.Parse(string(token))
+	if err != nil {
+		return serviceaccount.TokenOptions{}, fmt.Errorf("unable to parse the token %s due to %v", secret.Name, err)
+	}
+
+	maxExpiry, err := getTokenMaxExpiry(settingsProvider)
+	if err != nil {
+		return serviceaccount.TokenOptions{}, err
+	}
+	options := serviceaccount.TokenOptions{
+		Expiry: maxExpiry,
+		Scopes: customClaim.Scopes,
+	}
+	if publicClaim.Expiry != nil && publicClaim.IssuedAt != nil {
+		validity := publicClaim.Expiry.Time().Sub(publicClaim.IssuedAt.Time())
+		if expiry := serviceaccount.Now().Add(validity); expiry.Before(maxExpiry) {
+			options.Expiry = expiry
+		}
+	}
+	return options, nil
+}
+
 func listSAToken(ctx context.Context, userInfoGetter provider.UserInfoGetter, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, project *kubermaticapiv1.Project, sa *kubermaticapiv1.User, tokenID string) ([]*v1.Secret, error) {
 	options := &provider.ServiceAccountTokenListOptions{}
 	if tokenID != "" {
@@ -162,6 +229,11 @@ func ListTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProje
 
 		var errorList []string
 		for _, secret := range existingSecretList {
+			if serviceaccount.IsRevoked(secret) {
+				// the grace period of the rotated token is over, the token is rejected and
+				// deleted by the service account token cleanup controller
+				continue
+			}
 
 			externalToken, err := convertInternalTokenToPublicExternal(secret, tokenAuthenticator)
 			if err != nil {
@@ -180,7 +252,7 @@ func ListTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProje
 }
 
 // UpdateTokenEndpoint updates and regenerates the token for the given service account
-func UpdateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func UpdateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, settingsProvider provider.SettingsProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(updateTokenReq)
 		err := req.Validate()
@@ -188,7 +260,7 @@ func UpdateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, req.Body.Name, true)
+		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenAuthenticator, tokenGenerator, settingsProvider, req.ProjectID, req.ServiceAccountID, req.TokenID, req.Body.Name, true)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -203,7 +275,7 @@ func UpdateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 }
 
 // PatchTokenEndpoint patches the token name
-func PatchTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func PatchTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, settingsProvider provider.SettingsProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(patchTokenReq)
 		err := req.Validate()
@@ -219,7 +291,7 @@ func PatchTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProj
 			return nil, errors.NewBadRequest("new name can not be empty")
 		}
 
-		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, tokenReq.Name, false)
+		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenAuthenticator, tokenGenerator, settingsProvider, req.ProjectID, req.ServiceAccountID, req.TokenID, tokenReq.Name, false)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -233,6 +305,75 @@ func PatchTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProj
 	}
 }
 
+// RotateTokenEndpoint issues a replacement for the given token and revokes the old token after a grace period
+func RotateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, settingsProvider provider.SettingsProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateTokenReq)
+		err := req.Validate()
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		sa, err := getSA(ctx, serviceAccountProvider, privilegedServiceAccount, userInfoGetter, project, req.ServiceAccountID, &provider.ServiceAccountGetOptions{RemovePrefix: false})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		existingSecret, err := getSAToken(ctx, userInfoGetter, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, req.ProjectID, req.TokenID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if serviceaccount.RevokeAt(existingSecret) != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("the token %s has already been rotated", req.TokenID))
+		}
+		name, ok := existingSecret.Labels["name"]
+		if !ok {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not find token name in secret %s", existingSecret.Name))
+		}
+
+		options, err := renewedTokenOptions(existingSecret, tokenAuthenticator, settingsProvider)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		tokenID := rand.String(10)
+
+		token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions(sa.Spec.Email, project.Name, tokenID, options))
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, "can not generate token data")
+		}
+
+		secret, err := createSAToken(ctx, userInfoGetter, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, sa, project.Name, name, tokenID, token)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		gracePeriod := defaultRotationGracePeriod
+		if req.Body.GracePeriodSeconds != nil {
+			gracePeriod = time.Duration(*req.Body.GracePeriodSeconds) * time.Second
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = map[string]string{}
+		}
+		existingSecret.Annotations[serviceaccount.RevokeAtAnnotationKey] = serviceaccount.Now().Add(gracePeriod).UTC().Format(time.RFC3339)
+		if _, err := updateSAToken(ctx, userInfoGetter, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, existingSecret, req.ProjectID); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		externalToken, err := convertInternalTokenToPrivateExternal(secret, tokenAuthenticator)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, err.Error())
+		}
+
+		return externalToken, nil
+	}
+}
+
 // DeleteTokenEndpoint deletes the token from service account
 func DeleteTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -292,8 +433,8 @@ func getSAToken(ctx context.Context, userInfoGetter provider.UserInfoGetter, ser
 }
 
 func updateEndpoint(ctx context.Context, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider,
-	privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter, tokenGenerator serviceaccount.TokenGenerator,
-	projectID, saID, tokenID, newName string, regenerateToken bool) (*v1.Secret, error) {
+	privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator,
+	settingsProvider provider.SettingsProvider, projectID, saID, tokenID, newName string, regenerateToken bool) (*v1.Secret, error) {
 
 	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
 	if err != nil {
@@ -331,7 +472,11 @@ func updateEndpoint(ctx context.Context, projectProvider provider.ProjectProvide
 	}
 
 	if regenerateToken {
-		token, err := tokenGenerator.Generate(serviceaccount.Claims(sa.Spec.Email, project.Name, existingSecret.Name))
+		options, err := renewedTokenOptions(existingSecret, tokenAuthenticator, settingsProvider)
+		if err != nil {
+			return nil, err
+		}
+		token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions(sa.Spec.Email, project.Name, existingSecret.Name, options))
 		if err != nil {
 			return nil, fmt.Errorf("can not generate token data")
 		}
@@ -402,6 +547,15 @@ type patchTokenReq struct {
 	Body []byte
 }
 
+// rotateTokenReq defines HTTP request for rotateServiceAccountToken
+// swagger:parameters rotateServiceAccountToken
+type rotateTokenReq struct {
+	commonTokenReq
+	tokenIDReq
+	// in: body
+	Body apiv1.ServiceAccountTokenRotation
+}
+
 // deleteTokenReq defines HTTP request for deleteServiceAccountToken
 // swagger:parameters deleteServiceAccountToken
 type deleteTokenReq struct {
@@ -417,6 +571,11 @@ func (r addTokenReq) Validate() error {
 	if utf8.RuneCountInString(r.Body.Name) > 50 {
 		return fmt.Errorf("the name is too long, max 50 chars")
 	}
+	for _, scope := range r.Body.Scopes {
+		if err := serviceaccount.ValidateScope(scope); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -463,6 +622,26 @@ func (r patchTokenReq) Validate() error {
 	return nil
 }
 
+// Validate validates rotateTokenReq request
+func (r rotateTokenReq) Validate() error {
+	if err := r.commonTokenReq.Validate(); err != nil {
+		return err
+	}
+	if len(r.TokenID) == 0 {
+		return fmt.Errorf("token ID cannot be empty")
+	}
+	if gracePeriod := r.Body.GracePeriodSeconds; gracePeriod != nil {
+		if *gracePeriod < 0 {
+			return fmt.Errorf("the grace period can not be negative")
+		}
+		if time.Duration(*gracePeriod)*time.Second > maxRotationGracePeriod {
+			return fmt.Errorf("the grace period can not be longer than %s", maxRotationGracePeriod)
+		}
+	}
+
+	return nil
+}
+
 // Validate validates updateTokenReq request
 func (r deleteTokenReq) Validate() error {
 	if err := r.commonTokenReq.Validate(); err != nil {
@@ -589,6 +768,33 @@ func DecodeDeleteTokenReq(c context.Context, r *http.Request) (interface{}, erro
 	return req, nil
 }
 
+// DecodeRotateTokenReq  decodes an HTTP request into rotateTokenReq
+func DecodeRotateTokenReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req rotateTokenReq
+
+	rawReq, err := DecodeTokenReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	tokenReq := rawReq.(commonTokenReq)
+	req.ServiceAccountID = tokenReq.ServiceAccountID
+	req.ProjectID = tokenReq.ProjectID
+
+	// the body is optional, the defaults are used without it
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	tokenID, err := decodeTokenIDReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.TokenID = tokenID.TokenID
+
+	return req, nil
+}
+
 func decodeTokenIDReq(c context.Context, r *http.Request) (tokenIDReq, error) {
 	var req tokenIDReq
 
@@ -623,12 +829,22 @@ func convertInternalTokenToPublicExternal(internal *v1.Secret, authenticator ser
 		return nil, fmt.Errorf("can not find token data")
 	}
 
-	publicClaim, _, err := authenticator.Authenticate(string(token))
+	// expired tokens are still listed, so only the signature is verified
+	publicClaim, customClaim, err := authenticator.Parse(string(token))
 	if err != nil {
 		return nil, fmt.Errorf("unable to create a token for %s due to %v", internal.Name, err)
 	}
 
 	externalToken.Expiry = apiv1.NewTime(publicClaim.Expiry.Time())
+	externalToken.Scopes = customClaim.Scopes
+	if lastUsed := serviceaccount.LastUsed(internal); lastUsed != nil {
+		t := apiv1.NewTime(*lastUsed)
+		externalToken.LastUsed = &t
+	}
+	if revokeAt := serviceaccount.RevokeAt(internal); revokeAt != nil {
+		t := apiv1.NewTime(*revokeAt)
+		externalToken.RevokeAt = &t
+	}
 	externalToken.ID = internal.Name
 	name, ok := internal.Labels["name"]
 	if !ok {
@@ -17,18 +17,22 @@ limitations under the License.
 package serviceaccount_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -245,6 +249,34 @@ func TestListTokens(t *testing.T) {
 				genPublicServiceAccountToken("3", "test-3", expiry),
 			},
 		},
+		{
+			name:       "scenario 3: revoked tokens are not listed",
+			httpStatus: http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{
+				/*add projects*/
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add bindings*/
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				/*add users*/
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+			},
+			existingKubernetesObjs: []runtime.Object{
+				test.GenDefaultSaToken("plan9-ID", "serviceaccount-1", "test-1", "1"),
+				func() *corev1.Secret {
+					token := test.GenDefaultSaToken("plan9-ID", "serviceaccount-1", "test-3", "3")
+					token.Annotations = map[string]string{serviceaccount.RevokeAtAnnotationKey: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)}
+					return token
+				}(),
+			},
+			existingAPIUser: *test.GenAPIUser("john", "john@acme.com"),
+			projectToSync:   "plan9-ID",
+			saToSync:        "1",
+			expectedTokens: []apiv1.PublicServiceAccountToken{
+				genPublicServiceAccountToken("1", "test-1", expiry),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -252,7 +284,7 @@ func TestListTokens(t *testing.T) {
 			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/serviceaccounts/%s/tokens", tc.projectToSync, tc.saToSync), strings.NewReader(""))
 			res := httptest.NewRecorder()
 
-			ep, _, err := test.CreateTestEndpointAndGetClients(tc.existingAPIUser, nil, tc.existingKubernetesObjs, []runtime.Object{}, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			ep, fakeClients, err := test.CreateTestEndpointAndGetClients(tc.existingAPIUser, nil, tc.existingKubernetesObjs, []runtime.Object{}, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
 			if err != nil {
 				t.Fatalf("failed to create test endpoint due to %v", err)
 			}
@@ -271,6 +303,13 @@ func TestListTokens(t *testing.T) {
 
 			actualSA.EqualOrDie(wrappedExpectedToken, t)
 
+			// listing is read-only, revoked tokens are deleted by a controller
+			for _, obj := range tc.existingKubernetesObjs {
+				token := obj.(*corev1.Secret)
+				if err := fakeClients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: token.Name, Namespace: token.Namespace}, &corev1.Secret{}); err != nil {
+					t.Fatalf("expected token %s to be kept: %v", token.Name, err)
+				}
+			}
 		})
 	}
 }
@@ -353,6 +392,66 @@ func TestServiceAccountCanGetProject(t *testing.T) {
 	}
 }
 
+func TestServiceAccountTokenScopes(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name             string
+		scopes           []string
+		expectedResponse string
+		httpStatus       int
+	}{
+		{
+			name:             "scenario 1: a token scoped to the project can get the project",
+			scopes:           []string{"GET /api/v1/projects/{project_id}"},
+			httpStatus:       http.StatusOK,
+			expectedResponse: `{"id":"plan9-ID","name":"plan9","creationTimestamp":"2013-02-03T19:54:00Z","status":"Active","owners":[{"name":"john","creationTimestamp":"0001-01-01T00:00:00Z","email":"john@acme.com"}]}`,
+		},
+		{
+			name:             "scenario 2: a token scoped to clusters can not get the project",
+			scopes:           []string{"GET /api/v1/projects/{project_id}/clusters"},
+			httpStatus:       http.StatusForbidden,
+			expectedResponse: `{"error":{"code":403,"message":"forbidden: the token is not allowed to access this endpoint"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenGenerator, err := serviceaccount.JWTTokenGenerator([]byte(test.TestServiceAccountHashKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions("serviceaccount-1@sa.kubermatic.io", "plan9-ID", "1", serviceaccount.TokenOptions{Scopes: tc.scopes}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			secret := test.GenDefaultSaToken("plan9-ID", "serviceaccount-1", "test", "1")
+			secret.Data["token"] = []byte(token)
+
+			existingKubermaticObjs := []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+			}
+			ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("test-1", "serviceaccount-1@sa.kubermatic.io"), nil, []runtime.Object{secret}, []runtime.Object{}, existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			req := httptest.NewRequest("GET", "/api/v1/projects/plan9-ID", strings.NewReader(""))
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+			res := httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
 func TestPatchToken(t *testing.T) {
 	t.Parallel()
 	expiry, err := test.GenDefaultExpiry()
@@ -795,6 +894,212 @@ func TestDeleteToken(t *testing.T) {
 	}
 }
 
+func TestCreateTokenWithExpiryAndScopes(t *testing.T) {
+	t.Parallel()
+
+	settings := test.GenDefaultGlobalSettings()
+	settings.Spec.ServiceAccountTokenMaxExpiryHours = 48
+
+	testcases := []struct {
+		name           string
+		expiry         time.Time
+		scopes         []string
+		httpStatus     int
+		expectedExpiry time.Time
+	}{
+		{
+			name:           "scenario 1: the token expires at the maximum expiry by default",
+			httpStatus:     http.StatusCreated,
+			expectedExpiry: time.Now().Add(48 * time.Hour),
+		},
+		{
+			name:           "scenario 2: the token expires at the requested expiry and is limited to the scopes",
+			expiry:         time.Now().Add(24 * time.Hour),
+			scopes:         []string{"GET /api/v1/projects/{project_id}/clusters"},
+			httpStatus:     http.StatusCreated,
+			expectedExpiry: time.Now().Add(24 * time.Hour),
+		},
+		{
+			name:       "scenario 3: the requested expiry exceeds the maximum expiry",
+			expiry:     time.Now().Add(72 * time.Hour),
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "scenario 4: the requested expiry is in the past",
+			expiry:     time.Now().Add(-time.Hour),
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "scenario 5: the scope is invalid",
+			scopes:     []string{"GET /metrics"},
+			httpStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := apiv1.ServiceAccountToken{}
+			body.Name = "test"
+			body.Expiry = apiv1.NewTime(tc.expiry)
+			body.Scopes = tc.scopes
+			rawBody, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest("POST", "/api/v1/projects/plan9-ID/serviceaccounts/1/tokens", bytes.NewReader(rawBody))
+			res := httptest.NewRecorder()
+
+			existingKubermaticObjs := []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+				settings,
+			}
+			ep, fakeClients, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("john", "john@acme.com"), nil, []runtime.Object{}, []runtime.Object{}, existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			if tc.httpStatus != http.StatusCreated {
+				return
+			}
+
+			var saToken apiv1.ServiceAccountToken
+			if err := json.Unmarshal(res.Body.Bytes(), &saToken); err != nil {
+				t.Fatal(err)
+			}
+			publicClaim, customClaim, err := fakeClients.TokenAuthenticator.Authenticate(saToken.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := publicClaim.Expiry.Time().Sub(tc.expectedExpiry); diff > time.Minute || diff < -time.Minute {
+				t.Fatalf("expected expiry %v got %v", tc.expectedExpiry, publicClaim.Expiry.Time())
+			}
+			if !reflect.DeepEqual(customClaim.Scopes, tc.scopes) {
+				t.Fatalf("expected scopes %v got %v", tc.scopes, customClaim.Scopes)
+			}
+			if !reflect.DeepEqual(saToken.Scopes, tc.scopes) {
+				t.Fatalf("expected scopes %v in the response, got %v", tc.scopes, saToken.Scopes)
+			}
+		})
+	}
+}
+
+func TestRotateToken(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name                   string
+		body                   string
+		existingKubernetesObjs []runtime.Object
+		expectedErrorMsg       string
+		httpStatus             int
+		expectedGracePeriod    time.Duration
+	}{
+		{
+			name:       "scenario 1: rotate the token with the default grace period",
+			httpStatus: http.StatusCreated,
+			existingKubernetesObjs: []runtime.Object{
+				test.GenDefaultSaToken("plan9-ID", "serviceaccount-1", "test-1", "1"),
+			},
+			expectedGracePeriod: time.Hour,
+		},
+		{
+			name:       "scenario 2: rotate the token with a custom grace period",
+			body:       `{"gracePeriodSeconds":60}`,
+			httpStatus: http.StatusCreated,
+			existingKubernetesObjs: []runtime.Object{
+				test.GenDefaultSaToken("plan9-ID", "serviceaccount-1", "test-1", "1"),
+			},
+			expectedGracePeriod: time.Minute,
+		},
+		{
+			name:       "scenario 3: the token can not be rotated twice",
+			httpStatus: http.StatusBadRequest,
+			existingKubernetesObjs: []runtime.Object{
+				func() *corev1.Secret {
+					token := test.GenDefaultSaToken("plan9-ID", "serviceaccount-1", "test-1", "1")
+					token.Annotations = map[string]string{serviceaccount.RevokeAtAnnotationKey: time.Now().Add(time.Hour).UTC().Format(time.RFC3339)}
+					return token
+				}(),
+			},
+			expectedErrorMsg: `{"error":{"code":400,"message":"the token 1 has already been rotated"}}`,
+		},
+		{
+			name:       "scenario 4: the grace period is too long",
+			body:       `{"gracePeriodSeconds":864000}`,
+			httpStatus: http.StatusBadRequest,
+			existingKubernetesObjs: []runtime.Object{
+				test.GenDefaultSaToken("plan9-ID", "serviceaccount-1", "test-1", "1"),
+			},
+			expectedErrorMsg: `{"error":{"code":400,"message":"the grace period can not be longer than 168h0m0s"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/projects/plan9-ID/serviceaccounts/1/tokens/1/rotate", strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+
+			existingKubermaticObjs := []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+			}
+			ep, fakeClients, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("john", "john@acme.com"), nil, tc.existingKubernetesObjs, []runtime.Object{}, existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			if len(tc.expectedErrorMsg) > 0 {
+				test.CompareWithResult(t, res, tc.expectedErrorMsg)
+				return
+			}
+
+			var saToken apiv1.ServiceAccountToken
+			if err := json.Unmarshal(res.Body.Bytes(), &saToken); err != nil {
+				t.Fatal(err)
+			}
+			if saToken.Name != "test-1" {
+				t.Fatalf("expected the replacement to keep the name test-1, got %s", saToken.Name)
+			}
+			if saToken.ID == "1" {
+				t.Fatal("expected the replacement to have a new ID")
+			}
+			if _, _, err := fakeClients.TokenAuthenticator.Authenticate(saToken.Token); err != nil {
+				t.Fatal(err)
+			}
+
+			oldToken := &corev1.Secret{}
+			if err := fakeClients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: "sa-token-1", Namespace: "kubermatic"}, oldToken); err != nil {
+				t.Fatal(err)
+			}
+			revokeAt := serviceaccount.RevokeAt(oldToken)
+			if revokeAt == nil {
+				t.Fatal("expected the old token to be revoked")
+			}
+			if diff := revokeAt.Sub(time.Now().Add(tc.expectedGracePeriod)); diff > time.Minute || diff < -time.Minute {
+				t.Fatalf("expected the old token to be revoked after %v, but it is revoked at %v", tc.expectedGracePeriod, revokeAt)
+			}
+		})
+	}
+}
+
 func genPublicServiceAccountToken(id, name string, expiry apiv1.Time) apiv1.PublicServiceAccountToken {
 	token := apiv1.PublicServiceAccountToken{}
 	token.ID = id
@@ -0,0 +1,546 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+// maxInstances is the maximum number of clusters that can be created from a template at once
+const maxInstances = 10
+
+func CreateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	clusterTemplateProvider provider.ClusterTemplateProvider, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createClusterTemplateReq)
+		if err := validateTemplate(req.Body, settingsProvider, updateManager); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		userInfo, err := getUserInfo(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := convertTemplateToInternal(req.Body)
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		template.Name = rand.String(10)
+
+		created, err := clusterTemplateProvider.New(userInfo, template, req.Body.Scope, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertTemplateToAPI(created)
+	}
+}
+
+func ListEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(common.GetProjectRq)
+
+		userInfo, err := getUserInfo(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		templates, err := clusterTemplateProvider.List(userInfo, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := apiv1.ClusterTemplateList{}
+		for i := range templates {
+			apiTemplate, err := convertTemplateToAPI(&templates[i])
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *apiTemplate)
+		}
+		return result, nil
+	}
+}
+
+func GetEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getClusterTemplateReq)
+
+		userInfo, err := getUserInfo(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := clusterTemplateProvider.Get(userInfo, req.ProjectID, req.ClusterTemplateID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertTemplateToAPI(template)
+	}
+}
+
+func UpdateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	clusterTemplateProvider provider.ClusterTemplateProvider, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateClusterTemplateReq)
+		if err := validateTemplate(req.Body, settingsProvider, updateManager); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		userInfo, err := getUserInfo(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := convertTemplateToInternal(req.Body)
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		template.Name = req.ClusterTemplateID
+
+		updated, err := clusterTemplateProvider.Update(userInfo, req.ProjectID, template)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertTemplateToAPI(updated)
+	}
+}
+
+func DeleteEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getClusterTemplateReq)
+
+		userInfo, err := getUserInfo(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, common.KubernetesErrorToHTTPError(clusterTemplateProvider.Delete(userInfo, req.ProjectID, req.ClusterTemplateID))
+	}
+}
+
+// CreateInstancesEndpoint creates the requested number of clusters from the template. Every cluster
+// goes through the regular cluster creation, including its initial node deployments.
+func CreateInstancesEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider,
+	credentialManager provider.PresetProvider, exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createInstancesReq)
+		if req.Body.Replicas < 1 || req.Body.Replicas > maxInstances {
+			return nil, errors.NewBadRequest("the number of replicas must be between 1 and %d", maxInstances)
+		}
+
+		userInfo, err := getUserInfo(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := clusterTemplateProvider.Get(userInfo, req.ProjectID, req.ClusterTemplateID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		// the cluster providers are usually set by the middleware, but the seed is only known from the template
+		ctx, err = setClusterProviders(ctx, seedsGetter, clusterProviderGetter, template.Spec.Cluster.Cloud.DatacenterName)
+		if err != nil {
+			return nil, err
+		}
+
+		clusters := []*apiv1.Cluster{}
+		for i := int32(0); i < req.Body.Replicas; i++ {
+			// every instance gets its own copy, the node deployments are created in the background
+			apiTemplate, err := convertTemplateToAPI(template)
+			if err != nil {
+				return nil, err
+			}
+			apiCluster := *apiTemplate.Cluster
+			apiCluster.Name = fmt.Sprintf("%s-%s", apiCluster.Name, rand.String(5))
+
+			cluster, err := handlercommon.CreateClusterWithNodeDeployments(ctx, req.ProjectID, apiCluster, apiTemplate.NodeDeployments, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter)
+			if err != nil {
+				// the instances are created all or nothing, so a retry does not exceed the requested replicas
+				details := deleteInstances(ctx, userInfoGetter, req.ProjectID, clusters, sshKeyProvider, privilegedSSHKeyProvider, projectProvider, privilegedProjectProvider)
+				code := http.StatusInternalServerError
+				if httpErr, ok := err.(errors.HTTPError); ok {
+					code = httpErr.StatusCode()
+				}
+				return nil, errors.NewWithDetails(code, fmt.Sprintf("failed to create instance %d of %d: %v", i+1, req.Body.Replicas, err), details)
+			}
+			clusters = append(clusters, cluster)
+		}
+		return clusters, nil
+	}
+}
+
+// deleteInstances deletes the given clusters and returns a message for every cluster that could not be deleted
+func deleteInstances(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID string, clusters []*apiv1.Cluster, sshKeyProvider provider.SSHKeyProvider,
+	privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) []string {
+	var failures []string
+	for _, cluster := range clusters {
+		if _, err := handlercommon.DeleteEndpoint(ctx, userInfoGetter, projectID, cluster.ID, false, false, sshKeyProvider, privilegedSSHKeyProvider, projectProvider, privilegedProjectProvider); err != nil {
+			failures = append(failures, fmt.Sprintf("failed to delete the already created cluster %s: %v", cluster.ID, err))
+		}
+	}
+	return failures
+}
+
+// getUserInfo checks the access to the project and returns the user info bound to it.
+// Admins don't have to be members of the project.
+func getUserInfo(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, projectID string) (*provider.UserInfo, error) {
+	if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil); err != nil {
+		return nil, err
+	}
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if adminUserInfo.IsAdmin {
+		return adminUserInfo, nil
+	}
+	return userInfoGetter(ctx, projectID)
+}
+
+func setClusterProviders(ctx context.Context, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, datacenter string) (context.Context, error) {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	for _, seed := range seeds {
+		if _, ok := seed.Spec.Datacenters[datacenter]; !ok {
+			continue
+		}
+		clusterProvider, err := clusterProviderGetter(seed)
+		if err != nil {
+			return nil, errors.NewNotFound("cluster-provider", seed.Name)
+		}
+		ctx = context.WithValue(ctx, middleware.ClusterProviderContextKey, clusterProvider)
+		ctx = context.WithValue(ctx, middleware.PrivilegedClusterProviderContextKey, clusterProvider.(provider.PrivilegedClusterProvider))
+		return ctx, nil
+	}
+	return nil, errors.NewBadRequest("can not find seed for datacenter %s", datacenter)
+}
+
+func validateTemplate(template apiv1.ClusterTemplate, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) error {
+	if len(template.Name) == 0 {
+		return fmt.Errorf("the template name cannot be empty")
+	}
+	switch template.Scope {
+	case kubermaticv1.UserClusterTemplateScope, kubermaticv1.ProjectClusterTemplateScope, kubermaticv1.GlobalClusterTemplateScope:
+	default:
+		return fmt.Errorf("invalid scope %q, must be one of %s, %s or %s", template.Scope, kubermaticv1.UserClusterTemplateScope, kubermaticv1.ProjectClusterTemplateScope, kubermaticv1.GlobalClusterTemplateScope)
+	}
+	if template.Cluster == nil {
+		return fmt.Errorf("the template cluster cannot be empty")
+	}
+	if hasCredentials(template.Cluster.Spec.Cloud) {
+		return fmt.Errorf("the template cluster cannot contain cloud credentials, use a preset instead")
+	}
+	for _, nd := range template.NodeDeployments {
+		if nd == nil || nd.Spec.Replicas < 0 {
+			return fmt.Errorf("invalid node deployment, the number of replicas cannot be negative")
+		}
+	}
+
+	globalSettings, err := settingsProvider.GetGlobalSettings()
+	if err != nil {
+		return err
+	}
+	return handlercommon.ValidateClusterSpec(globalSettings.Spec.ClusterTypeOptions, updateManager, apiv1.CreateClusterSpec{Cluster: *template.Cluster})
+}
+
+// hasCredentials returns true if the cloud spec contains inline credentials or references
+// a credentials secret.
+func hasCredentials(cloud kubermaticv1.CloudSpec) bool {
+	withoutCredentials := cloud.DeepCopy()
+	removeCredentials(withoutCredentials)
+	return !reflect.DeepEqual(cloud, *withoutCredentials)
+}
+
+// removeCredentials clears the credentials of the cloud spec, which are otherwise filled in
+// from the preset of the template.
+func removeCredentials(cloud *kubermaticv1.CloudSpec) {
+	if cloud.AWS != nil {
+		cloud.AWS.CredentialsReference = nil
+		cloud.AWS.AccessKeyID = ""
+		cloud.AWS.SecretAccessKey = ""
+	}
+	if cloud.Azure != nil {
+		cloud.Azure.CredentialsReference = nil
+		cloud.Azure.TenantID = ""
+		cloud.Azure.SubscriptionID = ""
+		cloud.Azure.ClientID = ""
+		cloud.Azure.ClientSecret = ""
+	}
+	if cloud.Digitalocean != nil {
+		cloud.Digitalocean.CredentialsReference = nil
+		cloud.Digitalocean.Token = ""
+	}
+	if cloud.GCP != nil {
+		cloud.GCP.CredentialsReference = nil
+		cloud.GCP.ServiceAccount = ""
+	}
+	if cloud.Hetzner != nil {
+		cloud.Hetzner.CredentialsReference = nil
+		cloud.Hetzner.Token = ""
+	}
+	if cloud.Openstack != nil {
+		cloud.Openstack.CredentialsReference = nil
+		cloud.Openstack.Username = ""
+		cloud.Openstack.Password = ""
+		cloud.Openstack.Tenant = ""
+		cloud.Openstack.TenantID = ""
+		cloud.Openstack.Domain = ""
+	}
+	if cloud.Packet != nil {
+		cloud.Packet.CredentialsReference = nil
+		cloud.Packet.APIKey = ""
+		cloud.Packet.ProjectID = ""
+	}
+	if cloud.Kubevirt != nil {
+		cloud.Kubevirt.CredentialsReference = nil
+		cloud.Kubevirt.Kubeconfig = ""
+		cloud.Kubevirt.ServiceAccountCredentialsReference = nil
+	}
+	if cloud.VSphere != nil {
+		cloud.VSphere.CredentialsReference = nil
+		cloud.VSphere.Username = ""
+		cloud.VSphere.Password = ""
+		cloud.VSphere.InfraManagementUser = kubermaticv1.VSphereCredentials{}
+	}
+	if cloud.Alibaba != nil {
+		cloud.Alibaba.CredentialsReference = nil
+		cloud.Alibaba.AccessKeyID = ""
+		cloud.Alibaba.AccessKeySecret = ""
+	}
+}
+
+func convertTemplateToInternal(template apiv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error) {
+	cluster := template.Cluster
+
+	nodeDeployments := []runtime.RawExtension{}
+	for _, nd := range template.NodeDeployments {
+		raw, err := json.Marshal(nd)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node deployment: %v", err)
+		}
+		nodeDeployments = append(nodeDeployments, runtime.RawExtension{Raw: raw})
+	}
+
+	return &kubermaticv1.ClusterTemplate{
+		Spec: kubermaticv1.ClusterTemplateSpec{
+			HumanReadableName: template.Name,
+			ClusterType:       cluster.Type,
+			ClusterLabels:     cluster.Labels,
+			Credential:        cluster.Credential,
+			Cluster: kubermaticv1.ClusterSpec{
+				HumanReadableName:                   cluster.Name,
+				Cloud:                               cluster.Spec.Cloud,
+				MachineNetworks:                     cluster.Spec.MachineNetworks,
+				OIDC:                                cluster.Spec.OIDC,
+				UpdateWindow:                        cluster.Spec.UpdateWindow,
+				EtcdBackup:                          cluster.Spec.EtcdBackup,
+				Version:                             cluster.Spec.Version,
+				UsePodSecurityPolicyAdmissionPlugin: cluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
+				UsePodNodeSelectorAdmissionPlugin:   cluster.Spec.UsePodNodeSelectorAdmissionPlugin,
+				AuditLogging:                        cluster.Spec.AuditLogging,
+				APIServerAllowedIPRanges:            cluster.Spec.APIServerAllowedIPRanges,
+				NodeEgressIPRanges:                  cluster.Spec.NodeEgressIPRanges,
+				Openshift:                           cluster.Spec.Openshift,
+				AdmissionPlugins:                    cluster.Spec.AdmissionPlugins,
+			},
+			NodeDeployments: nodeDeployments,
+		},
+	}, nil
+}
+
+func convertTemplateToAPI(template *kubermaticv1.ClusterTemplate) (*apiv1.ClusterTemplate, error) {
+	spec := template.Spec.Cluster
+
+	nodeDeployments := []*apiv1.NodeDeployment{}
+	for _, raw := range template.Spec.NodeDeployments {
+		nd := &apiv1.NodeDeployment{}
+		if err := json.Unmarshal(raw.Raw, nd); err != nil {
+			return nil, fmt.Errorf("failed to decode node deployment of template %s: %v", template.Name, err)
+		}
+		nodeDeployments = append(nodeDeployments, nd)
+	}
+
+	// templates created before credentials were rejected may still contain them
+	cloud := spec.Cloud.DeepCopy()
+	removeCredentials(cloud)
+
+	return &apiv1.ClusterTemplate{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                template.Name,
+			Name:              template.Spec.HumanReadableName,
+			CreationTimestamp: apiv1.NewTime(template.CreationTimestamp.Time),
+		},
+		Scope:     template.Labels[kubermaticv1.ClusterTemplateScopeLabelKey],
+		ProjectID: template.Labels[kubermaticv1.ProjectIDLabelKey],
+		User:      template.Annotations[kubermaticv1.ClusterTemplateUserAnnotationKey],
+		Cluster: &apiv1.Cluster{
+			ObjectMeta: apiv1.ObjectMeta{
+				Name: spec.HumanReadableName,
+			},
+			Labels:     template.Spec.ClusterLabels,
+			Type:       template.Spec.ClusterType,
+			Credential: template.Spec.Credential,
+			Spec: apiv1.ClusterSpec{
+				Cloud:                               *cloud,
+				MachineNetworks:                     spec.MachineNetworks,
+				OIDC:                                spec.OIDC,
+				UpdateWindow:                        spec.UpdateWindow,
+				EtcdBackup:                          spec.EtcdBackup,
+				Version:                             spec.Version,
+				UsePodSecurityPolicyAdmissionPlugin: spec.UsePodSecurityPolicyAdmissionPlugin,
+				UsePodNodeSelectorAdmissionPlugin:   spec.UsePodNodeSelectorAdmissionPlugin,
+				AuditLogging:                        spec.AuditLogging,
+				APIServerAllowedIPRanges:            spec.APIServerAllowedIPRanges,
+				NodeEgressIPRanges:                  spec.NodeEgressIPRanges,
+				Openshift:                           spec.Openshift,
+				AdmissionPlugins:                    spec.AdmissionPlugins,
+			},
+		},
+		NodeDeployments: nodeDeployments,
+	}, nil
+}
+
+// createClusterTemplateReq defines HTTP request for createClusterTemplate
+// swagger:parameters createClusterTemplate
+type createClusterTemplateReq struct {
+	common.ProjectReq
+	// in: body
+	Body apiv1.ClusterTemplate
+}
+
+func DecodeCreateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createClusterTemplateReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+	defaultClusterType(&req.Body)
+
+	return req, nil
+}
+
+func defaultClusterType(template *apiv1.ClusterTemplate) {
+	if template.Cluster != nil && len(template.Cluster.Type) == 0 {
+		template.Cluster.Type = apiv1.KubernetesClusterType
+	}
+}
+
+// getClusterTemplateReq defines HTTP request for getClusterTemplate and deleteClusterTemplate
+// swagger:parameters getClusterTemplate deleteClusterTemplate
+type getClusterTemplateReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterTemplateID string `json:"template_id"`
+}
+
+func DecodeGetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req getClusterTemplateReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	req.ClusterTemplateID = mux.Vars(r)["template_id"]
+	if req.ClusterTemplateID == "" {
+		return nil, fmt.Errorf("'template_id' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
+
+// updateClusterTemplateReq defines HTTP request for updateClusterTemplate
+// swagger:parameters updateClusterTemplate
+type updateClusterTemplateReq struct {
+	getClusterTemplateReq
+	// in: body
+	Body apiv1.ClusterTemplate
+}
+
+func DecodeUpdateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req updateClusterTemplateReq
+
+	getReq, err := DecodeGetReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.getClusterTemplateReq = getReq.(getClusterTemplateReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+	defaultClusterType(&req.Body)
+
+	return req, nil
+}
+
+// createInstancesReq defines HTTP request for createClusterTemplateInstances
+// swagger:parameters createClusterTemplateInstances
+type createInstancesReq struct {
+	getClusterTemplateReq
+	// in: body
+	Body apiv1.ClusterTemplateInstances
+}
+
+func DecodeCreateInstancesReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createInstancesReq
+
+	getReq, err := DecodeGetReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.getClusterTemplateReq = getReq.(getClusterTemplateReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
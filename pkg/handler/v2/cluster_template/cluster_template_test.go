@@ -0,0 +1,330 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplate_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCreateClusterTemplateEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+		RewriteTemplateID      bool
+	}{
+		{
+			Name:                   "scenario 1: project template is created",
+			Body:                   `{"name":"small","scope":"project","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}},"nodeDeployments":[{"spec":{"replicas":1,"template":{"cloud":{},"operatingSystem":{},"versions":{"kubelet":""}}}}]}`,
+			ExpectedResponse:       `{"id":"%s","name":"small","creationTimestamp":"0001-01-01T00:00:00Z","scope":"project","projectID":"my-first-project-ID","user":"bob@acme.com","cluster":{"name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"","url":""}},"nodeDeployments":[{"name":"","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":1,"template":{"cloud":{},"operatingSystem":{},"versions":{"kubelet":""}}},"status":{}}]}`,
+			RewriteTemplateID:      true,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 2: regular user can't create a global template",
+			Body:                   `{"name":"small","scope":"global","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 3: invalid scope is rejected",
+			Body:                   `{"name":"small","scope":"cluster","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid scope \"cluster\", must be one of user, project or global"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 4: viewer can't create a project template",
+			Body:                   `{"name":"small","scope":"project","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" is a viewer of the project"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenUser("", "John", "john@acme.com"), test.GenBinding(test.GenDefaultProject().Name, "john@acme.com", "viewers")),
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:                   "scenario 5: templates with inline credentials are rejected",
+			Body:                   `{"name":"small","scope":"project","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"digitalocean":{"token":"secret"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"the template cluster cannot contain cloud credentials, use a preset instead"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 6: the apiserver IP ranges are kept in the template",
+			Body:                   `{"name":"small","scope":"project","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"},"apiServerAllowedIPRanges":["10.0.0.0/8"],"nodeEgressIPRanges":["192.168.1.0/24"]}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"small","creationTimestamp":"0001-01-01T00:00:00Z","scope":"project","projectID":"my-first-project-ID","user":"bob@acme.com","cluster":{"name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{},"apiServerAllowedIPRanges":["10.0.0.0/8"],"nodeEgressIPRanges":["192.168.1.0/24"]},"status":{"version":"","url":""}}}`,
+			RewriteTemplateID:      true,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clustertemplates", test.GenDefaultProject().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			expectedResponse := tc.ExpectedResponse
+			// since the template ID is automatically generated by the system just rewrite it.
+			if tc.RewriteTemplateID {
+				actualTemplate := &apiv1.ClusterTemplate{}
+				if err := json.Unmarshal(res.Body.Bytes(), actualTemplate); err != nil {
+					t.Fatal(err)
+				}
+				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualTemplate.ID)
+			}
+
+			test.CompareWithResult(t, res, expectedResponse)
+		})
+	}
+}
+
+func TestListClusterTemplatesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		ExpectedTemplates      []string
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:              "scenario 1: user sees global, project and own templates",
+			ExpectedTemplates: []string{"global", "own", "project"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("global", kubermaticv1.GlobalClusterTemplateScope, "", "admin@acme.com"),
+				genClusterTemplate("project", kubermaticv1.ProjectClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com"),
+				genClusterTemplate("own", kubermaticv1.UserClusterTemplateScope, test.GenDefaultProject().Name, "bob@acme.com"),
+				genClusterTemplate("foreign", kubermaticv1.UserClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com"),
+				genClusterTemplate("other-project", kubermaticv1.ProjectClusterTemplateScope, "other-project-ID", "bob@acme.com"),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:              "scenario 2: admin sees the user templates of the project",
+			ExpectedTemplates: []string{"foreign", "own"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", true),
+				genClusterTemplate("own", kubermaticv1.UserClusterTemplateScope, test.GenDefaultProject().Name, "bob@acme.com"),
+				genClusterTemplate("foreign", kubermaticv1.UserClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com"),
+				genClusterTemplate("other-project", kubermaticv1.UserClusterTemplateScope, "other-project-ID", "bob@acme.com"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:              "scenario 3: inline credentials of existing templates are not returned",
+			ExpectedTemplates: []string{"project"},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.ClusterTemplate {
+					template := genClusterTemplate("project", kubermaticv1.ProjectClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com")
+					template.Spec.Cluster.Cloud.Fake = nil
+					template.Spec.Cluster.Cloud.Digitalocean = &kubermaticv1.DigitaloceanCloudSpec{Token: "secret"}
+					return template
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/projects/%s/clustertemplates", test.GenDefaultProject().Name), strings.NewReader(""))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+			}
+
+			templates := apiv1.ClusterTemplateList{}
+			if err := json.Unmarshal(res.Body.Bytes(), &templates); err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, template := range templates {
+				names = append(names, template.ID)
+				if do := template.Cluster.Spec.Cloud.Digitalocean; do != nil && do.Token != "" {
+					t.Fatalf("expected the credentials of template %s to be removed", template.ID)
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tc.ExpectedTemplates, ",") {
+				t.Fatalf("expected templates %v, got %v", tc.ExpectedTemplates, names)
+			}
+		})
+	}
+}
+
+func TestCreateClusterTemplateInstancesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		TemplateID             string
+		Body                   string
+		ExpectedClusters       int
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: clusters are created from the template",
+			TemplateID:       "project",
+			Body:             `{"replicas":2}`,
+			ExpectedClusters: 2,
+			HTTPStatus:       http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("project", kubermaticv1.ProjectClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com"),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:       "scenario 2: the user template of another user can't be instantiated",
+			TemplateID: "foreign",
+			Body:       `{"replicas":1}`,
+			HTTPStatus: http.StatusNotFound,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("foreign", kubermaticv1.UserClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com"),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:       "scenario 3: the number of replicas must be positive",
+			TemplateID: "project",
+			Body:       `{"replicas":0}`,
+			HTTPStatus: http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("project", kubermaticv1.ProjectClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com"),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:       "scenario 4: the number of replicas is limited",
+			TemplateID: "project",
+			Body:       `{"replicas":11}`,
+			HTTPStatus: http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("project", kubermaticv1.ProjectClusterTemplateScope, test.GenDefaultProject().Name, "john@acme.com"),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/clustertemplates/%s/instances", test.GenDefaultProject().Name, tc.TemplateID), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.HTTPStatus != http.StatusCreated {
+				return
+			}
+
+			clusters := []apiv1.Cluster{}
+			if err := json.Unmarshal(res.Body.Bytes(), &clusters); err != nil {
+				t.Fatal(err)
+			}
+			if len(clusters) != tc.ExpectedClusters {
+				t.Fatalf("expected %d clusters, got %d", tc.ExpectedClusters, len(clusters))
+			}
+			for _, cluster := range clusters {
+				if !strings.HasPrefix(cluster.Name, "keen-snyder-") {
+					t.Fatalf("expected the cluster name to be derived from the template, got %s", cluster.Name)
+				}
+			}
+		})
+	}
+}
+
+func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
+	user := test.GenUser("", name, email)
+	user.Spec.IsAdmin = isAdmin
+	return user
+}
+
+func genClusterTemplate(name, scope, projectID, owner string) *kubermaticv1.ClusterTemplate {
+	template := &kubermaticv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{kubermaticv1.ClusterTemplateScopeLabelKey: scope},
+			Annotations: map[string]string{kubermaticv1.ClusterTemplateUserAnnotationKey: owner},
+		},
+		Spec: kubermaticv1.ClusterTemplateSpec{
+			HumanReadableName: name,
+			ClusterType:       apiv1.KubernetesClusterType,
+			Cluster: kubermaticv1.ClusterSpec{
+				HumanReadableName: "keen-snyder",
+				Cloud: kubermaticv1.CloudSpec{
+					DatacenterName: "fake-dc",
+					Fake:           &kubermaticv1.FakeCloudSpec{Token: "dummy_token"},
+				},
+				Version: *semver.NewSemverOrDie("1.15.0"),
+			},
+		},
+	}
+	if projectID != "" {
+		template.Labels[kubermaticv1.ProjectIDLabelKey] = projectID
+	}
+	return template
+}
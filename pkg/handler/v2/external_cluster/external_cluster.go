@@ -31,6 +31,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 
 	corev1 "k8s.io/api/core/v1"
@@ -41,25 +42,28 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func CreateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterProvider provider.ExternalClusterProvider, privilegedClusterProvider provider.PrivilegedExternalClusterProvider) endpoint.Endpoint {
+func CreateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterProvider provider.ExternalClusterProvider, privilegedClusterProvider provider.PrivilegedExternalClusterProvider, presetsProvider provider.PresetProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(createClusterReq)
 		if err := req.Validate(); err != nil {
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		config, err := base64.StdEncoding.DecodeString(req.Body.Kubeconfig)
-		if err != nil {
-			return nil, errors.NewBadRequest(err.Error())
-		}
+		// the kubeconfig of clusters imported from a managed Kubernetes service is generated by the external cluster controller
+		if req.Body.Cloud == nil {
+			config, err := base64.StdEncoding.DecodeString(req.Body.Kubeconfig)
+			if err != nil {
+				return nil, errors.NewBadRequest(err.Error())
+			}
 
-		cfg, err := clientcmd.Load(config)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
+			cfg, err := clientcmd.Load(config)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
 
-		if _, err := clusterProvider.GenerateClient(cfg); err != nil {
-			return nil, errors.NewBadRequest(fmt.Sprintf("cannot connect to the kubernetes cluster: %v", err))
+			if _, err := clusterProvider.GenerateClient(cfg); err != nil {
+				return nil, errors.NewBadRequest(fmt.Sprintf("cannot connect to the kubernetes cluster: %v", err))
+			}
 		}
 
 		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: false})
@@ -71,7 +75,11 @@ func CreateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider prov
 
 		kuberneteshelper.AddFinalizer(newCluster, apiv1.ExternalClusterKubeconfigCleanupFinalizer)
 
-		if err := clusterProvider.CreateOrUpdateKubeconfigSecretForCluster(ctx, newCluster, req.Body.Kubeconfig); err != nil {
+		if req.Body.Cloud != nil {
+			if err := setCloudSpec(ctx, userInfoGetter, clusterProvider, presetsProvider, project.Name, req.Body, newCluster); err != nil {
+				return nil, err
+			}
+		} else if err := clusterProvider.CreateOrUpdateKubeconfigSecretForCluster(ctx, newCluster, req.Body.Kubeconfig); err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
@@ -113,6 +121,137 @@ func (req createClusterReq) Validate() error {
 	if len(req.ProjectID) == 0 {
 		return fmt.Errorf("the project ID cannot be empty")
 	}
+	cloud := req.Body.Cloud
+	if cloud == nil {
+		if len(req.Body.Credential) > 0 {
+			return fmt.Errorf("the credential can only be used to import a cluster from a cloud provider")
+		}
+		return nil
+	}
+	if len(req.Body.Kubeconfig) > 0 {
+		return fmt.Errorf("the kubeconfig and the cloud spec cannot be set both")
+	}
+
+	providers := 0
+	if cloud.EKS != nil {
+		providers++
+		if len(cloud.EKS.Name) == 0 || len(cloud.EKS.Region) == 0 {
+			return fmt.Errorf("the EKS cluster name and region cannot be empty")
+		}
+	}
+	if cloud.GKE != nil {
+		providers++
+		if len(cloud.GKE.Name) == 0 || len(cloud.GKE.Zone) == 0 {
+			return fmt.Errorf("the GKE cluster name and zone cannot be empty")
+		}
+	}
+	if cloud.AKS != nil {
+		providers++
+		if len(cloud.AKS.Name) == 0 || len(cloud.AKS.ResourceGroup) == 0 {
+			return fmt.Errorf("the AKS cluster name and resource group cannot be empty")
+		}
+	}
+	if providers != 1 {
+		return fmt.Errorf("exactly one cloud provider has to be specified")
+	}
+	return nil
+}
+
+// setCloudSpec sets the managed Kubernetes service the cluster is imported from and stores the credentials
+// to access it. If a credential is given, only the name of the preset is stored and the credentials are
+// read from the preset whenever they are used.
+func setCloudSpec(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ExternalClusterProvider, presetsProvider provider.PresetProvider, projectID string, body body, cluster *kubermaticapiv1.ExternalCluster) error {
+	cloud := body.Cloud
+
+	cluster.Spec.CloudSpec = &kubermaticapiv1.ExternalClusterCloudSpec{}
+	switch {
+	case cloud.EKS != nil:
+		cluster.Spec.CloudSpec.EKS = &kubermaticapiv1.ExternalClusterEKSCloudSpec{
+			Name:   cloud.EKS.Name,
+			Region: cloud.EKS.Region,
+		}
+	case cloud.GKE != nil:
+		cluster.Spec.CloudSpec.GKE = &kubermaticapiv1.ExternalClusterGKECloudSpec{
+			Name: cloud.GKE.Name,
+			Zone: cloud.GKE.Zone,
+		}
+	case cloud.AKS != nil:
+		cluster.Spec.CloudSpec.AKS = &kubermaticapiv1.ExternalClusterAKSCloudSpec{
+			Name:          cloud.AKS.Name,
+			ResourceGroup: cloud.AKS.ResourceGroup,
+		}
+	}
+
+	if len(body.Credential) > 0 {
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return common.KubernetesErrorToHTTPError(err)
+		}
+		preset, err := presetsProvider.GetPreset(adminUserInfo, body.Credential)
+		if err != nil {
+			return errors.NewBadRequest("invalid credentials: %v", err)
+		}
+		if !preset.Spec.IsAvailableInProject(projectID) {
+			return errors.NewBadRequest("invalid credentials: the preset %s is not available in the project %s", body.Credential, projectID)
+		}
+		if err := validatePresetCredentials(preset, cloud); err != nil {
+			return errors.NewBadRequest("invalid credentials: %v", err)
+		}
+		cluster.Spec.CloudSpec.PresetName = preset.Name
+		return nil
+	}
+
+	var secretData map[string][]byte
+	switch {
+	case cloud.EKS != nil:
+		if len(cloud.EKS.AccessKeyID) == 0 || len(cloud.EKS.SecretAccessKey) == 0 {
+			return errors.NewBadRequest("the AWS access key ID and secret access key cannot be empty")
+		}
+		secretData = map[string][]byte{
+			resources.AWSAccessKeyID:     []byte(cloud.EKS.AccessKeyID),
+			resources.AWSSecretAccessKey: []byte(cloud.EKS.SecretAccessKey),
+		}
+	case cloud.GKE != nil:
+		if len(cloud.GKE.ServiceAccount) == 0 {
+			return errors.NewBadRequest("the GCP service account cannot be empty")
+		}
+		secretData = map[string][]byte{
+			resources.GCPServiceAccount: []byte(cloud.GKE.ServiceAccount),
+		}
+	case cloud.AKS != nil:
+		if len(cloud.AKS.TenantID) == 0 || len(cloud.AKS.SubscriptionID) == 0 || len(cloud.AKS.ClientID) == 0 || len(cloud.AKS.ClientSecret) == 0 {
+			return errors.NewBadRequest("the Azure tenant ID, subscription ID, client ID and client secret cannot be empty")
+		}
+		secretData = map[string][]byte{
+			resources.AzureTenantID:       []byte(cloud.AKS.TenantID),
+			resources.AzureSubscriptionID: []byte(cloud.AKS.SubscriptionID),
+			resources.AzureClientID:       []byte(cloud.AKS.ClientID),
+			resources.AzureClientSecret:   []byte(cloud.AKS.ClientSecret),
+		}
+	}
+
+	if err := clusterProvider.CreateOrUpdateCredentialSecretForCluster(ctx, cluster, secretData); err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	return nil
+}
+
+// validatePresetCredentials checks that the preset contains credentials for the managed Kubernetes service
+func validatePresetCredentials(preset *kubermaticapiv1.Preset, cloud *apiv1.ExternalClusterCloudSpec) error {
+	switch {
+	case cloud.EKS != nil:
+		if preset.Spec.AWS == nil {
+			return fmt.Errorf("the preset %s doesn't contain credentials for AWS", preset.Name)
+		}
+	case cloud.GKE != nil:
+		if preset.Spec.GCP == nil {
+			return fmt.Errorf("the preset %s doesn't contain credentials for GCP", preset.Name)
+		}
+	case cloud.AKS != nil:
+		if preset.Spec.Azure == nil {
+			return fmt.Errorf("the preset %s doesn't contain credentials for Azure", preset.Name)
+		}
+	}
 	return nil
 }
 
@@ -312,30 +451,30 @@ func UpdateEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider prov
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
+		updated := false
 		if req.Body.Kubeconfig != "" {
-			config, err := base64.StdEncoding.DecodeString(req.Body.Kubeconfig)
-			if err != nil {
-				return nil, errors.NewBadRequest(err.Error())
-			}
-			cfg, err := clientcmd.Load(config)
-			if err != nil {
-				return nil, common.KubernetesErrorToHTTPError(err)
-			}
-			if _, err := clusterProvider.GenerateClient(cfg); err != nil {
-				return nil, errors.NewBadRequest(fmt.Sprintf("cannot connect to the kubernetes cluster: %v", err))
-			}
-			if err := clusterProvider.CreateOrUpdateKubeconfigSecretForCluster(ctx, cluster, req.Body.Kubeconfig); err != nil {
-				return nil, common.KubernetesErrorToHTTPError(err)
+			if err := prepareKubeconfigRotation(ctx, userInfoGetter, clusterProvider, cluster, req.Body.Kubeconfig); err != nil {
+				return nil, err
 			}
+			updated = true
 		}
 
 		if req.Body.Name != "" && req.Body.Name != cluster.Spec.HumanReadableName {
 			cluster.Spec.HumanReadableName = req.Body.Name
+			updated = true
+		}
+
+		if updated {
 			cluster, err = upddateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project.Name, cluster)
 			if err != nil {
 				return nil, errors.NewBadRequest(err.Error())
 			}
 		}
+		if req.Body.Kubeconfig != "" {
+			if err := clusterProvider.CreateOrUpdateKubeconfigSecretForCluster(ctx, cluster, req.Body.Kubeconfig); err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+		}
 
 		return convertClusterToAPI(cluster), nil
 	}
@@ -481,6 +620,39 @@ func convertClusterToAPI(internalCluster *kubermaticapiv1.ExternalCluster) *apiv
 		Type:   apiv1.KubernetesClusterType,
 	}
 
+	status := internalCluster.Status
+	if status.LastCheckTime.IsZero() && status.KubeconfigUpdate == nil {
+		return cluster
+	}
+	cluster.Status.ExternalCluster = &apiv1.ExternalClusterStatus{}
+	if status.KubeconfigUpdate != nil {
+		cluster.Status.ExternalCluster.KubeconfigUpdate = &apiv1.ExternalClusterKubeconfigUpdate{
+			User: status.KubeconfigUpdate.User,
+			Time: apiv1.NewTime(status.KubeconfigUpdate.Time.Time),
+		}
+	}
+	if status.LastCheckTime.IsZero() {
+		return cluster
+	}
+
+	if status.Version != nil {
+		cluster.Spec.Version = *status.Version
+		cluster.Status.Version = *status.Version
+	}
+	cluster.Status.ExternalCluster.Reachable = status.Reachable
+	cluster.Status.ExternalCluster.NodeCount = status.NodeCount
+	cluster.Status.ExternalCluster.Allocatable = apiv1.NodeResources{
+		CPU:    status.Allocatable.Cpu().String(),
+		Memory: status.Allocatable.Memory().String(),
+	}
+	cluster.Status.ExternalCluster.LastError = status.LastError
+	lastCheckTime := apiv1.NewTime(status.LastCheckTime.Time)
+	cluster.Status.ExternalCluster.LastCheckTime = &lastCheckTime
+	if !status.LastHeartbeatTime.IsZero() {
+		lastHeartbeatTime := apiv1.NewTime(status.LastHeartbeatTime.Time)
+		cluster.Status.ExternalCluster.LastHeartbeatTime = &lastHeartbeatTime
+	}
+
 	return cluster
 }
 
@@ -536,5 +708,9 @@ type body struct {
 	// Name is human readable name for the external cluster
 	Name string `json:"name"`
 	// Kubeconfig Base64 encoded kubeconfig
-	Kubeconfig string `json:"kubeconfig"`
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Cloud imports the cluster from a managed Kubernetes service instead of using the kubeconfig
+	Cloud *apiv1.ExternalClusterCloudSpec `json:"cloud,omitempty"`
+	// Credential is the name of the preset which contains the credentials for the managed Kubernetes service
+	Credential string `json:"credential,omitempty"`
 }
@@ -17,23 +17,32 @@ limitations under the License.
 package externalcluster_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/semver"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestCreateClusterEndpoint(t *testing.T) {
@@ -144,6 +153,149 @@ func TestCreateClusterEndpoint(t *testing.T) {
 	}
 }
 
+func TestCreateCloudClusterEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExpectedCloudSpec      *kubermaticv1.ExternalClusterCloudSpec
+		ExpectedSecretData     map[string]string
+	}{
+		{
+			Name:                   "scenario 1: EKS cluster is imported with the given credentials",
+			Body:                   `{"name":"test","cloud":{"eks":{"name":"my-cluster","region":"eu-central-1","accessKeyId":"key","secretAccessKey":"secret"}}}`,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExpectedCloudSpec: &kubermaticv1.ExternalClusterCloudSpec{
+				EKS: &kubermaticv1.ExternalClusterEKSCloudSpec{Name: "my-cluster", Region: "eu-central-1"},
+			},
+			ExpectedSecretData: map[string]string{"accessKeyId": "key", "secretAccessKey": "secret"},
+		},
+		{
+			Name:       "scenario 2: GKE cluster is imported with the credentials of a preset",
+			Body:       `{"name":"test","credential":"gcp-preset","cloud":{"gke":{"name":"my-cluster","zone":"europe-west3-a"}}}`,
+			HTTPStatus: http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genPreset("gcp-preset", kubermaticv1.PresetSpec{GCP: &kubermaticv1.GCP{ServiceAccount: "sa"}}),
+			),
+			ExpectedCloudSpec: &kubermaticv1.ExternalClusterCloudSpec{
+				GKE:        &kubermaticv1.ExternalClusterGKECloudSpec{Name: "my-cluster", Zone: "europe-west3-a"},
+				PresetName: "gcp-preset",
+			},
+		},
+		{
+			Name:       "scenario 3: the preset must be available in the project",
+			Body:       `{"name":"test","credential":"gcp-preset","cloud":{"gke":{"name":"my-cluster","zone":"europe-west3-a"}}}`,
+			HTTPStatus: http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genPreset("gcp-preset", kubermaticv1.PresetSpec{GCP: &kubermaticv1.GCP{ServiceAccount: "sa"}, Projects: []string{"other-project"}}),
+			),
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid credentials: missing preset 'gcp-preset' for the user 'bob@acme.com'"}}`,
+		},
+		{
+			Name:       "scenario 4: the preset must contain credentials for the provider",
+			Body:       `{"name":"test","credential":"gcp-preset","cloud":{"aks":{"name":"my-cluster","resourceGroup":"my-group"}}}`,
+			HTTPStatus: http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genPreset("gcp-preset", kubermaticv1.PresetSpec{GCP: &kubermaticv1.GCP{ServiceAccount: "sa"}}),
+			),
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid credentials: the preset gcp-preset doesn't contain credentials for Azure"}}`,
+		},
+		{
+			Name:                   "scenario 5: credentials are required",
+			Body:                   `{"name":"test","cloud":{"eks":{"name":"my-cluster","region":"eu-central-1"}}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExpectedResponse:       `{"error":{"code":400,"message":"the AWS access key ID and secret access key cannot be empty"}}`,
+		},
+		{
+			Name:                   "scenario 6: only one provider can be specified",
+			Body:                   `{"name":"test","cloud":{"eks":{"name":"my-cluster","region":"eu-central-1"},"gke":{"name":"my-cluster","zone":"europe-west3-a"}}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExpectedResponse:       `{"error":{"code":400,"message":"exactly one cloud provider has to be specified"}}`,
+		},
+		{
+			Name:                   "scenario 7: the kubeconfig cannot be set for a cluster imported from a cloud provider",
+			Body:                   `{"name":"test","kubeconfig":"YQ==","cloud":{"eks":{"name":"my-cluster","region":"eu-central-1"}}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExpectedResponse:       `{"error":{"code":400,"message":"the kubeconfig and the cloud spec cannot be set both"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v2/projects/%s/kubernetes/clusters", test.GenDefaultProject().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, []runtime.Object{}, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.HTTPStatus != http.StatusCreated {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			apiCluster := &apiv1.Cluster{}
+			if err := json.Unmarshal(res.Body.Bytes(), apiCluster); err != nil {
+				t.Fatal(err)
+			}
+			cluster := &kubermaticv1.ExternalCluster{}
+			if err := clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: apiCluster.ID}, cluster); err != nil {
+				t.Fatal(err)
+			}
+			if cluster.Spec.KubeconfigReference != nil {
+				t.Fatal("expected no kubeconfig for a cluster imported from a cloud provider")
+			}
+
+			credentialsRef := &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{Name: cluster.GetCredentialsSecretName(), Namespace: resources.KubermaticNamespace},
+			}
+			secret := &corev1.Secret{}
+			if tc.ExpectedCloudSpec.PresetName != "" {
+				if !equality.Semantic.DeepEqual(cluster.Spec.CloudSpec, tc.ExpectedCloudSpec) {
+					t.Fatalf("cloud spec differs from the expected one: %s", diff.ObjectDiff(tc.ExpectedCloudSpec, cluster.Spec.CloudSpec))
+				}
+				err := clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: credentialsRef.Name, Namespace: credentialsRef.Namespace}, secret)
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("expected the credentials of the preset not to be copied, got %v", err)
+				}
+				return
+			}
+
+			if tc.ExpectedCloudSpec.EKS != nil {
+				tc.ExpectedCloudSpec.EKS.CredentialsReference = credentialsRef
+			}
+			if tc.ExpectedCloudSpec.GKE != nil {
+				tc.ExpectedCloudSpec.GKE.CredentialsReference = credentialsRef
+			}
+			if !equality.Semantic.DeepEqual(cluster.Spec.CloudSpec, tc.ExpectedCloudSpec) {
+				t.Fatalf("cloud spec differs from the expected one: %s", diff.ObjectDiff(tc.ExpectedCloudSpec, cluster.Spec.CloudSpec))
+			}
+
+			if err := clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: credentialsRef.Name, Namespace: credentialsRef.Namespace}, secret); err != nil {
+				t.Fatal(err)
+			}
+			for key, value := range tc.ExpectedSecretData {
+				if string(secret.Data[key]) != value {
+					t.Errorf("expected %q for the secret key %s, got %q", value, key, string(secret.Data[key]))
+				}
+			}
+		})
+	}
+}
+
 func TestDeleteClusterEndpoint(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -217,6 +369,7 @@ func TestDeleteClusterEndpoint(t *testing.T) {
 
 func TestListClusters(t *testing.T) {
 	t.Parallel()
+	lastCheckTime := apiv1.NewTime(time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC))
 	testcases := []struct {
 		Name                   string
 		ExpectedClusters       []apiv1.Cluster
@@ -283,6 +436,81 @@ func TestListClusters(t *testing.T) {
 			),
 			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
 		},
+		// scenario 3
+		{
+			Name: "scenario 3: list clusters with the status of the last health check",
+			ExpectedClusters: []apiv1.Cluster{
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						Name: "clusterAbcID",
+						ID:   "clusterAbcID",
+					},
+					Type:   "kubernetes",
+					Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: test.GenDefaultProject().Name},
+					Spec: apiv1.ClusterSpec{
+						Version: *semver.NewSemverOrDie("1.19.2"),
+					},
+					Status: apiv1.ClusterStatus{
+						Version: *semver.NewSemverOrDie("1.19.2"),
+						ExternalCluster: &apiv1.ExternalClusterStatus{
+							Reachable: true,
+							NodeCount: 3,
+							Allocatable: apiv1.NodeResources{
+								CPU:    "6",
+								Memory: "12Gi",
+							},
+							LastCheckTime:     &lastCheckTime,
+							LastHeartbeatTime: &lastCheckTime,
+						},
+					},
+				},
+				{
+					ObjectMeta: apiv1.ObjectMeta{
+						Name: "clusterDefID",
+						ID:   "clusterDefID",
+					},
+					Type:   "kubernetes",
+					Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: test.GenDefaultProject().Name},
+					Status: apiv1.ClusterStatus{
+						ExternalCluster: &apiv1.ExternalClusterStatus{
+							Allocatable: apiv1.NodeResources{
+								CPU:    "0",
+								Memory: "0",
+							},
+							LastError:     "failed to get the version: connection refused",
+							LastCheckTime: &lastCheckTime,
+						},
+					},
+				},
+			},
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.ExternalCluster {
+					cluster := genExternalCluster(test.GenDefaultProject().Name, "clusterAbcID")
+					cluster.Status = kubermaticv1.ExternalClusterStatus{
+						Reachable: true,
+						Version:   semver.NewSemverOrDie("1.19.2"),
+						NodeCount: 3,
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("6"),
+							corev1.ResourceMemory: resource.MustParse("12Gi"),
+						},
+						LastCheckTime:     metav1.NewTime(lastCheckTime.Time),
+						LastHeartbeatTime: metav1.NewTime(lastCheckTime.Time),
+					}
+					return cluster
+				}(),
+				func() *kubermaticv1.ExternalCluster {
+					cluster := genExternalCluster(test.GenDefaultProject().Name, "clusterDefID")
+					cluster.Status = kubermaticv1.ExternalClusterStatus{
+						LastError:     "failed to get the version: connection refused",
+						LastCheckTime: metav1.NewTime(lastCheckTime.Time),
+					}
+					return cluster
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -639,6 +867,13 @@ func TestGetClusterMetrics(t *testing.T) {
 	}
 }
 
+func genPreset(name string, spec kubermaticv1.PresetSpec) *kubermaticv1.Preset {
+	return &kubermaticv1.Preset{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+}
+
 func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
 	user := test.GenUser("", name, email)
 	user.Spec.IsAdmin = isAdmin
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// RotateKubeconfigEndpoint replaces the kubeconfig of an external cluster, the cluster keeps its ID and project
+func RotateKubeconfigEndpoint(userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, clusterProvider provider.ExternalClusterProvider, privilegedClusterProvider provider.PrivilegedExternalClusterProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateKubeconfigReq)
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: false})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		cluster, err := getCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project.Name, req.ClusterID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		if err := prepareKubeconfigRotation(ctx, userInfoGetter, clusterProvider, cluster, req.Body.Kubeconfig); err != nil {
+			return nil, err
+		}
+
+		cluster, err = upddateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project.Name, cluster)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if err := clusterProvider.CreateOrUpdateKubeconfigSecretForCluster(ctx, cluster, req.Body.Kubeconfig); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertClusterToAPI(cluster), nil
+	}
+}
+
+// rotateKubeconfigReq defines HTTP request for rotateExternalClusterKubeconfig
+// swagger:parameters rotateExternalClusterKubeconfig
+type rotateKubeconfigReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+	// in: body
+	Body kubeconfigBody
+}
+
+type kubeconfigBody struct {
+	// Kubeconfig Base64 encoded kubeconfig
+	Kubeconfig string `json:"kubeconfig"`
+}
+
+func DecodeRotateKubeconfigReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req rotateKubeconfigReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// Validate validates RotateKubeconfigEndpoint request
+func (req rotateKubeconfigReq) Validate() error {
+	if len(req.ProjectID) == 0 {
+		return fmt.Errorf("the project ID cannot be empty")
+	}
+	if len(req.ClusterID) == 0 {
+		return fmt.Errorf("the cluster ID cannot be empty")
+	}
+	if len(req.Body.Kubeconfig) == 0 {
+		return fmt.Errorf("the kubeconfig cannot be empty")
+	}
+	return nil
+}
+
+// prepareKubeconfigRotation verifies that the new kubeconfig connects to the same cluster and records the
+// user and the time of the change in the status of the cluster. The caller has to update the cluster first
+// and store the kubeconfig afterwards, so a failed update doesn't leave the new kubeconfig unrecorded.
+func prepareKubeconfigRotation(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ExternalClusterProvider, cluster *kubermaticapiv1.ExternalCluster, kubeconfig string) error {
+	if cluster.Spec.CloudSpec != nil {
+		return errors.NewBadRequest("the kubeconfig of a cluster imported from a cloud provider is generated and cannot be replaced")
+	}
+
+	config, err := base64.StdEncoding.DecodeString(kubeconfig)
+	if err != nil {
+		return errors.NewBadRequest(err.Error())
+	}
+	cfg, err := clientcmd.Load(config)
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	client, err := clusterProvider.GenerateClient(cfg)
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("cannot connect to the kubernetes cluster: %v", err))
+	}
+	uid, err := kubernetesprovider.KubeSystemUID(ctx, client)
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("cannot connect to the kubernetes cluster: %v", err))
+	}
+
+	// the cluster is identified by the external cluster controller, clusters which were never checked
+	// are identified with the current kubeconfig
+	expectedUID := cluster.Status.KubeSystemUID
+	if expectedUID == "" {
+		currentClient, err := clusterProvider.GetClient(cluster)
+		if err == nil {
+			expectedUID, err = kubernetesprovider.KubeSystemUID(ctx, currentClient)
+		}
+		if err != nil {
+			return errors.NewBadRequest(fmt.Sprintf("cannot verify that the kubeconfig belongs to the same cluster: %v", err))
+		}
+	}
+	if uid != expectedUID {
+		return errors.NewBadRequest("the kubeconfig belongs to a different cluster")
+	}
+
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+	cluster.Status.KubeconfigUpdate = &kubermaticapiv1.ExternalClusterKubeconfigUpdate{
+		User: userInfo.Email,
+		Time: metav1.Now(),
+	}
+	cluster.Status.KubeSystemUID = uid
+	return nil
+}
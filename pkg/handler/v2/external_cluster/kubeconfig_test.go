@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/handler/test"
+	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const rotatedKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://localhost:30808
+  name: rotated
+contexts:
+- context:
+    cluster: rotated
+    user: default
+  name: default
+current-context: default
+users:
+- name: default
+  user:
+    token: rotated-token
+`
+
+func TestRotateKubeconfigEndpoint(t *testing.T) {
+	t.Parallel()
+	kubeconfig := base64.StdEncoding.EncodeToString([]byte(rotatedKubeconfig))
+
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		ExpectedUser           string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name:                   "scenario 1: the owner replaces the kubeconfig",
+			Body:                   fmt.Sprintf(`{"kubeconfig":"%s"}`, kubeconfig),
+			ExpectedUser:           "bob@acme.com",
+			HTTPStatus:             http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genExternalCluster(test.GenDefaultProject().Name, "clusterAbcID")),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:         "scenario 2: the admin John replaces the kubeconfig of Bob's cluster",
+			Body:         fmt.Sprintf(`{"kubeconfig":"%s"}`, kubeconfig),
+			ExpectedUser: "john@acme.com",
+			HTTPStatus:   http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", true),
+				genExternalCluster(test.GenDefaultProject().Name, "clusterAbcID"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 3: the user John can not replace the kubeconfig of Bob's cluster",
+			Body:             fmt.Sprintf(`{"kubeconfig":"%s"}`, kubeconfig),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", false),
+				genExternalCluster(test.GenDefaultProject().Name, "clusterAbcID"),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 4: the kubeconfig of a cluster imported from a cloud provider can not be replaced",
+			Body:             fmt.Sprintf(`{"kubeconfig":"%s"}`, kubeconfig),
+			ExpectedResponse: `{"error":{"code":400,"message":"the kubeconfig of a cluster imported from a cloud provider is generated and cannot be replaced"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(func() *kubermaticv1.ExternalCluster {
+				cluster := genExternalCluster(test.GenDefaultProject().Name, "clusterAbcID")
+				cluster.Spec.CloudSpec = &kubermaticv1.ExternalClusterCloudSpec{
+					EKS: &kubermaticv1.ExternalClusterEKSCloudSpec{Name: "my-cluster", Region: "eu-central-1"},
+				}
+				return cluster
+			}()),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:             "scenario 5: the kubeconfig must belong to the same cluster",
+			Body:             fmt.Sprintf(`{"kubeconfig":"%s"}`, kubeconfig),
+			ExpectedResponse: `{"error":{"code":400,"message":"the kubeconfig belongs to a different cluster"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(func() *kubermaticv1.ExternalCluster {
+				cluster := genExternalCluster(test.GenDefaultProject().Name, "clusterAbcID")
+				cluster.Status.KubeSystemUID = "other-cluster"
+				return cluster
+			}()),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 6: the kubeconfig is required",
+			Body:                   `{}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"the kubeconfig cannot be empty"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genExternalCluster(test.GenDefaultProject().Name, "clusterAbcID")),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/api/v2/projects/%s/kubernetes/clusters/clusterAbcID/kubeconfig", test.GenDefaultProject().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			kubeSystem := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceSystem, UID: "kube-system-uid"}}
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{kubeSystem}, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.HTTPStatus != http.StatusOK {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			apiCluster := &apiv1.Cluster{}
+			if err := json.Unmarshal(res.Body.Bytes(), apiCluster); err != nil {
+				t.Fatal(err)
+			}
+			if apiCluster.Status.ExternalCluster == nil || apiCluster.Status.ExternalCluster.KubeconfigUpdate == nil {
+				t.Fatalf("expected the kubeconfig update in the response: %s", res.Body.String())
+			}
+			if user := apiCluster.Status.ExternalCluster.KubeconfigUpdate.User; user != tc.ExpectedUser {
+				t.Fatalf("expected the kubeconfig update by %s, got %s", tc.ExpectedUser, user)
+			}
+
+			cluster := &kubermaticv1.ExternalCluster{}
+			if err := clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: "clusterAbcID"}, cluster); err != nil {
+				t.Fatal(err)
+			}
+			if cluster.Status.KubeconfigUpdate == nil || cluster.Status.KubeconfigUpdate.User != tc.ExpectedUser || cluster.Status.KubeconfigUpdate.Time.IsZero() {
+				t.Fatalf("expected the kubeconfig update by %s to be stored, got %v", tc.ExpectedUser, cluster.Status.KubeconfigUpdate)
+			}
+			if cluster.Status.KubeSystemUID != "kube-system-uid" {
+				t.Fatalf("expected the cluster to be identified by its kube-system namespace, got %q", cluster.Status.KubeSystemUID)
+			}
+			if cluster.Spec.HumanReadableName != "clusterAbcID" {
+				t.Fatalf("expected the cluster to keep its name, got %s", cluster.Spec.HumanReadableName)
+			}
+
+			secret := &corev1.Secret{}
+			if err := clients.FakeClient.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: cluster.GetKubeconfigSecretName(), Namespace: resources.KubermaticNamespace}, secret); err != nil {
+				t.Fatal(err)
+			}
+			if string(secret.Data[resources.ExternalClusterKubeconfig]) != kubeconfig {
+				t.Fatal("expected the kubeconfig secret to contain the new kubeconfig")
+			}
+		})
+	}
+}
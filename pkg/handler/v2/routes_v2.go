@@ -28,6 +28,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/cluster"
+	clustertemplate "k8c.io/kubermatic/v2/pkg/handler/v2/cluster_template"
 	externalcluster "k8c.io/kubermatic/v2/pkg/handler/v2/external_cluster"
 )
 
@@ -80,6 +81,10 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/kubernetes/clusters/{cluster_id}").
 		Handler(r.updateExternalCluster())
 
+	mux.Methods(http.MethodPut).
+		Path("/projects/{project_id}/kubernetes/clusters/{cluster_id}/kubeconfig").
+		Handler(r.rotateExternalClusterKubeconfig())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/kubernetes/clusters/{cluster_id}/nodes").
 		Handler(r.listExternalClusterNodes())
@@ -87,6 +92,31 @@ func (r Routing) RegisterV2(mux *mux.Router, metrics common.ServerMetrics) {
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/kubernetes/clusters/{cluster_id}/nodes/{node_id}").
 		Handler(r.getExternalClusterNode())
+
+	// Defines a set of HTTP endpoints for cluster templates that are available in a project.
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clustertemplates").
+		Handler(r.createClusterTemplate())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clustertemplates").
+		Handler(r.listClusterTemplates())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clustertemplates/{template_id}").
+		Handler(r.getClusterTemplate())
+
+	mux.Methods(http.MethodPut).
+		Path("/projects/{project_id}/clustertemplates/{template_id}").
+		Handler(r.updateClusterTemplate())
+
+	mux.Methods(http.MethodDelete).
+		Path("/projects/{project_id}/clustertemplates/{template_id}").
+		Handler(r.deleteClusterTemplate())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clustertemplates/{template_id}/instances").
+		Handler(r.createClusterTemplateInstances(metrics.InitNodeDeploymentFailures))
 }
 
 // swagger:route POST /api/v2/projects/{project_id}/clusters project createClusterV2
@@ -214,7 +244,7 @@ func (r Routing) patchCluster() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(cluster.PatchEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter)),
+		)(cluster.PatchEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.admissionPluginProvider)),
 		cluster.DecodePatchReq,
 		handler.EncodeJSON,
 		r.defaultServerOptions()...,
@@ -241,7 +271,7 @@ func (r Routing) createExternalCluster() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(externalcluster.CreateEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.externalClusterProvider, r.privilegedExternalClusterProvider)),
+		)(externalcluster.CreateEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.externalClusterProvider, r.privilegedExternalClusterProvider, r.presetsProvider)),
 		externalcluster.DecodeCreateReq,
 		handler.SetStatusCreatedHeader(handler.EncodeJSON),
 		r.defaultServerOptions()...,
@@ -347,6 +377,33 @@ func (r Routing) updateExternalCluster() http.Handler {
 	)
 }
 
+// swagger:route PUT /api/v2/projects/{project_id}/kubernetes/clusters/{cluster_id}/kubeconfig project rotateExternalClusterKubeconfig
+//
+//     Replaces the kubeconfig of an external cluster. The cluster must be reachable with the new kubeconfig.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Cluster
+//       401: empty
+//       403: empty
+func (r Routing) rotateExternalClusterKubeconfig() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(externalcluster.RotateKubeconfigEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.externalClusterProvider, r.privilegedExternalClusterProvider)),
+		externalcluster.DecodeRotateKubeconfigReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v2/projects/{project_id}/kubernetes/clusters/{cluster_id}/nodes project listExternalClusterNodes
 //
 //     Gets an external cluster nodes.
@@ -421,3 +478,156 @@ func (r Routing) getExternalClusterMetrics() http.Handler {
 		r.defaultServerOptions()...,
 	)
 }
+
+// swagger:route POST /api/v2/projects/{project_id}/clustertemplates project createClusterTemplate
+//
+//     Creates a cluster template. Project and user templates belong to the given project.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ClusterTemplate
+//       401: empty
+//       403: empty
+func (r Routing) createClusterTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.CreateEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.clusterTemplateProvider, r.settingsProvider, r.updateManager)),
+		clustertemplate.DecodeCreateReq,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/clustertemplates project listClusterTemplates
+//
+//     Lists the global cluster templates and the templates of the project which are visible to the user.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterTemplateList
+//       401: empty
+//       403: empty
+func (r Routing) listClusterTemplates() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.ListEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.clusterTemplateProvider)),
+		common.DecodeGetProject,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v2/projects/{project_id}/clustertemplates/{template_id} project getClusterTemplate
+//
+//     Gets the cluster template.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterTemplate
+//       401: empty
+//       403: empty
+func (r Routing) getClusterTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.GetEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.clusterTemplateProvider)),
+		clustertemplate.DecodeGetReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PUT /api/v2/projects/{project_id}/clustertemplates/{template_id} project updateClusterTemplate
+//
+//     Updates the cluster template. The scope of a template can not be changed.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterTemplate
+//       401: empty
+//       403: empty
+func (r Routing) updateClusterTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.UpdateEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.clusterTemplateProvider, r.settingsProvider, r.updateManager)),
+		clustertemplate.DecodeUpdateReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v2/projects/{project_id}/clustertemplates/{template_id} project deleteClusterTemplate
+//
+//     Deletes the cluster template.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteClusterTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.DeleteEndpoint(r.userInfoGetter, r.projectProvider, r.privilegedProjectProvider, r.clusterTemplateProvider)),
+		clustertemplate.DecodeGetReq,
+		handler.EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v2/projects/{project_id}/clustertemplates/{template_id}/instances project createClusterTemplateInstances
+//
+//     Creates clusters from the cluster template. If one of them can not be created, the ones created before are deleted.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ClusterList
+//       401: empty
+//       403: empty
+func (r Routing) createClusterTemplateInstances(initNodeDeploymentFailures *prometheus.CounterVec) http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.CreateInstancesEndpoint(r.sshKeyProvider, r.privilegedSSHKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.clusterProviderGetter, initNodeDeploymentFailures, r.eventRecorderProvider, r.presetsProvider, r.exposeStrategy, r.userInfoGetter, r.clusterTemplateProvider)),
+		clustertemplate.DecodeCreateInstancesReq,
+		handler.SetStatusCreatedHeader(handler.EncodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
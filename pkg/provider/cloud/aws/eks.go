@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+const (
+	// eksTokenPrefix is the prefix of bearer tokens accepted by the EKS authenticator
+	eksTokenPrefix = "k8s-aws-v1."
+	// eksClusterIDHeader is the header which binds the token to a cluster
+	eksClusterIDHeader = "x-k8s-aws-id"
+	// eksTokenPresignDuration is the validity of the presigned URL, the EKS authenticator
+	// does not accept tokens older than 15 minutes regardless of this value
+	eksTokenPresignDuration = 15 * time.Minute
+	// eksTokenLifetime is the time after which a new token has to be generated
+	eksTokenLifetime = eksTokenPresignDuration - time.Minute
+)
+
+// EKSClientSet holds the clients required to generate the kubeconfig of an EKS cluster
+type EKSClientSet struct {
+	EKS eksiface.EKSAPI
+	STS stsiface.STSAPI
+}
+
+// GetEKSClientSet returns the clients to access the EKS clusters of the given region
+func GetEKSClientSet(accessKeyID, secretAccessKey, region string) (*EKSClientSet, error) {
+	config := aws.NewConfig()
+	config = config.WithRegion(region)
+	config = config.WithCredentials(credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""))
+	config = config.WithMaxRetries(3)
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API session: %v", err)
+	}
+
+	return &EKSClientSet{
+		EKS: eks.New(sess),
+		STS: sts.New(sess),
+	}, nil
+}
+
+// GetEKSKubeconfig generates a kubeconfig for the given EKS cluster. The kubeconfig authenticates
+// with a token derived from the credentials of the client set, the token has to be refreshed
+// after the returned time.
+func GetEKSKubeconfig(client *EKSClientSet, clusterName string) (*clientcmdapi.Config, time.Time, error) {
+	output, err := client.EKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get cluster %q: %v", clusterName, err)
+	}
+	cluster := output.Cluster
+	if cluster == nil || cluster.Endpoint == nil || cluster.CertificateAuthority == nil || cluster.CertificateAuthority.Data == nil {
+		return nil, time.Time{}, errors.New("cluster endpoint or certificate authority is not available yet")
+	}
+
+	caData, err := base64.StdEncoding.DecodeString(aws.StringValue(cluster.CertificateAuthority.Data))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode certificate authority: %v", err)
+	}
+	caCerts, err := certutil.ParseCertsPEM(caData)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse certificate authority: %v", err)
+	}
+
+	token, expiry, err := getEKSToken(client.STS, clusterName)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	config := resources.GetBaseKubeconfig(caCerts[0], aws.StringValue(cluster.Endpoint), clusterName)
+	config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
+		resources.KubeconfigDefaultContextKey: {
+			Token: token,
+		},
+	}
+	return config, expiry, nil
+}
+
+// getEKSToken creates a token the same way as the aws-iam-authenticator does: the token is a
+// presigned sts:GetCallerIdentity request which is bound to the cluster by a signed header.
+func getEKSToken(client stsiface.STSAPI, clusterName string) (string, time.Time, error) {
+	req, _ := client.GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	req.HTTPRequest.Header.Add(eksClusterIDHeader, clusterName)
+
+	expiry := time.Now().Add(eksTokenLifetime)
+	presignedURL, err := req.Presign(eksTokenPresignDuration)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to presign token request: %v", err)
+	}
+
+	return eksTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presignedURL)), expiry, nil
+}
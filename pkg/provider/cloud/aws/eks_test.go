@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+)
+
+type fakeEKS struct {
+	eksiface.EKSAPI
+	cluster *eks.Cluster
+}
+
+func (f *fakeEKS) DescribeCluster(input *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error) {
+	return &eks.DescribeClusterOutput{Cluster: f.cluster}, nil
+}
+
+func TestGetEKSKubeconfig(t *testing.T) {
+	ca, err := triple.NewCA("eks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess, err := session.NewSession(aws.NewConfig().
+		WithRegion("eu-central-1").
+		WithCredentials(credentials.NewStaticCredentials("key", "secret", "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name          string
+		cluster       *eks.Cluster
+		expectedError bool
+	}{
+		{
+			name: "scenario 1: generate the kubeconfig of an active cluster",
+			cluster: &eks.Cluster{
+				Name:     aws.String("my-cluster"),
+				Endpoint: aws.String("https://my-cluster.eks.amazonaws.com"),
+				CertificateAuthority: &eks.Certificate{
+					Data: aws.String(base64.StdEncoding.EncodeToString(triple.EncodeCertPEM(ca.Cert))),
+				},
+			},
+		},
+		{
+			name: "scenario 2: the endpoint of a cluster which is still creating is not available",
+			cluster: &eks.Cluster{
+				Name: aws.String("my-cluster"),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &EKSClientSet{
+				EKS: &fakeEKS{cluster: tc.cluster},
+				STS: sts.New(sess),
+			}
+
+			config, expiry, err := GetEKSKubeconfig(client, "my-cluster")
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if server := config.Clusters["my-cluster"].Server; server != "https://my-cluster.eks.amazonaws.com" {
+				t.Fatalf("expected server https://my-cluster.eks.amazonaws.com, got %s", server)
+			}
+			if !expiry.After(time.Now()) || expiry.After(time.Now().Add(eksTokenPresignDuration)) {
+				t.Fatalf("unexpected token expiry %v", expiry)
+			}
+
+			token := config.AuthInfos[resources.KubeconfigDefaultContextKey].Token
+			if !strings.HasPrefix(token, eksTokenPrefix) {
+				t.Fatalf("expected token with prefix %s, got %s", eksTokenPrefix, token)
+			}
+			rawURL, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, eksTokenPrefix))
+			if err != nil {
+				t.Fatal(err)
+			}
+			presignedURL, err := url.Parse(string(rawURL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if action := presignedURL.Query().Get("Action"); action != "GetCallerIdentity" {
+				t.Fatalf("expected action GetCallerIdentity, got %s", action)
+			}
+			if !strings.Contains(presignedURL.Query().Get("X-Amz-SignedHeaders"), eksClusterIDHeader) {
+				t.Fatalf("expected the %s header to be signed", eksClusterIDHeader)
+			}
+		})
+	}
+}
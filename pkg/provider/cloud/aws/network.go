@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+const defaultRouteDestination = "0.0.0.0/0"
+
+// validateSubnets checks that all subnets of the availability zone mapping exist in the given
+// vpc and belong to the availability zone they are mapped to.
+func validateSubnets(client ec2iface.EC2API, vpcID string, subnetIDs map[string]string) error {
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(subnetIDs))
+	for _, id := range subnetIDs {
+		ids = append(ids, id)
+	}
+	out, err := client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}},
+			{Name: aws.String("subnet-id"), Values: aws.StringSlice(ids)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list subnets: %v", err)
+	}
+
+	subnets := map[string]*ec2.Subnet{}
+	for _, subnet := range out.Subnets {
+		subnets[aws.StringValue(subnet.SubnetId)] = subnet
+	}
+	for zone, id := range subnetIDs {
+		subnet, ok := subnets[id]
+		if !ok {
+			return fmt.Errorf("unable to find subnet %q in vpc %q", id, vpcID)
+		}
+		if az := aws.StringValue(subnet.AvailabilityZone); az != zone {
+			return fmt.Errorf("subnet %q is in availability zone %q, not in %q", id, az, zone)
+		}
+	}
+	return nil
+}
+
+// ensureNATRoutes checks that the default routes of the nodes go through a NAT gateway or
+// a NAT instance. Nodes without public IPs can't reach the internet otherwise. If subnets are
+// mapped to the availability zones, the route table of each subnet is checked, otherwise the
+// route table of the cluster.
+func ensureNATRoutes(client ec2iface.EC2API, vpcID, routeTableID string, subnetIDs map[string]string) error {
+	if len(subnetIDs) == 0 {
+		out, err := client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+			RouteTableIds: []*string{aws.String(routeTableID)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get route table %q: %v", routeTableID, err)
+		}
+		if len(out.RouteTables) != 1 {
+			return fmt.Errorf("unable to find route table %q", routeTableID)
+		}
+		return checkNATRoute(out.RouteTables[0])
+	}
+
+	zones := make([]string, 0, len(subnetIDs))
+	for zone := range subnetIDs {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		routeTable, err := getSubnetRouteTable(client, vpcID, subnetIDs[zone])
+		if err != nil {
+			return err
+		}
+		if err := checkNATRoute(routeTable); err != nil {
+			return fmt.Errorf("subnet %q: %v", subnetIDs[zone], err)
+		}
+	}
+	return nil
+}
+
+// getSubnetRouteTable returns the route table which is explicitly associated with the given
+// subnet or the main route table of the vpc, which is used by all other subnets.
+func getSubnetRouteTable(client ec2iface.EC2API, vpcID, subnetID string) (*ec2.RouteTable, error) {
+	out, err := client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("association.subnet-id"), Values: []*string{aws.String(subnetID)}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get route table of subnet %q: %v", subnetID, err)
+	}
+	if len(out.RouteTables) > 0 {
+		return out.RouteTables[0], nil
+	}
+
+	routeTable, err := getRouteTable(vpcID, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get main route table of subnet %q: %v", subnetID, err)
+	}
+	return routeTable, nil
+}
+
+// checkNATRoute checks that the default route of the given route table goes through a NAT
+// gateway or a NAT instance.
+func checkNATRoute(routeTable *ec2.RouteTable) error {
+	routeTableID := aws.StringValue(routeTable.RouteTableId)
+	for _, route := range routeTable.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) != defaultRouteDestination {
+			continue
+		}
+		if aws.StringValue(route.State) == ec2.RouteStateBlackhole {
+			return fmt.Errorf("the default route of route table %q is a blackhole", routeTableID)
+		}
+		if route.NatGatewayId != nil || route.InstanceId != nil {
+			return nil
+		}
+		return fmt.Errorf("the default route of route table %q does not go through a NAT gateway", routeTableID)
+	}
+	return fmt.Errorf("route table %q has no default route through a NAT gateway", routeTableID)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type fakeNetworkClient struct {
+	ec2iface.EC2API
+	subnets     []*ec2.Subnet
+	routeTables []*ec2.RouteTable
+}
+
+func (c *fakeNetworkClient) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	vpcIDs, subnetIDs := sets.NewString(), sets.NewString()
+	for _, filter := range input.Filters {
+		switch aws.StringValue(filter.Name) {
+		case "vpc-id":
+			vpcIDs.Insert(aws.StringValueSlice(filter.Values)...)
+		case "subnet-id":
+			subnetIDs.Insert(aws.StringValueSlice(filter.Values)...)
+		}
+	}
+
+	out := &ec2.DescribeSubnetsOutput{}
+	for _, subnet := range c.subnets {
+		if vpcIDs.Has(aws.StringValue(subnet.VpcId)) && subnetIDs.Has(aws.StringValue(subnet.SubnetId)) {
+			out.Subnets = append(out.Subnets, subnet)
+		}
+	}
+	return out, nil
+}
+
+func (c *fakeNetworkClient) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	ids := sets.NewString(aws.StringValueSlice(input.RouteTableIds)...)
+
+	out := &ec2.DescribeRouteTablesOutput{}
+	for _, routeTable := range c.routeTables {
+		if ids.Len() > 0 && !ids.Has(aws.StringValue(routeTable.RouteTableId)) {
+			continue
+		}
+		if matchesRouteTableFilters(routeTable, input.Filters) {
+			out.RouteTables = append(out.RouteTables, routeTable)
+		}
+	}
+	return out, nil
+}
+
+func matchesRouteTableFilters(routeTable *ec2.RouteTable, filters []*ec2.Filter) bool {
+	for _, filter := range filters {
+		values := sets.NewString(aws.StringValueSlice(filter.Values)...)
+		matches := false
+		switch aws.StringValue(filter.Name) {
+		case "vpc-id":
+			matches = values.Has(aws.StringValue(routeTable.VpcId))
+		case "association.subnet-id":
+			for _, association := range routeTable.Associations {
+				matches = matches || values.Has(aws.StringValue(association.SubnetId))
+			}
+		case "association.main":
+			for _, association := range routeTable.Associations {
+				matches = matches || values.Has(fmt.Sprint(aws.BoolValue(association.Main)))
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
+func TestValidateSubnets(t *testing.T) {
+	client := &fakeNetworkClient{
+		subnets: []*ec2.Subnet{
+			{SubnetId: aws.String("subnet-a"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("eu-central-1a")},
+			{SubnetId: aws.String("subnet-b"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("eu-central-1b")},
+			{SubnetId: aws.String("subnet-other"), VpcId: aws.String("vpc-2"), AvailabilityZone: aws.String("eu-central-1a")},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		subnetIDs map[string]string
+		wantErr   bool
+	}{
+		{
+			name: "no subnets",
+		},
+		{
+			name:      "subnets in their availability zones",
+			subnetIDs: map[string]string{"eu-central-1a": "subnet-a", "eu-central-1b": "subnet-b"},
+		},
+		{
+			name:      "subnet mapped to the wrong availability zone",
+			subnetIDs: map[string]string{"eu-central-1a": "subnet-b"},
+			wantErr:   true,
+		},
+		{
+			name:      "subnet of another vpc",
+			subnetIDs: map[string]string{"eu-central-1a": "subnet-other"},
+			wantErr:   true,
+		},
+		{
+			name:      "missing subnet",
+			subnetIDs: map[string]string{"eu-central-1c": "subnet-c"},
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSubnets(client, "vpc-1", test.subnetIDs)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEnsureNATRoutes(t *testing.T) {
+	natRoutes := []*ec2.Route{
+		{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+		{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), State: aws.String(ec2.RouteStateActive)},
+	}
+	internetRoutes := []*ec2.Route{
+		{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1"), State: aws.String(ec2.RouteStateActive)},
+	}
+
+	tests := []struct {
+		name        string
+		routes      []*ec2.Route
+		subnetIDs   map[string]string
+		routeTables []*ec2.RouteTable
+		wantErr     bool
+	}{
+		{
+			name:   "default route through a NAT gateway",
+			routes: natRoutes,
+		},
+		{
+			name: "default route through a NAT instance",
+			routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), InstanceId: aws.String("i-1"), State: aws.String(ec2.RouteStateActive)},
+			},
+		},
+		{
+			name:    "default route through an internet gateway",
+			routes:  internetRoutes,
+			wantErr: true,
+		},
+		{
+			name: "default route to a deleted NAT gateway",
+			routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), State: aws.String(ec2.RouteStateBlackhole)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no default route",
+			routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "subnets with their own NAT route tables",
+			routes:    internetRoutes,
+			subnetIDs: map[string]string{"eu-central-1a": "subnet-a", "eu-central-1b": "subnet-b"},
+			routeTables: []*ec2.RouteTable{
+				{RouteTableId: aws.String("rtb-a"), VpcId: aws.String("vpc-1"), Routes: natRoutes, Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-a")}}},
+				{RouteTableId: aws.String("rtb-b"), VpcId: aws.String("vpc-1"), Routes: natRoutes, Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-b")}}},
+			},
+		},
+		{
+			name:      "subnet with a public route table",
+			routes:    natRoutes,
+			subnetIDs: map[string]string{"eu-central-1a": "subnet-a", "eu-central-1b": "subnet-b"},
+			routeTables: []*ec2.RouteTable{
+				{RouteTableId: aws.String("rtb-a"), VpcId: aws.String("vpc-1"), Routes: natRoutes, Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-a")}}},
+				{RouteTableId: aws.String("rtb-b"), VpcId: aws.String("vpc-1"), Routes: internetRoutes, Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-b")}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "subnet without a route table uses the main route table",
+			routes:    internetRoutes,
+			subnetIDs: map[string]string{"eu-central-1a": "subnet-a"},
+			routeTables: []*ec2.RouteTable{
+				{RouteTableId: aws.String("rtb-main"), VpcId: aws.String("vpc-1"), Routes: natRoutes, Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}}},
+			},
+		},
+		{
+			name:      "subnet without a route table and a public main route table",
+			routes:    natRoutes,
+			subnetIDs: map[string]string{"eu-central-1a": "subnet-a"},
+			routeTables: []*ec2.RouteTable{
+				{RouteTableId: aws.String("rtb-main"), VpcId: aws.String("vpc-1"), Routes: internetRoutes, Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakeNetworkClient{
+				routeTables: append([]*ec2.RouteTable{{RouteTableId: aws.String("rtb-1"), VpcId: aws.String("vpc-1"), Routes: test.routes}}, test.routeTables...),
+			}
+			err := ensureNATRoutes(client, "vpc-1", "rtb-1", test.subnetIDs)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+
+	if err := ensureNATRoutes(&fakeNetworkClient{}, "vpc-1", "rtb-1", nil); err == nil {
+		t.Error("expected a missing route table to be rejected")
+	}
+}
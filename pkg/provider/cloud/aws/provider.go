@@ -70,6 +70,9 @@ func (a *AmazonEC2) ValidateCloudSpec(spec kubermaticv1.CloudSpec) error {
 		if spec.AWS.SecurityGroupID != "" {
 			return fmt.Errorf("vpc must be set when specifying a security group")
 		}
+		if len(spec.AWS.SubnetIDs) > 0 {
+			return fmt.Errorf("vpc must be set when specifying subnets")
+		}
 	}
 
 	if spec.AWS.VPCID != "" {
@@ -83,6 +86,10 @@ func (a *AmazonEC2) ValidateCloudSpec(spec kubermaticv1.CloudSpec) error {
 				return err
 			}
 		}
+
+		if err := validateSubnets(client.EC2, spec.AWS.VPCID, spec.AWS.SubnetIDs); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -509,6 +516,12 @@ func (a *AmazonEC2) InitializeCloudProvider(cluster *kubermaticv1.Cluster, updat
 		}
 	}
 
+	if cluster.Spec.Cloud.AWS.PrivateNodes {
+		if err := ensureNATRoutes(client.EC2, cluster.Spec.Cloud.AWS.VPCID, cluster.Spec.Cloud.AWS.RouteTableID, cluster.Spec.Cloud.AWS.SubnetIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	if !kuberneteshelper.HasFinalizer(cluster, tagCleanupFinalizer) {
 		if err := tagResources(cluster, client.EC2); err != nil {
 			return nil, err
@@ -673,6 +686,10 @@ func isNotFound(err error) bool {
 
 // ValidateCloudSpecUpdate verifies whether an update of cloud spec is valid and permitted
 func (a *AmazonEC2) ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error {
+	// Existing nodes would keep or lack their public IPs.
+	if oldSpec.AWS.PrivateNodes != newSpec.AWS.PrivateNodes {
+		return errors.New("changing private nodes is not allowed")
+	}
 	return nil
 }
 
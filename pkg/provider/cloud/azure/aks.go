@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-06-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// aksCredentialsLifetime is the time after which the kubeconfig of an AKS cluster is fetched again.
+// The credentials returned by AKS do not expire but can be rotated by the cluster owner.
+const aksCredentialsLifetime = 24 * time.Hour
+
+// AKSCredentialsLister lists the credentials of AKS clusters
+type AKSCredentialsLister interface {
+	ListClusterUserCredentials(ctx context.Context, resourceGroupName string, resourceName string) (containerservice.CredentialResults, error)
+}
+
+// GetAKSCredentialsLister returns a client to list the credentials of the AKS clusters of the subscription
+func GetAKSCredentialsLister(credentials Credentials) (AKSCredentialsLister, error) {
+	var err error
+	managedClustersClient := containerservice.NewManagedClustersClient(credentials.SubscriptionID)
+	managedClustersClient.Authorizer, err = auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).Authorizer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %s", err.Error())
+	}
+
+	return &managedClustersClient, nil
+}
+
+// GetAKSKubeconfig fetches the kubeconfig of the given AKS cluster, the kubeconfig has to be
+// fetched again after the returned time.
+func GetAKSKubeconfig(ctx context.Context, client AKSCredentialsLister, resourceGroup, clusterName string) (*clientcmdapi.Config, time.Time, error) {
+	credentials, err := client.ListClusterUserCredentials(ctx, resourceGroup, clusterName)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get credentials of cluster %q: %v", clusterName, err)
+	}
+	if credentials.Kubeconfigs == nil || len(*credentials.Kubeconfigs) == 0 || (*credentials.Kubeconfigs)[0].Value == nil {
+		return nil, time.Time{}, errors.New("no kubeconfig returned for the cluster")
+	}
+
+	config, err := clientcmd.Load(*(*credentials.Kubeconfigs)[0].Value)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse kubeconfig: %v", err)
+	}
+	return config, time.Now().Add(aksCredentialsLifetime), nil
+}
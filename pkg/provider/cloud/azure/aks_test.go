@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2019-06-01/containerservice"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://my-cluster.hcp.westeurope.azmk8s.io:443
+  name: my-cluster
+contexts:
+- context:
+    cluster: my-cluster
+    user: clusterUser_my-group_my-cluster
+  name: my-cluster
+current-context: my-cluster
+users:
+- name: clusterUser_my-group_my-cluster
+  user:
+    token: token
+`
+
+type fakeAKSCredentialsLister struct {
+	kubeconfigs []containerservice.CredentialResult
+}
+
+func (f *fakeAKSCredentialsLister) ListClusterUserCredentials(ctx context.Context, resourceGroupName string, resourceName string) (containerservice.CredentialResults, error) {
+	return containerservice.CredentialResults{Kubeconfigs: &f.kubeconfigs}, nil
+}
+
+func TestGetAKSKubeconfig(t *testing.T) {
+	kubeconfig := []byte(testKubeconfig)
+
+	testcases := []struct {
+		name          string
+		kubeconfigs   []containerservice.CredentialResult
+		expectedError bool
+	}{
+		{
+			name:        "scenario 1: get the kubeconfig of the cluster",
+			kubeconfigs: []containerservice.CredentialResult{{Value: &kubeconfig}},
+		},
+		{
+			name:          "scenario 2: no kubeconfig is returned",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, expiry, err := GetAKSKubeconfig(context.Background(), &fakeAKSCredentialsLister{kubeconfigs: tc.kubeconfigs}, "my-group", "my-cluster")
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if server := config.Clusters["my-cluster"].Server; server != "https://my-cluster.hcp.westeurope.azmk8s.io:443" {
+				t.Fatalf("unexpected server %s", server)
+			}
+			if !expiry.After(time.Now()) {
+				t.Fatalf("expected expiry in the future, got %v", expiry)
+			}
+		})
+	}
+}
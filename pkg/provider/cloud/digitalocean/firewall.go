@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const nodePortRange = "30000-32767"
+
+var everywhere = []string{"0.0.0.0/0", "::/0"}
+
+func resourceName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("kubernetes-%s", cluster.Name)
+}
+
+// clusterTag is the tag machine-controller puts on all droplets of the cluster.
+func clusterTag(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("kubernetes-cluster-%s", cluster.Name)
+}
+
+// firewallRequest returns the firewall for the droplets of the cluster. It allows
+// all traffic between the droplets, but only SSH, ICMP and node ports from outside.
+func firewallRequest(cluster *kubermaticv1.Cluster) *godo.FirewallRequest {
+	tag := clusterTag(cluster)
+
+	return &godo.FirewallRequest{
+		Name: resourceName(cluster),
+		Tags: []string{tag},
+		InboundRules: []godo.InboundRule{
+			{Protocol: "tcp", PortRange: "all", Sources: &godo.Sources{Tags: []string{tag}}},
+			{Protocol: "udp", PortRange: "all", Sources: &godo.Sources{Tags: []string{tag}}},
+			{Protocol: "icmp", Sources: &godo.Sources{Tags: []string{tag}}},
+			{Protocol: "tcp", PortRange: strconv.Itoa(provider.DefaultSSHPort), Sources: &godo.Sources{Addresses: everywhere}},
+			{Protocol: "icmp", Sources: &godo.Sources{Addresses: everywhere}},
+			{Protocol: "tcp", PortRange: nodePortRange, Sources: &godo.Sources{Addresses: everywhere}},
+			{Protocol: "udp", PortRange: nodePortRange, Sources: &godo.Sources{Addresses: everywhere}},
+		},
+		OutboundRules: []godo.OutboundRule{
+			{Protocol: "tcp", PortRange: "all", Destinations: &godo.Destinations{Addresses: everywhere}},
+			{Protocol: "udp", PortRange: "all", Destinations: &godo.Destinations{Addresses: everywhere}},
+			{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: everywhere}},
+		},
+	}
+}
+
+// ownsFirewall returns whether the firewall was created for the cluster. Firewalls
+// have no labels, so it is recognized by applying only to the tag of the cluster.
+func ownsFirewall(firewall *godo.Firewall, cluster *kubermaticv1.Cluster) bool {
+	return len(firewall.Tags) == 1 && firewall.Tags[0] == clusterTag(cluster)
+}
+
+// ensureFirewall creates the firewall of the cluster, unless it was already
+// created by a previous, interrupted run.
+func ensureFirewall(ctx context.Context, client *apiClient, cluster *kubermaticv1.Cluster) (*godo.Firewall, error) {
+	request := firewallRequest(cluster)
+	firewall, err := getFirewallByName(ctx, client.Firewalls, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if firewall != nil {
+		if !ownsFirewall(firewall, cluster) {
+			return nil, fmt.Errorf("firewall %q already exists, but does not belong to the cluster", request.Name)
+		}
+		return firewall, nil
+	}
+
+	// Firewalls can only reference existing tags.
+	if err := ensureTag(ctx, client.Tags, clusterTag(cluster)); err != nil {
+		return nil, err
+	}
+
+	firewall, _, err = client.Firewalls.Create(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firewall %q: %v", request.Name, err)
+	}
+	return firewall, nil
+}
+
+// deleteFirewall deletes the firewall of the cluster. Firewalls that are already
+// gone or do not belong to the cluster are ignored.
+func deleteFirewall(ctx context.Context, firewalls godo.FirewallsService, cluster *kubermaticv1.Cluster) error {
+	name := resourceName(cluster)
+	firewall, err := getFirewallByName(ctx, firewalls, name)
+	if err != nil {
+		return err
+	}
+	if firewall == nil || !ownsFirewall(firewall, cluster) {
+		return nil
+	}
+
+	if _, err := firewalls.Delete(ctx, firewall.ID); err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete firewall %q: %v", name, err)
+	}
+	return nil
+}
+
+func getFirewallByName(ctx context.Context, firewalls godo.FirewallsService, name string) (*godo.Firewall, error) {
+	opts := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		list, resp, err := firewalls.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list firewalls: %v", err)
+		}
+		for i := range list {
+			if list[i].Name == name {
+				return &list[i], nil
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return nil, nil
+		}
+		opts.Page++
+	}
+}
+
+func ensureTag(ctx context.Context, tags godo.TagsService, name string) error {
+	_, _, err := tags.Get(ctx, name)
+	if err == nil {
+		return nil
+	}
+	if !isNotFound(err) {
+		return fmt.Errorf("failed to get tag %q: %v", name, err)
+	}
+
+	if _, _, err := tags.Create(ctx, &godo.TagCreateRequest{Name: name}); err != nil {
+		return fmt.Errorf("failed to create tag %q: %v", name, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	errResp, ok := err.(*godo.ErrorResponse)
+	return ok && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 
@@ -28,40 +29,103 @@ import (
 	"golang.org/x/oauth2"
 )
 
+const firewallCleanupFinalizer = "kubermatic.io/cleanup-digitalocean-firewall"
+
 type digitalocean struct {
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	// newClient is overridden in tests to use fakes of the API.
+	newClient func(token string) *apiClient
+}
+
+// apiClient holds the parts of the DigitalOcean API used by the provider.
+type apiClient struct {
+	Regions   godo.RegionsService
+	Firewalls godo.FirewallsService
+	Tags      godo.TagsService
+}
+
+func newAPIClient(token string) *apiClient {
+	static := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	client := godo.NewClient(oauth2.NewClient(context.Background(), static))
+	return &apiClient{
+		Regions:   client.Regions,
+		Firewalls: client.Firewalls,
+		Tags:      client.Tags,
+	}
 }
 
 // NewCloudProvider creates a new digitalocean provider.
 func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
 	return &digitalocean{
 		secretKeySelector: secretKeyGetter,
+		newClient:         newAPIClient,
 	}
 }
 
+func (do *digitalocean) getClient(cloud kubermaticv1.CloudSpec) (*apiClient, error) {
+	token, err := GetCredentialsForCluster(cloud, do.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+	return do.newClient(token), nil
+}
+
 func (do *digitalocean) DefaultCloudSpec(spec *kubermaticv1.CloudSpec) error {
 	return nil
 }
 
 func (do *digitalocean) ValidateCloudSpec(spec kubermaticv1.CloudSpec) error {
-	token, err := GetCredentialsForCluster(spec, do.secretKeySelector)
+	client, err := do.getClient(spec)
 	if err != nil {
 		return err
 	}
 
-	static := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	client := godo.NewClient(oauth2.NewClient(context.Background(), static))
+	ctx := context.Background()
+	if _, _, err = client.Regions.List(ctx, nil); err != nil {
+		return err
+	}
 
-	_, _, err = client.Regions.List(context.Background(), nil)
-	return err
+	return nil
 }
 
+// InitializeCloudProvider creates a firewall for the droplets of the cluster.
 func (do *digitalocean) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
-	return cluster, nil
+	if kuberneteshelper.HasFinalizer(cluster, firewallCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	client, err := do.getClient(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ensureFirewall(context.Background(), client, cluster); err != nil {
+		return nil, err
+	}
+
+	return update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.AddFinalizer(cluster, firewallCleanupFinalizer)
+	})
 }
 
-func (do *digitalocean) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
-	return cluster, nil
+// CleanUpCloudProvider deletes the firewall of the cluster.
+func (do *digitalocean) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, firewallCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	client, err := do.getClient(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deleteFirewall(context.Background(), client.Firewalls, cluster); err != nil {
+		return nil, err
+	}
+
+	return update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, firewallCleanupFinalizer)
+	})
 }
 
 // ValidateCloudSpecUpdate verifies whether an update of cloud spec is valid and permitted
@@ -0,0 +1,240 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	testhelper "k8c.io/kubermatic/v2/pkg/test"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testRegion = "fra1"
+
+var errNotFound = &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+// fakeFirewallsService keeps the firewalls in memory. Methods the provider does
+// not use panic through the embedded nil interface.
+type fakeFirewallsService struct {
+	godo.FirewallsService
+	firewalls []godo.Firewall
+}
+
+func (f *fakeFirewallsService) List(_ context.Context, _ *godo.ListOptions) ([]godo.Firewall, *godo.Response, error) {
+	return f.firewalls, &godo.Response{}, nil
+}
+
+func (f *fakeFirewallsService) Create(_ context.Context, req *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error) {
+	firewall := godo.Firewall{ID: fmt.Sprintf("fw-%d", len(f.firewalls)+1), Name: req.Name, Tags: req.Tags}
+	f.firewalls = append(f.firewalls, firewall)
+	return &firewall, nil, nil
+}
+
+func (f *fakeFirewallsService) Delete(_ context.Context, id string) (*godo.Response, error) {
+	for i := range f.firewalls {
+		if f.firewalls[i].ID == id {
+			f.firewalls = append(f.firewalls[:i], f.firewalls[i+1:]...)
+			return nil, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+type fakeTagsService struct {
+	godo.TagsService
+	tags map[string]bool
+}
+
+func (f *fakeTagsService) Get(_ context.Context, name string) (*godo.Tag, *godo.Response, error) {
+	if !f.tags[name] {
+		return nil, nil, errNotFound
+	}
+	return &godo.Tag{Name: name}, nil, nil
+}
+
+func (f *fakeTagsService) Create(_ context.Context, req *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error) {
+	f.tags[req.Name] = true
+	return &godo.Tag{Name: req.Name}, nil, nil
+}
+
+type fakeRegionsService struct {
+	godo.RegionsService
+}
+
+func (f *fakeRegionsService) List(_ context.Context, _ *godo.ListOptions) ([]godo.Region, *godo.Response, error) {
+	return []godo.Region{{Slug: testRegion}}, nil, nil
+}
+
+func newTestProvider(firewalls ...godo.Firewall) (*digitalocean, *apiClient) {
+	client := &apiClient{
+		Regions:   &fakeRegionsService{},
+		Firewalls: &fakeFirewallsService{firewalls: firewalls},
+		Tags:      &fakeTagsService{tags: map[string]bool{}},
+	}
+
+	return &digitalocean{
+		newClient: func(string) *apiClient { return client },
+	}, client
+}
+
+func newTestCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{
+					Token: "token",
+				},
+			},
+		},
+	}
+}
+
+func firewallNames(client *apiClient) []string {
+	var names []string
+	for _, firewall := range client.Firewalls.(*fakeFirewallsService).firewalls {
+		names = append(names, firewall.Name)
+	}
+	return names
+}
+
+func TestInitializeCloudProvider(t *testing.T) {
+	testCases := []struct {
+		name              string
+		existingFirewalls []godo.Firewall
+		expectErr         bool
+	}{
+		{
+			name: "firewall is created",
+		},
+		{
+			name: "firewall of a previous run is reused",
+			existingFirewalls: []godo.Firewall{
+				{ID: "fw-1", Name: "kubernetes-test-cluster", Tags: []string{"kubernetes-cluster-test-cluster"}},
+			},
+		},
+		{
+			name: "foreign firewall with the same name is not adopted",
+			existingFirewalls: []godo.Firewall{
+				{ID: "fw-1", Name: "kubernetes-test-cluster", Tags: []string{"production"}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			do, client := newTestProvider(tc.existingFirewalls...)
+			cluster := newTestCluster()
+			update := testhelper.NewClusterUpdater(cluster)
+
+			_, err := do.InitializeCloudProvider(cluster, update)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error = %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				if kuberneteshelper.HasFinalizer(cluster, firewallCleanupFinalizer) {
+					t.Errorf("expected cluster not to have the %q finalizer", firewallCleanupFinalizer)
+				}
+				return
+			}
+			// A second run must not create anything new.
+			if _, err := do.InitializeCloudProvider(cluster, update); err != nil {
+				t.Fatalf("failed to initialize cloud provider twice: %v", err)
+			}
+
+			if !kuberneteshelper.HasFinalizer(cluster, firewallCleanupFinalizer) {
+				t.Errorf("expected cluster to have the %q finalizer", firewallCleanupFinalizer)
+			}
+			if names := firewallNames(client); len(names) != 1 || names[0] != "kubernetes-test-cluster" {
+				t.Errorf("expected only firewall %q to exist, got %v", "kubernetes-test-cluster", names)
+			}
+		})
+	}
+}
+
+func TestFirewallOnlyAppliesToClusterDroplets(t *testing.T) {
+	request := firewallRequest(newTestCluster())
+
+	if len(request.Tags) != 1 || request.Tags[0] != "kubernetes-cluster-test-cluster" {
+		t.Errorf("expected firewall to apply to the cluster tag, got %v", request.Tags)
+	}
+	for _, rule := range request.InboundRules {
+		if len(rule.Sources.Tags) > 0 {
+			continue
+		}
+		if rule.Protocol != "icmp" && rule.PortRange != "22" && rule.PortRange != nodePortRange {
+			t.Errorf("expected only SSH, ICMP and node ports to be reachable from outside, got %s %s", rule.Protocol, rule.PortRange)
+		}
+	}
+}
+
+func TestCleanUpCloudProvider(t *testing.T) {
+	testCases := []struct {
+		name              string
+		existingFirewalls []godo.Firewall
+		expectedFirewalls []string
+	}{
+		{
+			name: "created firewall is deleted",
+			existingFirewalls: []godo.Firewall{
+				{ID: "fw-1", Name: "kubernetes-test-cluster", Tags: []string{"kubernetes-cluster-test-cluster"}},
+				{ID: "fw-2", Name: "kubernetes-other-cluster", Tags: []string{"kubernetes-cluster-other-cluster"}},
+			},
+			expectedFirewalls: []string{"kubernetes-other-cluster"},
+		},
+		{
+			name: "already deleted firewall is ignored",
+		},
+		{
+			name: "foreign firewall with the same name is kept",
+			existingFirewalls: []godo.Firewall{
+				{ID: "fw-1", Name: "kubernetes-test-cluster", Tags: []string{"production"}},
+			},
+			expectedFirewalls: []string{"kubernetes-test-cluster"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			do, client := newTestProvider(tc.existingFirewalls...)
+			cluster := newTestCluster()
+			kuberneteshelper.AddFinalizer(cluster, firewallCleanupFinalizer)
+
+			if _, err := do.CleanUpCloudProvider(cluster, testhelper.NewClusterUpdater(cluster)); err != nil {
+				t.Fatalf("failed to clean up: %v", err)
+			}
+
+			if len(cluster.Finalizers) != 0 {
+				t.Errorf("expected all finalizers to be removed, got %v", cluster.Finalizers)
+			}
+			if names := firewallNames(client); fmt.Sprint(names) != fmt.Sprint(tc.expectedFirewalls) {
+				t.Errorf("expected firewalls %v, got %v", tc.expectedFirewalls, names)
+			}
+		})
+	}
+}
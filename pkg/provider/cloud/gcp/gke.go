@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/option"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+// GKEClusterGetter gets a GKE cluster
+type GKEClusterGetter interface {
+	GetCluster(ctx context.Context, projectID, zone, name string) (*container.Cluster, error)
+}
+
+// GKEClient holds the clients required to generate the kubeconfig of a GKE cluster
+type GKEClient struct {
+	Clusters    GKEClusterGetter
+	TokenSource oauth2.TokenSource
+	ProjectID   string
+}
+
+type gkeClusterGetter struct {
+	svc *container.Service
+}
+
+func (g *gkeClusterGetter) GetCluster(ctx context.Context, projectID, zone, name string) (*container.Cluster, error) {
+	return g.svc.Projects.Zones.Clusters.Get(projectID, zone, name).Context(ctx).Do()
+}
+
+// ConnectToContainerService establishes a service connection to the Kubernetes Engine.
+func ConnectToContainerService(ctx context.Context, serviceAccount string) (*GKEClient, error) {
+	b, projectID, err := decodeServiceAccount(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := google.JWTConfigFromJSON(b, container.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := container.NewService(ctx, option.WithHTTPClient(conf.Client(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to Google Cloud: %v", err)
+	}
+	return &GKEClient{
+		Clusters:    &gkeClusterGetter{svc: svc},
+		TokenSource: conf.TokenSource(ctx),
+		ProjectID:   projectID,
+	}, nil
+}
+
+// GetGKEKubeconfig generates a kubeconfig for the given GKE cluster. The kubeconfig authenticates
+// with an access token of the service account, the token has to be refreshed after the returned time.
+func GetGKEKubeconfig(ctx context.Context, client *GKEClient, zone, clusterName string) (*clientcmdapi.Config, time.Time, error) {
+	cluster, err := client.Clusters.GetCluster(ctx, client.ProjectID, zone, clusterName)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get cluster %q: %v", clusterName, err)
+	}
+	if cluster.Endpoint == "" || cluster.MasterAuth == nil || cluster.MasterAuth.ClusterCaCertificate == "" {
+		return nil, time.Time{}, errors.New("cluster endpoint or certificate authority is not available yet")
+	}
+
+	caData, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode certificate authority: %v", err)
+	}
+	caCerts, err := certutil.ParseCertsPEM(caData)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse certificate authority: %v", err)
+	}
+
+	token, err := client.TokenSource.Token()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get access token: %v", err)
+	}
+
+	config := resources.GetBaseKubeconfig(caCerts[0], fmt.Sprintf("https://%s", cluster.Endpoint), clusterName)
+	config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
+		resources.KubeconfigDefaultContextKey: {
+			Token: token.AccessToken,
+		},
+	}
+	return config, token.Expiry, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/container/v1"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+)
+
+type fakeGKEClusterGetter struct {
+	cluster *container.Cluster
+}
+
+func (f *fakeGKEClusterGetter) GetCluster(ctx context.Context, projectID, zone, name string) (*container.Cluster, error) {
+	if f.cluster == nil || projectID != "my-project" || zone != "europe-west3-a" || name != f.cluster.Name {
+		return nil, errors.New("not found")
+	}
+	return f.cluster, nil
+}
+
+func TestGetGKEKubeconfig(t *testing.T) {
+	ca, err := triple.NewCA("gke")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiry := time.Now().Add(time.Hour)
+
+	testcases := []struct {
+		name          string
+		cluster       *container.Cluster
+		expectedError bool
+	}{
+		{
+			name: "scenario 1: generate the kubeconfig of a running cluster",
+			cluster: &container.Cluster{
+				Name:     "my-cluster",
+				Endpoint: "10.0.0.1",
+				MasterAuth: &container.MasterAuth{
+					ClusterCaCertificate: base64.StdEncoding.EncodeToString(triple.EncodeCertPEM(ca.Cert)),
+				},
+			},
+		},
+		{
+			name:          "scenario 2: the cluster does not exist",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &GKEClient{
+				Clusters:    &fakeGKEClusterGetter{cluster: tc.cluster},
+				TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token", Expiry: expiry}),
+				ProjectID:   "my-project",
+			}
+
+			config, tokenExpiry, err := GetGKEKubeconfig(context.Background(), client, "europe-west3-a", "my-cluster")
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if server := config.Clusters["my-cluster"].Server; server != "https://10.0.0.1" {
+				t.Fatalf("expected server https://10.0.0.1, got %s", server)
+			}
+			if token := config.AuthInfos[resources.KubeconfigDefaultContextKey].Token; token != "token" {
+				t.Fatalf("expected token token, got %s", token)
+			}
+			if !tokenExpiry.Equal(expiry) {
+				t.Fatalf("expected expiry %v, got %v", expiry, tokenExpiry)
+			}
+		})
+	}
+}
@@ -176,6 +176,25 @@ func (g *gcp) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update provide
 
 // ConnectToComputeService establishes a service connection to the Compute Engine.
 func ConnectToComputeService(serviceAccount string) (*compute.Service, string, error) {
+	b, projectID, err := decodeServiceAccount(serviceAccount)
+	if err != nil {
+		return nil, "", err
+	}
+	conf, err := google.JWTConfigFromJSON(b, compute.ComputeScope)
+	if err != nil {
+		return nil, "", err
+	}
+	ctx := context.Background()
+	client := conf.Client(ctx)
+	svc, err := compute.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot connect to Google Cloud: %v", err)
+	}
+	return svc, projectID, nil
+}
+
+// decodeServiceAccount decodes the base64 encoded service account and returns its project.
+func decodeServiceAccount(serviceAccount string) ([]byte, string, error) {
 	b, err := base64.StdEncoding.DecodeString(serviceAccount)
 	if err != nil {
 		return nil, "", fmt.Errorf("error decoding service account: %v", err)
@@ -190,17 +209,7 @@ func ConnectToComputeService(serviceAccount string) (*compute.Service, string, e
 	if projectID == "" {
 		return nil, "", errors.New("empty project_id")
 	}
-	conf, err := google.JWTConfigFromJSON(b, compute.ComputeScope)
-	if err != nil {
-		return nil, "", err
-	}
-	ctx := context.Background()
-	client := conf.Client(ctx)
-	svc, err := compute.NewService(ctx, option.WithHTTPClient(client))
-	if err != nil {
-		return nil, "", fmt.Errorf("cannot connect to Google Cloud: %v", err)
-	}
-	return svc, projectID, nil
+	return b, projectID, nil
 }
 
 func (g *gcp) ensureFirewallRules(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) error {
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+const (
+	// networkIPRange is the range of the networks created for clusters. The networks
+	// are validated against the pod and service CIDRs of the cluster.
+	networkIPRange = "192.168.0.0/16"
+	subnetIPRange  = "192.168.0.0/17"
+
+	clusterLabelKey = "kubernetes-cluster"
+)
+
+// networkClient is the part of the Hetzner API needed to manage private networks.
+// It is implemented by *hcloud.NetworkClient.
+type networkClient interface {
+	Get(ctx context.Context, idOrName string) (*hcloud.Network, *hcloud.Response, error)
+	Create(ctx context.Context, opts hcloud.NetworkCreateOpts) (*hcloud.Network, *hcloud.Response, error)
+	Delete(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error)
+}
+
+// locationClient is implemented by *hcloud.LocationClient.
+type locationClient interface {
+	Get(ctx context.Context, idOrName string) (*hcloud.Location, *hcloud.Response, error)
+}
+
+// datacenterClient is implemented by *hcloud.DatacenterClient.
+type datacenterClient interface {
+	Get(ctx context.Context, idOrName string) (*hcloud.Datacenter, *hcloud.Response, error)
+}
+
+func networkName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("kubernetes-%s", cluster.Name)
+}
+
+// networkZone returns the network zone of the datacenter the servers of the cluster are
+// created in. Servers can only be attached to subnets in the same zone.
+func networkZone(ctx context.Context, c *apiClient, dc *kubermaticv1.DatacenterSpecHetzner) (hcloud.NetworkZone, error) {
+	if dc.Datacenter != "" {
+		datacenter, _, err := c.Datacenter.Get(ctx, dc.Datacenter)
+		if err != nil {
+			return "", fmt.Errorf("failed to get datacenter %q: %v", dc.Datacenter, err)
+		}
+		if datacenter == nil || datacenter.Location == nil {
+			return "", fmt.Errorf("datacenter %q does not exist", dc.Datacenter)
+		}
+		return datacenter.Location.NetworkZone, nil
+	}
+
+	location, _, err := c.Location.Get(ctx, dc.Location)
+	if err != nil {
+		return "", fmt.Errorf("failed to get location %q: %v", dc.Location, err)
+	}
+	if location == nil {
+		return "", fmt.Errorf("location %q does not exist", dc.Location)
+	}
+	return location.NetworkZone, nil
+}
+
+// validateNetworkZone checks that the servers of the cluster can be attached to the network.
+func validateNetworkZone(network *hcloud.Network, zone hcloud.NetworkZone) error {
+	for _, subnet := range network.Subnets {
+		if subnet.Type == hcloud.NetworkSubnetTypeServer && subnet.NetworkZone == zone {
+			return nil
+		}
+	}
+	return fmt.Errorf("network %q has no server subnet in the network zone %q of the datacenter", network.Name, zone)
+}
+
+// validateNetwork checks that the servers of the cluster can be attached to the network
+// and that its range does not overlap with the pod and service CIDRs of the cluster, which
+// would make the routes of the network shadow the cluster network.
+func validateNetwork(network *hcloud.Network, zone hcloud.NetworkZone, cluster *kubermaticv1.Cluster) error {
+	if err := validateNetworkZone(network, zone); err != nil {
+		return err
+	}
+	if network.IPRange == nil {
+		return nil
+	}
+
+	clusterNetwork := cluster.Spec.ClusterNetwork
+	for _, cidr := range append(clusterNetwork.Pods.CIDRBlocks, clusterNetwork.Services.CIDRBlocks...) {
+		_, clusterRange, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid cluster network CIDR %q: %v", cidr, err)
+		}
+		if clusterRange.Contains(network.IPRange.IP) || network.IPRange.Contains(clusterRange.IP) {
+			return fmt.Errorf("range %s of network %q overlaps with the cluster network CIDR %s", network.IPRange, network.Name, cidr)
+		}
+	}
+	return nil
+}
+
+// ensureNetwork returns the private network of the cluster and creates it if it
+// does not exist yet. A network left behind by a previous, interrupted run is
+// reused, if it is labelled with the cluster name.
+func ensureNetwork(ctx context.Context, networks networkClient, cluster *kubermaticv1.Cluster, zone hcloud.NetworkZone) (*hcloud.Network, error) {
+	name := networkName(cluster)
+
+	network, _, err := networks.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network %q: %v", name, err)
+	}
+	if network != nil {
+		if network.Labels[clusterLabelKey] != cluster.Name {
+			return nil, fmt.Errorf("network %q already exists, but does not belong to the cluster", name)
+		}
+		return network, validateNetwork(network, zone, cluster)
+	}
+
+	_, networkRange, err := net.ParseCIDR(networkIPRange)
+	if err != nil {
+		return nil, err
+	}
+	_, subnetRange, err := net.ParseCIDR(subnetIPRange)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := hcloud.NetworkCreateOpts{
+		Name:    name,
+		IPRange: networkRange,
+		Subnets: []hcloud.NetworkSubnet{
+			{
+				Type:        hcloud.NetworkSubnetTypeServer,
+				IPRange:     subnetRange,
+				NetworkZone: zone,
+			},
+		},
+		Labels: map[string]string{
+			clusterLabelKey: cluster.Name,
+		},
+	}
+	// Nothing is created if the range would overlap with the cluster network
+	if err := validateNetwork(&hcloud.Network{Name: name, IPRange: opts.IPRange, Subnets: opts.Subnets}, zone, cluster); err != nil {
+		return nil, err
+	}
+
+	network, _, err = networks.Create(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network %q: %v", name, err)
+	}
+
+	return network, nil
+}
+
+// deleteNetwork deletes the network with the given name. Networks that are
+// already gone or do not belong to the cluster are ignored.
+func deleteNetwork(ctx context.Context, networks networkClient, cluster *kubermaticv1.Cluster, name string) error {
+	network, _, err := networks.Get(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get network %q: %v", name, err)
+	}
+	if network == nil || network.Labels[clusterLabelKey] != cluster.Name {
+		return nil
+	}
+
+	if _, err := networks.Delete(ctx, network); err != nil && !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+		return fmt.Errorf("failed to delete network %q: %v", name, err)
+	}
+	return nil
+}
@@ -19,22 +19,68 @@ package hetzner
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/hetznercloud/hcloud-go/hcloud"
+
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
+const (
+	networkCleanupFinalizer = "kubermatic.io/cleanup-hetzner-network"
+)
+
 type hetzner struct {
+	dc                *kubermaticv1.DatacenterSpecHetzner
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	// newClient is overridden in tests to use fakes of the API.
+	newClient func(token string) *apiClient
+}
+
+// apiClient holds the parts of the Hetzner API used by the provider.
+type apiClient struct {
+	Network    networkClient
+	Location   locationClient
+	Datacenter datacenterClient
+	ServerType serverTypeClient
+}
+
+// serverTypeClient is implemented by *hcloud.ServerTypeClient.
+type serverTypeClient interface {
+	List(ctx context.Context, opts hcloud.ServerTypeListOpts) ([]*hcloud.ServerType, *hcloud.Response, error)
+}
+
+func newAPIClient(token string) *apiClient {
+	client := hcloud.NewClient(hcloud.WithToken(token))
+	return &apiClient{
+		Network:    &client.Network,
+		Location:   &client.Location,
+		Datacenter: &client.Datacenter,
+		ServerType: &client.ServerType,
+	}
 }
 
 // NewCloudProvider creates a new hetzner provider.
-func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
+func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+	if dc.Spec.Hetzner == nil {
+		return nil, errors.New("datacenter is not a Hetzner datacenter")
+	}
 	return &hetzner{
+		dc:                dc.Spec.Hetzner,
 		secretKeySelector: secretKeyGetter,
+		newClient:         newAPIClient,
+	}, nil
+}
+
+func (h *hetzner) getClient(cloud kubermaticv1.CloudSpec) (*apiClient, error) {
+	hetznerToken, err := GetCredentialsForCluster(cloud, h.secretKeySelector)
+	if err != nil {
+		return nil, err
 	}
+	return h.newClient(hetznerToken), nil
 }
 
 // DefaultCloudSpec
@@ -44,28 +90,121 @@ func (h *hetzner) DefaultCloudSpec(spec *kubermaticv1.CloudSpec) error {
 
 // ValidateCloudSpec
 func (h *hetzner) ValidateCloudSpec(spec kubermaticv1.CloudSpec) error {
-	hetznerToken, err := GetCredentialsForCluster(spec, h.secretKeySelector)
+	client, err := h.getClient(spec)
 	if err != nil {
 		return err
 	}
 
-	client := hcloud.NewClient(hcloud.WithToken(hetznerToken))
-	_, _, err = client.ServerType.List(context.Background(), hcloud.ServerTypeListOpts{})
-	return err
+	ctx := context.Background()
+	if _, _, err = client.ServerType.List(ctx, hcloud.ServerTypeListOpts{}); err != nil {
+		return err
+	}
+
+	if spec.Hetzner.Network != "" {
+		network, _, err := client.Network.Get(ctx, spec.Hetzner.Network)
+		if err != nil {
+			return err
+		}
+		if network == nil {
+			return fmt.Errorf("network %q does not exist", spec.Hetzner.Network)
+		}
+		zone, err := networkZone(ctx, client, h.dc)
+		if err != nil {
+			return err
+		}
+		if err := validateNetworkZone(network, zone); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// InitializeCloudProvider
+// InitializeCloudProvider creates a private network for the cluster, unless an
+// existing network was configured. Both are validated against the network zone of
+// the datacenter and the cluster network.
+//
+// No firewall is created. Hetzner Cloud firewalls only filter the public interfaces
+// and cannot allow the traffic of the other servers of the cluster, which reach each
+// other via their public IPs unless the CNI is bound to the private network. The
+// servers therefore stay reachable on their public IPs.
 func (h *hetzner) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
-	return cluster, nil
+	ctx := context.Background()
+
+	client, err := h.getClient(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+	zone, err := networkZone(ctx, client, h.dc)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := cluster.Spec.Cloud.Hetzner.Network; name != "" {
+		network, _, err := client.Network.Get(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network %q: %v", name, err)
+		}
+		if network == nil {
+			return nil, fmt.Errorf("network %q does not exist", name)
+		}
+		if err := validateNetwork(network, zone, cluster); err != nil {
+			return nil, err
+		}
+		return cluster, nil
+	}
+
+	// The finalizer is added before the network is created, so we never leak a network
+	// if storing its name fails.
+	if !kuberneteshelper.HasFinalizer(cluster, networkCleanupFinalizer) {
+		cluster, err = update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(cluster, networkCleanupFinalizer)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	network, err := ensureNetwork(ctx, client.Network, cluster, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		cluster.Spec.Cloud.Hetzner.Network = network.Name
+	})
 }
 
-// CleanUpCloudProvider
-func (h *hetzner) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
-	return cluster, nil
+// CleanUpCloudProvider deletes the private network, if it was created for the cluster.
+func (h *hetzner) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, networkCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	client, err := h.getClient(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	// the network name is not stored if the cluster got deleted while the network was created
+	name := cluster.Spec.Cloud.Hetzner.Network
+	if name == "" {
+		name = networkName(cluster)
+	}
+	if err := deleteNetwork(context.Background(), client.Network, cluster, name); err != nil {
+		return nil, err
+	}
+
+	return update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, networkCleanupFinalizer)
+	})
 }
 
 // ValidateCloudSpecUpdate verifies whether an update of cloud spec is valid and permitted
 func (h *hetzner) ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error {
+	if oldSpec.Hetzner.Network != "" && oldSpec.Hetzner.Network != newSpec.Hetzner.Network {
+		return errors.New("changing the network is not allowed")
+	}
 	return nil
 }
 
@@ -0,0 +1,315 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	testhelper "k8c.io/kubermatic/v2/pkg/test"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeNetworkClient keeps the networks in memory.
+type fakeNetworkClient struct {
+	networks map[string]*hcloud.Network
+}
+
+func (f *fakeNetworkClient) Get(_ context.Context, name string) (*hcloud.Network, *hcloud.Response, error) {
+	return f.networks[name], nil, nil
+}
+
+func (f *fakeNetworkClient) Create(_ context.Context, opts hcloud.NetworkCreateOpts) (*hcloud.Network, *hcloud.Response, error) {
+	network := &hcloud.Network{
+		Name:    opts.Name,
+		IPRange: opts.IPRange,
+		Subnets: opts.Subnets,
+		Labels:  opts.Labels,
+	}
+	f.networks[network.Name] = network
+	return network, nil, nil
+}
+
+func (f *fakeNetworkClient) Delete(_ context.Context, network *hcloud.Network) (*hcloud.Response, error) {
+	delete(f.networks, network.Name)
+	return nil, nil
+}
+
+type fakeLocationClient map[string]*hcloud.Location
+
+func (f fakeLocationClient) Get(_ context.Context, name string) (*hcloud.Location, *hcloud.Response, error) {
+	return f[name], nil, nil
+}
+
+type fakeDatacenterClient map[string]*hcloud.Datacenter
+
+func (f fakeDatacenterClient) Get(_ context.Context, name string) (*hcloud.Datacenter, *hcloud.Response, error) {
+	return f[name], nil, nil
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return ipNet
+}
+
+func serverSubnet(zone hcloud.NetworkZone) []hcloud.NetworkSubnet {
+	return []hcloud.NetworkSubnet{{Type: hcloud.NetworkSubnetTypeServer, NetworkZone: zone, IPRange: mustParseCIDR(subnetIPRange)}}
+}
+
+func newTestProvider(dc *kubermaticv1.DatacenterSpecHetzner, networks ...*hcloud.Network) (*hetzner, *fakeNetworkClient) {
+	networkClient := &fakeNetworkClient{networks: map[string]*hcloud.Network{}}
+	for _, network := range networks {
+		networkClient.networks[network.Name] = network
+	}
+	client := &apiClient{
+		Network: networkClient,
+		Location: fakeLocationClient{
+			"fsn1": {Name: "fsn1", NetworkZone: hcloud.NetworkZoneEUCentral},
+			"ash":  {Name: "ash", NetworkZone: "us-east"},
+		},
+		Datacenter: fakeDatacenterClient{
+			"ash-dc1": {Name: "ash-dc1", Location: &hcloud.Location{Name: "ash", NetworkZone: "us-east"}},
+		},
+	}
+
+	return &hetzner{
+		dc:        dc,
+		newClient: func(string) *apiClient { return client },
+	}, networkClient
+}
+
+func newTestCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Hetzner: &kubermaticv1.HetznerCloudSpec{
+					Token: "token",
+				},
+			},
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods:     kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16"}},
+				Services: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+			},
+		},
+	}
+}
+
+func TestInitializeCloudProvider(t *testing.T) {
+	testCases := []struct {
+		name              string
+		dc                *kubermaticv1.DatacenterSpecHetzner
+		existingNetworks  []*hcloud.Network
+		network           string
+		podCIDR           string
+		expectedNetwork   string
+		expectedZone      hcloud.NetworkZone
+		expectedFinalizer bool
+		expectErr         bool
+	}{
+		{
+			name:              "network is created in the zone of the location",
+			dc:                &kubermaticv1.DatacenterSpecHetzner{Location: "fsn1"},
+			expectedNetwork:   "kubernetes-test-cluster",
+			expectedZone:      hcloud.NetworkZoneEUCentral,
+			expectedFinalizer: true,
+		},
+		{
+			name:              "network is created in the zone of the datacenter",
+			dc:                &kubermaticv1.DatacenterSpecHetzner{Datacenter: "ash-dc1"},
+			expectedNetwork:   "kubernetes-test-cluster",
+			expectedZone:      "us-east",
+			expectedFinalizer: true,
+		},
+		{
+			name:      "network overlapping with the pod CIDR is not created",
+			dc:        &kubermaticv1.DatacenterSpecHetzner{Location: "fsn1"},
+			podCIDR:   "192.168.0.0/16",
+			expectErr: true,
+		},
+		{
+			name: "network of a previous run is reused",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Location: "fsn1"},
+			existingNetworks: []*hcloud.Network{
+				{
+					Name:    "kubernetes-test-cluster",
+					IPRange: mustParseCIDR(networkIPRange),
+					Subnets: serverSubnet(hcloud.NetworkZoneEUCentral),
+					Labels:  map[string]string{clusterLabelKey: "test-cluster"},
+				},
+			},
+			expectedNetwork:   "kubernetes-test-cluster",
+			expectedZone:      hcloud.NetworkZoneEUCentral,
+			expectedFinalizer: true,
+		},
+		{
+			name: "foreign network with the same name is not adopted",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Location: "fsn1"},
+			existingNetworks: []*hcloud.Network{
+				{
+					Name:    "kubernetes-test-cluster",
+					IPRange: mustParseCIDR(networkIPRange),
+					Subnets: serverSubnet(hcloud.NetworkZoneEUCentral),
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "configured network is used as-is",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Location: "fsn1"},
+			existingNetworks: []*hcloud.Network{
+				{Name: "my-network", IPRange: mustParseCIDR("10.0.0.0/16"), Subnets: serverSubnet(hcloud.NetworkZoneEUCentral)},
+			},
+			network:         "my-network",
+			expectedNetwork: "my-network",
+			expectedZone:    hcloud.NetworkZoneEUCentral,
+		},
+		{
+			name: "configured network without a subnet in the zone is rejected",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Datacenter: "ash-dc1"},
+			existingNetworks: []*hcloud.Network{
+				{Name: "my-network", IPRange: mustParseCIDR("10.0.0.0/16"), Subnets: serverSubnet(hcloud.NetworkZoneEUCentral)},
+			},
+			network:   "my-network",
+			expectErr: true,
+		},
+		{
+			name: "configured network overlapping with the service CIDR is rejected",
+			dc:   &kubermaticv1.DatacenterSpecHetzner{Location: "fsn1"},
+			existingNetworks: []*hcloud.Network{
+				{Name: "my-network", IPRange: mustParseCIDR("10.0.0.0/8"), Subnets: serverSubnet(hcloud.NetworkZoneEUCentral)},
+			},
+			network:   "my-network",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, networks := newTestProvider(tc.dc, tc.existingNetworks...)
+			cluster := newTestCluster()
+			cluster.Spec.Cloud.Hetzner.Network = tc.network
+			if tc.podCIDR != "" {
+				cluster.Spec.ClusterNetwork.Pods.CIDRBlocks = []string{tc.podCIDR}
+			}
+
+			_, err := h.InitializeCloudProvider(cluster, testhelper.NewClusterUpdater(cluster))
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error = %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				if len(networks.networks) != len(tc.existingNetworks) {
+					t.Errorf("expected no network to be created, got %d networks", len(networks.networks))
+				}
+				return
+			}
+
+			if cluster.Spec.Cloud.Hetzner.Network != tc.expectedNetwork {
+				t.Errorf("expected network %q, got %q", tc.expectedNetwork, cluster.Spec.Cloud.Hetzner.Network)
+			}
+			if kuberneteshelper.HasFinalizer(cluster, networkCleanupFinalizer) != tc.expectedFinalizer {
+				t.Errorf("expected finalizer = %v, got %v", tc.expectedFinalizer, cluster.Finalizers)
+			}
+			if len(networks.networks) != 1 {
+				t.Fatalf("expected only network %q to exist, got %d networks", tc.expectedNetwork, len(networks.networks))
+			}
+			network := networks.networks[tc.expectedNetwork]
+			if network == nil {
+				t.Fatalf("expected network %q to exist", tc.expectedNetwork)
+			}
+			if zone := network.Subnets[0].NetworkZone; zone != tc.expectedZone {
+				t.Errorf("expected subnet in zone %q, got %q", tc.expectedZone, zone)
+			}
+		})
+	}
+}
+
+func TestCleanUpCloudProvider(t *testing.T) {
+	ownedNetwork := &hcloud.Network{Name: "kubernetes-test-cluster", Labels: map[string]string{clusterLabelKey: "test-cluster"}}
+
+	testCases := []struct {
+		name             string
+		existingNetworks []*hcloud.Network
+		network          string
+		finalizer        bool
+		expectedNetworks int
+	}{
+		{
+			name:             "created network is deleted",
+			existingNetworks: []*hcloud.Network{ownedNetwork},
+			network:          "kubernetes-test-cluster",
+			finalizer:        true,
+			expectedNetworks: 0,
+		},
+		{
+			name:             "created network that was not stored is deleted",
+			existingNetworks: []*hcloud.Network{ownedNetwork},
+			finalizer:        true,
+			expectedNetworks: 0,
+		},
+		{
+			name:             "foreign network with the same name is kept",
+			existingNetworks: []*hcloud.Network{{Name: "kubernetes-test-cluster"}},
+			finalizer:        true,
+			expectedNetworks: 1,
+		},
+		{
+			name:      "already deleted network is ignored",
+			network:   "kubernetes-test-cluster",
+			finalizer: true,
+		},
+		{
+			name:             "configured network is kept",
+			existingNetworks: []*hcloud.Network{{Name: "kubernetes-test-cluster"}},
+			network:          "kubernetes-test-cluster",
+			expectedNetworks: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, networks := newTestProvider(&kubermaticv1.DatacenterSpecHetzner{Location: "fsn1"}, tc.existingNetworks...)
+			cluster := newTestCluster()
+			cluster.Spec.Cloud.Hetzner.Network = tc.network
+			if tc.finalizer {
+				kuberneteshelper.AddFinalizer(cluster, networkCleanupFinalizer)
+			}
+
+			if _, err := h.CleanUpCloudProvider(cluster, testhelper.NewClusterUpdater(cluster)); err != nil {
+				t.Fatalf("failed to clean up: %v", err)
+			}
+
+			if kuberneteshelper.HasFinalizer(cluster, networkCleanupFinalizer) {
+				t.Error("expected finalizer to be removed")
+			}
+			if len(networks.networks) != tc.expectedNetworks {
+				t.Errorf("expected %d networks, got %d", tc.expectedNetworks, len(networks.networks))
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	resourceQuotaName      = "cluster-quota"
+	networkPolicyName      = "tenant-isolation"
+	serviceAccountName     = "machine-controller"
+	serviceAccountRoleName = "machine-controller"
+	tokenSecretName        = "machine-controller-token"
+)
+
+// infraNamespaceName returns the name of the namespace on the infra cluster. It does
+// not use the "cluster-" prefix, as the seed itself is commonly used as infra cluster.
+func infraNamespaceName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("kubevirt-cluster-%s", cluster.Name)
+}
+
+// reconcileInfraNamespace creates or updates the namespace of a user cluster on the
+// infra cluster, including everything needed to isolate it from other tenants.
+func reconcileInfraNamespace(ctx context.Context, client ctrlruntimeclient.Client, namespace string, dc *kubermaticv1.DatacenterSpecKubevirt) error {
+	if err := reconciling.ReconcileNamespaces(ctx, []reconciling.NamedNamespaceCreatorGetter{
+		namespaceCreator(namespace),
+	}, "", client); err != nil {
+		return err
+	}
+	if err := reconciling.ReconcileResourceQuotas(ctx, []reconciling.NamedResourceQuotaCreatorGetter{
+		resourceQuotaCreator(dc.ResourceQuota),
+	}, namespace, client); err != nil {
+		return err
+	}
+	if err := reconciling.ReconcileNetworkPolicies(ctx, []reconciling.NamedNetworkPolicyCreatorGetter{
+		networkPolicyCreator(),
+	}, namespace, client); err != nil {
+		return err
+	}
+	if err := reconciling.ReconcileServiceAccounts(ctx, []reconciling.NamedServiceAccountCreatorGetter{
+		serviceAccountCreator(),
+	}, namespace, client); err != nil {
+		return err
+	}
+	if err := reconciling.ReconcileRoles(ctx, []reconciling.NamedRoleCreatorGetter{
+		roleCreator(),
+	}, namespace, client); err != nil {
+		return err
+	}
+	if err := reconciling.ReconcileRoleBindings(ctx, []reconciling.NamedRoleBindingCreatorGetter{
+		roleBindingCreator(namespace),
+	}, namespace, client); err != nil {
+		return err
+	}
+	return reconciling.ReconcileSecrets(ctx, []reconciling.NamedSecretCreatorGetter{
+		tokenSecretCreator(),
+	}, namespace, client)
+}
+
+func namespaceCreator(name string) reconciling.NamedNamespaceCreatorGetter {
+	return func() (string, reconciling.NamespaceCreator) {
+		return name, func(ns *corev1.Namespace) (*corev1.Namespace, error) {
+			return ns, nil
+		}
+	}
+}
+
+func resourceQuotaCreator(hard corev1.ResourceList) reconciling.NamedResourceQuotaCreatorGetter {
+	return func() (string, reconciling.ResourceQuotaCreator) {
+		return resourceQuotaName, func(rq *corev1.ResourceQuota) (*corev1.ResourceQuota, error) {
+			rq.Spec.Hard = hard.DeepCopy()
+			return rq, nil
+		}
+	}
+}
+
+// networkPolicyCreator only allows ingress traffic from within the namespace, so
+// virtual machines of different user clusters can not reach each other.
+func networkPolicyCreator() reconciling.NamedNetworkPolicyCreatorGetter {
+	return func() (string, reconciling.NetworkPolicyCreator) {
+		return networkPolicyName, func(np *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+			np.Spec = networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{
+						From: []networkingv1.NetworkPolicyPeer{
+							{
+								PodSelector: &metav1.LabelSelector{},
+							},
+						},
+					},
+				},
+			}
+			return np, nil
+		}
+	}
+}
+
+func serviceAccountCreator() reconciling.NamedServiceAccountCreatorGetter {
+	return func() (string, reconciling.ServiceAccountCreator) {
+		return serviceAccountName, func(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+			return sa, nil
+		}
+	}
+}
+
+// roleCreator grants everything machine-controller needs to manage virtual machines
+// in the namespace, but nothing outside of it.
+func roleCreator() reconciling.NamedRoleCreatorGetter {
+	return func() (string, reconciling.RoleCreator) {
+		return serviceAccountRoleName, func(r *rbacv1.Role) (*rbacv1.Role, error) {
+			r.Rules = []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"kubevirt.io"},
+					Resources: []string{"virtualmachines", "virtualmachineinstances"},
+					Verbs:     []string{"*"},
+				},
+				{
+					APIGroups: []string{"cdi.kubevirt.io"},
+					Resources: []string{"datavolumes"},
+					Verbs:     []string{"*"},
+				},
+				{
+					APIGroups: []string{""},
+					Resources: []string{"secrets", "persistentvolumeclaims"},
+					Verbs:     []string{"*"},
+				},
+				{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			}
+			return r, nil
+		}
+	}
+}
+
+func roleBindingCreator(namespace string) reconciling.NamedRoleBindingCreatorGetter {
+	return func() (string, reconciling.RoleBindingCreator) {
+		return serviceAccountRoleName, func(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+			rb.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     serviceAccountRoleName,
+			}
+			rb.Subjects = []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      serviceAccountName,
+					Namespace: namespace,
+				},
+			}
+			return rb, nil
+		}
+	}
+}
+
+// tokenSecretCreator requests a token for the service account. The token itself is
+// filled in by the token controller of the infra cluster.
+func tokenSecretCreator() reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return tokenSecretName, func(s *corev1.Secret) (*corev1.Secret, error) {
+			if s.Annotations == nil {
+				s.Annotations = map[string]string{}
+			}
+			s.Annotations[corev1.ServiceAccountNameKey] = serviceAccountName
+			s.Type = corev1.SecretTypeServiceAccountToken
+			return s, nil
+		}
+	}
+}
+
+// serviceAccountCredentialsCreator adds the service account kubeconfig to the credentials Secret
+// of the cluster on the seed, keeping the admin kubeconfig stored in it.
+func serviceAccountCredentialsCreator(name, kubeconfig string) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return name, func(s *corev1.Secret) (*corev1.Secret, error) {
+			if s.Data == nil {
+				s.Data = map[string][]byte{}
+			}
+			s.Data[resources.KubevirtServiceAccountKubeConfig] = []byte(kubeconfig)
+			return s, nil
+		}
+	}
+}
+
+func serviceAccountToken(ctx context.Context, client ctrlruntimeclient.Client, namespace string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: tokenSecretName}, secret); err != nil {
+		return "", fmt.Errorf("failed to get service account token: %v", err)
+	}
+	token := secret.Data[corev1.ServiceAccountTokenKey]
+	if len(token) == 0 {
+		return "", fmt.Errorf("service account token secret %s/%s has not been populated yet", namespace, tokenSecretName)
+	}
+	return string(token), nil
+}
+
+// serviceAccountKubeconfig returns a kubeconfig that points to the same cluster as the
+// admin kubeconfig, but authenticates as the restricted service account.
+func serviceAccountKubeconfig(admin *clientcmdapi.Config, namespace, token string) (string, error) {
+	currentContext, ok := admin.Contexts[admin.CurrentContext]
+	if !ok {
+		return "", fmt.Errorf("kubeconfig has no context %q", admin.CurrentContext)
+	}
+	cluster, ok := admin.Clusters[currentContext.Cluster]
+	if !ok {
+		return "", fmt.Errorf("kubeconfig has no cluster %q", currentContext.Cluster)
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["infra"] = &clientcmdapi.Cluster{
+		Server:                   cluster.Server,
+		CertificateAuthorityData: cluster.CertificateAuthorityData,
+		InsecureSkipTLSVerify:    cluster.InsecureSkipTLSVerify,
+	}
+	config.AuthInfos[serviceAccountName] = &clientcmdapi.AuthInfo{
+		Token: token,
+	}
+	config.Contexts["default"] = &clientcmdapi.Context{
+		Cluster:   "infra",
+		AuthInfo:  serviceAccountName,
+		Namespace: namespace,
+	}
+	config.CurrentContext = "default"
+
+	b, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -17,42 +17,73 @@ limitations under the License.
 package kubevirt
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"reflect"
 
-	v1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-type kubevirt struct {
+const (
+	namespaceCleanupFinalizer = "kubermatic.io/cleanup-kubevirt-namespace"
+)
+
+// infraClientGetter returns a client for the KubeVirt infra cluster described by the kubeconfig.
+type infraClientGetter func(config *clientcmdapi.Config) (ctrlruntimeclient.Client, error)
+
+var _ provider.PeriodicReconciler = &Provider{}
+
+// Provider is the KubeVirt cloud provider.
+type Provider struct {
+	dc                *kubermaticv1.DatacenterSpecKubevirt
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	infraClient       infraClientGetter
 }
 
-func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
-	return &kubevirt{
+func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+	if dc.Spec.Kubevirt == nil {
+		return nil, errors.New("datacenter is not a KubeVirt datacenter")
+	}
+	return &Provider{
+		dc:                dc.Spec.Kubevirt,
 		secretKeySelector: secretKeyGetter,
+		infraClient:       newInfraClient,
+	}, nil
+}
+
+func newInfraClient(config *clientcmdapi.Config) (ctrlruntimeclient.Client, error) {
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, nil).ClientConfig()
+	if err != nil {
+		return nil, err
 	}
+	return ctrlruntimeclient.New(restConfig, ctrlruntimeclient.Options{})
 }
 
-func (k *kubevirt) DefaultCloudSpec(spec *v1.CloudSpec) error {
+func (k *Provider) DefaultCloudSpec(spec *kubermaticv1.CloudSpec) error {
 	return nil
 }
 
-func (k *kubevirt) ValidateCloudSpec(spec v1.CloudSpec) error {
+func (k *Provider) ValidateCloudSpec(spec kubermaticv1.CloudSpec) error {
 	kubeconfig, err := GetCredentialsForCluster(spec, k.secretKeySelector)
 	if err != nil {
 		return err
 	}
 
-	config, err := base64.StdEncoding.DecodeString(kubeconfig)
-	if err != nil {
-		// if the decoding failed, the kubeconfig is sent already decoded without the need of decoding it,
-		// for example the value has been read from Vault during the ci tests, which is saved as json format.
-		config = []byte(kubeconfig)
-	}
+	config := decodeKubeconfig(kubeconfig)
 
 	_, err = clientcmd.RESTConfigFromKubeConfig(config)
 	if err != nil {
@@ -64,20 +95,159 @@ func (k *kubevirt) ValidateCloudSpec(spec v1.CloudSpec) error {
 	return nil
 }
 
-func (k *kubevirt) InitializeCloudProvider(c *v1.Cluster, p provider.ClusterUpdater) (*v1.Cluster, error) {
-	return c, nil
+func (k *Provider) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	ctx := context.Background()
+
+	adminConfig, err := k.getAdminKubeconfig(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+	client, err := k.infraClient(adminConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create infra cluster client: %v", err)
+	}
+
+	// The finalizer is added before anything is created, so we never leak a namespace
+	// on the infra cluster.
+	if cluster.Spec.Cloud.Kubevirt.InfraNamespace == "" {
+		cluster, err = update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(cluster, namespaceCleanupFinalizer)
+			cluster.Spec.Cloud.Kubevirt.InfraNamespace = infraNamespaceName(cluster)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	namespace := cluster.Spec.Cloud.Kubevirt.InfraNamespace
+	if err := reconcileInfraNamespace(ctx, client, namespace, k.dc); err != nil {
+		return nil, fmt.Errorf("failed to reconcile namespace %q on the infra cluster: %v", namespace, err)
+	}
+
+	return cluster, nil
+}
+
+// ReconcileServiceAccountCredentials stores the kubeconfig of the service account, which is only
+// allowed to manage virtual machines inside the infra namespace, in the credentials Secret of the
+// cluster on the seed. It is referenced by the cloud spec and handed to machine-controller instead
+// of the admin kubeconfig.
+func (k *Provider) ReconcileServiceAccountCredentials(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	namespace := cluster.Spec.Cloud.Kubevirt.InfraNamespace
+	if namespace == "" {
+		return nil, errors.New("the infra namespace has not been created yet")
+	}
+
+	adminConfig, err := k.getAdminKubeconfig(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+	client, err := k.infraClient(adminConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create infra cluster client: %v", err)
+	}
+
+	token, err := serviceAccountToken(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig, err := serviceAccountKubeconfig(adminConfig, namespace, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account kubeconfig: %v", err)
+	}
+
+	// The credentials Secret of the cluster gets removed together with the cluster
+	secretName := cluster.GetSecretName()
+	if err := reconciling.ReconcileSecrets(ctx, []reconciling.NamedSecretCreatorGetter{
+		serviceAccountCredentialsCreator(secretName, kubeconfig),
+	}, resources.KubermaticNamespace, seedClient); err != nil {
+		return nil, fmt.Errorf("failed to store service account kubeconfig: %v", err)
+	}
+
+	ref := &providerconfig.GlobalSecretKeySelector{
+		ObjectReference: corev1.ObjectReference{
+			Name:      secretName,
+			Namespace: resources.KubermaticNamespace,
+		},
+		Key: resources.KubevirtServiceAccountKubeConfig,
+	}
+	if !reflect.DeepEqual(cluster.Spec.Cloud.Kubevirt.ServiceAccountCredentialsReference, ref) {
+		return update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+			cluster.Spec.Cloud.Kubevirt.ServiceAccountCredentialsReference = ref
+		})
+	}
+
+	return cluster, nil
+}
+
+// ReconcileCloudProvider reconciles the service account credentials, as the token of the service
+// account can be rotated on the infra cluster.
+func (k *Provider) ReconcileCloudProvider(ctx context.Context, seedClient ctrlruntimeclient.Client, _ provider.UserClusterClientGetter, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return k.ReconcileServiceAccountCredentials(ctx, seedClient, cluster, update)
 }
 
-func (k *kubevirt) CleanUpCloudProvider(c *v1.Cluster, p provider.ClusterUpdater) (*v1.Cluster, error) {
-	return c, nil
+func (k *Provider) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, namespaceCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	adminConfig, err := k.getAdminKubeconfig(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, err
+	}
+	client, err := k.infraClient(adminConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create infra cluster client: %v", err)
+	}
+
+	// Deleting the namespace removes all virtual machines, disks and secrets
+	// that machine-controller might have left behind.
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: cluster.Spec.Cloud.Kubevirt.InfraNamespace,
+		},
+	}
+	if err := client.Delete(context.Background(), ns); err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to delete namespace %q on the infra cluster: %v", ns.Name, err)
+	}
+
+	return update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, namespaceCleanupFinalizer)
+	})
 }
 
-func (k *kubevirt) ValidateCloudSpecUpdate(oldSpec v1.CloudSpec, newSpec v1.CloudSpec) error {
+func (k *Provider) ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error {
+	if oldSpec.Kubevirt.InfraNamespace != "" && oldSpec.Kubevirt.InfraNamespace != newSpec.Kubevirt.InfraNamespace {
+		return errors.New("changing the infra namespace is not allowed")
+	}
 	return nil
 }
 
+func (k *Provider) getAdminKubeconfig(cloud kubermaticv1.CloudSpec) (*clientcmdapi.Config, error) {
+	kubeconfig, err := GetCredentialsForCluster(cloud, k.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.Load(decodeKubeconfig(kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+	return config, nil
+}
+
+// decodeKubeconfig returns the kubeconfig either base64-decoded or unchanged, if
+// it is not base64 encoded.
+func decodeKubeconfig(kubeconfig string) []byte {
+	config, err := base64.StdEncoding.DecodeString(kubeconfig)
+	if err != nil {
+		// if the decoding failed, the kubeconfig is sent already decoded without the need of decoding it,
+		// for example the value has been read from Vault during the ci tests, which is saved as json format.
+		config = []byte(kubeconfig)
+	}
+	return config
+}
+
 // GetCredentialsForCluster returns the credentials for the passed in cloud spec or an error
-func GetCredentialsForCluster(cloud v1.CloudSpec, secretKeySelector provider.SecretKeySelectorValueFunc) (kubeconfig string, err error) {
+func GetCredentialsForCluster(cloud kubermaticv1.CloudSpec, secretKeySelector provider.SecretKeySelectorValueFunc) (kubeconfig string, err error) {
 	kubeconfig = cloud.Kubevirt.Kubeconfig
 
 	if kubeconfig == "" {
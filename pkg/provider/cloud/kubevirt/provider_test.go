@@ -0,0 +1,251 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubevirt
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	testhelper "k8c.io/kubermatic/v2/pkg/test"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testClusterName = "test-cluster"
+	testNamespace   = "kubevirt-cluster-test-cluster"
+
+	adminKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: infra
+  cluster:
+    server: https://infra.example.com:6443
+    certificate-authority-data: Y2EtZGF0YQ==
+contexts:
+- name: admin@infra
+  context:
+    cluster: infra
+    user: admin
+current-context: admin@infra
+users:
+- name: admin
+  user:
+    token: admin-token
+`
+)
+
+func testCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testClusterName,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Kubevirt: &kubermaticv1.KubevirtCloudSpec{
+					Kubeconfig: adminKubeconfig,
+				},
+			},
+		},
+	}
+}
+
+func testProvider(client ctrlruntimeclient.Client) *Provider {
+	return &Provider{
+		dc: &kubermaticv1.DatacenterSpecKubevirt{
+			ResourceQuota: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("8"),
+			},
+		},
+		infraClient: func(_ *clientcmdapi.Config) (ctrlruntimeclient.Client, error) {
+			return client, nil
+		},
+	}
+}
+
+func TestInitializeCloudProvider(t *testing.T) {
+	ctx := context.Background()
+	client := ctrlruntimefakeclient.NewFakeClient()
+	cluster := testCluster()
+	k := testProvider(client)
+
+	if _, err := k.InitializeCloudProvider(cluster, testhelper.NewClusterUpdater(cluster)); err != nil {
+		t.Fatalf("failed to initialize cloud provider: %v", err)
+	}
+
+	if cluster.Spec.Cloud.Kubevirt.InfraNamespace != testNamespace {
+		t.Errorf("expected infra namespace %q, got %q", testNamespace, cluster.Spec.Cloud.Kubevirt.InfraNamespace)
+	}
+	if !kuberneteshelper.HasFinalizer(cluster, namespaceCleanupFinalizer) {
+		t.Errorf("expected cluster to have the %q finalizer", namespaceCleanupFinalizer)
+	}
+
+	if err := client.Get(ctx, types.NamespacedName{Name: testNamespace}, &corev1.Namespace{}); err != nil {
+		t.Errorf("failed to get namespace: %v", err)
+	}
+
+	quota := &corev1.ResourceQuota{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resourceQuotaName}, quota); err != nil {
+		t.Fatalf("failed to get resource quota: %v", err)
+	}
+	if cpu := quota.Spec.Hard[corev1.ResourceRequestsCPU]; cpu.String() != "8" {
+		t.Errorf("expected resource quota for 8 cpus, got %s", cpu.String())
+	}
+
+	for _, o := range []struct {
+		name string
+		obj  runtime.Object
+	}{
+		{name: networkPolicyName, obj: &networkingv1.NetworkPolicy{}},
+		{name: serviceAccountName, obj: &corev1.ServiceAccount{}},
+		{name: serviceAccountRoleName, obj: &rbacv1.Role{}},
+		{name: tokenSecretName, obj: &corev1.Secret{}},
+	} {
+		if err := client.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: o.name}, o.obj); err != nil {
+			t.Errorf("failed to get %T %q: %v", o.obj, o.name, err)
+		}
+	}
+
+	roleBinding := &rbacv1.RoleBinding{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: serviceAccountRoleName}, roleBinding); err != nil {
+		t.Fatalf("failed to get role binding: %v", err)
+	}
+	if len(roleBinding.Subjects) != 1 || roleBinding.Subjects[0].Namespace != testNamespace {
+		t.Errorf("expected role binding to only bind the service account in %q, got %v", testNamespace, roleBinding.Subjects)
+	}
+}
+
+func TestReconcileServiceAccountCredentials(t *testing.T) {
+	ctx := context.Background()
+	// The fake client has no token controller, so the token is set upfront.
+	infraClient := ctrlruntimefakeclient.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      tokenSecretName,
+		},
+		Data: map[string][]byte{
+			corev1.ServiceAccountTokenKey: []byte("sa-token"),
+		},
+	})
+	cluster := testCluster()
+	cluster.Spec.Cloud.Kubevirt.InfraNamespace = testNamespace
+	seedClient := ctrlruntimefakeclient.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resources.KubermaticNamespace,
+			Name:      cluster.GetSecretName(),
+		},
+		Data: map[string][]byte{
+			resources.KubevirtKubeConfig: []byte(adminKubeconfig),
+		},
+	})
+	k := testProvider(infraClient)
+
+	if _, err := k.ReconcileServiceAccountCredentials(ctx, seedClient, cluster, testhelper.NewClusterUpdater(cluster)); err != nil {
+		t.Fatalf("failed to reconcile service account credentials: %v", err)
+	}
+
+	ref := cluster.Spec.Cloud.Kubevirt.ServiceAccountCredentialsReference
+	if ref == nil || ref.Name != cluster.GetSecretName() || ref.Key != resources.KubevirtServiceAccountKubeConfig {
+		t.Fatalf("expected the service account kubeconfig to be referenced in the credentials secret, got %v", ref)
+	}
+
+	secret := &corev1.Secret{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		t.Fatalf("failed to get credentials secret: %v", err)
+	}
+	if string(secret.Data[resources.KubevirtKubeConfig]) != adminKubeconfig {
+		t.Error("expected the admin kubeconfig to be kept in the credentials secret")
+	}
+
+	config, err := clientcmd.Load(secret.Data[resources.KubevirtServiceAccountKubeConfig])
+	if err != nil {
+		t.Fatalf("failed to load service account kubeconfig: %v", err)
+	}
+	currentContext := config.Contexts[config.CurrentContext]
+	if currentContext.Namespace != testNamespace {
+		t.Errorf("expected kubeconfig namespace %q, got %q", testNamespace, currentContext.Namespace)
+	}
+	if token := config.AuthInfos[currentContext.AuthInfo].Token; token != "sa-token" {
+		t.Errorf("expected kubeconfig to use the service account token, got %q", token)
+	}
+	if server := config.Clusters[currentContext.Cluster].Server; server != "https://infra.example.com:6443" {
+		t.Errorf("expected kubeconfig to point to the infra cluster, got %q", server)
+	}
+}
+
+func TestReconcileServiceAccountCredentialsWaitsForToken(t *testing.T) {
+	ctx := context.Background()
+	seedClient := ctrlruntimefakeclient.NewFakeClient()
+	cluster := testCluster()
+	cluster.Spec.Cloud.Kubevirt.InfraNamespace = testNamespace
+	k := testProvider(ctrlruntimefakeclient.NewFakeClient())
+
+	if _, err := k.ReconcileServiceAccountCredentials(ctx, seedClient, cluster, testhelper.NewClusterUpdater(cluster)); err == nil {
+		t.Fatal("expected an error as long as the service account token is missing")
+	}
+
+	if cluster.Spec.Cloud.Kubevirt.ServiceAccountCredentialsReference != nil {
+		t.Error("expected no service account credentials without a token")
+	}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: cluster.GetSecretName()}, &corev1.Secret{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected no credentials secret without a token, got %v", err)
+	}
+}
+
+func TestCleanUpCloudProvider(t *testing.T) {
+	ctx := context.Background()
+	client := ctrlruntimefakeclient.NewFakeClient(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testNamespace,
+		},
+	})
+	cluster := testCluster()
+	cluster.Spec.Cloud.Kubevirt.InfraNamespace = testNamespace
+	kuberneteshelper.AddFinalizer(cluster, namespaceCleanupFinalizer)
+	k := testProvider(client)
+
+	if _, err := k.CleanUpCloudProvider(cluster, testhelper.NewClusterUpdater(cluster)); err != nil {
+		t.Fatalf("failed to clean up cloud provider: %v", err)
+	}
+
+	if err := client.Get(ctx, types.NamespacedName{Name: testNamespace}, &corev1.Namespace{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected namespace to be deleted, got %v", err)
+	}
+	if kuberneteshelper.HasFinalizer(cluster, namespaceCleanupFinalizer) {
+		t.Errorf("expected the %q finalizer to be removed", namespaceCleanupFinalizer)
+	}
+
+	// A second cleanup must not fail on the already deleted namespace.
+	kuberneteshelper.AddFinalizer(cluster, namespaceCleanupFinalizer)
+	if _, err := k.CleanUpCloudProvider(cluster, testhelper.NewClusterUpdater(cluster)); err != nil {
+		t.Fatalf("failed to clean up cloud provider twice: %v", err)
+	}
+}
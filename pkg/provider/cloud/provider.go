@@ -55,7 +55,7 @@ func Provider(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.Secr
 		return packet.NewCloudProvider(secretKeyGetter), nil
 	}
 	if datacenter.Spec.Hetzner != nil {
-		return hetzner.NewCloudProvider(secretKeyGetter), nil
+		return hetzner.NewCloudProvider(datacenter, secretKeyGetter)
 	}
 	if datacenter.Spec.VSphere != nil {
 		return vsphere.NewCloudProvider(datacenter, secretKeyGetter)
@@ -67,7 +67,7 @@ func Provider(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.Secr
 		return fake.NewCloudProvider(), nil
 	}
 	if datacenter.Spec.Kubevirt != nil {
-		return kubevirt.NewCloudProvider(secretKeyGetter), nil
+		return kubevirt.NewCloudProvider(datacenter, secretKeyGetter)
 	}
 	if datacenter.Spec.Alibaba != nil {
 		return alibaba.NewCloudProvider(datacenter, secretKeyGetter)
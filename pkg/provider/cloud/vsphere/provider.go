@@ -28,17 +28,23 @@ import (
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
 
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	kruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	folderCleanupFinalizer = "kubermatic.io/cleanup-vsphere-folder"
+	tagCleanupFinalizer    = "kubermatic.io/cleanup-vsphere-tag"
 )
 
+var _ provider.PeriodicReconciler = &Provider{}
+
 // Provider represents the vsphere provider.
 type Provider struct {
 	dc                *kubermaticv1.DatacenterSpecVSphere
@@ -50,6 +56,11 @@ type Folder struct {
 	Path string
 }
 
+// ResourcePool represents a vsphere resource pool.
+type ResourcePool struct {
+	Path string
+}
+
 // NewCloudProvider creates a new vSphere provider.
 func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (*Provider, error) {
 	if dc.Spec.VSphere == nil {
@@ -85,12 +96,7 @@ func newSession(ctx context.Context, dc *kubermaticv1.DatacenterSpecVSphere, use
 		return nil, err
 	}
 
-	user := url.UserPassword(username, password)
-	if dc.InfraManagementUser != nil {
-		user = url.UserPassword(dc.InfraManagementUser.Username, dc.InfraManagementUser.Password)
-	}
-
-	if err = client.Login(ctx, user); err != nil {
+	if err = client.Login(ctx, sessionUser(dc, username, password)); err != nil {
 		return nil, err
 	}
 
@@ -108,6 +114,15 @@ func newSession(ctx context.Context, dc *kubermaticv1.DatacenterSpecVSphere, use
 	}, nil
 }
 
+// sessionUser returns the user to log into vCenter with. The infra management user of the
+// datacenter takes precedence over the passed credentials.
+func sessionUser(dc *kubermaticv1.DatacenterSpecVSphere, username, password string) *url.Userinfo {
+	if dc.InfraManagementUser != nil {
+		return url.UserPassword(dc.InfraManagementUser.Username, dc.InfraManagementUser.Password)
+	}
+	return url.UserPassword(username, password)
+}
+
 // getVMRootPath is a helper func to get the root path for VM's
 // We extracted it because we use it in several places
 func getVMRootPath(dc *kubermaticv1.DatacenterSpecVSphere) string {
@@ -152,6 +167,79 @@ func (v *Provider) InitializeCloudProvider(cluster *kubermaticv1.Cluster, update
 		}
 	}
 
+	if cluster.Spec.Cloud.VSphere.TagCategoryID != "" && cluster.Spec.Cloud.VSphere.ClusterTagID == "" {
+		restSession, err := newRESTSession(ctx, session, v.dc, username, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create vCenter REST session: %v", err)
+		}
+		defer restSession.Logout()
+
+		tagID, err := ensureClusterTag(ctx, restSession, cluster, cluster.Spec.Cloud.VSphere.TagCategoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		cluster, err = update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(cluster, tagCleanupFinalizer)
+			cluster.Spec.Cloud.VSphere.ClusterTagID = tagID
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// ReconcileCloudProvider attaches the cluster tag and the additional tags to the virtual machines
+// of the cluster. machine-controller can not tag the virtual machines it creates, so this has to
+// be done periodically. The virtual machines are named after the machines of the cluster, so
+// virtual machines of others in the same folder are never tagged.
+func (v *Provider) ReconcileCloudProvider(ctx context.Context, _ ctrlruntimeclient.Client, userClusterClient provider.UserClusterClientGetter, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if cluster.Spec.Cloud.VSphere.ClusterTagID == "" || !kuberneteshelper.HasFinalizer(cluster, tagCleanupFinalizer) {
+		return cluster, nil
+	}
+	// there are no machines before the control plane is up
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return cluster, nil
+	}
+
+	client, err := userClusterClient(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+	machines := &clusterv1alpha1.MachineList{}
+	if err := client.List(ctx, machines); err != nil {
+		return nil, fmt.Errorf("failed to list machines: %v", err)
+	}
+	if len(machines.Items) == 0 {
+		return cluster, nil
+	}
+	var vmNames []string
+	for _, machine := range machines.Items {
+		vmNames = append(vmNames, machine.Spec.Name)
+	}
+
+	username, password, err := GetCredentialsForCluster(cluster.Spec.Cloud, v.secretKeySelector, v.dc)
+	if err != nil {
+		return nil, err
+	}
+	session, err := newSession(ctx, v.dc, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vCenter session: %v", err)
+	}
+	defer session.Logout()
+
+	restSession, err := newRESTSession(ctx, session, v.dc, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vCenter REST session: %v", err)
+	}
+	defer restSession.Logout()
+
+	tagIDs := append([]string{cluster.Spec.Cloud.VSphere.ClusterTagID}, cluster.Spec.Cloud.VSphere.Tags...)
+	if err := attachTagsToVMs(ctx, session, restSession, cluster.Spec.Cloud.VSphere.Folder, vmNames, tagIDs); err != nil {
+		return nil, err
+	}
 	return cluster, nil
 }
 
@@ -204,6 +292,69 @@ func GetVMFolders(dc *kubermaticv1.DatacenterSpecVSphere, username, password str
 	return folders, nil
 }
 
+// GetResourcePools returns a slice of VSphereResourcePools of the cluster of the datacenter.
+func GetResourcePools(dc *kubermaticv1.DatacenterSpecVSphere, username, password string) ([]ResourcePool, error) {
+	ctx := context.TODO()
+
+	session, err := newSession(ctx, dc, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vCenter session: %v", err)
+	}
+	defer session.Logout()
+
+	// Like folders, resource pools are only listed recursively when using "*".
+	poolRefs, err := session.Finder.ResourcePoolList(ctx, "*")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't retrieve resource pool list: %v", err)
+	}
+
+	// Machines can only be placed in resource pools of the configured cluster.
+	rootPath := path.Join("/", dc.Datacenter, "host", dc.Cluster, "Resources")
+	var pools []ResourcePool
+	for _, poolRef := range poolRefs {
+		if !strings.HasPrefix(poolRef.InventoryPath, rootPath+"/") && poolRef.InventoryPath != rootPath {
+			continue
+		}
+		pools = append(pools, ResourcePool{Path: poolRef.InventoryPath})
+	}
+
+	return pools, nil
+}
+
+// GetTagCategories returns a slice of VSphereTagCategories of the vCenter of the datacenter.
+func GetTagCategories(dc *kubermaticv1.DatacenterSpecVSphere, username, password string) ([]TagCategory, error) {
+	ctx := context.TODO()
+
+	session, err := newSession(ctx, dc, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vCenter session: %v", err)
+	}
+	defer session.Logout()
+
+	restSession, err := newRESTSession(ctx, session, dc, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vCenter REST session: %v", err)
+	}
+	defer restSession.Logout()
+
+	categories, err := restSession.TagsManager.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't retrieve tag category list: %v", err)
+	}
+
+	var tagCategories []TagCategory
+	for _, category := range categories {
+		tagCategories = append(tagCategories, TagCategory{
+			ID:          category.ID,
+			Name:        category.Name,
+			Description: category.Description,
+			Cardinality: category.Cardinality,
+		})
+	}
+
+	return tagCategories, nil
+}
+
 // DefaultCloudSpec adds defaults to the cloud spec
 func (v *Provider) DefaultCloudSpec(cloud *kubermaticv1.CloudSpec) error {
 	return nil
@@ -220,12 +371,33 @@ func (v *Provider) ValidateCloudSpec(spec kubermaticv1.CloudSpec) error {
 		return errors.New("either datastore or datastore cluster can be selected")
 	}
 
-	session, err := newSession(context.TODO(), v.dc, username, password)
+	ctx := context.TODO()
+	session, err := newSession(ctx, v.dc, username, password)
 	if err != nil {
 		return fmt.Errorf("failed to create vCenter session: %v", err)
 	}
 	defer session.Logout()
-	return nil
+
+	if spec.VSphere.ResourcePool != "" {
+		if _, err := session.Finder.ResourcePool(ctx, spec.VSphere.ResourcePool); err != nil {
+			return fmt.Errorf("failed to get resource pool %q: %v", spec.VSphere.ResourcePool, err)
+		}
+	}
+
+	if spec.VSphere.TagCategoryID == "" {
+		if len(spec.VSphere.Tags) > 0 {
+			return errors.New("tags can only be attached if a tag category is set")
+		}
+		return nil
+	}
+
+	restSession, err := newRESTSession(ctx, session, v.dc, username, password)
+	if err != nil {
+		return fmt.Errorf("failed to create vCenter REST session: %v", err)
+	}
+	defer restSession.Logout()
+
+	return validateTags(ctx, restSession, spec.VSphere.TagCategoryID, spec.VSphere.Tags)
 }
 
 // CleanUpCloudProvider we always check if the folder is there and remove it if yes because we know its absolute path
@@ -244,6 +416,24 @@ func (v *Provider) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update pr
 	}
 	defer session.Logout()
 
+	if kuberneteshelper.HasFinalizer(cluster, tagCleanupFinalizer) {
+		restSession, err := newRESTSession(ctx, session, v.dc, username, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create vCenter REST session: %v", err)
+		}
+		defer restSession.Logout()
+
+		if err := deleteTag(ctx, restSession, cluster.Spec.Cloud.VSphere.ClusterTagID); err != nil {
+			return nil, err
+		}
+		cluster, err = update(cluster.Name, func(cluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(cluster, tagCleanupFinalizer)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if kuberneteshelper.HasFinalizer(cluster, folderCleanupFinalizer) {
 		if err := deleteVMFolder(ctx, session, cluster.Spec.Cloud.VSphere.Folder); err != nil {
 			return nil, err
@@ -261,6 +451,18 @@ func (v *Provider) CleanUpCloudProvider(cluster *kubermaticv1.Cluster, update pr
 
 // ValidateCloudSpecUpdate verifies whether an update of cloud spec is valid and permitted
 func (v *Provider) ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error {
+	if oldSpec.VSphere.ClusterTagID != "" {
+		if oldSpec.VSphere.ClusterTagID != newSpec.VSphere.ClusterTagID {
+			return errors.New("changing the cluster tag is not allowed")
+		}
+		if oldSpec.VSphere.TagCategoryID != newSpec.VSphere.TagCategoryID {
+			return errors.New("changing the tag category is not allowed")
+		}
+		// Added tags are attached to all virtual machines, but removed tags would stay attached.
+		if removed := sets.NewString(oldSpec.VSphere.Tags...).Difference(sets.NewString(newSpec.VSphere.Tags...)); removed.Len() > 0 {
+			return fmt.Errorf("removing tags is not allowed: %v", removed.List())
+		}
+	}
 	return nil
 }
 
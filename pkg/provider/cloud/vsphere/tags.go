@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+// TagCategory represents a vsphere tag category.
+type TagCategory struct {
+	ID          string
+	Name        string
+	Description string
+	Cardinality string
+}
+
+// RESTSession is a session with the vSphere Automation API, which is needed to manage tags.
+type RESTSession struct {
+	Client      *rest.Client
+	TagsManager *tags.Manager
+}
+
+// Logout closes the idling vCenter connections
+func (s *RESTSession) Logout() {
+	if err := s.Client.Logout(context.Background()); err != nil {
+		kruntime.HandleError(fmt.Errorf("vSphere REST client failed to logout: %s", err))
+	}
+}
+
+// newRESTSession logs into the vSphere Automation API, reusing the connection of the given session.
+func newRESTSession(ctx context.Context, session *Session, dc *kubermaticv1.DatacenterSpecVSphere, username, password string) (*RESTSession, error) {
+	client := rest.NewClient(session.Client.Client)
+	if err := client.Login(ctx, sessionUser(dc, username, password)); err != nil {
+		return nil, err
+	}
+
+	return &RESTSession{
+		Client:      client,
+		TagsManager: tags.NewManager(client),
+	}, nil
+}
+
+// ensureClusterTag returns the ID of the tag of the cluster in the given category and creates
+// the tag if it does not exist yet.
+func ensureClusterTag(ctx context.Context, restSession *RESTSession, cluster *kubermaticv1.Cluster, categoryID string) (string, error) {
+	existing, err := restSession.TagsManager.GetTagsForCategory(ctx, categoryID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags of category %q: %v", categoryID, err)
+	}
+	for _, tag := range existing {
+		if tag.Name == cluster.Name {
+			return tag.ID, nil
+		}
+	}
+
+	id, err := restSession.TagsManager.CreateTag(ctx, &tags.Tag{
+		Name:        cluster.Name,
+		Description: fmt.Sprintf("Kubernetes cluster %s", cluster.Name),
+		CategoryID:  categoryID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create tag %q: %v", cluster.Name, err)
+	}
+	return id, nil
+}
+
+// attachTagsToVMs attaches the given tags to the virtual machines with the given names in the
+// given folder that do not carry them yet. Virtual machines that do not exist are ignored.
+func attachTagsToVMs(ctx context.Context, session *Session, restSession *RESTSession, folder string, names []string, tagIDs []string) error {
+	var vms []*object.VirtualMachine
+	for _, name := range names {
+		vm, err := session.Finder.VirtualMachine(ctx, path.Join(folder, name))
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get virtual machine %q in folder %q: %v", name, folder, err)
+		}
+		vms = append(vms, vm)
+	}
+
+	for _, tagID := range tagIDs {
+		tagged, err := restSession.TagsManager.ListAttachedObjects(ctx, tagID)
+		if err != nil {
+			return fmt.Errorf("failed to list objects with tag %q: %v", tagID, err)
+		}
+		taggedRefs := map[string]bool{}
+		for _, ref := range tagged {
+			taggedRefs[ref.Reference().Value] = true
+		}
+
+		for _, vm := range vms {
+			if taggedRefs[vm.Reference().Value] {
+				continue
+			}
+			if err := restSession.TagsManager.AttachTag(ctx, tagID, vm.Reference()); err != nil {
+				return fmt.Errorf("failed to attach tag %q to virtual machine %q: %v", tagID, vm.InventoryPath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteTag deletes the given tag, which also detaches it from all objects. Tags that are
+// already gone are ignored.
+func deleteTag(ctx context.Context, restSession *RESTSession, tagID string) error {
+	if err := restSession.TagsManager.DeleteTag(ctx, &tags.Tag{ID: tagID}); err != nil && !isRESTNotFound(err) {
+		return fmt.Errorf("failed to delete tag %q: %v", tagID, err)
+	}
+	return nil
+}
+
+// isRESTNotFound returns whether the error of the vSphere Automation API is caused by a
+// missing object. The REST client does not expose the status code in a typed error.
+func isRESTNotFound(err error) bool {
+	return strings.Contains(err.Error(), fmt.Sprintf("%d %s", http.StatusNotFound, http.StatusText(http.StatusNotFound)))
+}
+
+// validateTags checks that the tag category and all additional tags exist.
+func validateTags(ctx context.Context, restSession *RESTSession, categoryID string, tagIDs []string) error {
+	if _, err := restSession.TagsManager.GetCategory(ctx, categoryID); err != nil {
+		return fmt.Errorf("failed to get tag category %q: %v", categoryID, err)
+	}
+	for _, tagID := range tagIDs {
+		if _, err := restSession.TagsManager.GetTag(ctx, tagID); err != nil {
+			return fmt.Errorf("failed to get tag %q: %v", tagID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/types"
+
+	// Registers the vSphere Automation API endpoints, which are needed for tags.
+	_ "github.com/vmware/govmomi/vapi/simulator"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	testhelper "k8c.io/kubermatic/v2/pkg/test"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newSimulator starts a vcsim instance with a single datacenter "DC0" and cluster "DC0_C0".
+func newSimulator(t *testing.T) (*kubermaticv1.DatacenterSpecVSphere, string, string) {
+	model := simulator.VPX()
+	if err := model.Create(); err != nil {
+		t.Fatalf("failed to create simulator model: %v", err)
+	}
+	t.Cleanup(model.Remove)
+
+	model.Service.RegisterEndpoints = true
+	server := model.Service.NewServer()
+	t.Cleanup(server.Close)
+
+	password, _ := server.URL.User.Password()
+	dc := &kubermaticv1.DatacenterSpecVSphere{
+		Endpoint:      server.URL.Scheme + "://" + server.URL.Host,
+		AllowInsecure: true,
+		Datacenter:    "DC0",
+		Cluster:       "DC0_C0",
+	}
+	return dc, server.URL.User.Username(), password
+}
+
+// newTestRESTSession returns a session for preparing and inspecting the simulator.
+func newTestRESTSession(t *testing.T, dc *kubermaticv1.DatacenterSpecVSphere, username, password string) (*Session, *RESTSession) {
+	ctx := context.Background()
+	session, err := newSession(ctx, dc, username, password)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	t.Cleanup(session.Logout)
+
+	restSession, err := newRESTSession(ctx, session, dc, username, password)
+	if err != nil {
+		t.Fatalf("failed to create REST session: %v", err)
+	}
+	t.Cleanup(restSession.Logout)
+
+	return session, restSession
+}
+
+func newTagTestCluster(username, password, categoryID string) *kubermaticv1.Cluster {
+	cloud := testVsphereCloudSpec(username, password, "", "", false)
+	cloud.VSphere.TagCategoryID = categoryID
+
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: cloud,
+		},
+	}
+}
+
+func TestClusterTagLifecycle(t *testing.T) {
+	ctx := context.Background()
+	dc, username, password := newSimulator(t)
+	session, restSession := newTestRESTSession(t, dc, username, password)
+
+	categoryID, err := restSession.TagsManager.CreateCategory(ctx, &tags.Category{
+		Name:            "kubernetes-clusters",
+		Cardinality:     "MULTIPLE",
+		AssociableTypes: []string{"VirtualMachine"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create tag category: %v", err)
+	}
+	chargebackTagID, err := restSession.TagsManager.CreateTag(ctx, &tags.Tag{Name: "team-a", CategoryID: categoryID})
+	if err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+
+	p := &Provider{dc: dc}
+	cluster := newTagTestCluster(username, password, categoryID)
+	cluster.Spec.Cloud.VSphere.Tags = []string{chargebackTagID}
+	update := testhelper.NewClusterUpdater(cluster)
+
+	if err := p.ValidateCloudSpec(cluster.Spec.Cloud); err != nil {
+		t.Fatalf("failed to validate cloud spec: %v", err)
+	}
+	if _, err := p.InitializeCloudProvider(cluster, update); err != nil {
+		t.Fatalf("failed to initialize cloud provider: %v", err)
+	}
+
+	clusterTagID := cluster.Spec.Cloud.VSphere.ClusterTagID
+	if clusterTagID == "" {
+		t.Fatal("expected the cluster tag to be recorded")
+	}
+	if !kuberneteshelper.HasFinalizer(cluster, tagCleanupFinalizer) {
+		t.Errorf("expected cluster to have the %q finalizer", tagCleanupFinalizer)
+	}
+	tag, err := restSession.TagsManager.GetTag(ctx, clusterTagID)
+	if err != nil {
+		t.Fatalf("failed to get cluster tag: %v", err)
+	}
+	if tag.Name != cluster.Name || tag.CategoryID != categoryID {
+		t.Errorf("expected tag %q in category %q, got %q in %q", cluster.Name, categoryID, tag.Name, tag.CategoryID)
+	}
+
+	// Pretend machine-controller created a virtual machine in the cluster folder, next to
+	// one that does not belong to the cluster.
+	vm, err := session.Finder.VirtualMachine(ctx, "DC0_H0_VM0")
+	if err != nil {
+		t.Fatalf("failed to get virtual machine: %v", err)
+	}
+	foreignVM, err := session.Finder.VirtualMachine(ctx, "DC0_H0_VM1")
+	if err != nil {
+		t.Fatalf("failed to get virtual machine: %v", err)
+	}
+	folder, err := session.Finder.Folder(ctx, cluster.Spec.Cloud.VSphere.Folder)
+	if err != nil {
+		t.Fatalf("failed to get cluster folder: %v", err)
+	}
+	task, err := folder.MoveInto(ctx, []types.ManagedObjectReference{vm.Reference(), foreignVM.Reference()})
+	if err != nil {
+		t.Fatalf("failed to move virtual machines: %v", err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		t.Fatalf("failed to move virtual machines: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clusterv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to register machine types: %v", err)
+	}
+	userClusterClient := fakectrlruntimeclient.NewFakeClientWithScheme(scheme, &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: metav1.NamespaceSystem},
+		Spec:       clusterv1alpha1.MachineSpec{ObjectMeta: metav1.ObjectMeta{Name: "DC0_H0_VM0"}},
+	})
+	userClusterClientGetter := func(*kubermaticv1.Cluster) (ctrlruntimeclient.Client, error) {
+		return userClusterClient, nil
+	}
+	cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
+
+	if _, err := p.InitializeCloudProvider(cluster, update); err != nil {
+		t.Fatalf("failed to initialize cloud provider again: %v", err)
+	}
+	if cluster.Spec.Cloud.VSphere.ClusterTagID != clusterTagID {
+		t.Errorf("expected the cluster tag to be reused, got %q", cluster.Spec.Cloud.VSphere.ClusterTagID)
+	}
+	if _, err := p.ReconcileCloudProvider(ctx, nil, userClusterClientGetter, cluster, update); err != nil {
+		t.Fatalf("failed to tag virtual machines: %v", err)
+	}
+	attached, err := restSession.TagsManager.ListAttachedTags(ctx, vm.Reference())
+	if err != nil {
+		t.Fatalf("failed to list tags of virtual machine: %v", err)
+	}
+	if len(attached) != 2 {
+		t.Errorf("expected the cluster and the chargeback tag to be attached, got %v", attached)
+	}
+	attached, err = restSession.TagsManager.ListAttachedTags(ctx, foreignVM.Reference())
+	if err != nil {
+		t.Fatalf("failed to list tags of virtual machine: %v", err)
+	}
+	if len(attached) != 0 {
+		t.Errorf("expected no tags on the virtual machine of another owner, got %v", attached)
+	}
+
+	// Tags added later must be attached to the already tagged virtual machine.
+	costCenterTagID, err := restSession.TagsManager.CreateTag(ctx, &tags.Tag{Name: "cost-center-1", CategoryID: categoryID})
+	if err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+	oldSpec := cluster.Spec.Cloud.DeepCopy()
+	cluster.Spec.Cloud.VSphere.Tags = append(cluster.Spec.Cloud.VSphere.Tags, costCenterTagID)
+	if err := p.ValidateCloudSpecUpdate(*oldSpec, cluster.Spec.Cloud); err != nil {
+		t.Fatalf("expected adding a tag to be allowed, got %v", err)
+	}
+	if _, err := p.ReconcileCloudProvider(ctx, nil, userClusterClientGetter, cluster, update); err != nil {
+		t.Fatalf("failed to tag virtual machines again: %v", err)
+	}
+	attached, err = restSession.TagsManager.ListAttachedTags(ctx, vm.Reference())
+	if err != nil {
+		t.Fatalf("failed to list tags of virtual machine: %v", err)
+	}
+	if len(attached) != 3 {
+		t.Errorf("expected the added tag to be attached as well, got %v", attached)
+	}
+	if err := p.ValidateCloudSpecUpdate(cluster.Spec.Cloud, *oldSpec); err == nil {
+		t.Error("expected removing a tag to be rejected")
+	}
+
+	if _, err := p.CleanUpCloudProvider(cluster, update); err != nil {
+		t.Fatalf("failed to clean up cloud provider: %v", err)
+	}
+	if kuberneteshelper.HasFinalizer(cluster, tagCleanupFinalizer) {
+		t.Errorf("expected the %q finalizer to be removed", tagCleanupFinalizer)
+	}
+	if _, err := restSession.TagsManager.GetTag(ctx, clusterTagID); err == nil {
+		t.Error("expected the cluster tag to be deleted")
+	}
+	if _, err := restSession.TagsManager.GetTag(ctx, chargebackTagID); err != nil {
+		t.Errorf("expected the chargeback tag to be kept, got %v", err)
+	}
+
+	// A second cleanup must not fail on the already deleted tag.
+	kuberneteshelper.AddFinalizer(cluster, tagCleanupFinalizer)
+	if _, err := p.CleanUpCloudProvider(cluster, update); err != nil {
+		t.Fatalf("failed to clean up cloud provider twice: %v", err)
+	}
+}
+
+func TestValidateCloudSpecResourcePool(t *testing.T) {
+	dc, username, password := newSimulator(t)
+	p := &Provider{dc: dc}
+
+	cluster := newTagTestCluster(username, password, "")
+	cluster.Spec.Cloud.VSphere.ResourcePool = path.Join("/", dc.Datacenter, "host", dc.Cluster, "Resources")
+	if err := p.ValidateCloudSpec(cluster.Spec.Cloud); err != nil {
+		t.Errorf("expected existing resource pool to be valid, got %v", err)
+	}
+
+	cluster.Spec.Cloud.VSphere.ResourcePool = "/DC0/host/DC0_C0/Resources/does-not-exist"
+	if err := p.ValidateCloudSpec(cluster.Spec.Cloud); err == nil {
+		t.Error("expected missing resource pool to be rejected")
+	}
+
+	cluster.Spec.Cloud.VSphere.ResourcePool = ""
+	cluster.Spec.Cloud.VSphere.Tags = []string{"some-tag"}
+	if err := p.ValidateCloudSpec(cluster.Spec.Cloud); err == nil {
+		t.Error("expected tags without a tag category to be rejected")
+	}
+}
+
+func TestGetResourcePools(t *testing.T) {
+	dc, username, password := newSimulator(t)
+
+	pools, err := GetResourcePools(dc, username, password)
+	if err != nil {
+		t.Fatalf("failed to get resource pools: %v", err)
+	}
+
+	// The pools of other clusters and standalone hosts must not be listed.
+	expected := path.Join("/", dc.Datacenter, "host", dc.Cluster, "Resources")
+	if len(pools) != 1 || pools[0].Path != expected {
+		t.Errorf("expected only resource pool %q, got %v", expected, pools)
+	}
+}
+
+func TestGetTagCategories(t *testing.T) {
+	ctx := context.Background()
+	dc, username, password := newSimulator(t)
+	_, restSession := newTestRESTSession(t, dc, username, password)
+
+	id, err := restSession.TagsManager.CreateCategory(ctx, &tags.Category{
+		Name:            "kubernetes-clusters",
+		Cardinality:     "SINGLE",
+		AssociableTypes: []string{"VirtualMachine"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create tag category: %v", err)
+	}
+
+	categories, err := GetTagCategories(dc, username, password)
+	if err != nil {
+		t.Fatalf("failed to get tag categories: %v", err)
+	}
+	if len(categories) != 1 || categories[0].ID != id || categories[0].Name != "kubernetes-clusters" {
+		t.Errorf("expected only tag category %q, got %v", id, categories)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterTemplateProvider is a object to handle cluster templates
+type ClusterTemplateProvider struct {
+	client ctrlruntimeclient.Client
+	ctx    context.Context
+}
+
+var _ provider.ClusterTemplateProvider = &ClusterTemplateProvider{}
+
+func NewClusterTemplateProvider(ctx context.Context, client ctrlruntimeclient.Client) *ClusterTemplateProvider {
+	return &ClusterTemplateProvider{client: client, ctx: ctx}
+}
+
+func (p *ClusterTemplateProvider) New(userInfo *provider.UserInfo, template *kubermaticv1.ClusterTemplate, scope, projectID string) (*kubermaticv1.ClusterTemplate, error) {
+	if template == nil {
+		return nil, fmt.Errorf("the template can not be nil")
+	}
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+
+	switch scope {
+	case kubermaticv1.GlobalClusterTemplateScope:
+		delete(template.Labels, kubermaticv1.ProjectIDLabelKey)
+	case kubermaticv1.ProjectClusterTemplateScope, kubermaticv1.UserClusterTemplateScope:
+		template.Labels[kubermaticv1.ProjectIDLabelKey] = projectID
+	default:
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("invalid template scope %q", scope))
+	}
+	template.Labels[kubermaticv1.ClusterTemplateScopeLabelKey] = scope
+	template.Annotations[kubermaticv1.ClusterTemplateUserAnnotationKey] = userInfo.Email
+
+	if err := canModifyTemplate(userInfo, template); err != nil {
+		return nil, err
+	}
+	if err := p.client.Create(p.ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (p *ClusterTemplateProvider) List(userInfo *provider.UserInfo, projectID string) ([]kubermaticv1.ClusterTemplate, error) {
+	templateList := &kubermaticv1.ClusterTemplateList{}
+	if err := p.client.List(p.ctx, templateList); err != nil {
+		return nil, fmt.Errorf("failed to list cluster templates: %v", err)
+	}
+
+	templates := []kubermaticv1.ClusterTemplate{}
+	for _, template := range templateList.Items {
+		if canViewTemplate(userInfo, projectID, &template) {
+			templates = append(templates, template)
+		}
+	}
+	return templates, nil
+}
+
+func (p *ClusterTemplateProvider) Get(userInfo *provider.UserInfo, projectID, templateID string) (*kubermaticv1.ClusterTemplate, error) {
+	template := &kubermaticv1.ClusterTemplate{}
+	if err := p.client.Get(p.ctx, ctrlruntimeclient.ObjectKey{Name: templateID}, template); err != nil {
+		return nil, err
+	}
+	// hide templates of other projects and users as if they didn't exist
+	if !canViewTemplate(userInfo, projectID, template) {
+		return nil, kerrors.NewNotFound(schema.GroupResource{Resource: kubermaticv1.ClusterTemplateResourceName}, templateID)
+	}
+	return template, nil
+}
+
+func (p *ClusterTemplateProvider) Update(userInfo *provider.UserInfo, projectID string, template *kubermaticv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error) {
+	if template == nil {
+		return nil, fmt.Errorf("the template can not be nil")
+	}
+
+	oldTemplate, err := p.Get(userInfo, projectID, template.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := canModifyTemplate(userInfo, oldTemplate); err != nil {
+		return nil, err
+	}
+
+	// the scope, the project and the creator can not be changed
+	newTemplate := oldTemplate.DeepCopy()
+	newTemplate.Spec = template.Spec
+	if err := p.client.Patch(p.ctx, newTemplate, ctrlruntimeclient.MergeFrom(oldTemplate)); err != nil {
+		return nil, fmt.Errorf("failed to update cluster template: %v", err)
+	}
+	return newTemplate, nil
+}
+
+func (p *ClusterTemplateProvider) Delete(userInfo *provider.UserInfo, projectID, templateID string) error {
+	template, err := p.Get(userInfo, projectID, templateID)
+	if err != nil {
+		return err
+	}
+	if err := canModifyTemplate(userInfo, template); err != nil {
+		return err
+	}
+	return p.client.Delete(p.ctx, template)
+}
+
+func canViewTemplate(userInfo *provider.UserInfo, projectID string, template *kubermaticv1.ClusterTemplate) bool {
+	scope := template.Labels[kubermaticv1.ClusterTemplateScopeLabelKey]
+	if scope == kubermaticv1.GlobalClusterTemplateScope {
+		return true
+	}
+	if template.Labels[kubermaticv1.ProjectIDLabelKey] != projectID {
+		return false
+	}
+	switch scope {
+	case kubermaticv1.ProjectClusterTemplateScope:
+		return true
+	case kubermaticv1.UserClusterTemplateScope:
+		return userInfo.IsAdmin || template.Annotations[kubermaticv1.ClusterTemplateUserAnnotationKey] == userInfo.Email
+	}
+	return false
+}
+
+func canModifyTemplate(userInfo *provider.UserInfo, template *kubermaticv1.ClusterTemplate) error {
+	if userInfo.IsAdmin {
+		return nil
+	}
+
+	forbidden := func(reason string) error {
+		return kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q %s", userInfo.Email, reason))
+	}
+
+	switch template.Labels[kubermaticv1.ClusterTemplateScopeLabelKey] {
+	case kubermaticv1.GlobalClusterTemplateScope:
+		return forbidden("doesn't have admin rights")
+	case kubermaticv1.UserClusterTemplateScope:
+		if template.Annotations[kubermaticv1.ClusterTemplateUserAnnotationKey] != userInfo.Email {
+			return forbidden("is not the owner of the template")
+		}
+	}
+	if strings.HasPrefix(userInfo.Group, "viewers") {
+		return forbidden("is a viewer of the project")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClusterTemplateProviderUpdate(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name            string
+		userInfo        *provider.UserInfo
+		scope           string
+		owner           string
+		expectForbidden bool
+	}{
+		{
+			name:     "test 1: owner updates the user template",
+			userInfo: &provider.UserInfo{Email: "bob@acme.com", Group: "editors-my-project"},
+			scope:    kubermaticv1.UserClusterTemplateScope,
+			owner:    "bob@acme.com",
+		},
+		{
+			name:     "test 2: project member updates the project template of another user",
+			userInfo: &provider.UserInfo{Email: "bob@acme.com", Group: "editors-my-project"},
+			scope:    kubermaticv1.ProjectClusterTemplateScope,
+			owner:    "john@acme.com",
+		},
+		{
+			name:            "test 3: viewer can't update the project template",
+			userInfo:        &provider.UserInfo{Email: "bob@acme.com", Group: "viewers-my-project"},
+			scope:           kubermaticv1.ProjectClusterTemplateScope,
+			owner:           "john@acme.com",
+			expectForbidden: true,
+		},
+		{
+			name:            "test 4: regular user can't update the global template",
+			userInfo:        &provider.UserInfo{Email: "bob@acme.com", Group: "owners-my-project"},
+			scope:           kubermaticv1.GlobalClusterTemplateScope,
+			owner:           "john@acme.com",
+			expectForbidden: true,
+		},
+		{
+			name:     "test 5: admin updates the global template",
+			userInfo: &provider.UserInfo{Email: "john@acme.com", IsAdmin: true},
+			scope:    kubermaticv1.GlobalClusterTemplateScope,
+			owner:    "bob@acme.com",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := &kubermaticv1.ClusterTemplate{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "template",
+					Labels: map[string]string{
+						kubermaticv1.ClusterTemplateScopeLabelKey: tc.scope,
+						kubermaticv1.ProjectIDLabelKey:            "my-project",
+					},
+					Annotations: map[string]string{kubermaticv1.ClusterTemplateUserAnnotationKey: tc.owner},
+				},
+				Spec: kubermaticv1.ClusterTemplateSpec{HumanReadableName: "old"},
+			}
+			client := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, existing)
+			templateProvider := kubernetes.NewClusterTemplateProvider(context.Background(), client)
+
+			// the labels of the update are ignored, the scope and the owner can't be changed
+			update := &kubermaticv1.ClusterTemplate{
+				ObjectMeta: v1.ObjectMeta{
+					Name:   "template",
+					Labels: map[string]string{kubermaticv1.ClusterTemplateScopeLabelKey: kubermaticv1.GlobalClusterTemplateScope},
+				},
+				Spec: kubermaticv1.ClusterTemplateSpec{HumanReadableName: "new"},
+			}
+			updated, err := templateProvider.Update(tc.userInfo, "my-project", update)
+			if tc.expectForbidden {
+				if !kerrors.IsForbidden(err) {
+					t.Fatalf("expected forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if updated.Spec.HumanReadableName != "new" {
+				t.Fatalf("expected the template to be renamed, got %q", updated.Spec.HumanReadableName)
+			}
+			if updated.Labels[kubermaticv1.ClusterTemplateScopeLabelKey] != tc.scope {
+				t.Fatalf("expected scope %q to be kept, got %q", tc.scope, updated.Labels[kubermaticv1.ClusterTemplateScopeLabelKey])
+			}
+			if updated.Annotations[kubermaticv1.ClusterTemplateUserAnnotationKey] != tc.owner {
+				t.Fatalf("expected owner %q to be kept, got %q", tc.owner, updated.Annotations[kubermaticv1.ClusterTemplateUserAnnotationKey])
+			}
+		})
+	}
+}
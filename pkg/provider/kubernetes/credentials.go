@@ -110,7 +110,10 @@ func ensureCredentialSecret(ctx context.Context, seedClient ctrlruntimeclient.Cl
 		}
 
 		if requiresUpdate {
-			existingSecret.Data = secretData
+			// Keys added by controllers, like the KubeVirt service account kubeconfig, are kept
+			for k, v := range secretData {
+				existingSecret.Data[k] = v
+			}
 			if err := seedClient.Update(ctx, existingSecret); err != nil {
 				return nil, fmt.Errorf("failed to update credential secret: %v", err)
 			}
@@ -22,6 +22,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"time"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -259,8 +260,27 @@ func (p *ExternalClusterProvider) GetVersion(cluster *kubermaticapiv1.ExternalCl
 	return v, nil
 }
 
+// GetKubeSystemUID returns the UID of the kube-system namespace of the cluster
+func (p *ExternalClusterProvider) GetKubeSystemUID(cluster *kubermaticapiv1.ExternalCluster) (types.UID, error) {
+	client, err := p.GetClient(cluster)
+	if err != nil {
+		return "", err
+	}
+	return KubeSystemUID(context.Background(), client)
+}
+
+// KubeSystemUID returns the UID of the kube-system namespace of the cluster the client connects to.
+// The namespace can't be removed, so its UID identifies the cluster.
+func KubeSystemUID(ctx context.Context, client ctrlruntimeclient.Client) (types.UID, error) {
+	namespace := &corev1.Namespace{}
+	if err := client.Get(ctx, types.NamespacedName{Name: metav1.NamespaceSystem}, namespace); err != nil {
+		return "", fmt.Errorf("failed to get the kube-system namespace: %v", err)
+	}
+	return namespace.UID, nil
+}
+
 func (p *ExternalClusterProvider) CreateOrUpdateKubeconfigSecretForCluster(ctx context.Context, cluster *kubermaticapiv1.ExternalCluster, kubeconfig string) error {
-	kubeconfigRef, err := p.ensureKubeconfigSecret(ctx, cluster, map[string][]byte{
+	kubeconfigRef, err := p.ensureSecret(ctx, cluster.GetKubeconfigSecretName(), map[string][]byte{
 		resources.ExternalClusterKubeconfig: []byte(kubeconfig),
 	})
 	if err != nil {
@@ -270,6 +290,30 @@ func (p *ExternalClusterProvider) CreateOrUpdateKubeconfigSecretForCluster(ctx c
 	return nil
 }
 
+// CreateOrUpdateCredentialSecretForCluster stores the cloud credentials of a cluster imported from a
+// managed Kubernetes service and references them in the cloud spec of the cluster
+func (p *ExternalClusterProvider) CreateOrUpdateCredentialSecretForCluster(ctx context.Context, cluster *kubermaticapiv1.ExternalCluster, secretData map[string][]byte) error {
+	cloud := cluster.Spec.CloudSpec
+	if cloud == nil {
+		return errors.New("the cluster has no cloud spec")
+	}
+
+	credentialsRef, err := p.ensureSecret(ctx, cluster.GetCredentialsSecretName(), secretData)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cloud.EKS != nil:
+		cloud.EKS.CredentialsReference = credentialsRef
+	case cloud.GKE != nil:
+		cloud.GKE.CredentialsReference = credentialsRef
+	case cloud.AKS != nil:
+		cloud.AKS.CredentialsReference = credentialsRef
+	}
+	return nil
+}
+
 func (p *ExternalClusterProvider) ListNodes(cluster *kubermaticapiv1.ExternalCluster) (*corev1.NodeList, error) {
 	client, err := p.GetClient(cluster)
 	if err != nil {
@@ -314,9 +358,7 @@ func (p *ExternalClusterProvider) IsMetricServerAvailable(cluster *kubermaticapi
 	return true, nil
 }
 
-func (p *ExternalClusterProvider) ensureKubeconfigSecret(ctx context.Context, cluster *kubermaticapiv1.ExternalCluster, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
-	name := cluster.GetKubeconfigSecretName()
-
+func (p *ExternalClusterProvider) ensureSecret(ctx context.Context, name string, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
 	namespacedName := types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: name}
 	existingSecret := &corev1.Secret{}
 
@@ -324,14 +366,14 @@ func (p *ExternalClusterProvider) ensureKubeconfigSecret(ctx context.Context, cl
 		if !kerrors.IsNotFound(err) {
 			return nil, fmt.Errorf("failed to probe for secret %q: %v", name, err)
 		}
-		return createKubeconfigSecret(ctx, p.clientPrivileged, name, secretData)
+		return createSecret(ctx, p.clientPrivileged, name, secretData)
 	}
 
-	return updateKubeconfigSecret(ctx, p.clientPrivileged, existingSecret, secretData)
+	return updateSecret(ctx, p.clientPrivileged, existingSecret, secretData)
 
 }
 
-func createKubeconfigSecret(ctx context.Context, client ctrlruntimeclient.Client, name string, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
+func createSecret(ctx context.Context, client ctrlruntimeclient.Client, name string, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -341,7 +383,7 @@ func createKubeconfigSecret(ctx context.Context, client ctrlruntimeclient.Client
 		Data: secretData,
 	}
 	if err := client.Create(ctx, secret); err != nil {
-		return nil, fmt.Errorf("failed to create kubeconfig secret: %v", err)
+		return nil, fmt.Errorf("failed to create secret %q: %v", name, err)
 	}
 	return &providerconfig.GlobalSecretKeySelector{
 		ObjectReference: corev1.ObjectReference{
@@ -351,7 +393,7 @@ func createKubeconfigSecret(ctx context.Context, client ctrlruntimeclient.Client
 	}, nil
 }
 
-func updateKubeconfigSecret(ctx context.Context, client ctrlruntimeclient.Client, existingSecret *corev1.Secret, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
+func updateSecret(ctx context.Context, client ctrlruntimeclient.Client, existingSecret *corev1.Secret, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
 	if existingSecret.Data == nil {
 		existingSecret.Data = map[string][]byte{}
 	}
@@ -368,7 +410,7 @@ func updateKubeconfigSecret(ctx context.Context, client ctrlruntimeclient.Client
 	if requiresUpdate {
 		existingSecret.Data = secretData
 		if err := client.Update(ctx, existingSecret); err != nil {
-			return nil, fmt.Errorf("failed to update kubeconfig secret: %v", err)
+			return nil, fmt.Errorf("failed to update secret %q: %v", existingSecret.Name, err)
 		}
 	}
 
@@ -380,6 +422,9 @@ func updateKubeconfigSecret(ctx context.Context, client ctrlruntimeclient.Client
 	}, nil
 }
 
+// externalClusterRequestTimeout limits the duration of every request to an external cluster.
+const externalClusterRequestTimeout = 5 * time.Second
+
 func getRestConfig(cfg *clientcmdapi.Config) (*rest.Config, error) {
 	iconfig := clientcmd.NewNonInteractiveClientConfig(
 		*cfg,
@@ -396,6 +441,8 @@ func getRestConfig(cfg *clientcmdapi.Config) (*rest.Config, error) {
 	// Avoid blocking of the controller by increasing the QPS for user cluster interaction
 	clientConfig.QPS = 20
 	clientConfig.Burst = 50
+	// Unreachable clusters must not block the controller or the API for long
+	clientConfig.Timeout = externalClusterRequestTimeout
 
 	return clientConfig, nil
 }
@@ -22,17 +22,21 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
 
 // presetsGetter is a function to retrieve preset list
-type presetsGetter = func(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error)
+type presetsGetter = func() (*kubermaticv1.PresetList, error)
 
 // LoadPresets loads the custom presets for supported providers
 func LoadPresets(yamlContent []byte) (*kubermaticv1.PresetList, error) {
@@ -66,12 +70,12 @@ func LoadPresetsFromFile(path string) (*kubermaticv1.PresetList, error) {
 
 func presetsGetterFactory(ctx context.Context, client ctrlruntimeclient.Client, presetsFile string, dynamicPresets bool) (presetsGetter, error) {
 	if dynamicPresets {
-		return func(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
+		return func() (*kubermaticv1.PresetList, error) {
 			presetList := &kubermaticv1.PresetList{}
 			if err := client.List(ctx, presetList); err != nil {
 				return nil, fmt.Errorf("failed to get presets %v", err)
 			}
-			return filterOutPresets(userInfo, presetList)
+			return presetList, nil
 		}, nil
 	}
 	var presets *kubermaticv1.PresetList
@@ -88,14 +92,17 @@ func presetsGetterFactory(ctx context.Context, client ctrlruntimeclient.Client,
 		presets = &kubermaticv1.PresetList{Items: []kubermaticv1.Preset{}}
 	}
 
-	return func(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
-		return filterOutPresets(userInfo, presets)
+	return func() (*kubermaticv1.PresetList, error) {
+		return presets, nil
 	}, nil
 }
 
 // PresetsProvider is a object to handle presets from a predefined config
 type PresetsProvider struct {
-	presetsGetter presetsGetter
+	presetsGetter  presetsGetter
+	client         ctrlruntimeclient.Client
+	ctx            context.Context
+	dynamicPresets bool
 }
 
 func NewPresetsProvider(ctx context.Context, client ctrlruntimeclient.Client, presetsFile string, dynamicPresets bool) (*PresetsProvider, error) {
@@ -103,17 +110,83 @@ func NewPresetsProvider(ctx context.Context, client ctrlruntimeclient.Client, pr
 	if err != nil {
 		return nil, err
 	}
-	return &PresetsProvider{presetsGetter: presetsGetter}, nil
+	return &PresetsProvider{presetsGetter: presetsGetter, client: client, ctx: ctx, dynamicPresets: dynamicPresets}, nil
 }
 
-// GetPresets returns presets which belong to the specific email group and for all users
+// GetPresets returns presets which belong to the specific email group and for all users. Presets which
+// are restricted to projects are only returned to members of these projects. The credentials of disabled
+// providers are removed from the presets.
 func (m *PresetsProvider) GetPresets(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
-	return m.presetsGetter(userInfo)
+	presetList, err := m.presetsGetter()
+	if err != nil {
+		return nil, err
+	}
+	presets, err := filterOutPresets(userInfo, presetList)
+	if err != nil {
+		return nil, err
+	}
+
+	var userProjects map[string]bool
+	var result []kubermaticv1.Preset
+	for _, preset := range presets {
+		if len(preset.Spec.Projects) > 0 && !userInfo.IsAdmin {
+			if userProjects == nil {
+				userProjects, err = m.getUserProjects(userInfo.Email)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !isMemberOfAny(userProjects, preset.Spec.Projects) {
+				continue
+			}
+		}
+		result = append(result, removeDisabledProviders(preset))
+	}
+	return result, nil
+}
+
+// getUserProjects returns the IDs of the projects the user is a member of
+func (m *PresetsProvider) getUserProjects(email string) (map[string]bool, error) {
+	bindings := &kubermaticv1.UserProjectBindingList{}
+	if err := m.client.List(m.ctx, bindings); err != nil {
+		return nil, fmt.Errorf("failed to get project members %v", err)
+	}
+	projects := map[string]bool{}
+	for _, binding := range bindings.Items {
+		if strings.EqualFold(binding.Spec.UserEmail, email) {
+			projects[binding.Spec.ProjectID] = true
+		}
+	}
+	return projects, nil
+}
+
+func isMemberOfAny(userProjects map[string]bool, projects []string) bool {
+	for _, project := range projects {
+		if userProjects[project] {
+			return true
+		}
+	}
+	return false
+}
+
+// removeDisabledProviders returns a copy of the preset without the credentials of disabled providers
+func removeDisabledProviders(preset kubermaticv1.Preset) kubermaticv1.Preset {
+	spec := reflect.ValueOf(&preset.Spec).Elem()
+	for i := 0; i < spec.NumField(); i++ {
+		field := spec.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		if providerPreset, ok := field.Interface().(interface{ IsEnabled() bool }); ok && !providerPreset.IsEnabled() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+	return preset
 }
 
 // GetPreset returns preset with the name which belong to the specific email group
 func (m *PresetsProvider) GetPreset(userInfo *provider.UserInfo, name string) (*kubermaticv1.Preset, error) {
-	presets, err := m.presetsGetter(userInfo)
+	presets, err := m.GetPresets(userInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +221,16 @@ func filterOutPresets(userInfo *provider.UserInfo, list *kubermaticv1.PresetList
 	return presetList, nil
 }
 
-func (m *PresetsProvider) SetCloudCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error) {
+// SetCloudCredentials sets the credentials of the preset with the given name in the cloud spec of a
+// cluster in the given project
+func (m *PresetsProvider) SetCloudCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, presetName)
+	if err != nil {
+		return nil, err
+	}
+	if !preset.Spec.IsAvailableInProject(projectID) {
+		return nil, fmt.Errorf("the preset %s is not available in the project %s", presetName, projectID)
+	}
 
 	if cloud.VSphere != nil {
 		return m.setVsphereCredentials(userInfo, presetName, cloud)
@@ -187,6 +269,66 @@ func (m *PresetsProvider) SetCloudCredentials(userInfo *provider.UserInfo, prese
 	return nil, fmt.Errorf("can not find provider to set credentials")
 }
 
+// GetAllPresets returns all presets including the disabled provider credentials, it is restricted to admins
+func (m *PresetsProvider) GetAllPresets(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
+	if !userInfo.IsAdmin {
+		return nil, kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	presetList, err := m.presetsGetter()
+	if err != nil {
+		return nil, err
+	}
+	return presetList.Items, nil
+}
+
+// CreatePreset creates the given preset, it is restricted to admins
+func (m *PresetsProvider) CreatePreset(userInfo *provider.UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error) {
+	if err := m.checkManagePresets(userInfo); err != nil {
+		return nil, err
+	}
+	if err := m.client.Create(m.ctx, preset); err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// UpdatePreset updates the given preset, it is restricted to admins
+func (m *PresetsProvider) UpdatePreset(userInfo *provider.UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error) {
+	if err := m.checkManagePresets(userInfo); err != nil {
+		return nil, err
+	}
+	existing := &kubermaticv1.Preset{}
+	if err := m.client.Get(m.ctx, ctrlruntimeclient.ObjectKey{Name: preset.Name}, existing); err != nil {
+		return nil, err
+	}
+	updated := existing.DeepCopy()
+	updated.Spec = preset.Spec
+	if err := m.client.Update(m.ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeletePreset deletes the preset with the given name, it is restricted to admins
+func (m *PresetsProvider) DeletePreset(userInfo *provider.UserInfo, name string) error {
+	if err := m.checkManagePresets(userInfo); err != nil {
+		return err
+	}
+	return m.client.Delete(m.ctx, &kubermaticv1.Preset{ObjectMeta: metav1.ObjectMeta{Name: name}})
+}
+
+// checkManagePresets checks that the user can manage presets. Presets which are loaded from a file
+// can not be managed through the API.
+func (m *PresetsProvider) checkManagePresets(userInfo *provider.UserInfo) error {
+	if !userInfo.IsAdmin {
+		return kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	if !m.dynamicPresets {
+		return kerrors.NewBadRequest("the presets are loaded from a file and can not be managed through the API")
+	}
+	return nil
+}
+
 func emptyCredentialError(preset, provider string) error {
 	return fmt.Errorf("the preset %s doesn't contain credential for %s provider", preset, provider)
 }
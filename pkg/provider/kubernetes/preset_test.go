@@ -332,6 +332,64 @@ func TestGetPresets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "test 3: get Presets restricted to the projects of the user",
+			userInfo: provider.UserInfo{Email: "test@example.com"},
+			presets: []runtime.Object{
+				genPresetWithProjects("test-1", "my-project"),
+				genPresetWithProjects("test-2", "other-project"),
+				genBinding("my-project", "test@example.com", "owners"),
+			},
+			expected: []kubermaticv1.Preset{
+				*genPresetWithProjects("test-1", "my-project"),
+			},
+		},
+		{
+			name:     "test 4: the admin gets Presets of all projects",
+			userInfo: provider.UserInfo{Email: "admin@example.com", IsAdmin: true},
+			presets: []runtime.Object{
+				genPresetWithProjects("test-1", "my-project"),
+				genPresetWithProjects("test-2", "other-project"),
+			},
+			expected: []kubermaticv1.Preset{
+				*genPresetWithProjects("test-1", "my-project"),
+				*genPresetWithProjects("test-2", "other-project"),
+			},
+		},
+		{
+			name:     "test 5: the credentials of disabled providers are removed",
+			userInfo: provider.UserInfo{Email: "test@example.com"},
+			presets: []runtime.Object{
+				&kubermaticv1.Preset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-1",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Fake: &kubermaticv1.Fake{
+							ProviderPreset: kubermaticv1.ProviderPreset{Enabled: boolPtr(false)},
+							Token:          "aaaaa",
+						},
+						Hetzner: &kubermaticv1.Hetzner{
+							ProviderPreset: kubermaticv1.ProviderPreset{Enabled: boolPtr(true)},
+							Token:          "bbbbb",
+						},
+					},
+				},
+			},
+			expected: []kubermaticv1.Preset{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-1",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Hetzner: &kubermaticv1.Hetzner{
+							ProviderPreset: kubermaticv1.ProviderPreset{Enabled: boolPtr(true)},
+							Token:          "bbbbb",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -361,6 +419,7 @@ func TestCredentialEndpoint(t *testing.T) {
 	testcases := []struct {
 		name              string
 		presetName        string
+		projectID         string
 		userInfo          provider.UserInfo
 		expectedError     string
 		cloudSpec         kubermaticv1.CloudSpec
@@ -672,6 +731,51 @@ func TestCredentialEndpoint(t *testing.T) {
 			cloudSpec:         kubermaticv1.CloudSpec{Alibaba: &kubermaticv1.AlibabaCloudSpec{}},
 			expectedCloudSpec: &kubermaticv1.CloudSpec{Alibaba: &kubermaticv1.AlibabaCloudSpec{AccessKeyID: "key", AccessKeySecret: "secret"}},
 		},
+		{
+			name:       "test 15: set credentials of a preset in one of its projects",
+			presetName: "test",
+			projectID:  "my-project",
+			userInfo:   provider.UserInfo{Email: "test@example.com"},
+			presets: []runtime.Object{
+				genPresetWithProjects("test", "my-project"),
+				genBinding("my-project", "test@example.com", "owners"),
+			},
+			cloudSpec:         kubermaticv1.CloudSpec{Fake: &kubermaticv1.FakeCloudSpec{}},
+			expectedCloudSpec: &kubermaticv1.CloudSpec{Fake: &kubermaticv1.FakeCloudSpec{Token: "abcd"}},
+		},
+		{
+			name:       "test 16: credentials of a preset can not be used in other projects",
+			presetName: "test",
+			projectID:  "other-project",
+			userInfo:   provider.UserInfo{Email: "test@example.com"},
+			presets: []runtime.Object{
+				genPresetWithProjects("test", "my-project"),
+				genBinding("my-project", "test@example.com", "owners"),
+				genBinding("other-project", "test@example.com", "owners"),
+			},
+			cloudSpec:     kubermaticv1.CloudSpec{Fake: &kubermaticv1.FakeCloudSpec{}},
+			expectedError: "the preset test is not available in the project other-project",
+		},
+		{
+			name:       "test 17: credentials of a disabled provider can not be used",
+			presetName: "test",
+			userInfo:   provider.UserInfo{Email: "test@example.com"},
+			presets: []runtime.Object{
+				&kubermaticv1.Preset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Fake: &kubermaticv1.Fake{
+							ProviderPreset: kubermaticv1.ProviderPreset{Enabled: boolPtr(false)},
+							Token:          "abcd",
+						},
+					},
+				},
+			},
+			cloudSpec:     kubermaticv1.CloudSpec{Fake: &kubermaticv1.FakeCloudSpec{}},
+			expectedError: "the preset test doesn't contain credential for Fake provider",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -681,7 +785,7 @@ func TestCredentialEndpoint(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			cloudResult, err := provider.SetCloudCredentials(&tc.userInfo, tc.presetName, tc.cloudSpec, tc.dc)
+			cloudResult, err := provider.SetCloudCredentials(&tc.userInfo, tc.projectID, tc.presetName, tc.cloudSpec, tc.dc)
 
 			if len(tc.expectedError) > 0 {
 				if err == nil {
@@ -697,3 +801,21 @@ func TestCredentialEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func genPresetWithProjects(name string, projects ...string) *kubermaticv1.Preset {
+	return &kubermaticv1.Preset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.PresetSpec{
+			Projects: projects,
+			Fake: &kubermaticv1.Fake{
+				Token: "abcd",
+			},
+		},
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -82,15 +82,16 @@ func (s *SettingsProvider) createDefaultGlobalSettings() (*kubermaticv1.Kubermat
 				Enabled:  false,
 				Enforced: false,
 			},
-			DefaultNodeCount:        10,
-			ClusterTypeOptions:      kubermaticv1.ClusterTypeKubernetes,
-			DisplayDemoInfo:         false,
-			DisplayAPIDocs:          false,
-			DisplayTermsOfService:   false,
-			EnableDashboard:         true,
-			EnableOIDCKubeconfig:    false,
-			UserProjectsLimit:       0,
-			RestrictProjectCreation: false,
+			DefaultNodeCount:                  10,
+			ClusterTypeOptions:                kubermaticv1.ClusterTypeKubernetes,
+			DisplayDemoInfo:                   false,
+			DisplayAPIDocs:                    false,
+			DisplayTermsOfService:             false,
+			EnableDashboard:                   true,
+			EnableOIDCKubeconfig:              false,
+			UserProjectsLimit:                 0,
+			RestrictProjectCreation:           false,
+			ServiceAccountTokenMaxExpiryHours: 0,
 		},
 	}
 	if err := s.runtimeClient.Create(context.Background(), defaultSettings); err != nil {
@@ -74,6 +74,15 @@ type CloudProvider interface {
 	ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error
 }
 
+// PeriodicReconciler is implemented by cloud providers which manage resources that can change
+// without a change of the cluster. It is called periodically once the cloud provider got initialized.
+type PeriodicReconciler interface {
+	ReconcileCloudProvider(context.Context, ctrlruntimeclient.Client, UserClusterClientGetter, *kubermaticv1.Cluster, ClusterUpdater) (*kubermaticv1.Cluster, error)
+}
+
+// UserClusterClientGetter returns a client for the user cluster
+type UserClusterClientGetter func(*kubermaticv1.Cluster) (ctrlruntimeclient.Client, error)
+
 // ClusterUpdater defines a function to persist an update to a cluster
 type ClusterUpdater func(string, func(*kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error)
 
@@ -701,7 +710,14 @@ type AdminProvider interface {
 type PresetProvider interface {
 	GetPresets(userInfo *UserInfo) ([]kubermaticv1.Preset, error)
 	GetPreset(userInfo *UserInfo, name string) (*kubermaticv1.Preset, error)
-	SetCloudCredentials(userInfo *UserInfo, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error)
+	SetCloudCredentials(userInfo *UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error)
+
+	// GetAllPresets returns all presets without any filtering, it is restricted to admins
+	GetAllPresets(userInfo *UserInfo) ([]kubermaticv1.Preset, error)
+	// CreatePreset, UpdatePreset and DeletePreset are restricted to admins and require dynamic presets
+	CreatePreset(userInfo *UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error)
+	UpdatePreset(userInfo *UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error)
+	DeletePreset(userInfo *UserInfo, name string) error
 }
 
 // AdmissionPluginsProvider declares the set of methods for interacting with admission plugins
@@ -713,6 +729,21 @@ type AdmissionPluginsProvider interface {
 	ListPluginNamesFromVersion(fromVersion string) ([]string, error)
 }
 
+// ClusterTemplateProvider declares the set of methods for interacting with cluster templates
+type ClusterTemplateProvider interface {
+	// New creates a template with the given scope. Project and user templates are bound to the project.
+	New(userInfo *UserInfo, template *kubermaticv1.ClusterTemplate, scope, projectID string) (*kubermaticv1.ClusterTemplate, error)
+
+	// List returns the global templates and the templates of the project which are visible to the user
+	List(userInfo *UserInfo, projectID string) ([]kubermaticv1.ClusterTemplate, error)
+
+	Get(userInfo *UserInfo, projectID, templateID string) (*kubermaticv1.ClusterTemplate, error)
+
+	Update(userInfo *UserInfo, projectID string, template *kubermaticv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error)
+
+	Delete(userInfo *UserInfo, projectID, templateID string) error
+}
+
 // ExternalClusterProvider declares the set of methods for interacting with external cluster
 type ExternalClusterProvider interface {
 	New(userInfo *UserInfo, project *kubermaticv1.Project, cluster *kubermaticv1.ExternalCluster) (*kubermaticv1.ExternalCluster, error)
@@ -731,6 +762,8 @@ type ExternalClusterProvider interface {
 
 	CreateOrUpdateKubeconfigSecretForCluster(ctx context.Context, cluster *kubermaticv1.ExternalCluster, kubeconfig string) error
 
+	CreateOrUpdateCredentialSecretForCluster(ctx context.Context, cluster *kubermaticv1.ExternalCluster, secretData map[string][]byte) error
+
 	GetVersion(cluster *kubermaticv1.ExternalCluster) (*ksemver.Semver, error)
 
 	ListNodes(cluster *kubermaticv1.ExternalCluster) (*corev1.NodeList, error)
@@ -32,6 +32,9 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// sniPort is the port of the NodePort proxy on which the clusters exposed via SNI are reachable
+const sniPort = 443
+
 func SyncClusterAddress(ctx context.Context,
 	log *zap.SugaredLogger,
 	cluster *kubermaticv1.Cluster,
@@ -124,7 +127,13 @@ func SyncClusterAddress(ctx context.Context,
 	}
 
 	// URL
-	url := fmt.Sprintf("https://%s:%d", externalName, port)
+	// The apiserver keeps listening on the NodePort, only the URL used to reach
+	// it from outside of the seed changes when the cluster is exposed via SNI
+	urlPort := port
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+		urlPort = sniPort
+	}
+	url := fmt.Sprintf("https://%s:%d", externalName, urlPort)
 	if cluster.Address.URL != url {
 		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
 			c.Address.URL = url
@@ -151,6 +151,25 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(32000),
 			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:32000", fakeClusterName, fakeExternalURL),
 		},
+		{
+			name: "Verify properties for SNI",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(443),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						},
+					},
+				}},
+			exposeStrategy:       kubermaticv1.ExposeStrategySNI,
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(32000),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s:443", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
 		{
 			name: "Verify error when service has less than one ports",
 			apiserverService: corev1.Service{
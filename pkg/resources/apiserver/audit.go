@@ -0,0 +1,306 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	auditPolicyKey     = "policy.yaml"
+	auditLogDir        = "/var/log/kubernetes/audit"
+	auditLogPath       = auditLogDir + "/audit.log"
+	auditSidecarName   = "audit-logs"
+	legacyFluentBitTag = "1.2.2"
+	// Forwarding to syslog requires at least fluent-bit 1.5
+	fluentBitTag = "1.6.10"
+
+	defaultAuditLogMaxAge    = 30
+	defaultAuditLogMaxBackup = 3
+	defaultAuditLogMaxSize   = 100
+	// auditSidecarStateSize is the space in megabytes reserved for the state of the sidecar on the audit log volume
+	auditSidecarStateSize = 10
+)
+
+const metadataAuditPolicy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: Metadata
+`
+
+const recommendedAuditPolicy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+# Skip noisy, read-only requests of system components and health checks.
+- level: None
+  users: ["system:kube-proxy"]
+  verbs: ["watch"]
+  resources:
+  - group: ""
+    resources: ["endpoints", "services", "services/status"]
+- level: None
+  nonResourceURLs: ["/healthz*", "/livez*", "/readyz*", "/version"]
+- level: None
+  resources:
+  - group: ""
+    resources: ["events"]
+  - group: "events.k8s.io"
+    resources: ["events"]
+# Secrets, ConfigMaps and tokens must never be logged with their content.
+- level: Metadata
+  resources:
+  - group: ""
+    resources: ["secrets", "configmaps", "serviceaccounts/token"]
+  - group: "authentication.k8s.io"
+    resources: ["tokenreviews"]
+# Log the full request and response of changes to permissions and of interactive pod sessions.
+- level: RequestResponse
+  verbs: ["create", "update", "patch", "delete", "deletecollection"]
+  resources:
+  - group: "rbac.authorization.k8s.io"
+  - group: "admissionregistration.k8s.io"
+  - group: "policy"
+    resources: ["podsecuritypolicies"]
+  - group: ""
+    resources: ["serviceaccounts", "pods/exec", "pods/attach", "pods/portforward"]
+- level: Metadata
+`
+
+// AuditConfigMapCreator returns a function to create the ConfigMap containing the audit policy of the apiserver
+func AuditConfigMapCreator(data *resources.TemplateData) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			settings := data.Cluster().Spec.AuditLogging
+			// Without an explicitly selected preset we only create the default policy and keep
+			// manual changes to existing ConfigMaps.
+			if settings == nil || settings.PolicyPreset == "" {
+				if cm.Data == nil {
+					cm.Data = map[string]string{
+						auditPolicyKey: metadataAuditPolicy,
+					}
+				}
+				return cm, nil
+			}
+
+			policy, err := getAuditPolicy(data, settings)
+			if err != nil {
+				return nil, err
+			}
+			cm.Data = map[string]string{
+				auditPolicyKey: policy,
+			}
+			return cm, nil
+		}
+	}
+}
+
+func getAuditPolicy(data *resources.TemplateData, settings *kubermaticv1.AuditLoggingSettings) (string, error) {
+	switch settings.PolicyPreset {
+	case kubermaticv1.AuditPolicyMetadata:
+		return metadataAuditPolicy, nil
+	case kubermaticv1.AuditPolicyRecommended:
+		return recommendedAuditPolicy, nil
+	case kubermaticv1.AuditPolicyCustom:
+		if settings.CustomPolicy == nil || settings.CustomPolicy.Name == "" {
+			return "", fmt.Errorf("audit policy preset %q requires a custom policy ConfigMap", settings.PolicyPreset)
+		}
+		configMap, err := data.GetSeedConfigMap(settings.CustomPolicy.Name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get custom audit policy ConfigMap %q: %v", settings.CustomPolicy.Name, err)
+		}
+		key := settings.CustomPolicy.Key
+		if key == "" {
+			key = auditPolicyKey
+		}
+		policy, ok := configMap.Data[key]
+		if !ok || policy == "" {
+			return "", fmt.Errorf("custom audit policy ConfigMap %q has no key %q", settings.CustomPolicy.Name, key)
+		}
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown audit policy preset %q", settings.PolicyPreset)
+	}
+}
+
+// getAuditLogRotation returns the rotation settings of the audit log with defaults applied.
+func getAuditLogRotation(settings *kubermaticv1.AuditLoggingSettings) kubermaticv1.AuditLogRotationSettings {
+	rotation := kubermaticv1.AuditLogRotationSettings{
+		MaxAge:    defaultAuditLogMaxAge,
+		MaxBackup: defaultAuditLogMaxBackup,
+		MaxSize:   defaultAuditLogMaxSize,
+	}
+	if settings == nil || settings.LogRotation == nil {
+		return rotation
+	}
+	if settings.LogRotation.MaxAge > 0 {
+		rotation.MaxAge = settings.LogRotation.MaxAge
+	}
+	if settings.LogRotation.MaxBackup > 0 {
+		rotation.MaxBackup = settings.LogRotation.MaxBackup
+	}
+	if settings.LogRotation.MaxSize > 0 {
+		rotation.MaxSize = settings.LogRotation.MaxSize
+	}
+	return rotation
+}
+
+// getAuditLogPath returns the path of the file the apiserver writes the audit log to. A file sink
+// replaces the default file, so it gets rotated by the apiserver.
+func getAuditLogPath(settings *kubermaticv1.AuditLoggingSettings) string {
+	if settings != nil {
+		for _, sink := range settings.Sinks {
+			if sink.File != nil {
+				return auditLogDir + "/" + sink.File.Name
+			}
+		}
+	}
+	return auditLogPath
+}
+
+// auditLogVolumeSizeLimit returns the size limit of the audit log volume, which has to hold the
+// current and the rotated audit log files as well as the state of the sidecar.
+func auditLogVolumeSizeLimit(settings *kubermaticv1.AuditLoggingSettings) *resource.Quantity {
+	rotation := getAuditLogRotation(settings)
+	limit := resource.MustParse(fmt.Sprintf("%dMi", (rotation.MaxBackup+1)*rotation.MaxSize+auditSidecarStateSize))
+	return &limit
+}
+
+// auditSidecarContainer returns the fluent-bit container which tails the audit log and
+// ships it to the configured sinks.
+func auditSidecarContainer(settings *kubermaticv1.AuditLoggingSettings) (*corev1.Container, error) {
+	image := "docker.io/fluent/fluent-bit:" + legacyFluentBitTag
+	args := []string{"-i", "tail", "-p", "path=" + getAuditLogPath(settings), "-p", "db=" + auditLogDir + "/fluentbit.db"}
+
+	if len(settings.Sinks) == 0 {
+		args = append(args, "-o", "stdout")
+	} else {
+		image = "docker.io/fluent/fluent-bit:" + fluentBitTag
+		var outputs []string
+		for i, sink := range settings.Sinks {
+			sinkArgs, err := getAuditSinkArgs(sink)
+			if err != nil {
+				return nil, fmt.Errorf("invalid audit sink %d: %v", i, err)
+			}
+			outputs = append(outputs, sinkArgs...)
+		}
+		// file sinks are written by the apiserver, so there may be nothing left to ship
+		if len(outputs) == 0 {
+			outputs = []string{"-o", "null"}
+		}
+		args = append(args, outputs...)
+	}
+
+	return &corev1.Container{
+		Name:    auditSidecarName,
+		Image:   image,
+		Command: []string{"/fluent-bit/bin/fluent-bit"},
+		Args:    args,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      resources.AuditLogVolumeName,
+				MountPath: auditLogDir,
+				ReadOnly:  false,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("10Mi"),
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("60Mi"),
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+			},
+		},
+	}, nil
+}
+
+func getAuditSinkArgs(sink kubermaticv1.AuditSink) ([]string, error) {
+	switch {
+	case sink.Webhook != nil:
+		u, err := url.Parse(sink.Webhook.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse webhook URL: %v", err)
+		}
+		port := u.Port()
+		tls := "off"
+		switch u.Scheme {
+		case "https":
+			tls = "on"
+			if port == "" {
+				port = "443"
+			}
+		case "http":
+			if port == "" {
+				port = "80"
+			}
+		default:
+			return nil, fmt.Errorf("unsupported webhook URL scheme %q", u.Scheme)
+		}
+		uri := u.RequestURI()
+		tlsVerify := "on"
+		if sink.Webhook.InsecureSkipVerify {
+			tlsVerify = "off"
+		}
+		return []string{
+			"-o", "http",
+			"-p", "match=*",
+			"-p", "host=" + u.Hostname(),
+			"-p", "port=" + port,
+			"-p", "uri=" + uri,
+			"-p", "format=json_lines",
+			"-p", "tls=" + tls,
+			"-p", "tls.verify=" + tlsVerify,
+		}, nil
+
+	case sink.Syslog != nil:
+		mode := sink.Syslog.Mode
+		if mode == "" {
+			mode = "udp"
+		}
+		format := sink.Syslog.Format
+		if format == "" {
+			format = "rfc5424"
+		}
+		return []string{
+			"-o", "syslog",
+			"-p", "match=*",
+			"-p", "host=" + sink.Syslog.Host,
+			"-p", "port=" + strconv.Itoa(sink.Syslog.Port),
+			"-p", "mode=" + mode,
+			"-p", "syslog_format=" + format,
+			"-p", "syslog_message_key=log",
+		}, nil
+
+	case sink.File != nil:
+		// the apiserver writes and rotates the file itself, see getAuditLogPath
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("no sink configured")
+}
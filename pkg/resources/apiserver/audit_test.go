@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAuditConfigMapCreator(t *testing.T) {
+	const customPolicy = "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: RequestResponse\n"
+
+	testCases := []struct {
+		name           string
+		settings       *kubermaticv1.AuditLoggingSettings
+		existingPolicy string
+		expectedPolicy string
+		errExpected    bool
+	}{
+		{
+			name:           "Default policy is created",
+			expectedPolicy: metadataAuditPolicy,
+		},
+		{
+			name:           "Manual changes are kept without a preset",
+			settings:       &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			existingPolicy: customPolicy,
+			expectedPolicy: customPolicy,
+		},
+		{
+			name: "Manual changes are overwritten by a preset",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyRecommended,
+			},
+			existingPolicy: customPolicy,
+			expectedPolicy: recommendedAuditPolicy,
+		},
+		{
+			name: "Custom policy is read from the seed namespace",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyCustom,
+				CustomPolicy: &kubermaticv1.AuditPolicyConfigMapRef{Name: "security-audit-policy"},
+			},
+			expectedPolicy: customPolicy,
+		},
+		{
+			name: "Missing key of the custom policy is rejected",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyCustom,
+				CustomPolicy: &kubermaticv1.AuditPolicyConfigMapRef{Name: "security-audit-policy", Key: "other.yaml"},
+			},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "security-audit-policy", Namespace: "kubermatic"},
+				Data:       map[string]string{auditPolicyKey: customPolicy},
+			})
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{AuditLogging: tc.settings},
+			}
+			seed := &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{Name: "europe-west3", Namespace: "kubermatic"},
+			}
+			data := resources.NewTemplateData(context.Background(), client, cluster, nil, seed, "", "", "", resource.Quantity{}, "", "", false, false, "", "", "", "", false, "", "", "", false)
+
+			existing := &corev1.ConfigMap{}
+			if tc.existingPolicy != "" {
+				existing.Data = map[string]string{auditPolicyKey: tc.existingPolicy}
+			}
+
+			_, creator := AuditConfigMapCreator(data)()
+			cm, err := creator(existing)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("Expected err: %t, but got err %v", tc.errExpected, err)
+			}
+			if err != nil {
+				return
+			}
+			if policy := cm.Data[auditPolicyKey]; policy != tc.expectedPolicy {
+				t.Errorf("Expected policy\n%s\nbut got\n%s", tc.expectedPolicy, policy)
+			}
+		})
+	}
+}
+
+func TestAuditSidecarContainer(t *testing.T) {
+	testCases := []struct {
+		name          string
+		settings      *kubermaticv1.AuditLoggingSettings
+		expectedImage string
+		expectedArgs  []string
+	}{
+		{
+			name:          "Audit log is written to stdout without sinks",
+			settings:      &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			expectedImage: "docker.io/fluent/fluent-bit:1.2.2",
+			expectedArgs:  []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db", "-o", "stdout"},
+		},
+		{
+			name: "Audit log is shipped to webhook and syslog",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled: true,
+				Sinks: []kubermaticv1.AuditSink{
+					{Webhook: &kubermaticv1.AuditWebhookSink{URL: "https://siem.example.com/audit?source=kkp"}},
+					{Syslog: &kubermaticv1.AuditSyslogSink{Host: "10.0.0.1", Port: 6514, Mode: "tls"}},
+				},
+			},
+			expectedImage: "docker.io/fluent/fluent-bit:1.6.10",
+			expectedArgs: []string{
+				"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db",
+				"-o", "http", "-p", "match=*", "-p", "host=siem.example.com", "-p", "port=443", "-p", "uri=/audit?source=kkp", "-p", "format=json_lines", "-p", "tls=on", "-p", "tls.verify=on",
+				"-o", "syslog", "-p", "match=*", "-p", "host=10.0.0.1", "-p", "port=6514", "-p", "mode=tls", "-p", "syslog_format=rfc5424", "-p", "syslog_message_key=log",
+			},
+		},
+		{
+			name: "Audit log written to a file sink is tailed from there",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled: true,
+				Sinks: []kubermaticv1.AuditSink{
+					{File: &kubermaticv1.AuditFileSink{Name: "shipped.log"}},
+				},
+			},
+			expectedImage: "docker.io/fluent/fluent-bit:1.6.10",
+			expectedArgs:  []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/shipped.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db", "-o", "null"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			container, err := auditSidecarContainer(tc.settings)
+			if err != nil {
+				t.Fatalf("failed to get audit sidecar: %v", err)
+			}
+			if container.Image != tc.expectedImage {
+				t.Errorf("Expected image %q, but got %q", tc.expectedImage, container.Image)
+			}
+			if !reflect.DeepEqual(container.Args, tc.expectedArgs) {
+				t.Errorf("Expected args\n%s\nbut got\n%s", strings.Join(tc.expectedArgs, " "), strings.Join(container.Args, " "))
+			}
+		})
+	}
+}
+
+func TestAuditLogVolumeSizeLimit(t *testing.T) {
+	settings := &kubermaticv1.AuditLoggingSettings{
+		LogRotation: &kubermaticv1.AuditLogRotationSettings{MaxBackup: 5, MaxSize: 200},
+	}
+	if limit := auditLogVolumeSizeLimit(settings); limit.String() != "1210Mi" {
+		t.Errorf("Expected a size limit of 1210Mi, but got %s", limit.String())
+	}
+}
+
+func TestGetAuditLogRotation(t *testing.T) {
+	settings := &kubermaticv1.AuditLoggingSettings{
+		LogRotation: &kubermaticv1.AuditLogRotationSettings{MaxAge: 90, MaxSize: 500},
+	}
+	expected := kubermaticv1.AuditLogRotationSettings{MaxAge: 90, MaxBackup: 3, MaxSize: 500}
+	if rotation := getAuditLogRotation(settings); rotation != expected {
+		t.Errorf("Expected rotation settings %+v, but got %+v", expected, rotation)
+	}
+}
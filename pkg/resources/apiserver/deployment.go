@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -54,22 +55,6 @@ const (
 	defaultNodePortRange = "30000-32767"
 )
 
-func AuditConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
-	return func() (string, reconciling.ConfigMapCreator) {
-		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
-			if cm.Data == nil {
-				cm.Data = map[string]string{
-					"policy.yaml": `apiVersion: audit.k8s.io/v1
-kind: Policy
-rules:
-- level: Metadata
-`}
-			}
-			return cm, nil
-		}
-	}
-}
-
 // DeploymentCreator returns the function to create and update the API server deployment
 func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bool) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
@@ -87,7 +72,7 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			}
 			dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
 
-			volumes := getVolumes()
+			volumes := getVolumes(data.Cluster().Spec.AuditLogging)
 			volumeMounts := getVolumeMounts()
 
 			if enableOIDCAuthentication && len(data.OIDCCAFile()) > 0 {
@@ -209,32 +194,12 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				return nil, fmt.Errorf("failed to set resource requirements: %v", err)
 			}
 
-			if data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled {
-				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers,
-					corev1.Container{
-						Name:    "audit-logs",
-						Image:   "docker.io/fluent/fluent-bit:1.2.2",
-						Command: []string{"/fluent-bit/bin/fluent-bit"},
-						Args:    []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db", "-o", "stdout"},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      resources.AuditLogVolumeName,
-								MountPath: "/var/log/kubernetes/audit",
-								ReadOnly:  false,
-							},
-						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceMemory: resource.MustParse("10Mi"),
-								corev1.ResourceCPU:    resource.MustParse("5m"),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceMemory: resource.MustParse("60Mi"),
-								corev1.ResourceCPU:    resource.MustParse("50m"),
-							},
-						},
-					},
-				)
+			if auditLogEnabled {
+				auditSidecar, err := auditSidecarContainer(data.Cluster().Spec.AuditLogging)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get audit-logs sidecar: %v", err)
+				}
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, *auditSidecar)
 			}
 
 			dep.Spec.Template.Spec.Affinity = resources.HostnameAntiAffinity(name, data.Cluster().Name)
@@ -270,10 +235,18 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 
 	admissionPlugins.Insert(data.Cluster().Spec.AdmissionPlugins...)
 
+	auditLogRotation := getAuditLogRotation(data.Cluster().Spec.AuditLogging)
+
+	// The advertise address is used as endpoint address for the kubernetes
+	// service in the default namespace of the user cluster. When exposed via
+	// SNI, the apiserver is only reachable through the SNI tunnel agent.
+	advertiseAddress := data.Cluster().Address.IP
+	if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+		advertiseAddress = resources.SNITunnelAgentIP
+	}
+
 	flags := []string{
-		// The advertise address is used as endpoint address for the kubernetes
-		// service in the default namespace of the user cluster.
-		"--advertise-address", data.Cluster().Address.IP,
+		"--advertise-address", advertiseAddress,
 		// The secure port is used as target port for the kubernetes service in
 		// the default namespace of the user cluster, we use the NodePort value
 		// for being able to access the apiserver from the usercluster side.
@@ -294,10 +267,10 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		"--service-cluster-ip-range", data.Cluster().Spec.ClusterNetwork.Services.CIDRBlocks[0],
 		"--service-node-port-range", nodePortRange,
 		"--allow-privileged",
-		"--audit-log-maxage", "30",
-		"--audit-log-maxbackup", "3",
-		"--audit-log-maxsize", "100",
-		"--audit-log-path", "/var/log/kubernetes/audit/audit.log",
+		"--audit-log-maxage", strconv.Itoa(auditLogRotation.MaxAge),
+		"--audit-log-maxbackup", strconv.Itoa(auditLogRotation.MaxBackup),
+		"--audit-log-maxsize", strconv.Itoa(auditLogRotation.MaxSize),
+		"--audit-log-path", getAuditLogPath(data.Cluster().Spec.AuditLogging),
 		"--tls-cert-file", "/etc/kubernetes/tls/apiserver-tls.crt",
 		"--tls-private-key-file", "/etc/kubernetes/tls/apiserver-tls.key",
 		"--proxy-client-cert-file", "/etc/kubernetes/pki/front-proxy/client/" + resources.ApiserverProxyClientCertificateCertSecretKey,
@@ -313,7 +286,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	}
 
 	if auditLogEnabled {
-		flags = append(flags, "--audit-policy-file", "/etc/kubernetes/audit/policy.yaml")
+		flags = append(flags, "--audit-policy-file", "/etc/kubernetes/audit/"+auditPolicyKey)
 	}
 
 	if endpointReconcilingDisabled {
@@ -420,7 +393,7 @@ func getVolumeMounts() []corev1.VolumeMount {
 	}, resources.GetHostCACertVolumeMounts()...)
 }
 
-func getVolumes() []corev1.Volume {
+func getVolumes(auditLogging *kubermaticv1.AuditLoggingSettings) []corev1.Volume {
 	return append([]corev1.Volume{
 		{
 			Name: resources.ApiserverTLSSecretName,
@@ -532,7 +505,9 @@ func getVolumes() []corev1.Volume {
 		{
 			Name: resources.AuditLogVolumeName,
 			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					SizeLimit: auditLogVolumeSizeLimit(auditLogging),
+				},
 			},
 		},
 	}, resources.GetHostCACertVolumes()...)
@@ -18,7 +18,9 @@ package apiserver
 
 import (
 	"fmt"
+	"strings"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
@@ -27,8 +29,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-// ServiceCreator returns the function to reconcile the external API server service
-func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceCreatorGetter {
+// ServiceCreator returns the function to reconcile the external API server service. The externalName
+// is the hostname the NodePort proxy routes the connections by when the cluster is exposed via SNI.
+// The allowedIPRanges are enforced by the NodePort proxy, unless the cluster is exposed via a
+// dedicated LoadBalancer which enforces them itself.
+func ServiceCreator(exposeStrategy corev1.ServiceType, externalName string, allowedIPRanges []string) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.ApiserverServiceName, func(se *corev1.Service) (*corev1.Service, error) {
 			// Always set it to NodePort. Even when using exposeStrategy==LoadBalancer, we create
@@ -40,15 +45,31 @@ func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceC
 			if se.Annotations == nil {
 				se.Annotations = map[string]string{}
 			}
-			if exposeStrategy != corev1.ServiceTypeNodePort && exposeStrategy != corev1.ServiceTypeLoadBalancer {
-				return nil, fmt.Errorf("exposeStrategy on the cluster must be one of `NodePort` or `LoadBalancer, got %q`", exposeStrategy)
-			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
+			switch exposeStrategy {
+			case corev1.ServiceTypeNodePort:
 				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
-			} else {
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeSNIAnnotationKey)
+			case corev1.ServiceTypeLoadBalancer:
 				se.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
 				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeSNIAnnotationKey)
+			case kubermaticv1.ExposeStrategySNI:
+				// Only the SNI listener routes to the apiserver. Clients within the cluster reach it
+				// by IP, their connections get tunneled by the SNI tunnel agent.
+				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				// The external name is only known once the address of the cluster got synced
+				if externalName != "" {
+					se.Annotations[nodeportproxy.NodePortProxyExposeSNIAnnotationKey] = externalName
+				}
+			default:
+				return nil, fmt.Errorf("exposeStrategy on the cluster must be one of `NodePort`, `LoadBalancer` or `SNI`, got %q", exposeStrategy)
+			}
+			if len(allowedIPRanges) > 0 && exposeStrategy != corev1.ServiceTypeLoadBalancer {
+				se.Annotations[nodeportproxy.NodePortProxyAllowedSourceRangesAnnotationKey] = strings.Join(allowedIPRanges, ",")
+			} else {
+				delete(se.Annotations, nodeportproxy.NodePortProxyAllowedSourceRangesAnnotationKey)
 			}
 
 			se.Spec.Selector = map[string]string{
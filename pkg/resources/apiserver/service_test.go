@@ -17,9 +17,14 @@ limitations under the License.
 package apiserver
 
 import (
+	"reflect"
 	"testing"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -37,6 +42,10 @@ func TestServiceCreatorRequiresExposeStrategy(t *testing.T) {
 			name:           "LoadBalancer is accepted as exposeStrategy",
 			exposeStrategy: corev1.ServiceTypeLoadBalancer,
 		},
+		{
+			name:           "SNI is accepted as exposeStrategy",
+			exposeStrategy: kubermaticv1.ExposeStrategySNI,
+		},
 		{
 			name:        "Empty is not accepted as exposeStrategy",
 			errExpected: true,
@@ -45,7 +54,7 @@ func TestServiceCreatorRequiresExposeStrategy(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ServiceCreator(tc.exposeStrategy)()
+			_, creator := ServiceCreator(tc.exposeStrategy, "", nil)()
 			_, err := creator(&corev1.Service{})
 			if (err != nil) != tc.errExpected {
 				t.Errorf("Expected err: %t, but got err %v", tc.errExpected, err)
@@ -104,7 +113,7 @@ func TestServiceCreatorSetsPort(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ServiceCreator(tc.inService.Spec.Type)()
+			_, creator := ServiceCreator(tc.inService.Spec.Type, "", nil)()
 			svc, err := creator(tc.inService)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
@@ -127,3 +136,84 @@ func TestServiceCreatorSetsPort(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceCreatorSetsAnnotations(t *testing.T) {
+	testCases := []struct {
+		name                string
+		exposeStrategy      corev1.ServiceType
+		externalName        string
+		allowedIPRanges     []string
+		inAnnotations       map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:           "SNI only exposes the hostname",
+			exposeStrategy: kubermaticv1.ExposeStrategySNI,
+			externalName:   "abcd.europe-west3-c.dev.kubermatic.io",
+			expectedAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyExposeSNIAnnotationKey: "abcd.europe-west3-c.dev.kubermatic.io",
+			},
+		},
+		{
+			name:                "SNI without an external name exposes nothing",
+			exposeStrategy:      kubermaticv1.ExposeStrategySNI,
+			expectedAnnotations: map[string]string{},
+		},
+		{
+			name:           "The NodePort is no longer exposed when switching to SNI",
+			exposeStrategy: kubermaticv1.ExposeStrategySNI,
+			externalName:   "abcd.europe-west3-c.dev.kubermatic.io",
+			inAnnotations: map[string]string{
+				"nodeport-proxy.k8s.io/expose": "true",
+			},
+			expectedAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyExposeSNIAnnotationKey: "abcd.europe-west3-c.dev.kubermatic.io",
+			},
+		},
+		{
+			name:           "The hostname is removed when switching to NodePort",
+			exposeStrategy: corev1.ServiceTypeNodePort,
+			externalName:   "abcd.europe-west3-c.dev.kubermatic.io",
+			inAnnotations: map[string]string{
+				"nodeport-proxy.k8s.io/expose":                    "true",
+				nodeportproxy.NodePortProxyExposeSNIAnnotationKey: "abcd.europe-west3-c.dev.kubermatic.io",
+			},
+			expectedAnnotations: map[string]string{
+				"nodeport-proxy.k8s.io/expose": "true",
+			},
+		},
+		{
+			name:            "The NodePort proxy enforces the allowed IP ranges",
+			exposeStrategy:  corev1.ServiceTypeNodePort,
+			allowedIPRanges: []string{"192.0.2.0/24", "198.51.100.17/32"},
+			expectedAnnotations: map[string]string{
+				"nodeport-proxy.k8s.io/expose":                              "true",
+				nodeportproxy.NodePortProxyAllowedSourceRangesAnnotationKey: "192.0.2.0/24,198.51.100.17/32",
+			},
+		},
+		{
+			name:            "The allowed IP ranges are enforced by the LoadBalancer",
+			exposeStrategy:  corev1.ServiceTypeLoadBalancer,
+			allowedIPRanges: []string{"192.0.2.0/24"},
+			inAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyAllowedSourceRangesAnnotationKey: "192.0.2.0/24",
+			},
+			expectedAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey: "true",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, creator := ServiceCreator(tc.exposeStrategy, tc.externalName, tc.allowedIPRanges)()
+			svc, err := creator(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: tc.inAnnotations}})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(svc.Annotations, tc.expectedAnnotations) {
+				t.Errorf("Expected annotations to be %v but were %v", tc.expectedAnnotations, svc.Annotations)
+			}
+		})
+	}
+}
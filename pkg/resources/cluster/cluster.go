@@ -36,12 +36,15 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 		MachineNetworks:                     apiCluster.Spec.MachineNetworks,
 		OIDC:                                apiCluster.Spec.OIDC,
 		UpdateWindow:                        apiCluster.Spec.UpdateWindow,
+		EtcdBackup:                          apiCluster.Spec.EtcdBackup,
 		Version:                             apiCluster.Spec.Version,
 		UsePodSecurityPolicyAdmissionPlugin: apiCluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
 		UsePodNodeSelectorAdmissionPlugin:   apiCluster.Spec.UsePodNodeSelectorAdmissionPlugin,
 		AuditLogging:                        apiCluster.Spec.AuditLogging,
 		Openshift:                           apiCluster.Spec.Openshift,
 		AdmissionPlugins:                    apiCluster.Spec.AdmissionPlugins,
+		APIServerAllowedIPRanges:            apiCluster.Spec.APIServerAllowedIPRanges,
+		NodeEgressIPRanges:                  apiCluster.Spec.NodeEgressIPRanges,
 	}
 
 	providerName, err := provider.ClusterCloudProviderName(spec.Cloud)
@@ -334,7 +334,13 @@ func GetKubevirtCredentials(data CredentialsData) (KubevirtCredentials, error) {
 	kubevirtCredentials := KubevirtCredentials{}
 	var err error
 
-	if spec.Kubeconfig != "" {
+	// Prefer the kubeconfig of the service account that is restricted to the
+	// namespace of the cluster on the infra cluster.
+	if spec.ServiceAccountCredentialsReference != nil {
+		if kubevirtCredentials.KubeConfig, err = data.GetGlobalSecretKeySelectorValue(spec.ServiceAccountCredentialsReference, KubevirtServiceAccountKubeConfig); err != nil {
+			return KubevirtCredentials{}, err
+		}
+	} else if spec.Kubeconfig != "" {
 		kubevirtCredentials.KubeConfig = spec.Kubeconfig
 	} else if kubevirtCredentials.KubeConfig, err = data.GetGlobalSecretKeySelectorValue(spec.CredentialsReference, KubevirtKubeConfig); err != nil {
 		return KubevirtCredentials{}, err
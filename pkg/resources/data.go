@@ -355,3 +355,15 @@ func GetKubernetesCloudProviderName(cluster *kubermaticv1.Cluster) string {
 func (d *TemplateData) Seed() *kubermaticv1.Seed {
 	return d.seed
 }
+
+// GetSeedConfigMap returns the ConfigMap with the given name from the namespace of the Seed resource
+func (d *TemplateData) GetSeedConfigMap(name string) (*corev1.ConfigMap, error) {
+	if d.seed == nil {
+		return nil, fmt.Errorf("no seed available to look up ConfigMap %q", name)
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := d.client.Get(d.ctx, ctrlruntimeclient.ObjectKey{Name: name, Namespace: d.seed.Namespace}, configMap); err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.etcd.io/etcd/v3/clientv3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewClient returns a client for the etcd of the given cluster, which authenticates with the
+// client certificate of the apiserver
+func NewClient(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (*clientv3.Client, error) {
+	namespace := cluster.Status.NamespaceName
+
+	caSecret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.CASecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get cluster CA: %v", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caSecret.Data[resources.CACertSecretKey]) {
+		return nil, errors.New("cluster CA secret contains no valid certificate")
+	}
+
+	certSecret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.ApiserverEtcdClientCertificateSecretName}, certSecret); err != nil {
+		return nil, fmt.Errorf("failed to get etcd client certificate: %v", err)
+	}
+	cert, err := tls.X509KeyPair(
+		certSecret.Data[resources.ApiserverEtcdClientCertificateCertSecretKey],
+		certSecret.Data[resources.ApiserverEtcdClientCertificateKeySecretKey],
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load etcd client certificate: %v", err)
+	}
+
+	return clientv3.New(clientv3.Config{
+		Endpoints:   GetClientEndpoints(namespace),
+		DialTimeout: 5 * time.Second,
+		Context:     ctx,
+		TLS: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      certPool,
+		},
+	})
+}
+
+// MemberEndpoint returns the client URL of the given member. The URLs advertised by the
+// members also contain their pod IP, which is not part of the serving certificate.
+func MemberEndpoint(cluster *kubermaticv1.Cluster, member string) string {
+	return fmt.Sprintf("https://%s.%s:2379", member, resources.GetAbsoluteServiceDNSName(resources.EtcdServiceName, cluster.Status.NamespaceName))
+}
@@ -34,7 +34,9 @@ type pdbData interface {
 func PodDisruptionBudgetCreator(data pdbData) reconciling.NamedPodDisruptionBudgetCreatorGetter {
 	return func() (string, reconciling.PodDisruptionBudgetCreator) {
 		return resources.EtcdPodDisruptionBudgetName, func(pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
-			minAvailable := intstr.FromInt((resources.EtcdClusterSize / 2) + 1)
+			// A percentage is relative to the current number of members, so the budget keeps
+			// the quorum while the cluster is being resized. 51% always rounds up to a majority.
+			minAvailable := intstr.FromString("51%")
 			pdb.Spec = policyv1beta1.PodDisruptionBudgetSpec{
 				Selector: &metav1.LabelSelector{
 					MatchLabels: getBasePodLabels(data.Cluster()),
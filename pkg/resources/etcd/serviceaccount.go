@@ -18,9 +18,17 @@ package etcd
 
 import (
 	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	roleName        = "kubermatic:etcd-launcher"
+	roleBindingName = "kubermatic:etcd-launcher"
 )
 
 // ServiceAccountCreator returns a func to create/update the ServiceAccount used by etcd launcher.
@@ -29,3 +37,49 @@ func ServiceAccountCreator() (string, reconciling.ServiceAccountCreator) {
 		return sa, nil
 	}
 }
+
+// RoleCreator returns a func to create/update the Role used by etcd launcher. It allows the
+// launcher to find active etcd restores and the credentials to download their backups, and
+// to get the size of the etcd StatefulSet.
+func RoleCreator() (string, reconciling.RoleCreator) {
+	return roleName, func(r *rbacv1.Role) (*rbacv1.Role, error) {
+		r.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{kubermaticv1.GroupName},
+				Resources: []string{kubermaticv1.EtcdRestoreResourceName},
+				Verbs:     []string{"get", "list"},
+			},
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{resources.EtcdRestoreCredentialsSecretName},
+				Verbs:         []string{"get"},
+			},
+			{
+				APIGroups:     []string{"apps"},
+				Resources:     []string{"statefulsets"},
+				ResourceNames: []string{resources.EtcdStatefulSetName},
+				Verbs:         []string{"get"},
+			},
+		}
+		return r, nil
+	}
+}
+
+// RoleBindingCreator returns a func to create/update the RoleBinding used by etcd launcher.
+func RoleBindingCreator() (string, reconciling.RoleBindingCreator) {
+	return roleBindingName, func(rb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+		rb.RoleRef = rbacv1.RoleRef{
+			Name:     roleName,
+			Kind:     "Role",
+			APIGroup: rbacv1.GroupName,
+		}
+		rb.Subjects = []rbacv1.Subject{
+			{
+				Kind: rbacv1.ServiceAccountKind,
+				Name: rbac.EtcdLauncherServiceAccountName,
+			},
+		}
+		return rb, nil
+	}
+}
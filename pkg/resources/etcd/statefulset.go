@@ -148,10 +148,6 @@ func StatefulSetCreator(data etcdStatefulSetCreatorData, enableDataCorruptionChe
 							Name:  "TOKEN",
 							Value: data.Cluster().Name,
 						},
-						{
-							Name:  "ETCD_CLUSTER_SIZE",
-							Value: strconv.Itoa(replicas),
-						},
 						{
 							Name:  "ENABLE_CORRUPTION_CHECK",
 							Value: strconv.FormatBool(enableDataCorruptionChecks),
@@ -337,34 +333,49 @@ func ImageTag(c *kubermaticv1.Cluster) string {
 	return etcdImageTagV34
 }
 
-func computeReplicas(data etcdStatefulSetCreatorData, set *appsv1.StatefulSet) int {
-	etcdClusterSize := data.Cluster().Spec.ComponentsOverride.Etcd.ClusterSize
+// GetClusterSize returns the number of etcd members the cluster should have. Clusters
+// without a configured size get the default size.
+func GetClusterSize(cluster *kubermaticv1.Cluster) (int, error) {
+	size := cluster.Spec.ComponentsOverride.Etcd.ClusterSize
 	// handle existing clusters that don't have a configured size
-	if etcdClusterSize < kubermaticv1.DefaultEtcdClusterSize {
-		etcdClusterSize = kubermaticv1.DefaultEtcdClusterSize
+	if size < kubermaticv1.DefaultEtcdClusterSize {
+		return kubermaticv1.DefaultEtcdClusterSize, nil
 	}
+	if size > kubermaticv1.MaxEtcdClusterSize || size%2 == 0 {
+		return 0, fmt.Errorf("unsupported etcd cluster size %d, must be 3, 5 or 7", size)
+	}
+	return size, nil
+}
+
+func computeReplicas(data etcdStatefulSetCreatorData, set *appsv1.StatefulSet) int {
+	etcdClusterSize, err := GetClusterSize(data.Cluster())
 	if set.Spec.Replicas == nil { // new replicaset
+		if err != nil {
+			return kubermaticv1.DefaultEtcdClusterSize
+		}
 		return etcdClusterSize
 	}
 	replicas := int(*set.Spec.Replicas)
-	// at required size. do nothing
-	if etcdClusterSize == replicas {
+	// at required size or invalid size. do nothing
+	if err != nil || etcdClusterSize == replicas {
+		return replicas
+	}
+	// Scaling in is done by the etcd resize controller, as the departing member must be
+	// removed from etcd before its pod gets deleted.
+	if etcdClusterSize < replicas {
 		return replicas
 	}
+	// New members join as learners and are only ready once they got promoted, so the next
+	// member is only added once the previous one counts towards the quorum.
 	isEtcdHealthy := data.Cluster().Status.ExtendedHealth.Etcd == kubermaticv1.HealthStatusUp
-	if isEtcdHealthy { // no scaling until we are healthy
-
-		if etcdClusterSize > replicas {
-			return replicas + 1
-		}
-		return replicas - 1
+	if isEtcdHealthy {
+		return replicas + 1
 	}
 	return replicas
 }
 
 func getLauncherArgs(enableCorruptionCheck bool) []string {
 	command := []string{"-namespace", "$(NAMESPACE)",
-		"-etcd-cluster-size", "$(ETCD_CLUSTER_SIZE)",
 		"-pod-name", "$(POD_NAME)",
 		"-pod-ip", "$(POD_IP)",
 		"-api-version", "$(ETCDCTL_API)",
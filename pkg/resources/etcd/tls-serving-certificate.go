@@ -53,7 +53,9 @@ func TLSCertificateCreator(data tlsCertificateCreatorData) reconciling.NamedSecr
 				},
 			}
 
-			for i := 0; i < 3; i++ {
+			// Cover all members the cluster can be resized to, so the certificate does not
+			// need to be replaced while members are added
+			for i := 0; i < kubermaticv1.MaxEtcdClusterSize; i++ {
 				// Member name
 				podName := fmt.Sprintf("etcd-%d", i)
 				altNames.DNSNames = append(altNames.DNSNames, podName)
@@ -45,6 +45,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 )
 
 func getOsName(nodeSpec apiv1.NodeSpec) (providerconfig.OperatingSystem, error) {
@@ -80,9 +81,14 @@ func getAWSProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *ku
 		ami = nodeSpec.Cloud.AWS.AMI
 	}
 
+	// If neither the node spec nor the cluster provide a subnet ID, AWS will just pick the AZ's default subnet.
+	subnetID := nodeSpec.Cloud.AWS.SubnetID
+	if subnetID == "" {
+		subnetID = c.Spec.Cloud.AWS.SubnetIDs[nodeSpec.Cloud.AWS.AvailabilityZone]
+	}
+
 	config := aws.RawConfig{
-		// If the node spec doesn't provide a subnet ID, AWS will just pick the AZ's default subnet.
-		SubnetID:         providerconfig.ConfigVarString{Value: nodeSpec.Cloud.AWS.SubnetID},
+		SubnetID:         providerconfig.ConfigVarString{Value: subnetID},
 		VpcID:            providerconfig.ConfigVarString{Value: c.Spec.Cloud.AWS.VPCID},
 		SecurityGroupIDs: []providerconfig.ConfigVarString{{Value: c.Spec.Cloud.AWS.SecurityGroupID}},
 		Region:           providerconfig.ConfigVarString{Value: dc.Spec.AWS.Region},
@@ -94,6 +100,9 @@ func getAWSProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *ku
 		AMI:              providerconfig.ConfigVarString{Value: ami},
 		AssignPublicIP:   nodeSpec.Cloud.AWS.AssignPublicIP,
 	}
+	if c.Spec.Cloud.AWS.PrivateNodes {
+		config.AssignPublicIP = pointer.BoolPtr(false)
+	}
 	if config.DiskType.Value == "" {
 		config.DiskType.Value = ec2.VolumeTypeGp2
 	}
@@ -179,6 +188,7 @@ func getVSphereProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc
 		DatastoreCluster: providerconfig.ConfigVarString{Value: c.Spec.Cloud.VSphere.DatastoreCluster},
 		Cluster:          providerconfig.ConfigVarString{Value: dc.Spec.VSphere.Cluster},
 		Folder:           providerconfig.ConfigVarString{Value: c.Spec.Cloud.VSphere.Folder},
+		ResourcePool:     providerconfig.ConfigVarString{Value: c.Spec.Cloud.VSphere.ResourcePool},
 		AllowInsecure:    providerconfig.ConfigVarBool{Value: dc.Spec.VSphere.AllowInsecure},
 	}
 
@@ -247,6 +257,11 @@ func getHetznerProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc
 		Location:   providerconfig.ConfigVarString{Value: dc.Spec.Hetzner.Location},
 		ServerType: providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Hetzner.Type},
 	}
+	if c.Spec.Cloud.Hetzner.Network != "" {
+		config.Networks = []providerconfig.ConfigVarString{
+			{Value: c.Spec.Cloud.Hetzner.Network},
+		}
+	}
 
 	ext := &runtime.RawExtension{}
 	b, err := json.Marshal(config)
@@ -358,13 +373,20 @@ func getGCPProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec, dc *ku
 	return ext, nil
 }
 
-func getKubevirtProviderSpec(nodeSpec apiv1.NodeSpec) (*runtime.RawExtension, error) {
+func getKubevirtProviderSpec(c *kubermaticv1.Cluster, nodeSpec apiv1.NodeSpec) (*runtime.RawExtension, error) {
+	namespace := nodeSpec.Cloud.Kubevirt.Namespace
+	// machine-controller is only allowed to manage virtual machines in the
+	// namespace that was created for the cluster on the infra cluster.
+	if c.Spec.Cloud.Kubevirt != nil && c.Spec.Cloud.Kubevirt.InfraNamespace != "" {
+		namespace = c.Spec.Cloud.Kubevirt.InfraNamespace
+	}
+
 	config := kubevirt.RawConfig{
 		CPUs:             providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Kubevirt.CPUs},
 		PVCSize:          providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Kubevirt.PVCSize},
 		StorageClassName: providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Kubevirt.StorageClassName},
 		SourceURL:        providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Kubevirt.SourceURL},
-		Namespace:        providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Kubevirt.Namespace},
+		Namespace:        providerconfig.ConfigVarString{Value: namespace},
 		Memory:           providerconfig.ConfigVarString{Value: nodeSpec.Cloud.Kubevirt.Memory},
 	}
 
@@ -18,12 +18,16 @@ package machine
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
+	aws "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/aws/types"
 	vsphere "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/vsphere/types"
 	providerconfigtypes "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/utils/pointer"
 )
 
 func TestGetVSphereProviderSpec(t *testing.T) {
@@ -133,3 +137,71 @@ func TestGetVSphereProviderSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAWSProviderSpec(t *testing.T) {
+	tests := []struct {
+		name               string
+		cloudSpec          *kubermaticv1.AWSCloudSpec
+		nodeSpec           *apiv1.AWSNodeSpec
+		wantSubnetID       string
+		wantAssignPublicIP *bool
+	}{
+		{
+			name:      "Default subnet",
+			cloudSpec: &kubermaticv1.AWSCloudSpec{},
+			nodeSpec:  &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1a"},
+		},
+		{
+			name:         "Subnet of the availability zone",
+			cloudSpec:    &kubermaticv1.AWSCloudSpec{SubnetIDs: map[string]string{"eu-central-1a": "subnet-a", "eu-central-1b": "subnet-b"}},
+			nodeSpec:     &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1b"},
+			wantSubnetID: "subnet-b",
+		},
+		{
+			name:         "Subnet of the node",
+			cloudSpec:    &kubermaticv1.AWSCloudSpec{SubnetIDs: map[string]string{"eu-central-1a": "subnet-a"}},
+			nodeSpec:     &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1a", SubnetID: "subnet-node"},
+			wantSubnetID: "subnet-node",
+		},
+		{
+			name:               "Private nodes",
+			cloudSpec:          &kubermaticv1.AWSCloudSpec{SubnetIDs: map[string]string{"eu-central-1a": "subnet-a"}, PrivateNodes: true},
+			nodeSpec:           &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1a"},
+			wantSubnetID:       "subnet-a",
+			wantAssignPublicIP: pointer.BoolPtr(false),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{AWS: tt.cloudSpec},
+				},
+			}
+			nodeSpec := apiv1.NodeSpec{
+				Cloud:           apiv1.NodeCloudSpec{AWS: tt.nodeSpec},
+				OperatingSystem: apiv1.OperatingSystemSpec{Ubuntu: &apiv1.UbuntuSpec{}},
+			}
+			dc := &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{
+					AWS: &kubermaticv1.DatacenterSpecAWS{Region: "eu-central-1"},
+				},
+			}
+
+			got, err := getAWSProviderSpec(cluster, nodeSpec, dc)
+			if err != nil {
+				t.Fatalf("getAWSProviderSpec() error = %v", err)
+			}
+			gotRawConf := aws.RawConfig{}
+			if err := json.Unmarshal(got.Raw, &gotRawConf); err != nil {
+				t.Fatalf("error occurred whil unmarshaling raw config: %v", err)
+			}
+			if gotRawConf.SubnetID.Value != tt.wantSubnetID {
+				t.Errorf("expected subnet %q, got %q", tt.wantSubnetID, gotRawConf.SubnetID.Value)
+			}
+			if !reflect.DeepEqual(gotRawConf.AssignPublicIP, tt.wantAssignPublicIP) {
+				t.Errorf("expected assignPublicIP %v, got %v", tt.wantAssignPublicIP, gotRawConf.AssignPublicIP)
+			}
+		})
+	}
+}
@@ -44,6 +44,16 @@ const (
 	// We use it when clusters get exposed via a LoadBalancer, to allow re-using that LoadBalancer
 	// for both the kube-apiserver and the openVPN server
 	NodePortProxyExposeNamespacedAnnotationKey = "nodeport-proxy.k8s.io/expose-namespaced"
+
+	// NodePortProxyExposeSNIAnnotationKey is the annotation key used to indicate that a service
+	// should be exposed by the NodeportProxy on its SNI listener. The value is the hostname the
+	// connections are routed by. We use it when clusters get exposed via SNI.
+	NodePortProxyExposeSNIAnnotationKey = "nodeport-proxy.k8s.io/expose-sni"
+
+	// NodePortProxyAllowedSourceRangesAnnotationKey is the annotation key containing the comma separated
+	// source IP ranges the NodeportProxy accepts connections to the service from. All sources are
+	// accepted if the annotation is not set.
+	NodePortProxyAllowedSourceRangesAnnotationKey = "nodeport-proxy.k8s.io/allowed-source-ranges"
 )
 
 var (
@@ -124,6 +134,7 @@ func EnsureResources(ctx context.Context, client ctrlruntimeclient.Client, data
 type nodePortProxyData interface {
 	ImageRegistry(string) string
 	Cluster() *kubermaticv1.Cluster
+	Seed() *kubermaticv1.Seed
 }
 
 func serviceAccount() reconciling.NamedServiceAccountCreatorGetter {
@@ -334,7 +345,7 @@ func podDisruptionBudget() reconciling.NamedPodDisruptionBudgetCreatorGetter {
 
 // FrontLoadBalancerServiceCreator returns the creator for the LoadBalancer that fronts apiserver
 // and openVPN when using exposeStrategy=LoadBalancer
-func FrontLoadBalancerServiceCreator() reconciling.NamedServiceCreatorGetter {
+func FrontLoadBalancerServiceCreator(data nodePortProxyData) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.FrontLoadBalancerServiceName, func(s *corev1.Service) (*corev1.Service, error) {
 			// We don't actually manage this service, that is done by the nodeport proxy, we just
@@ -353,6 +364,8 @@ func FrontLoadBalancerServiceCreator() reconciling.NamedServiceCreatorGetter {
 			}
 
 			s.Spec.Selector = resources.BaseAppLabels(envoyAppLabelValue, nil)
+			// The LoadBalancer is dedicated to the cluster, so the allowed IP ranges can be enforced by it
+			s.Spec.LoadBalancerSourceRanges = resources.APIServerAllowedIPRanges(data.Cluster(), data.Seed())
 			return s, nil
 		}
 	}
@@ -57,6 +57,16 @@ var (
 			},
 		},
 		"openvpn-exporter": openvpnResourceRequirements.DeepCopy(),
+		sniTunnelContainerName: {
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+			},
+		},
 	}
 )
 
@@ -296,6 +306,9 @@ done`,
 					},
 				},
 			}
+			if exposedViaSNITunnel(data.Cluster()) {
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, sniTunnelContainer(data))
+			}
 			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defaultResourceRequirements, nil, dep.Annotations)
 			if err != nil {
 				return nil, fmt.Errorf("failed to set resource requirements: %v", err)
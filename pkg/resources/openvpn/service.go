@@ -17,6 +17,9 @@ limitations under the License.
 package openvpn
 
 import (
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
@@ -26,7 +29,7 @@ import (
 )
 
 // ServiceCreator returns the function to reconcile the external OpenVPN service
-func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceCreatorGetter {
+func ServiceCreator(exposeStrategy corev1.ServiceType, externalName string) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.OpenVPNServerServiceName, func(se *corev1.Service) (*corev1.Service, error) {
 			se.Name = resources.OpenVPNServerServiceName
@@ -35,26 +38,56 @@ func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceC
 			if se.Annotations == nil {
 				se.Annotations = map[string]string{}
 			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
-				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
-				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
-			} else {
+			switch exposeStrategy {
+			case corev1.ServiceTypeLoadBalancer:
 				se.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
 				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeSNIAnnotationKey)
+			case kubermaticv1.ExposeStrategySNI:
+				// The SNI tunnel agent reaches the OpenVPN server and the apiserver through the
+				// tunnel port, the NodePort is not exposed.
+				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				// The external name is only known once the address of the cluster got synced
+				if externalName != "" {
+					se.Annotations[nodeportproxy.NodePortProxyExposeSNIAnnotationKey] = strings.Join([]string{
+						resources.OpenVPNSNIHostname(externalName),
+						resources.ApiserverTunnelSNIHostname(externalName),
+					}, ",")
+				}
+			default:
+				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeSNIAnnotationKey)
 			}
 			se.Spec.Selector = map[string]string{
 				resources.AppLabelKey: name,
 			}
 			se.Spec.Type = corev1.ServiceTypeNodePort
-			if len(se.Spec.Ports) == 0 {
-				se.Spec.Ports = make([]corev1.ServicePort, 1)
+
+			ports := 1
+			if exposeStrategy == kubermaticv1.ExposeStrategySNI {
+				ports = 2
+			}
+			// Keep the allocated NodePorts
+			for len(se.Spec.Ports) < ports {
+				se.Spec.Ports = append(se.Spec.Ports, corev1.ServicePort{})
 			}
+			se.Spec.Ports = se.Spec.Ports[:ports]
 
 			se.Spec.Ports[0].Name = "secure"
 			se.Spec.Ports[0].Port = 1194
 			se.Spec.Ports[0].Protocol = corev1.ProtocolTCP
 			se.Spec.Ports[0].TargetPort = intstr.FromInt(1194)
 
+			if exposeStrategy == kubermaticv1.ExposeStrategySNI {
+				// The NodePort proxy routes the connections from its SNI listener to the port 443
+				se.Spec.Ports[1].Name = "sni-tunnel"
+				se.Spec.Ports[1].Port = 443
+				se.Spec.Ports[1].Protocol = corev1.ProtocolTCP
+				se.Spec.Ports[1].TargetPort = intstr.FromInt(resources.SNITunnelPort)
+			}
+
 			return se, nil
 		}
 	}
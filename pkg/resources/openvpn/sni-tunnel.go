@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openvpn
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const sniTunnelContainerName = "sni-tunnel"
+
+// sniTunnelContainer returns the container which terminates the tunnels of the SNI tunnel agent.
+// The agent runs on the nodes of clusters exposed via SNI and wraps the connections to the OpenVPN
+// server and to the apiserver into TLS, so they can be routed by the SNI listener of the NodePort
+// proxy. The client certificate of the agent must be signed by the OpenVPN CA.
+func sniTunnelContainer(data openVPNDeploymentCreatorData) corev1.Container {
+	cluster := data.Cluster()
+	apiserverAddress := fmt.Sprintf("%s.%s.svc.cluster.local", resources.ApiserverServiceName, cluster.Status.NamespaceName)
+
+	return corev1.Container{
+		Name:    sniTunnelContainerName,
+		Image:   data.ImageRegistry(resources.RegistryDocker) + "/envoyproxy/envoy-alpine:v1.13.0",
+		Command: []string{"/usr/local/bin/envoy"},
+		Args: []string{
+			"--config-yaml", sniTunnelConfig(cluster.Address.ExternalName, apiserverAddress),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: resources.SNITunnelPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      resources.OpenVPNServerCertificatesSecretName,
+				MountPath: "/etc/openvpn/pki/server",
+				ReadOnly:  true,
+			},
+			{
+				Name:      resources.CASecretName,
+				MountPath: "/etc/kubernetes/pki/ca",
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// sniTunnelConfig returns the envoy config which passes the tunneled connections to the OpenVPN
+// server or to the apiserver, depending on the SNI hostname the agent used.
+func sniTunnelConfig(externalName, apiserverAddress string) string {
+	return fmt.Sprintf(`static_resources:
+  listeners:
+  - name: sni-tunnel
+    address:
+      socket_address:
+        address: 0.0.0.0
+        port_value: %d
+    listener_filters:
+    - name: envoy.listener.tls_inspector
+    filter_chains:
+%s
+%s
+  clusters:
+  - name: openvpn
+    connect_timeout: 5s
+    type: STATIC
+    load_assignment:
+      cluster_name: openvpn
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: 127.0.0.1
+                port_value: 1194
+  - name: apiserver
+    connect_timeout: 5s
+    type: STRICT_DNS
+    load_assignment:
+      cluster_name: apiserver
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: %s
+                port_value: 443
+`,
+		resources.SNITunnelPort,
+		sniTunnelFilterChain(resources.OpenVPNSNIHostname(externalName), "openvpn"),
+		sniTunnelFilterChain(resources.ApiserverTunnelSNIHostname(externalName), "apiserver"),
+		apiserverAddress,
+	)
+}
+
+func sniTunnelFilterChain(hostname, clusterName string) string {
+	return fmt.Sprintf(`    - filter_chain_match:
+        server_names:
+        - %s
+      transport_socket:
+        name: envoy.transport_sockets.tls
+        typed_config:
+          "@type": type.googleapis.com/envoy.api.v2.auth.DownstreamTlsContext
+          require_client_certificate: true
+          common_tls_context:
+            tls_certificates:
+            - certificate_chain:
+                filename: /etc/openvpn/pki/server/%s
+              private_key:
+                filename: /etc/openvpn/pki/server/%s
+            validation_context:
+              trusted_ca:
+                filename: /etc/kubernetes/pki/ca/%s
+      filters:
+      - name: envoy.tcp_proxy
+        typed_config:
+          "@type": type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+          stat_prefix: %s
+          cluster: %s`,
+		hostname,
+		resources.OpenVPNServerCertSecretKey,
+		resources.OpenVPNServerKeySecretKey,
+		resources.OpenVPNCACertKey,
+		clusterName,
+		clusterName,
+	)
+}
+
+// exposedViaSNITunnel returns whether the SNI tunnel agent needs to reach the OpenVPN server
+// of the cluster. The hostnames are only known once the address of the cluster got synced.
+func exposedViaSNITunnel(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI && cluster.Address.ExternalName != ""
+}
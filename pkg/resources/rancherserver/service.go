@@ -20,13 +20,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 )
 
 // ServiceCreator creates the service for rancher server
-func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceCreatorGetter {
+func ServiceCreator(exposeStrategy corev1.ServiceType, externalName string) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.RancherServerServiceName, func(s *corev1.Service) (*corev1.Service, error) {
 			s.Name = resources.RancherServerServiceName
@@ -34,12 +35,23 @@ func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceC
 			if s.Annotations == nil {
 				s.Annotations = map[string]string{}
 			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
-				s.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
-				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
-			} else {
+			switch exposeStrategy {
+			case corev1.ServiceTypeLoadBalancer:
 				s.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
 				delete(s.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(s.Annotations, nodeportproxy.NodePortProxyExposeSNIAnnotationKey)
+			case kubermaticv1.ExposeStrategySNI:
+				// Only the https port is reachable, through the SNI listener of the NodePort proxy
+				delete(s.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				// The external name is only known once the address of the cluster got synced
+				if externalName != "" {
+					s.Annotations[nodeportproxy.NodePortProxyExposeSNIAnnotationKey] = resources.RancherServerSNIHostname(externalName)
+				}
+			default:
+				s.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
+				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				delete(s.Annotations, nodeportproxy.NodePortProxyExposeSNIAnnotationKey)
 			}
 			s.Spec.Selector = resources.BaseAppLabels(resources.RancherStatefulSetName, nil)
 			s.Spec.Type = corev1.ServiceTypeNodePort
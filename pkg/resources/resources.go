@@ -200,6 +200,21 @@ const (
 	GoogleServiceAccountVolumeName = "google-service-account-volume"
 	// AuditLogVolumeName is the name of the volume that hold the audit log of the apiserver.
 	AuditLogVolumeName = "audit-log"
+	// EtcdRestoreCredentialsSecretName is the name of the secret that contains the credentials the etcd launcher
+	// uses to download the backup during an etcd restore
+	EtcdRestoreCredentialsSecretName = "etcd-restore-credentials"
+	// EtcdRestoreEncryptionKeyPrefix prefixes the backup encryption keys of the seed in the etcd restore
+	// credentials, followed by the key ID
+	EtcdRestoreEncryptionKeyPrefix = "encryption-key."
+	// EtcdBackupCredentialsSecretName is the name of the secret in the kube-system namespace of the seed that
+	// contains the credentials and location of the etcd backup bucket
+	EtcdBackupCredentialsSecretName = "s3-credentials"
+	// DefaultEtcdBackupEndpoint is the S3 endpoint the default store container uploads backups to,
+	// unless ENDPOINT is set in the etcd backup credentials
+	DefaultEtcdBackupEndpoint = "minio.minio.svc.cluster.local:9000"
+	// DefaultEtcdBackupBucket is the bucket the default store container uploads backups to,
+	// unless BUCKET is set in the etcd backup credentials
+	DefaultEtcdBackupBucket = "kubermatic-etcd-backups"
 	// KubernetesDashboardKeyHolderSecretName is the name of the secret that contains JWE token encryption key
 	// used by the Kubernetes Dashboard
 	KubernetesDashboardKeyHolderSecretName = "kubernetes-dashboard-key-holder"
@@ -327,9 +342,6 @@ const (
 	// ClusterLabelKey defines the label key for the cluster name
 	ClusterLabelKey = "cluster"
 
-	// EtcdClusterSize defines the size of the etcd to use
-	EtcdClusterSize = 3
-
 	// RegistryK8SGCR defines the kubernetes specific docker registry at google
 	RegistryK8SGCR = "k8s.gcr.io"
 	// RegistryGCR defines the kubernetes docker registry at google
@@ -472,6 +484,9 @@ const (
 	PacketProjectID = "projectID"
 
 	KubevirtKubeConfig = "kubeConfig"
+	// KubevirtServiceAccountKubeConfig is the kubeconfig of the service account restricted to the
+	// infra namespace of a cluster
+	KubevirtServiceAccountKubeConfig = "serviceAccountKubeConfig"
 
 	VsphereUsername                    = "username"
 	VspherePassword                    = "password"
@@ -500,6 +515,16 @@ const (
 	NodeLocalDNSDaemonSetName      = "node-local-dns"
 )
 
+const (
+	SNITunnelAgentConfigMapName = "sni-tunnel-agent"
+	SNITunnelAgentDaemonSetName = "sni-tunnel-agent"
+	// SNITunnelAgentIP is the address the SNI tunnel agent listens on, on every node of a cluster
+	// which is exposed via SNI. It is advertised as endpoint of the kubernetes service.
+	SNITunnelAgentIP = "100.64.30.10"
+	// SNITunnelPort is the port of the OpenVPN server pod on which the tunnels of the agent are terminated
+	SNITunnelPort = 8443
+)
+
 const (
 	TokenBlacklist = "token-blacklist"
 )
@@ -835,6 +860,39 @@ func GetAbsoluteServiceDNSName(service, namespace string) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local.", service, namespace)
 }
 
+// APIServerAllowedIPRanges returns the source IP ranges which may reach the apiserver of the cluster,
+// nil if all sources are allowed. The egress ranges of the nodes and of the master are always allowed,
+// as they reach the apiserver through its external address as well.
+func APIServerAllowedIPRanges(cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed) []string {
+	if len(cluster.Spec.APIServerAllowedIPRanges) == 0 {
+		return nil
+	}
+	ranges := sets.NewString(cluster.Spec.APIServerAllowedIPRanges...)
+	ranges.Insert(cluster.Spec.NodeEgressIPRanges...)
+	if seed != nil {
+		ranges.Insert(seed.Spec.MasterEgressIPRanges...)
+	}
+	return ranges.List()
+}
+
+// ApiserverTunnelSNIHostname returns the hostname by which the SNI tunnel agent reaches the apiserver
+// of a cluster exposed via SNI.
+func ApiserverTunnelSNIHostname(externalName string) string {
+	return "apiserver-tunnel-" + externalName
+}
+
+// OpenVPNSNIHostname returns the hostname by which the SNI tunnel agent reaches the OpenVPN server
+// of a cluster exposed via SNI.
+func OpenVPNSNIHostname(externalName string) string {
+	return "openvpn-" + externalName
+}
+
+// RancherServerSNIHostname returns the hostname of the rancher server of a cluster exposed via SNI.
+// It is covered by the same wildcard DNS record as the external name of the cluster.
+func RancherServerSNIHostname(externalName string) string {
+	return "rancher-" + externalName
+}
+
 // SecretRevision returns the resource version of the Secret specified by name.
 func SecretRevision(ctx context.Context, key types.NamespacedName, client ctrlruntimeclient.Client) (string, error) {
 	secret := &corev1.Secret{}
@@ -101,6 +101,41 @@ func TestUserClusterDNSResolverIP(t *testing.T) {
 	}
 }
 
+func TestAPIServerAllowedIPRanges(t *testing.T) {
+	seed := &kubermaticv1.Seed{}
+	seed.Spec.MasterEgressIPRanges = []string{"203.0.113.0/28"}
+
+	testCases := []struct {
+		name           string
+		allowedRanges  []string
+		nodeRanges     []string
+		expectedResult []string
+	}{
+		{
+			name:       "All sources allowed",
+			nodeRanges: []string{"198.51.100.64/26"},
+		},
+		{
+			name:           "Node and master egress ranges added",
+			allowedRanges:  []string{"192.0.2.0/24", "198.51.100.64/26"},
+			nodeRanges:     []string{"198.51.100.64/26"},
+			expectedResult: []string{"192.0.2.0/24", "198.51.100.64/26", "203.0.113.0/28"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{}
+			cluster.Spec.APIServerAllowedIPRanges = tc.allowedRanges
+			cluster.Spec.NodeEgressIPRanges = tc.nodeRanges
+
+			if diff := deep.Equal(APIServerAllowedIPRanges(cluster, seed), tc.expectedResult); diff != nil {
+				t.Errorf("wrong result, diff: %v", diff)
+			}
+		})
+	}
+}
+
 func TestSetResourceRequirements(t *testing.T) {
 	defaultResourceRequirements := corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
@@ -132,6 +132,11 @@ func DeploymentCreator(data userclusterControllerData, openshift bool) reconcili
 				"-owner-email", data.Cluster().Status.UserEmail,
 			}, getNetworkArgs(data)...)
 
+			// The apiserver and the OpenVPN server are only reachable through the SNI listener
+			if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+				args = append(args, "-sni-tunnel-apiserver-port", fmt.Sprint(data.Cluster().Address.Port))
+			}
+
 			if openshiftConsoleCallbackURI := data.Cluster().Address.OpenshiftConsoleCallBack; openshiftConsoleCallbackURI != "" {
 				args = append(args, "-openshift-console-callback-uri", openshiftConsoleCallbackURI)
 			}
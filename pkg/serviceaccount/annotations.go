@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LastUsedAnnotationKey is the annotation on a token secret holding the time the token was
+	// last used to authenticate a request
+	LastUsedAnnotationKey = "kubermatic.io/last-used"
+
+	// RevokeAtAnnotationKey is the annotation on a token secret holding the time after which a
+	// rotated token is no longer accepted
+	RevokeAtAnnotationKey = "kubermatic.io/revoke-at"
+
+	// LastUsedUpdateInterval is the minimum interval between two updates of the last used time
+	// of a token, to not write the token secret on every request
+	LastUsedUpdateInterval = 5 * time.Minute
+)
+
+// LastUsed returns the time the token was last used, nil if it was never used
+func LastUsed(secret *corev1.Secret) *time.Time {
+	return annotationTime(secret, LastUsedAnnotationKey)
+}
+
+// RevokeAt returns the time after which the token is revoked, nil if the token was not rotated
+func RevokeAt(secret *corev1.Secret) *time.Time {
+	return annotationTime(secret, RevokeAtAnnotationKey)
+}
+
+// IsRevoked returns true if the token was rotated and its grace period is over
+func IsRevoked(secret *corev1.Secret) bool {
+	revokeAt := RevokeAt(secret)
+	return revokeAt != nil && !Now().Before(*revokeAt)
+}
+
+func annotationTime(secret *corev1.Secret, key string) *time.Time {
+	value, ok := secret.Annotations[key]
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
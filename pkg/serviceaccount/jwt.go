@@ -27,6 +27,11 @@ import (
 // Now stubbed out to allow testing
 var Now = time.Now
 
+// DefaultTokenExpiry returns the expiry of tokens which are issued without a custom expiry
+func DefaultTokenExpiry() time.Time {
+	return Now().AddDate(3, 0, 0)
+}
+
 // TokenGenerator declares the method to generate JWT token
 type TokenGenerator interface {
 	// Generate generates a token which will identify the given
@@ -42,6 +47,9 @@ type TokenGenerator interface {
 type TokenAuthenticator interface {
 	// Authenticate checks given token and transform it to custom claim object
 	Authenticate(tokenData string) (*jwt.Claims, *CustomTokenClaim, error)
+	// Parse verifies the signature of the given token and returns its claims without
+	// checking whether the token has already expired
+	Parse(tokenData string) (*jwt.Claims, *CustomTokenClaim, error)
 }
 
 // CustomTokenClaim represents authenticated user
@@ -49,19 +57,39 @@ type CustomTokenClaim struct {
 	Email     string `json:"email,omitempty"`
 	ProjectID string `json:"project_id,omitempty"`
 	TokenID   string `json:"token_id,omitempty"`
+	// Scopes restricts the API routes the token can be used for, see ScopesAllow
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// TokenOptions customizes the claims of a generated token
+type TokenOptions struct {
+	// Expiry is the time the token expires, defaults to DefaultTokenExpiry
+	Expiry time.Time
+	// Scopes restricts the API routes the token can be used for, all routes are allowed if empty
+	Scopes []string
 }
 
 func Claims(email, projectID, tokenID string) (*jwt.Claims, *CustomTokenClaim) {
+	return ClaimsWithOptions(email, projectID, tokenID, TokenOptions{})
+}
+
+// ClaimsWithOptions returns the claims of a token with a custom expiry and scopes
+func ClaimsWithOptions(email, projectID, tokenID string, options TokenOptions) (*jwt.Claims, *CustomTokenClaim) {
+	expiry := options.Expiry
+	if expiry.IsZero() {
+		expiry = DefaultTokenExpiry()
+	}
 
 	sc := &jwt.Claims{
 		IssuedAt:  jwt.NewNumericDate(Now()),
 		NotBefore: jwt.NewNumericDate(Now()),
-		Expiry:    jwt.NewNumericDate(Now().AddDate(3, 0, 0)),
+		Expiry:    jwt.NewNumericDate(expiry),
 	}
 	pc := &CustomTokenClaim{
 		Email:     email,
 		ProjectID: projectID,
 		TokenID:   tokenID,
+		Scopes:    options.Scopes,
 	}
 
 	return sc, pc
@@ -107,19 +135,11 @@ func JWTTokenAuthenticator(privateKey []byte) TokenAuthenticator {
 
 // Authenticate decrypts signed token data to CustomTokenClaim object and checks if token expired
 func (a *jwtTokenAuthenticator) Authenticate(tokenData string) (*jwt.Claims, *CustomTokenClaim, error) {
-
-	tok, err := jwt.ParseSigned(tokenData)
+	public, customClaims, err := a.Parse(tokenData)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	public := &jwt.Claims{}
-	customClaims := &CustomTokenClaim{}
-
-	if err := tok.Claims(a.key, customClaims, public); err != nil {
-		return nil, nil, err
-	}
-
 	err = public.Validate(jwt.Expected{
 		Time: Now(),
 	})
@@ -134,6 +154,23 @@ func (a *jwtTokenAuthenticator) Authenticate(tokenData string) (*jwt.Claims, *Cu
 	return public, customClaims, nil
 }
 
+// Parse decrypts signed token data to CustomTokenClaim object without checking if the token expired
+func (a *jwtTokenAuthenticator) Parse(tokenData string) (*jwt.Claims, *CustomTokenClaim, error) {
+	tok, err := jwt.ParseSigned(tokenData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	public := &jwt.Claims{}
+	customClaims := &CustomTokenClaim{}
+
+	if err := tok.Claims(a.key, customClaims, public); err != nil {
+		return nil, nil, err
+	}
+
+	return public, customClaims, nil
+}
+
 func ValidateKey(privateKey []byte) error {
 	if len(privateKey) == 0 {
 		return fmt.Errorf("the signing key can not be empty")
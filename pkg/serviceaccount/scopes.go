@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var scopeMethods = map[string]bool{
+	"*":                true,
+	http.MethodGet:     true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// ValidateScope checks that the given scope has the form "[METHOD] PATH", where METHOD is
+// an HTTP method or "*" and PATH is an API route template like
+// "/api/v1/projects/{project_id}/clusters".
+func ValidateScope(scope string) error {
+	method, path := splitScope(scope)
+	if !scopeMethods[method] {
+		return fmt.Errorf("invalid method %q in scope %q", method, scope)
+	}
+	if !strings.HasPrefix(path, "/api/") {
+		return fmt.Errorf("the path of scope %q must start with /api/", scope)
+	}
+	return nil
+}
+
+// ScopesAllow returns true if the given method and route path template are covered by one of
+// the scopes. A scope covers its path and all routes below it, e.g. the scope
+// "GET /api/v1/projects/{project_id}/clusters" allows to list clusters as well as to
+// get a single cluster.
+func ScopesAllow(scopes []string, method, pathTemplate string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	if pathTemplate == "" {
+		return false
+	}
+
+	for _, scope := range scopes {
+		scopeMethod, scopePath := splitScope(scope)
+		if scopeMethod != "*" && scopeMethod != method {
+			continue
+		}
+		scopePath = strings.TrimSuffix(scopePath, "/")
+		if pathTemplate == scopePath || strings.HasPrefix(pathTemplate, scopePath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func splitScope(scope string) (string, string) {
+	parts := strings.Fields(scope)
+	switch len(parts) {
+	case 1:
+		return "*", parts[0]
+	case 2:
+		return strings.ToUpper(parts[0]), parts[1]
+	default:
+		return "", ""
+	}
+}
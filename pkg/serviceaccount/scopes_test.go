@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount_test
+
+import (
+	"net/http"
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/serviceaccount"
+)
+
+func TestValidateScope(t *testing.T) {
+	testcases := []struct {
+		scope       string
+		errExpected bool
+	}{
+		{scope: "GET /api/v1/projects/{project_id}/clusters"},
+		{scope: "* /api/v1/projects/{project_id}/clusters"},
+		{scope: "/api/v2/projects/{project_id}/clusters"},
+		{scope: "get /api/v1/projects"},
+		{scope: "FETCH /api/v1/projects", errExpected: true},
+		{scope: "GET /metrics", errExpected: true},
+		{scope: "GET /api/v1/projects extra", errExpected: true},
+		{scope: "", errExpected: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.scope, func(t *testing.T) {
+			err := serviceaccount.ValidateScope(tc.scope)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("expected err: %t, but got err %v", tc.errExpected, err)
+			}
+		})
+	}
+}
+
+func TestScopesAllow(t *testing.T) {
+	scopes := []string{
+		"GET /api/v1/projects/{project_id}/clusters",
+		"* /api/v1/projects/{project_id}/sshkeys/",
+	}
+
+	testcases := []struct {
+		name         string
+		scopes       []string
+		method       string
+		pathTemplate string
+		expected     bool
+	}{
+		{
+			name:         "scenario 1: a token without scopes can access every route",
+			method:       http.MethodDelete,
+			pathTemplate: "/api/v1/projects/{project_id}",
+			expected:     true,
+		},
+		{
+			name:         "scenario 2: the route of the scope is allowed",
+			scopes:       scopes,
+			method:       http.MethodGet,
+			pathTemplate: "/api/v1/projects/{project_id}/clusters",
+			expected:     true,
+		},
+		{
+			name:         "scenario 3: routes below the scope are allowed",
+			scopes:       scopes,
+			method:       http.MethodGet,
+			pathTemplate: "/api/v1/projects/{project_id}/clusters/{cluster_id}",
+			expected:     true,
+		},
+		{
+			name:         "scenario 4: other methods are forbidden",
+			scopes:       scopes,
+			method:       http.MethodPost,
+			pathTemplate: "/api/v1/projects/{project_id}/clusters",
+		},
+		{
+			name:         "scenario 5: the wildcard method allows every method",
+			scopes:       scopes,
+			method:       http.MethodDelete,
+			pathTemplate: "/api/v1/projects/{project_id}/sshkeys/{key_id}",
+			expected:     true,
+		},
+		{
+			name:         "scenario 6: routes sharing the prefix of the scope are forbidden",
+			scopes:       scopes,
+			method:       http.MethodGet,
+			pathTemplate: "/api/v1/projects/{project_id}/clustersettings",
+		},
+		{
+			name:   "scenario 7: unknown routes are forbidden",
+			scopes: scopes,
+			method: http.MethodGet,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if allowed := serviceaccount.ScopesAllow(tc.scopes, tc.method, tc.pathTemplate); allowed != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, allowed)
+			}
+		})
+	}
+}
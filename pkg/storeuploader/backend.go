@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storeuploader
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// BackendS3 stores backups in an S3 compatible object storage
+	BackendS3 = "s3"
+	// BackendFilesystem stores backups in a local directory, e.g. a mounted PVC
+	BackendFilesystem = "filesystem"
+	// BackendSFTP stores backups on an SFTP server
+	BackendSFTP = "sftp"
+)
+
+// Object describes a single stored file
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Backend is a storage the StoreUploader can manage backups in. A bucket is a
+// S3 bucket or a directory, depending on the backend.
+type Backend interface {
+	// EnsureBucket creates the given bucket if it does not exist yet
+	EnsureBucket(bucket string) error
+	// Upload stores the given file as object. Backends without support for object metadata
+	// ignore the given metadata.
+	Upload(bucket, objectName, file string, metadata map[string]string) error
+	// Download writes the given object to file
+	Download(bucket, objectName, file string) error
+	// List returns all objects whose name starts with the given prefix
+	List(bucket, prefix string) ([]Object, error)
+	// Delete removes the given object
+	Delete(bucket, objectName string) error
+	// Close releases the connections of the backend
+	Close() error
+}
+
+// Config holds the settings for all backends. Only the ones for the selected backend are used.
+type Config struct {
+	// Backend is one of BackendS3, BackendFilesystem or BackendSFTP. Defaults to BackendS3.
+	Backend string
+
+	// Endpoint is the address of the S3 endpoint
+	Endpoint string
+	// Secure enables TLS for the S3 endpoint
+	Secure          bool
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Root is the directory the filesystem backend stores its buckets in
+	Root string
+
+	// Address is the host:port of the SFTP server
+	Address  string
+	Username string
+	Password string
+	// PrivateKey is a PEM encoded private key used to authenticate at the SFTP server
+	PrivateKey string
+	// HostKey is the public key of the SFTP server in authorized_keys format
+	HostKey string
+}
+
+// NewBackend returns the backend selected in the given config
+func NewBackend(config Config) (Backend, error) {
+	switch config.Backend {
+	case "", BackendS3:
+		return newS3Backend(config.Endpoint, config.Secure, config.AccessKeyID, config.SecretAccessKey)
+	case BackendFilesystem:
+		return newFilesystemBackend(config.Root)
+	case BackendSFTP:
+		return newSFTPBackend(config.Address, config.Username, config.Password, config.PrivateKey, config.HostKey)
+	default:
+		return nil, fmt.Errorf("unknown backend %q", config.Backend)
+	}
+}
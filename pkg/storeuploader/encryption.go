@@ -0,0 +1,306 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storeuploader
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EncryptionKeyIDMetadataKey is the object metadata key under which the ID of the key
+	// an object was encrypted with is recorded
+	EncryptionKeyIDMetadataKey = "kubermatic-encryption-key-id"
+
+	// keySize is the size of both the key encryption keys and the generated data keys (AES-256)
+	keySize = 32
+	// chunkSize is the amount of plaintext that gets sealed at once, so snapshots never
+	// need to be held in memory completely
+	chunkSize = 64 * 1024
+)
+
+// encryptionMagic prefixes every encrypted file
+var encryptionMagic = []byte("KKPENC1\n")
+
+// Keys maps key IDs to 256 bit key encryption keys
+type Keys map[string][]byte
+
+// ParseKeys parses base64 encoded keys, e.g. taken from the data of a Secret
+func ParseKeys(data map[string][]byte) (Keys, error) {
+	keys := Keys{}
+	for id, encoded := range data {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key %q: %v", id, err)
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("key %q must be %d bytes long, got %d", id, keySize, len(key))
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// LoadKeys reads the keys from the given directory, in which every file holds a single
+// base64 encoded key and is named after its ID. This matches the layout of a mounted Secret.
+func LoadKeys(dir string) (Keys, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string][]byte{}
+	for _, info := range infos {
+		// mounted secrets contain hidden symlinks to the actual data
+		if strings.HasPrefix(info.Name(), ".") || info.IsDir() {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+		if err != nil {
+			return nil, err
+		}
+		data[info.Name()] = content
+	}
+	return ParseKeys(data)
+}
+
+// IsEncrypted returns whether the given file was encrypted by EncryptFile
+func IsEncrypted(file string) (bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(encryptionMagic))
+	if _, err := io.ReadFull(f, magic); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil
+		}
+		return false, err
+	}
+	return bytes.Equal(magic, encryptionMagic), nil
+}
+
+// EncryptFile encrypts src to dst with a newly generated data key, which is stored in the
+// header of dst, encrypted with the key identified by keyID.
+//
+// The file layout is: magic, key ID length (uint16), key ID, nonce and sealed data key,
+// followed by the chunks of the payload, each one sealed with the data key.
+func EncryptFile(keys Keys, keyID, src, dst string) error {
+	key, ok := keys[keyID]
+	if !ok {
+		return fmt.Errorf("unknown encryption key %q", keyID)
+	}
+	if len(keyID) > 0xffff {
+		return fmt.Errorf("key ID %q is too long", keyID)
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return fmt.Errorf("failed to generate data key: %v", err)
+	}
+	keyAEAD, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	keyNonce := make([]byte, keyAEAD.NonceSize())
+	if _, err := rand.Read(keyNonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(out)
+
+	header := append([]byte{}, encryptionMagic...)
+	header = append(header, byte(len(keyID)>>8), byte(len(keyID)))
+	header = append(header, keyID...)
+	header = append(header, keyNonce...)
+	header = keyAEAD.Seal(header, keyNonce, dataKey, []byte(keyID))
+	if _, err := w.Write(header); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := encryptChunks(dataKey, bufio.NewReader(in), w); err != nil {
+		out.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// DecryptFile decrypts a file created by EncryptFile, looking up the key by the ID in its header
+func DecryptFile(keys Keys, src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	r := bufio.NewReader(in)
+
+	prefix := make([]byte, len(encryptionMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return fmt.Errorf("failed to read header: %v", err)
+	}
+	if !bytes.Equal(prefix[:len(encryptionMagic)], encryptionMagic) {
+		return errors.New("file is not encrypted")
+	}
+	keyID := make([]byte, int(prefix[len(prefix)-2])<<8|int(prefix[len(prefix)-1]))
+	if _, err := io.ReadFull(r, keyID); err != nil {
+		return fmt.Errorf("failed to read header: %v", err)
+	}
+
+	key, ok := keys[string(keyID)]
+	if !ok {
+		return fmt.Errorf("file was encrypted with unknown key %q", keyID)
+	}
+	keyAEAD, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	sealedKey := make([]byte, keyAEAD.NonceSize()+keySize+keyAEAD.Overhead())
+	if _, err := io.ReadFull(r, sealedKey); err != nil {
+		return fmt.Errorf("failed to read header: %v", err)
+	}
+	dataKey, err := keyAEAD.Open(nil, sealedKey[:keyAEAD.NonceSize()], sealedKey[keyAEAD.NonceSize():], keyID)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt data key with key %q: %v", keyID, err)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(out)
+	if err := decryptChunks(dataKey, r, w); err != nil {
+		out.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// encryptChunks seals the plaintext in chunks of chunkSize. Every chunk is sealed with a counter
+// as nonce and the last one is marked, so reordered or truncated files are detected.
+func encryptChunks(dataKey []byte, r *bufio.Reader, w io.Writer) error {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	for counter := uint64(0); ; counter++ {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		last := n < chunkSize
+		if !last {
+			if _, err := r.Peek(1); err == io.EOF {
+				last = true
+			}
+		}
+
+		if _, err := w.Write(aead.Seal(nil, chunkNonce(aead, counter), buf[:n], chunkAdditionalData(last))); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+func decryptChunks(dataKey []byte, r *bufio.Reader, w io.Writer) error {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize+aead.Overhead())
+	for counter := uint64(0); ; counter++ {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		last := n < len(buf)
+		if !last {
+			if _, err := r.Peek(1); err == io.EOF {
+				last = true
+			}
+		}
+
+		plaintext, err := aead.Open(nil, chunkNonce(aead, counter), buf[:n], chunkAdditionalData(last))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt chunk %d, the file is corrupted or truncated: %v", counter, err)
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(aead cipher.AEAD, counter uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], counter)
+	return nonce
+}
+
+func chunkAdditionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storeuploader
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+)
+
+func TestEncryptDecryptFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryption")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := testKeys(t, "old", "new")
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "empty file", size: 0},
+		{name: "single chunk", size: 100},
+		{name: "exactly one chunk", size: chunkSize},
+		{name: "multiple chunks", size: 3*chunkSize + 7},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plaintext := make([]byte, test.size)
+			if _, err := rand.Read(plaintext); err != nil {
+				t.Fatalf("failed to generate plaintext: %v", err)
+			}
+			src := filepath.Join(dir, "snapshot.db")
+			encrypted := filepath.Join(dir, "snapshot.db.enc")
+			decrypted := filepath.Join(dir, "snapshot.db.dec")
+			if err := ioutil.WriteFile(src, plaintext, 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			if err := EncryptFile(keys, "new", src, encrypted); err != nil {
+				t.Fatalf("failed to encrypt: %v", err)
+			}
+			if isEncrypted, err := IsEncrypted(encrypted); err != nil || !isEncrypted {
+				t.Fatalf("expected file to be detected as encrypted, got %v (%v)", isEncrypted, err)
+			}
+			if isEncrypted, err := IsEncrypted(src); err != nil || isEncrypted {
+				t.Fatalf("expected plain file not to be detected as encrypted, got %v (%v)", isEncrypted, err)
+			}
+
+			if err := DecryptFile(keys, encrypted, decrypted); err != nil {
+				t.Fatalf("failed to decrypt: %v", err)
+			}
+			result, err := ioutil.ReadFile(decrypted)
+			if err != nil {
+				t.Fatalf("failed to read decrypted file: %v", err)
+			}
+			if !bytes.Equal(result, plaintext) {
+				t.Fatal("decrypted content does not match the original")
+			}
+
+			// a rotated key ring without the key the file was encrypted with
+			if err := DecryptFile(testKeys(t, "newer"), encrypted, decrypted); err == nil {
+				t.Error("expected decryption with an unknown key to fail")
+			}
+		})
+	}
+}
+
+func TestDecryptTruncatedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryption")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := testKeys(t, "key")
+	src := filepath.Join(dir, "snapshot.db")
+	encrypted := filepath.Join(dir, "snapshot.db.enc")
+	if err := ioutil.WriteFile(src, make([]byte, 2*chunkSize+1), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := EncryptFile(keys, "key", src, encrypted); err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(encrypted)
+	if err != nil {
+		t.Fatalf("failed to read encrypted file: %v", err)
+	}
+	// drop the last chunk, so the file ends at a chunk boundary
+	lastChunkSize := 1 + 16
+	if err := ioutil.WriteFile(encrypted, content[:len(content)-lastChunkSize], 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if err := DecryptFile(keys, encrypted, filepath.Join(dir, "snapshot.db.dec")); err == nil {
+		t.Error("expected decryption of a truncated file to fail")
+	}
+}
+
+func TestStoreUploaderEncryption(t *testing.T) {
+	root, err := ioutil.TempDir("", "encryption")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(root)
+
+	backend, err := NewBackend(Config{Backend: BackendFilesystem, Root: root})
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	logger := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+	keys := testKeys(t, "key")
+
+	uploader := NewWithBackend(backend, logger)
+	if err := uploader.SetEncryption(keys, "key"); err != nil {
+		t.Fatalf("failed to set encryption: %v", err)
+	}
+
+	snapshot := filepath.Join(root, "snapshot.db")
+	if err := ioutil.WriteFile(snapshot, []byte("etcd"), 0644); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	objectName, err := uploader.Store(snapshot, "backups", "cluster-a", true)
+	if err != nil {
+		t.Fatalf("failed to store snapshot: %v", err)
+	}
+	if isEncrypted, err := IsEncrypted(filepath.Join(root, "backups", objectName)); err != nil || !isEncrypted {
+		t.Fatalf("expected the stored snapshot to be encrypted, got %v (%v)", isEncrypted, err)
+	}
+
+	restored := filepath.Join(root, "restored.db")
+	if err := NewWithBackend(backend, logger).Download("backups", objectName, restored); err == nil {
+		t.Error("expected download of an encrypted snapshot without keys to fail")
+	}
+
+	downloader := NewWithBackend(backend, logger)
+	if err := downloader.SetEncryption(keys, ""); err != nil {
+		t.Fatalf("failed to set encryption: %v", err)
+	}
+	if err := downloader.Download("backups", objectName, restored); err != nil {
+		t.Fatalf("failed to download snapshot: %v", err)
+	}
+	content, err := ioutil.ReadFile(restored)
+	if err != nil {
+		t.Fatalf("failed to read restored snapshot: %v", err)
+	}
+	if string(content) != "etcd" {
+		t.Errorf("expected restored snapshot to contain %q, got %q", "etcd", content)
+	}
+}
+
+func testKeys(t *testing.T, ids ...string) Keys {
+	data := map[string][]byte{}
+	for _, id := range ids {
+		key := make([]byte, keySize)
+		if _, err := rand.Read(key); err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		data[id] = []byte(base64.StdEncoding.EncodeToString(key))
+	}
+	keys, err := ParseKeys(data)
+	if err != nil {
+		t.Fatalf("failed to parse keys: %v", err)
+	}
+	return keys
+}
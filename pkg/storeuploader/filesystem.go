@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storeuploader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// filesystemBackend stores backups in a local directory, every bucket being a subdirectory of root
+type filesystemBackend struct {
+	root string
+}
+
+func newFilesystemBackend(root string) (*filesystemBackend, error) {
+	if root == "" {
+		return nil, errors.New("root directory cannot be empty")
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	return &filesystemBackend{root: root}, nil
+}
+
+func (b *filesystemBackend) path(bucket string, elems ...string) (string, error) {
+	for _, elem := range append([]string{bucket}, elems...) {
+		if elem == "" || elem == "." || elem == ".." || strings.ContainsRune(elem, filepath.Separator) {
+			return "", fmt.Errorf("invalid path element %q", elem)
+		}
+	}
+	return filepath.Join(append([]string{b.root, bucket}, elems...)...), nil
+}
+
+func (b *filesystemBackend) EnsureBucket(bucket string) error {
+	dir, err := b.path(bucket)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+func (b *filesystemBackend) Upload(bucket, objectName, file string, _ map[string]string) error {
+	dst, err := b.path(bucket, objectName)
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first, so a failed upload never leaves a truncated backup behind
+	tmp := dst + ".tmp"
+	if err := copyFile(file, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+func (b *filesystemBackend) Download(bucket, objectName, file string) error {
+	src, err := b.path(bucket, objectName)
+	if err != nil {
+		return err
+	}
+	return copyFile(src, file)
+}
+
+func (b *filesystemBackend) List(bucket, prefix string) ([]Object, error) {
+	dir, err := b.path(bucket)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasPrefix(info.Name(), prefix) || strings.HasSuffix(info.Name(), ".tmp") {
+			continue
+		}
+		objects = append(objects, Object{
+			Key:          info.Name(),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+	}
+	return objects, nil
+}
+
+func (b *filesystemBackend) Delete(bucket, objectName string) error {
+	file, err := b.path(bucket, objectName)
+	if err != nil {
+		return err
+	}
+	return os.Remove(file)
+}
+
+func (b *filesystemBackend) Close() error {
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storeuploader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+)
+
+func TestFilesystemBackend(t *testing.T) {
+	root, err := ioutil.TempDir("", "storeuploader")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(root)
+
+	backend, err := NewBackend(Config{Backend: BackendFilesystem, Root: root})
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	uploader := NewWithBackend(backend, kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar())
+
+	snapshot := filepath.Join(root, "snapshot.db")
+	if err := ioutil.WriteFile(snapshot, []byte("etcd"), 0644); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+
+	objectName, err := uploader.Store(snapshot, "backups", "cluster-a", true)
+	if err != nil {
+		t.Fatalf("failed to store snapshot: %v", err)
+	}
+
+	// an older revision and a backup of another cluster
+	older := "cluster-a-" + prefixSeparator + "-2020-01-01T00:00:00-snapshot.db"
+	if err := backend.Upload("backups", older, snapshot, nil); err != nil {
+		t.Fatalf("failed to upload snapshot: %v", err)
+	}
+	oldTime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(root, "backups", older), oldTime, oldTime); err != nil {
+		t.Fatalf("failed to change modification time: %v", err)
+	}
+	if err := backend.Upload("backups", "cluster-b-"+prefixSeparator+"-snapshot.db", snapshot, nil); err != nil {
+		t.Fatalf("failed to upload snapshot: %v", err)
+	}
+
+	objects, err := uploader.List("backups", "cluster-a")
+	if err != nil {
+		t.Fatalf("failed to list objects: %v", err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objects))
+	}
+
+	if err := uploader.DeleteOldBackups("backups", "cluster-a", 1); err != nil {
+		t.Fatalf("failed to delete old backups: %v", err)
+	}
+	objects, err = uploader.List("backups", "cluster-a")
+	if err != nil {
+		t.Fatalf("failed to list objects: %v", err)
+	}
+	if len(objects) != 1 || objects[0].Key != objectName {
+		t.Fatalf("expected only %q to be kept, got %v", objectName, objects)
+	}
+
+	restored := filepath.Join(root, "restored.db")
+	if err := uploader.Download("backups", objectName, restored); err != nil {
+		t.Fatalf("failed to download snapshot: %v", err)
+	}
+	content, err := ioutil.ReadFile(restored)
+	if err != nil {
+		t.Fatalf("failed to read restored snapshot: %v", err)
+	}
+	if string(content) != "etcd" {
+		t.Errorf("expected restored snapshot to contain %q, got %q", "etcd", content)
+	}
+
+	if err := backend.Upload("backups", "../escape", snapshot, nil); err == nil {
+		t.Error("expected object names containing path separators to be rejected")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storeuploader
+
+import (
+	"github.com/minio/minio-go"
+)
+
+// s3Backend stores backups in an S3 compatible object storage
+type s3Backend struct {
+	client *minio.Client
+}
+
+func newS3Backend(endpoint string, secure bool, accessKeyID, secretAccessKey string) (*s3Backend, error) {
+	client, err := minio.New(endpoint, accessKeyID, secretAccessKey, secure)
+	if err != nil {
+		return nil, err
+	}
+	client.SetAppInfo("kubermatic-store-uploader", "v0.1")
+	return &s3Backend{client: client}, nil
+}
+
+func (b *s3Backend) EnsureBucket(bucket string) error {
+	exists, err := b.client.BucketExists(bucket)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return b.client.MakeBucket(bucket, "")
+}
+
+func (b *s3Backend) Upload(bucket, objectName, file string, metadata map[string]string) error {
+	_, err := b.client.FPutObject(bucket, objectName, file, minio.PutObjectOptions{UserMetadata: metadata})
+	return err
+}
+
+func (b *s3Backend) Download(bucket, objectName, file string) error {
+	return b.client.FGetObject(bucket, objectName, file, minio.GetObjectOptions{})
+}
+
+func (b *s3Backend) List(bucket, prefix string) ([]Object, error) {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	var objects []Object
+	for object := range b.client.ListObjects(bucket, prefix, true, doneCh) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		objects = append(objects, Object{
+			Key:          object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+func (b *s3Backend) Delete(bucket, objectName string) error {
+	return b.client.RemoveObject(bucket, objectName)
+}
+
+// Close is a no-op, the S3 client does not hold open connections
+func (b *s3Backend) Close() error {
+	return nil
+}
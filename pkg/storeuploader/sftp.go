@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storeuploader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// sftpBackend stores backups on an SFTP server, every bucket being a directory
+// relative to the login directory of the user
+type sftpBackend struct {
+	conn   *ssh.Client
+	client *sftp.Client
+}
+
+func newSFTPBackend(address, username, password, privateKey, hostKey string) (*sftpBackend, error) {
+	if address == "" || username == "" {
+		return nil, errors.New("address and username must be set")
+	}
+	if hostKey == "" {
+		return nil, errors.New("the host key of the server must be set")
+	}
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key: %v", err)
+	}
+
+	var auth []ssh.AuthMethod
+	if privateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %v", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if password != "" {
+		auth = append(auth, ssh.Password(password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("either a password or a private key must be set")
+	}
+
+	conn, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            username,
+		Auth:            auth,
+		HostKeyCallback: ssh.FixedHostKey(publicKey),
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %v", address, err)
+	}
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SFTP session: %v", err)
+	}
+
+	return &sftpBackend{conn: conn, client: client}, nil
+}
+
+func (b *sftpBackend) path(bucket string, elems ...string) (string, error) {
+	for _, elem := range append([]string{bucket}, elems...) {
+		if elem == "" || elem == "." || elem == ".." || strings.ContainsRune(elem, '/') {
+			return "", fmt.Errorf("invalid path element %q", elem)
+		}
+	}
+	return path.Join(append([]string{bucket}, elems...)...), nil
+}
+
+func (b *sftpBackend) EnsureBucket(bucket string) error {
+	dir, err := b.path(bucket)
+	if err != nil {
+		return err
+	}
+	return b.client.MkdirAll(dir)
+}
+
+func (b *sftpBackend) Upload(bucket, objectName, file string, _ map[string]string) error {
+	dst, err := b.path(bucket, objectName)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// Write to a temporary file first, so a failed upload never leaves a truncated backup behind
+	tmp := dst + ".tmp"
+	out, err := b.client.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		_ = b.client.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return b.client.PosixRename(tmp, dst)
+}
+
+func (b *sftpBackend) Download(bucket, objectName, file string) error {
+	src, err := b.path(bucket, objectName)
+	if err != nil {
+		return err
+	}
+
+	in, err := b.client.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func (b *sftpBackend) List(bucket, prefix string) ([]Object, error) {
+	dir, err := b.path(bucket)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := b.client.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasPrefix(info.Name(), prefix) || strings.HasSuffix(info.Name(), ".tmp") {
+			continue
+		}
+		objects = append(objects, Object{
+			Key:          info.Name(),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+	}
+	return objects, nil
+}
+
+func (b *sftpBackend) Delete(bucket, objectName string) error {
+	file, err := b.path(bucket, objectName)
+	if err != nil {
+		return err
+	}
+	return b.client.Remove(file)
+}
+
+func (b *sftpBackend) Close() error {
+	// closing the SFTP client only ends the session, not the SSH connection
+	if err := b.client.Close(); err != nil {
+		_ = b.conn.Close()
+		return err
+	}
+	return b.conn.Close()
+}
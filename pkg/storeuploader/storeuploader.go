@@ -19,12 +19,12 @@ package storeuploader
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"sort"
 	"time"
 
-	"github.com/minio/minio-go"
 	"go.uber.org/zap"
 )
 
@@ -37,83 +37,181 @@ const prefixSeparator = "storeuploader"
 // StoreUploader is the configuration
 // for the StoreUploader
 type StoreUploader struct {
-	// client is a pointer to an initialized client
-	client *minio.Client
-	logger *zap.SugaredLogger
+	// backend is the storage the backups are managed in
+	backend Backend
+	logger  *zap.SugaredLogger
+
+	// keys are used to decrypt downloaded backups
+	keys Keys
+	// encryptionKeyID is the ID of the key stored backups get encrypted with. If empty,
+	// backups are stored unencrypted.
+	encryptionKeyID string
 }
 
-// New returns a new instance of the StoreUploader
+// New returns a new instance of the StoreUploader using an S3 backend
 func New(endpoint string, secure bool, accessKeyID, secretAccessKey string, logger *zap.SugaredLogger) (*StoreUploader, error) {
-	client, err := minio.New(endpoint, accessKeyID, secretAccessKey, secure)
+	backend, err := newS3Backend(endpoint, secure, accessKeyID, secretAccessKey)
 	if err != nil {
 		return nil, err
 	}
-	client.SetAppInfo("kubermatic-store-uploader", "v0.1")
+	return NewWithBackend(backend, logger), nil
+}
+
+// NewWithBackend returns a new instance of the StoreUploader using the given backend
+func NewWithBackend(backend Backend, logger *zap.SugaredLogger) *StoreUploader {
 	return &StoreUploader{
-		client: client,
-		logger: logger,
-	}, nil
+		backend: backend,
+		logger:  logger,
+	}
+}
+
+// Close releases the connections of the backend
+func (u *StoreUploader) Close() error {
+	return u.backend.Close()
+}
+
+// SetEncryption configures the keys used to decrypt downloaded backups. If activeKeyID is set, stored
+// backups are encrypted with that key. Keys must be kept as long as backups encrypted with them exist,
+// so a key is rotated by adding a new one and making it the active key.
+func (u *StoreUploader) SetEncryption(keys Keys, activeKeyID string) error {
+	if activeKeyID != "" {
+		if _, ok := keys[activeKeyID]; !ok {
+			return fmt.Errorf("encryption key %q not found", activeKeyID)
+		}
+	}
+	u.keys = keys
+	u.encryptionKeyID = activeKeyID
+	return nil
 }
 
-// Store uploads the given file to S3
-func (u *StoreUploader) Store(file, bucket, prefix string, createBucket bool) error {
+// Store uploads the given file to the backend and returns the name of the created object
+func (u *StoreUploader) Store(file, bucket, prefix string, createBucket bool) (string, error) {
 	if len(prefix) == 0 {
-		return errors.New("prefix cannot be empty")
+		return "", errors.New("prefix cannot be empty")
 	}
 
 	if _, err := os.Stat(file); os.IsNotExist(err) {
-		return fmt.Errorf("%s not found", file)
+		return "", fmt.Errorf("%s not found", file)
 	}
 
 	logger := u.logger.With("bucket", bucket)
 
 	if createBucket {
-		logger.Debug("Check if bucket exists")
-		exists, err := u.client.BucketExists(bucket)
+		logger.Debug("Ensuring bucket exists")
+		if err := u.backend.EnsureBucket(bucket); err != nil {
+			return "", err
+		}
+	}
+
+	objectName := fmt.Sprintf("%s-%s-%s-%s", prefix, prefixSeparator, time.Now().Format("2006-01-02T15:04:05"), path.Base(file))
+
+	var metadata map[string]string
+	if u.encryptionKeyID != "" {
+		encrypted, err := tempFile(file)
 		if err != nil {
-			return err
+			return "", err
 		}
-		if !exists {
-			logger.Infow("Creating bucket")
-			if err := u.client.MakeBucket(bucket, ""); err != nil {
-				return err
-			}
+		defer os.Remove(encrypted)
+
+		logger.Debugw("Encrypting file", "key", u.encryptionKeyID)
+		if err := EncryptFile(u.keys, u.encryptionKeyID, file, encrypted); err != nil {
+			return "", fmt.Errorf("failed to encrypt %s: %v", file, err)
 		}
+		file = encrypted
+		metadata = map[string]string{EncryptionKeyIDMetadataKey: u.encryptionKeyID}
 	}
 
-	objectName := fmt.Sprintf("%s-%s-%s-%s", prefix, prefixSeparator, time.Now().Format("2006-01-02T15:04:05"), path.Base(file))
 	logger.Infow("Uploading file", "src", file, "dst", objectName)
 
-	_, err := u.client.FPutObject(bucket, objectName, file, minio.PutObjectOptions{})
-	return err
+	if err := u.backend.Upload(bucket, objectName, file, metadata); err != nil {
+		return "", err
+	}
+	return objectName, nil
 }
 
-// DeleteOldBackups deletes revisions of all files of the given prefix which are older than max-revisions
-func (u *StoreUploader) DeleteOldBackups(bucket, prefix string, revisionsToKeep int) error {
-	if len(prefix) == 0 {
-		return errors.New("prefix cannot be empty")
+// Download downloads the given object from the backend to the given file
+func (u *StoreUploader) Download(bucket, objectName, file string) error {
+	if len(objectName) == 0 {
+		return errors.New("object name cannot be empty")
 	}
 
-	doneCh := make(chan struct{})
-	defer close(doneCh)
+	logger := u.logger.With("bucket", bucket)
+	logger.Infow("Downloading file", "src", objectName, "dst", file)
 
-	logger := u.logger.With("bucket", bucket, "prefix", prefix, "keep", revisionsToKeep)
+	if err := u.backend.Download(bucket, objectName, file); err != nil {
+		return err
+	}
+
+	encrypted, err := IsEncrypted(file)
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		return nil
+	}
+	if len(u.keys) == 0 {
+		return fmt.Errorf("%s is encrypted, but no encryption keys are configured", objectName)
+	}
+
+	decrypted, err := tempFile(file)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(decrypted)
+
+	logger.Debugw("Decrypting file", "file", file)
+	if err := DecryptFile(u.keys, file, decrypted); err != nil {
+		return fmt.Errorf("failed to decrypt %s: %v", objectName, err)
+	}
+	return os.Rename(decrypted, file)
+}
+
+// tempFile returns the name of a new temporary file in the directory of the given file, so
+// it can be renamed to it and snapshots don't need to fit into the default temp dir
+func tempFile(file string) (string, error) {
+	f, err := ioutil.TempFile(path.Dir(file), path.Base(file)+".")
+	if err != nil {
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// List returns all revisions of all files of the given prefix
+func (u *StoreUploader) List(bucket, prefix string) ([]Object, error) {
+	if len(prefix) == 0 {
+		return nil, errors.New("prefix cannot be empty")
+	}
+
+	logger := u.logger.With("bucket", bucket, "prefix", prefix)
 
 	logger.Debugw("Listing existing objects")
 
-	var existingObjects []minio.ObjectInfo
-	for object := range u.client.ListObjects(bucket, fmt.Sprintf("%s-%s", prefix, prefixSeparator), true, doneCh) {
-		if object.Err != nil {
-			return object.Err
-		}
-		existingObjects = append(existingObjects, object)
+	existingObjects, err := u.backend.List(bucket, fmt.Sprintf("%s-%s", prefix, prefixSeparator))
+	if err != nil {
+		return nil, err
 	}
 
 	logger.Debugw("Done listing bucket", "objects", len(existingObjects))
 
+	return existingObjects, nil
+}
+
+// DeleteOldBackups deletes revisions of all files of the given prefix which are older than max-revisions
+func (u *StoreUploader) DeleteOldBackups(bucket, prefix string, revisionsToKeep int) error {
+	if len(prefix) == 0 {
+		return errors.New("prefix cannot be empty")
+	}
+
+	logger := u.logger.With("bucket", bucket, "prefix", prefix, "keep", revisionsToKeep)
+
+	existingObjects, err := u.List(bucket, prefix)
+	if err != nil {
+		return err
+	}
+
 	for _, object := range u.getObjectsToDelete(existingObjects, revisionsToKeep) {
 		logger.Infow("Removing object", "object", object.Key)
-		if err := u.client.RemoveObject(bucket, object.Key); err != nil {
+		if err := u.backend.Delete(bucket, object.Key); err != nil {
 			return err
 		}
 	}
@@ -127,26 +225,16 @@ func (u *StoreUploader) DeleteAll(bucket, prefix string) error {
 		return errors.New("prefix cannot be empty")
 	}
 
-	doneCh := make(chan struct{})
-	defer close(doneCh)
-
 	logger := u.logger.With("bucket", bucket, "prefix", prefix)
 
-	logger.Debugw("Listing existing objects")
-
-	var existingObjects []minio.ObjectInfo
-	for object := range u.client.ListObjects(bucket, fmt.Sprintf("%s-%s", prefix, prefixSeparator), true, doneCh) {
-		if object.Err != nil {
-			return object.Err
-		}
-		existingObjects = append(existingObjects, object)
+	existingObjects, err := u.List(bucket, prefix)
+	if err != nil {
+		return err
 	}
 
-	logger.Debugw("Done listing bucket", "objects", len(existingObjects))
-
 	for _, object := range existingObjects {
 		logger.Infow("Removing object", "object", object.Key)
-		if err := u.client.RemoveObject(bucket, object.Key); err != nil {
+		if err := u.backend.Delete(bucket, object.Key); err != nil {
 			return err
 		}
 	}
@@ -154,7 +242,7 @@ func (u *StoreUploader) DeleteAll(bucket, prefix string) error {
 	return nil
 }
 
-func (u *StoreUploader) getObjectsToDelete(objects []minio.ObjectInfo, revisionsToKeep int) []minio.ObjectInfo {
+func (u *StoreUploader) getObjectsToDelete(objects []Object, revisionsToKeep int) []Object {
 	if len(objects) <= revisionsToKeep {
 		return nil
 	}
@@ -165,7 +253,7 @@ func (u *StoreUploader) getObjectsToDelete(objects []minio.ObjectInfo, revisions
 
 	numRevisionsToDelete := len(objects) - revisionsToKeep
 
-	var objectsToDelete []minio.ObjectInfo
+	var objectsToDelete []Object
 	for idx, object := range objects {
 		if idx >= numRevisionsToDelete {
 			return objectsToDelete
@@ -21,20 +21,19 @@ import (
 	"time"
 
 	"github.com/go-test/deep"
-	"github.com/minio/minio-go"
 )
 
 func TestGetObjectsToDelete(t *testing.T) {
 	tests := []struct {
 		name             string
-		existingObjects  []minio.ObjectInfo
-		expectedToDelete []minio.ObjectInfo
+		existingObjects  []Object
+		expectedToDelete []Object
 		revisions        int
 	}{
 		{
 			name:      "nothing gets deleted as revisions==existing-backups",
 			revisions: 1,
-			existingObjects: []minio.ObjectInfo{
+			existingObjects: []Object{
 				{
 					Key:          "foo",
 					LastModified: time.Unix(1, 0),
@@ -45,7 +44,7 @@ func TestGetObjectsToDelete(t *testing.T) {
 		{
 			name:      "oldest should be deleted as revisions < existing-backups",
 			revisions: 1,
-			existingObjects: []minio.ObjectInfo{
+			existingObjects: []Object{
 				{
 					Key:          "foo",
 					LastModified: time.Unix(1, 0),
@@ -55,7 +54,7 @@ func TestGetObjectsToDelete(t *testing.T) {
 					LastModified: time.Unix(10, 0),
 				},
 			},
-			expectedToDelete: []minio.ObjectInfo{
+			expectedToDelete: []Object{
 				{
 					Key:          "foo",
 					LastModified: time.Unix(1, 0),
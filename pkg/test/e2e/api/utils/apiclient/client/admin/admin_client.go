@@ -25,8 +25,12 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	CreatePreset(params *CreatePresetParams, authInfo runtime.ClientAuthInfoWriter) (*CreatePresetCreated, error)
+
 	DeleteAdmissionPlugin(params *DeleteAdmissionPluginParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteAdmissionPluginOK, error)
 
+	DeletePreset(params *DeletePresetParams, authInfo runtime.ClientAuthInfoWriter) (*DeletePresetOK, error)
+
 	DeleteSeed(params *DeleteSeedParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteSeedOK, error)
 
 	GetAdmins(params *GetAdminsParams, authInfo runtime.ClientAuthInfoWriter) (*GetAdminsOK, error)
@@ -37,10 +41,14 @@ type ClientService interface {
 
 	GetKubermaticSettings(params *GetKubermaticSettingsParams, authInfo runtime.ClientAuthInfoWriter) (*GetKubermaticSettingsOK, error)
 
+	GetPreset(params *GetPresetParams, authInfo runtime.ClientAuthInfoWriter) (*GetPresetOK, error)
+
 	GetSeed(params *GetSeedParams, authInfo runtime.ClientAuthInfoWriter) (*GetSeedOK, error)
 
 	ListAdmissionPlugins(params *ListAdmissionPluginsParams, authInfo runtime.ClientAuthInfoWriter) (*ListAdmissionPluginsOK, error)
 
+	ListPresets(params *ListPresetsParams, authInfo runtime.ClientAuthInfoWriter) (*ListPresetsOK, error)
+
 	ListSeeds(params *ListSeedsParams, authInfo runtime.ClientAuthInfoWriter) (*ListSeedsOK, error)
 
 	PatchKubermaticSettings(params *PatchKubermaticSettingsParams, authInfo runtime.ClientAuthInfoWriter) (*PatchKubermaticSettingsOK, error)
@@ -49,11 +57,47 @@ type ClientService interface {
 
 	UpdateAdmissionPlugin(params *UpdateAdmissionPluginParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateAdmissionPluginOK, error)
 
+	UpdatePreset(params *UpdatePresetParams, authInfo runtime.ClientAuthInfoWriter) (*UpdatePresetOK, error)
+
 	UpdateSeed(params *UpdateSeedParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateSeedOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  CreatePreset creates the preset
+*/
+func (a *Client) CreatePreset(params *CreatePresetParams, authInfo runtime.ClientAuthInfoWriter) (*CreatePresetCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreatePresetParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "createPreset",
+		Method:             "POST",
+		PathPattern:        "/api/v1/admin/presets",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &CreatePresetReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreatePresetCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreatePresetDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   DeleteAdmissionPlugin deletes the admission plugin
 */
@@ -88,6 +132,40 @@ func (a *Client) DeleteAdmissionPlugin(params *DeleteAdmissionPluginParams, auth
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  DeletePreset deletes the preset
+*/
+func (a *Client) DeletePreset(params *DeletePresetParams, authInfo runtime.ClientAuthInfoWriter) (*DeletePresetOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeletePresetParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "deletePreset",
+		Method:             "DELETE",
+		PathPattern:        "/api/v1/admin/presets/{preset_name}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &DeletePresetReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeletePresetOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*DeletePresetDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   DeleteSeed deletes the seed c r d object from the kubermatic
 */
@@ -258,6 +336,40 @@ func (a *Client) GetKubermaticSettings(params *GetKubermaticSettingsParams, auth
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetPreset gets the preset
+*/
+func (a *Client) GetPreset(params *GetPresetParams, authInfo runtime.ClientAuthInfoWriter) (*GetPresetOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetPresetParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getPreset",
+		Method:             "GET",
+		PathPattern:        "/api/v1/admin/presets/{preset_name}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetPresetReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetPresetOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetPresetDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetSeed returns the seed object
 */
@@ -326,6 +438,40 @@ func (a *Client) ListAdmissionPlugins(params *ListAdmissionPluginsParams, authIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListPresets returns all presets including the ones restricted to projects
+*/
+func (a *Client) ListPresets(params *ListPresetsParams, authInfo runtime.ClientAuthInfoWriter) (*ListPresetsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListPresetsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listPresets",
+		Method:             "GET",
+		PathPattern:        "/api/v1/admin/presets",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListPresetsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListPresetsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListPresetsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListSeeds returns all seeds from the c r ds
 */
@@ -462,6 +608,40 @@ func (a *Client) UpdateAdmissionPlugin(params *UpdateAdmissionPluginParams, auth
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  UpdatePreset updates the preset
+*/
+func (a *Client) UpdatePreset(params *UpdatePresetParams, authInfo runtime.ClientAuthInfoWriter) (*UpdatePresetOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdatePresetParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "updatePreset",
+		Method:             "PUT",
+		PathPattern:        "/api/v1/admin/presets/{preset_name}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &UpdatePresetReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UpdatePresetOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*UpdatePresetDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   UpdateSeed updates the seed
 */
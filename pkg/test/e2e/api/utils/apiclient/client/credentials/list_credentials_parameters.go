@@ -62,6 +62,11 @@ type ListCredentialsParams struct {
 
 	/*Datacenter*/
 	Datacenter *string
+	/*ProjectID
+	  ProjectID limits the credentials to the presets available in the given project
+
+	*/
+	ProjectID *string
 	/*ProviderName*/
 	ProviderName string
 
@@ -114,6 +119,17 @@ func (o *ListCredentialsParams) SetDatacenter(datacenter *string) {
 	o.Datacenter = datacenter
 }
 
+// WithProjectID adds the projectID to the list credentials params
+func (o *ListCredentialsParams) WithProjectID(projectID *string) *ListCredentialsParams {
+	o.SetProjectID(projectID)
+	return o
+}
+
+// SetProjectID adds the projectId to the list credentials params
+func (o *ListCredentialsParams) SetProjectID(projectID *string) {
+	o.ProjectID = projectID
+}
+
 // WithProviderName adds the providerName to the list credentials params
 func (o *ListCredentialsParams) WithProviderName(providerName string) *ListCredentialsParams {
 	o.SetProviderName(providerName)
@@ -149,6 +165,22 @@ func (o *ListCredentialsParams) WriteToRequest(r runtime.ClientRequest, reg strf
 
 	}
 
+	if o.ProjectID != nil {
+
+		// query param project_id
+		var qrProjectID string
+		if o.ProjectID != nil {
+			qrProjectID = *o.ProjectID
+		}
+		qProjectID := qrProjectID
+		if qProjectID != "" {
+			if err := r.SetQueryParam("project_id", qProjectID); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param provider_name
 	if err := r.SetPathParam("provider_name", o.ProviderName); err != nil {
 		return err
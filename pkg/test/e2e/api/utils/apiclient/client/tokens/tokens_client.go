@@ -33,6 +33,8 @@ type ClientService interface {
 
 	PatchServiceAccountToken(params *PatchServiceAccountTokenParams, authInfo runtime.ClientAuthInfoWriter) (*PatchServiceAccountTokenOK, error)
 
+	RotateServiceAccountToken(params *RotateServiceAccountTokenParams, authInfo runtime.ClientAuthInfoWriter) (*RotateServiceAccountTokenCreated, error)
+
 	UpdateServiceAccountToken(params *UpdateServiceAccountTokenParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateServiceAccountTokenOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
@@ -174,6 +176,40 @@ func (a *Client) PatchServiceAccountToken(params *PatchServiceAccountTokenParams
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  RotateServiceAccountToken Issues a replacement for the token. The old token is revoked after the grace period.
+*/
+func (a *Client) RotateServiceAccountToken(params *RotateServiceAccountTokenParams, authInfo runtime.ClientAuthInfoWriter) (*RotateServiceAccountTokenCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRotateServiceAccountTokenParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "rotateServiceAccountToken",
+		Method:             "POST",
+		PathPattern:        "/api/v1/projects/{project_id}/serviceaccounts/{serviceaccount_id}/tokens/{token_id}/rotate",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &RotateServiceAccountTokenReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RotateServiceAccountTokenCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*RotateServiceAccountTokenDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   UpdateServiceAccountToken Updates and regenerates the token
 */
@@ -33,6 +33,10 @@ type ClientService interface {
 
 	ListVSphereNetworksNoCredentials(params *ListVSphereNetworksNoCredentialsParams, authInfo runtime.ClientAuthInfoWriter) (*ListVSphereNetworksNoCredentialsOK, error)
 
+	ListVSphereResourcePools(params *ListVSphereResourcePoolsParams, authInfo runtime.ClientAuthInfoWriter) (*ListVSphereResourcePoolsOK, error)
+
+	ListVSphereTagCategories(params *ListVSphereTagCategoriesParams, authInfo runtime.ClientAuthInfoWriter) (*ListVSphereTagCategoriesOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -172,6 +176,74 @@ func (a *Client) ListVSphereNetworksNoCredentials(params *ListVSphereNetworksNoC
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListVSphereResourcePools Lists resource pools from vsphere datacenter
+*/
+func (a *Client) ListVSphereResourcePools(params *ListVSphereResourcePoolsParams, authInfo runtime.ClientAuthInfoWriter) (*ListVSphereResourcePoolsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListVSphereResourcePoolsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listVSphereResourcePools",
+		Method:             "GET",
+		PathPattern:        "/api/v1/providers/vsphere/resourcepools",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListVSphereResourcePoolsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListVSphereResourcePoolsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListVSphereResourcePoolsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  ListVSphereTagCategories Lists tag categories from vsphere datacenter
+*/
+func (a *Client) ListVSphereTagCategories(params *ListVSphereTagCategoriesParams, authInfo runtime.ClientAuthInfoWriter) (*ListVSphereTagCategoriesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListVSphereTagCategoriesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listVSphereTagCategories",
+		Method:             "GET",
+		PathPattern:        "/api/v1/providers/vsphere/tagcategories",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListVSphereTagCategoriesReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListVSphereTagCategoriesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListVSphereTagCategoriesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
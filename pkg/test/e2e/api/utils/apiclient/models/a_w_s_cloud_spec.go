@@ -25,6 +25,12 @@ type AWSCloudSpec struct {
 	// instance profile name
 	InstanceProfileName string `json:"instanceProfileName,omitempty"`
 
+	// PrivateNodes disables public IPs for all nodes of the cluster. The nodes then reach the internet
+	// through a NAT gateway, which must be routed to in the route tables of the subnets of the
+	// nodes, or in the route table of the cluster if no subnets are set.
+	// +optional
+	PrivateNodes bool `json:"privateNodes,omitempty"`
+
 	// DEPRECATED. Don't care for the role name. We only require the ControlPlaneRoleARN to be set so the control plane
 	// can perform the assume-role.
 	// We keep it for backwards compatibility (We use this name for cleanup purpose).
@@ -39,6 +45,11 @@ type AWSCloudSpec struct {
 	// security group ID
 	SecurityGroupID string `json:"securityGroupID,omitempty"`
 
+	// SubnetIDs maps availability zones to the subnets the nodes in that zone are placed in.
+	// When set, nodes can only be created in the listed availability zones. Requires the VPCID to be set.
+	// +optional
+	SubnetIDs map[string]string `json:"subnetIDs,omitempty"`
+
 	// v p c ID
 	VPCID string `json:"vpcId,omitempty"`
 
@@ -6,6 +6,9 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -17,10 +20,121 @@ type AuditLoggingSettings struct {
 
 	// enabled
 	Enabled bool `json:"enabled,omitempty"`
+
+	// Sinks configures where the audit log is shipped to. The audit log is written to stdout
+	// of the audit-logs sidecar if no sinks are configured.
+	Sinks []*AuditSink `json:"sinks"`
+
+	// custom policy
+	CustomPolicy *AuditPolicyConfigMapRef `json:"customPolicy,omitempty"`
+
+	// log rotation
+	LogRotation *AuditLogRotationSettings `json:"logRotation,omitempty"`
+
+	// policy preset
+	PolicyPreset AuditPolicyPreset `json:"policyPreset,omitempty"`
 }
 
 // Validate validates this audit logging settings
 func (m *AuditLoggingSettings) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateSinks(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCustomPolicy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLogRotation(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePolicyPreset(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AuditLoggingSettings) validateSinks(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Sinks) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Sinks); i++ {
+		if swag.IsZero(m.Sinks[i]) { // not required
+			continue
+		}
+
+		if m.Sinks[i] != nil {
+			if err := m.Sinks[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("sinks" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *AuditLoggingSettings) validateCustomPolicy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CustomPolicy) { // not required
+		return nil
+	}
+
+	if m.CustomPolicy != nil {
+		if err := m.CustomPolicy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("customPolicy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditLoggingSettings) validateLogRotation(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LogRotation) { // not required
+		return nil
+	}
+
+	if m.LogRotation != nil {
+		if err := m.LogRotation.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("logRotation")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditLoggingSettings) validatePolicyPreset(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyPreset) { // not required
+		return nil
+	}
+
+	if err := m.PolicyPreset.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyPreset")
+		}
+		return err
+	}
+
 	return nil
 }
 
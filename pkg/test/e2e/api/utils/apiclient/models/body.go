@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -15,15 +16,48 @@ import (
 // swagger:model body
 type Body struct {
 
+	// Credential is the name of the preset which contains the credentials for the managed Kubernetes service
+	Credential string `json:"credential,omitempty"`
+
 	// Kubeconfig Base64 encoded kubeconfig
 	Kubeconfig string `json:"kubeconfig,omitempty"`
 
 	// Name is human readable name for the external cluster
 	Name string `json:"name,omitempty"`
+
+	// cloud
+	Cloud *ExternalClusterCloudSpec `json:"cloud,omitempty"`
 }
 
 // Validate validates this body
 func (m *Body) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCloud(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Body) validateCloud(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Cloud) { // not required
+		return nil
+	}
+
+	if m.Cloud != nil {
+		if err := m.Cloud.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("cloud")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -18,12 +18,20 @@ import (
 // swagger:model ClusterSpec
 type ClusterSpec struct {
 
+	// APIServerAllowedIPRanges restricts the source IP ranges in CIDR notation which may reach the
+	// apiserver, all sources are allowed if empty. The node and master egress ranges are always allowed.
+	APIServerAllowedIPRanges []string `json:"apiServerAllowedIPRanges"`
+
 	// Additional Admission Controller plugins
 	AdmissionPlugins []string `json:"admissionPlugins"`
 
 	// MachineNetworks optionally specifies the parameters for IPAM.
 	MachineNetworks []*MachineNetworkingConfig `json:"machineNetworks"`
 
+	// NodeEgressIPRanges are the source IP ranges in CIDR notation the worker nodes reach the
+	// apiserver from. They are required if APIServerAllowedIPRanges is set.
+	NodeEgressIPRanges []string `json:"nodeEgressIPRanges"`
+
 	// If active the PodNodeSelector admission plugin is configured at the apiserver
 	UsePodNodeSelectorAdmissionPlugin bool `json:"usePodNodeSelectorAdmissionPlugin,omitempty"`
 
@@ -36,6 +44,9 @@ type ClusterSpec struct {
 	// cloud
 	Cloud *CloudSpec `json:"cloud,omitempty"`
 
+	// etcd backup
+	EtcdBackup *EtcdBackupSettings `json:"etcdBackup,omitempty"`
+
 	// oidc
 	Oidc *OIDCSettings `json:"oidc,omitempty"`
 
@@ -65,6 +76,10 @@ func (m *ClusterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateEtcdBackup(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOidc(formats); err != nil {
 		res = append(res, err)
 	}
@@ -144,6 +159,24 @@ func (m *ClusterSpec) validateCloud(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ClusterSpec) validateEtcdBackup(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.EtcdBackup) { // not required
+		return nil
+	}
+
+	if m.EtcdBackup != nil {
+		if err := m.EtcdBackup.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("etcdBackup")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ClusterSpec) validateOidc(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Oidc) { // not required
@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -18,12 +19,67 @@ type ClusterStatus struct {
 	// URL specifies the address at which the cluster is available
 	URL string `json:"url,omitempty"`
 
+	// etcd backup
+	EtcdBackup *EtcdBackupStatus `json:"etcdBackup,omitempty"`
+
+	// external cluster
+	ExternalCluster *ExternalClusterStatus `json:"externalCluster,omitempty"`
+
 	// version
 	Version Semver `json:"version,omitempty"`
 }
 
 // Validate validates this cluster status
 func (m *ClusterStatus) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateEtcdBackup(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateExternalCluster(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ClusterStatus) validateEtcdBackup(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.EtcdBackup) { // not required
+		return nil
+	}
+
+	if m.EtcdBackup != nil {
+		if err := m.EtcdBackup.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("etcdBackup")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ClusterStatus) validateExternalCluster(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ExternalCluster) { // not required
+		return nil
+	}
+
+	if m.ExternalCluster != nil {
+		if err := m.ExternalCluster.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("externalCluster")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
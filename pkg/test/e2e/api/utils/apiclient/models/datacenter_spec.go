@@ -60,6 +60,9 @@ type DatacenterSpec struct {
 	// digitalocean
 	Digitalocean *DatacenterSpecDigitalocean `json:"digitalocean,omitempty"`
 
+	// enforced audit policy preset
+	EnforcedAuditPolicyPreset AuditPolicyPreset `json:"enforcedAuditPolicyPreset,omitempty"`
+
 	// fake
 	Fake *DatacenterSpecFake `json:"fake,omitempty"`
 
@@ -70,7 +73,7 @@ type DatacenterSpec struct {
 	Hetzner *DatacenterSpecHetzner `json:"hetzner,omitempty"`
 
 	// kubevirt
-	Kubevirt DatacenterSpecKubevirt `json:"kubevirt,omitempty"`
+	Kubevirt *DatacenterSpecKubevirt `json:"kubevirt,omitempty"`
 
 	// node
 	Node *NodeSettings `json:"node,omitempty"`
@@ -105,6 +108,10 @@ func (m *DatacenterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateEnforcedAuditPolicyPreset(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateFake(formats); err != nil {
 		res = append(res, err)
 	}
@@ -117,6 +124,10 @@ func (m *DatacenterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateKubevirt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateNode(formats); err != nil {
 		res = append(res, err)
 	}
@@ -211,6 +222,22 @@ func (m *DatacenterSpec) validateDigitalocean(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DatacenterSpec) validateEnforcedAuditPolicyPreset(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.EnforcedAuditPolicyPreset) { // not required
+		return nil
+	}
+
+	if err := m.EnforcedAuditPolicyPreset.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("enforcedAuditPolicyPreset")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *DatacenterSpec) validateFake(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Fake) { // not required
@@ -265,6 +292,24 @@ func (m *DatacenterSpec) validateHetzner(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DatacenterSpec) validateKubevirt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Kubevirt) { // not required
+		return nil
+	}
+
+	if m.Kubevirt != nil {
+		if err := m.Kubevirt.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("kubevirt")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *DatacenterSpec) validateNode(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Node) { // not required
@@ -5,7 +5,65 @@ package models
 // This file was generated by the swagger tool.
 // Editing this file might prove futile when you re-run the swagger generate command
 
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
 // DatacenterSpecKubevirt DatacenterSpecKubevirt describes a kubevirt datacenter.
 //
 // swagger:model DatacenterSpecKubevirt
-type DatacenterSpecKubevirt interface{}
+type DatacenterSpecKubevirt struct {
+
+	// resource quota
+	ResourceQuota ResourceList `json:"resourceQuota,omitempty"`
+}
+
+// Validate validates this datacenter spec kubevirt
+func (m *DatacenterSpecKubevirt) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateResourceQuota(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DatacenterSpecKubevirt) validateResourceQuota(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ResourceQuota) { // not required
+		return nil
+	}
+
+	if err := m.ResourceQuota.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("resourceQuota")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DatacenterSpecKubevirt) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DatacenterSpecKubevirt) UnmarshalBinary(b []byte) error {
+	var res DatacenterSpecKubevirt
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -16,6 +16,12 @@ import (
 // swagger:model HetznerCloudSpec
 type HetznerCloudSpec struct {
 
+	// Network is the name of the private network the nodes are attached to.
+	// If empty, a network is created for the cluster and deleted together with it.
+	// It must have a server subnet in the network zone of the datacenter and must not
+	// overlap with the pod and service CIDRs. No firewall is created for the nodes.
+	Network string `json:"network,omitempty"`
+
 	// token
 	Token string `json:"token,omitempty"`
 
@@ -6,6 +6,9 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -22,12 +25,54 @@ type MasterVersion struct {
 	// with one of the kubelets inside cluster and shouldn't be used.
 	RestrictedByKubeletVersion bool `json:"restrictedByKubeletVersion,omitempty"`
 
+	// UpgradeBlockers lists the issues found in the cluster that have to be
+	// resolved before the control plane can be updated to this version.
+	// Resources using a removed API version are only found if they were
+	// applied with kubectl apply, as the version is taken from their
+	// last-applied-configuration annotation. An empty list does not guarantee
+	// that no client uses removed APIs.
+	UpgradeBlockers []*UpgradeBlocker `json:"upgradeBlockers"`
+
 	// version
 	Version Version `json:"version,omitempty"`
 }
 
 // Validate validates this master version
 func (m *MasterVersion) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateUpgradeBlockers(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *MasterVersion) validateUpgradeBlockers(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.UpgradeBlockers) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.UpgradeBlockers); i++ {
+		if swag.IsZero(m.UpgradeBlockers[i]) { // not required
+			continue
+		}
+
+		if m.UpgradeBlockers[i] != nil {
+			if err := m.UpgradeBlockers[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("upgradeBlockers" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -32,9 +32,21 @@ type ServiceAccountToken struct {
 	// ID unique value that identifies the resource generated by the server. Read-Only.
 	ID string `json:"id,omitempty"`
 
+	// LastUsed is a timestamp representing the time when this token was last used.
+	// Format: date-time
+	LastUsed strfmt.DateTime `json:"lastUsed,omitempty"`
+
 	// Name represents human readable name for the resource
 	Name string `json:"name,omitempty"`
 
+	// RevokeAt is a timestamp representing the time when this token will be revoked because it was rotated.
+	// Format: date-time
+	RevokeAt strfmt.DateTime `json:"revokeAt,omitempty"`
+
+	// Scopes restricts the API routes the token can be used for, e.g. "GET /api/v1/projects/{project_id}/clusters".
+	// The token can be used for all routes if no scopes are set.
+	Scopes []string `json:"scopes"`
+
 	// Token the JWT token
 	Token string `json:"token,omitempty"`
 }
@@ -55,6 +67,14 @@ func (m *ServiceAccountToken) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateLastUsed(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRevokeAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -100,6 +120,32 @@ func (m *ServiceAccountToken) validateExpiry(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ServiceAccountToken) validateLastUsed(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LastUsed) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("lastUsed", "body", "date-time", m.LastUsed.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ServiceAccountToken) validateRevokeAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RevokeAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("revokeAt", "body", "date-time", m.RevokeAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *ServiceAccountToken) MarshalBinary() ([]byte, error) {
 	if m == nil {
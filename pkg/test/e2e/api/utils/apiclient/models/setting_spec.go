@@ -37,6 +37,10 @@ type SettingSpec struct {
 	// restrict project creation
 	RestrictProjectCreation bool `json:"restrictProjectCreation,omitempty"`
 
+	// ServiceAccountTokenMaxExpiryHours caps the validity of newly issued service account
+	// tokens. Zero means that tokens can be valid for up to three years.
+	ServiceAccountTokenMaxExpiryHours int64 `json:"serviceAccountTokenMaxExpiryHours,omitempty"`
+
 	// user projects limit
 	UserProjectsLimit int64 `json:"userProjectsLimit,omitempty"`
 
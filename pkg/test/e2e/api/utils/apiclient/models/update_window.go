@@ -10,15 +10,15 @@ import (
 	"github.com/go-openapi/swag"
 )
 
-// UpdateWindow update window
+// UpdateWindow UpdateWindow restricts automatic updates of the cluster to a recurring maintenance window.
 //
 // swagger:model UpdateWindow
 type UpdateWindow struct {
 
-	// length
+	// Length is the duration of the window, e.g. "3h".
 	Length string `json:"length,omitempty"`
 
-	// start
+	// Start is the UTC time of day, optionally prefixed by a weekday, at which the window opens, e.g. "Tue 02:00".
 	Start string `json:"start,omitempty"`
 }
 
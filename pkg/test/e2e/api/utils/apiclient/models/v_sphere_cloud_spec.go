@@ -16,6 +16,10 @@ import (
 // swagger:model VSphereCloudSpec
 type VSphereCloudSpec struct {
 
+	// ClusterTagID is the ID of the tag that was created for the cluster.
+	// +optional
+	ClusterTagID string `json:"clusterTagID,omitempty"`
+
 	// Datastore to be used for storing virtual machines, it is mutually
 	// exclusive with DatastoreCluster.
 	// +optional
@@ -35,6 +39,24 @@ type VSphereCloudSpec struct {
 	// +optional
 	Password string `json:"password,omitempty"`
 
+	// ResourcePool is the path of the resource pool the virtual machines are
+	// placed in. Defaults to the root resource pool of the vSphere cluster.
+	// +optional
+	ResourcePool string `json:"resourcePool,omitempty"`
+
+	// TagCategoryID is the ID of the tag category in which a tag is created
+	// for the cluster. The tag is periodically attached to the virtual machines
+	// in the folder of the cluster, if the folder was created for it, and is
+	// deleted together with the cluster. No tags are managed if it is empty.
+	// +optional
+	TagCategoryID string `json:"tagCategoryID,omitempty"`
+
+	// Tags is a list of IDs of existing tags, which are attached to the
+	// virtual machines of the cluster in addition to the cluster tag.
+	// Tags can be added, but not removed once the cluster tag was created.
+	// +optional
+	Tags []string `json:"tags"`
+
 	// Username is the vSphere user name.
 	// +optional
 	Username string `json:"username,omitempty"`
@@ -22,6 +22,9 @@ import (
 	"testing"
 
 	"github.com/pmezard/go-difflib/difflib"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
 )
 
 func CompareOutput(t *testing.T, name, output string, update bool, suffix string) {
@@ -59,3 +62,12 @@ func CompareOutput(t *testing.T, name, output string, update bool, suffix string
 		t.Errorf("got diff between expected and actual result: \n%s\n", diffStr)
 	}
 }
+
+// NewClusterUpdater returns a provider.ClusterUpdater which modifies the given cluster in place,
+// the way the cloud controller persists the modifications of cloud providers.
+func NewClusterUpdater(cluster *kubermaticv1.Cluster) provider.ClusterUpdater {
+	return func(_ string, modify func(*kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error) {
+		modify(cluster)
+		return cluster.DeepCopy(), nil
+	}
+}
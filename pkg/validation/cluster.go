@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
@@ -30,6 +32,8 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources"
 
 	"github.com/coreos/locksmith/pkg/timeutil"
+	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	utilerror "k8s.io/apimachinery/pkg/util/errors"
 )
@@ -333,6 +337,22 @@ func validateAWSCloudSpec(spec *kubermaticv1.AWSCloudSpec) error {
 			return err
 		}
 	}
+
+	for zone, subnetID := range spec.SubnetIDs {
+		if zone == "" {
+			return errors.New("subnets must be mapped to an availability zone")
+		}
+		if subnetID == "" {
+			return fmt.Errorf("no subnet specified for availability zone %q", zone)
+		}
+	}
+	if len(spec.SubnetIDs) > 0 && spec.VPCID == "" {
+		return errors.New("vpc must be set when specifying subnets")
+	}
+	// The default subnets of a VPC assign public IPs, so private nodes need dedicated subnets.
+	if spec.PrivateNodes && len(spec.SubnetIDs) == 0 {
+		return errors.New("private nodes require a subnet per availability zone")
+	}
 	return nil
 }
 
@@ -464,3 +484,141 @@ func ValidateUpdateWindow(updateWindow *kubermaticv1.UpdateWindow) error {
 	}
 	return nil
 }
+
+// ValidateEtcdBackupSettings validates the per-cluster etcd backup settings
+func ValidateEtcdBackupSettings(settings *kubermaticv1.EtcdBackupSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.Schedule != "" {
+		if _, err := cron.ParseStandard(settings.Schedule); err != nil {
+			return fmt.Errorf("invalid etcd backup schedule %q: %v", settings.Schedule, err)
+		}
+	}
+	if settings.Retention != nil && *settings.Retention < 1 {
+		return fmt.Errorf("etcd backup retention must be at least 1, got %d", *settings.Retention)
+	}
+	return nil
+}
+
+// ValidateAPIServerAllowedIPRanges validates the source IP ranges which may reach the apiserver of a cluster.
+// The nodes and the master must still reach the apiserver, so their egress ranges must be known and the
+// NodePort proxy of the seed must pass the source IPs of the connections to envoy.
+func ValidateAPIServerAllowedIPRanges(spec *kubermaticv1.ClusterSpec, seed *kubermaticv1.Seed) error {
+	for _, ipRange := range spec.APIServerAllowedIPRanges {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			return fmt.Errorf("invalid apiserver allowed IP range %q: %v", ipRange, err)
+		}
+	}
+	for _, ipRange := range spec.NodeEgressIPRanges {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			return fmt.Errorf("invalid node egress IP range %q: %v", ipRange, err)
+		}
+	}
+	if len(spec.APIServerAllowedIPRanges) == 0 {
+		return nil
+	}
+
+	if len(spec.NodeEgressIPRanges) == 0 {
+		return errors.New("the node egress IP ranges must be set to restrict the apiserver allowed IP ranges")
+	}
+	if len(seed.Spec.MasterEgressIPRanges) == 0 {
+		return fmt.Errorf("seed %q does not define the master egress IP ranges, which are required to restrict the apiserver allowed IP ranges", seed.Name)
+	}
+	if spec.ExposeStrategy != corev1.ServiceTypeLoadBalancer &&
+		seed.Spec.NodeportProxy.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeLocal {
+		return fmt.Errorf("the NodePort proxy of seed %q does not preserve the source IPs, the apiserver allowed IP ranges can only be restricted with the LoadBalancer expose strategy", seed.Name)
+	}
+	return nil
+}
+
+// ValidateAuditLoggingSettings validates the audit logging settings of a cluster
+func ValidateAuditLoggingSettings(settings *kubermaticv1.AuditLoggingSettings) error {
+	if settings == nil {
+		return nil
+	}
+
+	switch settings.PolicyPreset {
+	case "", kubermaticv1.AuditPolicyMetadata, kubermaticv1.AuditPolicyRecommended:
+	case kubermaticv1.AuditPolicyCustom:
+		if settings.CustomPolicy == nil || settings.CustomPolicy.Name == "" {
+			return fmt.Errorf("audit policy preset %q requires a custom policy ConfigMap", settings.PolicyPreset)
+		}
+	default:
+		return fmt.Errorf("unknown audit policy preset %q", settings.PolicyPreset)
+	}
+
+	if rotation := settings.LogRotation; rotation != nil {
+		if rotation.MaxAge < 0 || rotation.MaxBackup < 0 || rotation.MaxSize < 0 {
+			return errors.New("audit log rotation settings must not be negative")
+		}
+	}
+
+	var fileSinks int
+	for i, sink := range settings.Sinks {
+		if err := validateAuditSink(sink); err != nil {
+			return fmt.Errorf("invalid audit sink %d: %v", i, err)
+		}
+		if sink.File != nil {
+			fileSinks++
+		}
+	}
+	// the file sink replaces the audit log file of the apiserver
+	if fileSinks > 1 {
+		return errors.New("at most one file sink can be configured")
+	}
+
+	return nil
+}
+
+func validateAuditSink(sink kubermaticv1.AuditSink) error {
+	configured := 0
+	if sink.Webhook != nil {
+		configured++
+		u, err := url.Parse(sink.Webhook.URL)
+		if err != nil {
+			return fmt.Errorf("failed to parse webhook URL: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("webhook URL must use http or https, got %q", u.Scheme)
+		}
+		if u.Hostname() == "" {
+			return errors.New("webhook URL must contain a host")
+		}
+	}
+	if sink.Syslog != nil {
+		configured++
+		if sink.Syslog.Host == "" {
+			return errors.New("syslog host must be set")
+		}
+		if sink.Syslog.Port < 1 || sink.Syslog.Port > 65535 {
+			return fmt.Errorf("invalid syslog port %d", sink.Syslog.Port)
+		}
+		switch sink.Syslog.Mode {
+		case "", "udp", "tcp", "tls":
+		default:
+			return fmt.Errorf("unknown syslog mode %q", sink.Syslog.Mode)
+		}
+		switch sink.Syslog.Format {
+		case "", "rfc5424", "rfc3164":
+		default:
+			return fmt.Errorf("unknown syslog format %q", sink.Syslog.Format)
+		}
+	}
+	if sink.File != nil {
+		configured++
+		name := sink.File.Name
+		if name == "" || strings.ContainsAny(name, "/\\") || name == "." || name == ".." {
+			return fmt.Errorf("invalid file name %q", name)
+		}
+		// The audit log itself and the state of the sidecar live on the same volume.
+		if name == "audit.log" || strings.HasPrefix(name, "audit-") || strings.HasPrefix(name, "fluentbit.db") {
+			return fmt.Errorf("file name %q is reserved", name)
+		}
+	}
+
+	if configured != 1 {
+		return fmt.Errorf("exactly one sink type must be configured, got %d", configured)
+	}
+	return nil
+}
@@ -22,6 +22,9 @@ import (
 	"testing"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -112,6 +115,80 @@ func TestValidateCloudSpec(t *testing.T) {
 	}
 }
 
+func TestValidateAWSCloudSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec kubermaticv1.AWSCloudSpec
+		err  error
+	}{
+		{
+			name: "valid spec",
+			spec: kubermaticv1.AWSCloudSpec{
+				AccessKeyID:     "some-key",
+				SecretAccessKey: "some-secret",
+			},
+		},
+		{
+			name: "valid private nodes spec",
+			spec: kubermaticv1.AWSCloudSpec{
+				AccessKeyID:     "some-key",
+				SecretAccessKey: "some-secret",
+				VPCID:           "vpc-1",
+				SubnetIDs:       map[string]string{"eu-central-1a": "subnet-a", "eu-central-1b": "subnet-b"},
+				PrivateNodes:    true,
+			},
+		},
+		{
+			name: "subnets without vpc",
+			err:  errors.New("vpc must be set when specifying subnets"),
+			spec: kubermaticv1.AWSCloudSpec{
+				AccessKeyID:     "some-key",
+				SecretAccessKey: "some-secret",
+				SubnetIDs:       map[string]string{"eu-central-1a": "subnet-a"},
+			},
+		},
+		{
+			name: "subnet without availability zone",
+			err:  errors.New("subnets must be mapped to an availability zone"),
+			spec: kubermaticv1.AWSCloudSpec{
+				AccessKeyID:     "some-key",
+				SecretAccessKey: "some-secret",
+				VPCID:           "vpc-1",
+				SubnetIDs:       map[string]string{"": "subnet-a"},
+			},
+		},
+		{
+			name: "availability zone without subnet",
+			err:  errors.New(`no subnet specified for availability zone "eu-central-1a"`),
+			spec: kubermaticv1.AWSCloudSpec{
+				AccessKeyID:     "some-key",
+				SecretAccessKey: "some-secret",
+				VPCID:           "vpc-1",
+				SubnetIDs:       map[string]string{"eu-central-1a": ""},
+			},
+		},
+		{
+			name: "private nodes without subnets",
+			err:  errors.New("private nodes require a subnet per availability zone"),
+			spec: kubermaticv1.AWSCloudSpec{
+				AccessKeyID:     "some-key",
+				SecretAccessKey: "some-secret",
+				VPCID:           "vpc-1",
+				PrivateNodes:    true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateAWSCloudSpec(&test.spec)
+			if fmt.Sprint(err) != fmt.Sprint(test.err) {
+				t.Errorf("Extected err to be %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
 func TestValidateUpdateWindow(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -152,3 +229,232 @@ func TestValidateUpdateWindow(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEtcdBackupSettings(t *testing.T) {
+	hourlyFor30Days := 720
+	noRetention := 0
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.EtcdBackupSettings
+		wantErr  bool
+	}{
+		{
+			name: "unset settings",
+		},
+		{
+			name: "hourly backups kept for 30 days",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				Schedule:  "0 * * * *",
+				Retention: &hourlyFor30Days,
+			},
+		},
+		{
+			name: "invalid schedule",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				Schedule: "every hour",
+			},
+			wantErr: true,
+		},
+		{
+			name: "retention below one",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				Retention: &noRetention,
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateEtcdBackupSettings(test.settings)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerAllowedIPRanges(t *testing.T) {
+	seed := &kubermaticv1.Seed{
+		ObjectMeta: metav1.ObjectMeta{Name: "europe-west3"},
+		Spec: kubermaticv1.SeedSpec{
+			MasterEgressIPRanges: []string{"203.0.113.0/28"},
+			NodeportProxy: kubermaticv1.NodeportProxyConfig{
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+			},
+		},
+	}
+	seedWithoutMasterRanges := seed.DeepCopy()
+	seedWithoutMasterRanges.Spec.MasterEgressIPRanges = nil
+	seedWithClusterPolicy := seed.DeepCopy()
+	seedWithClusterPolicy.Spec.NodeportProxy.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+
+	tests := []struct {
+		name           string
+		ranges         []string
+		nodeRanges     []string
+		exposeStrategy corev1.ServiceType
+		seed           *kubermaticv1.Seed
+		wantErr        bool
+	}{
+		{
+			name: "unset ranges",
+			seed: seedWithoutMasterRanges,
+		},
+		{
+			name:       "office and CI egress ranges",
+			ranges:     []string{"192.0.2.0/24", "198.51.100.17/32", "2001:db8::/32"},
+			nodeRanges: []string{"198.51.100.64/26"},
+			seed:       seed,
+		},
+		{
+			name:       "IP without prefix length",
+			ranges:     []string{"192.0.2.1"},
+			nodeRanges: []string{"198.51.100.64/26"},
+			seed:       seed,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid range",
+			ranges:     []string{"192.0.2.0/24", "office"},
+			nodeRanges: []string{"198.51.100.64/26"},
+			seed:       seed,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid node egress range",
+			ranges:     []string{"192.0.2.0/24"},
+			nodeRanges: []string{"nodes"},
+			seed:       seed,
+			wantErr:    true,
+		},
+		{
+			name:    "missing node egress ranges",
+			ranges:  []string{"192.0.2.0/24"},
+			seed:    seed,
+			wantErr: true,
+		},
+		{
+			name:       "seed without master egress ranges",
+			ranges:     []string{"192.0.2.0/24"},
+			nodeRanges: []string{"198.51.100.64/26"},
+			seed:       seedWithoutMasterRanges,
+			wantErr:    true,
+		},
+		{
+			name:       "NodePort proxy hides the source IPs",
+			ranges:     []string{"192.0.2.0/24"},
+			nodeRanges: []string{"198.51.100.64/26"},
+			seed:       seedWithClusterPolicy,
+			wantErr:    true,
+		},
+		{
+			name:           "dedicated LoadBalancer enforces the ranges",
+			ranges:         []string{"192.0.2.0/24"},
+			nodeRanges:     []string{"198.51.100.64/26"},
+			exposeStrategy: corev1.ServiceTypeLoadBalancer,
+			seed:           seedWithClusterPolicy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exposeStrategy := test.exposeStrategy
+			if exposeStrategy == "" {
+				exposeStrategy = corev1.ServiceTypeNodePort
+			}
+			spec := &kubermaticv1.ClusterSpec{
+				ExposeStrategy:           exposeStrategy,
+				APIServerAllowedIPRanges: test.ranges,
+				NodeEgressIPRanges:       test.nodeRanges,
+			}
+			err := ValidateAPIServerAllowedIPRanges(spec, test.seed)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuditLoggingSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.AuditLoggingSettings
+		wantErr  bool
+	}{
+		{
+			name: "unset settings",
+		},
+		{
+			name: "recommended preset with webhook and syslog sinks",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyRecommended,
+				Sinks: []kubermaticv1.AuditSink{
+					{Webhook: &kubermaticv1.AuditWebhookSink{URL: "https://audit.example.com/ingest"}},
+					{Syslog: &kubermaticv1.AuditSyslogSink{Host: "syslog.example.com", Port: 514, Mode: "tcp"}},
+				},
+			},
+		},
+		{
+			name: "unknown preset",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				PolicyPreset: "everything",
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom preset without ConfigMap",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				PolicyPreset: kubermaticv1.AuditPolicyCustom,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sink with two types",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sinks: []kubermaticv1.AuditSink{
+					{
+						Webhook: &kubermaticv1.AuditWebhookSink{URL: "https://audit.example.com"},
+						File:    &kubermaticv1.AuditFileSink{Name: "shipped.log"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhook with unsupported scheme",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sinks: []kubermaticv1.AuditSink{
+					{Webhook: &kubermaticv1.AuditWebhookSink{URL: "ftp://audit.example.com"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "two file sinks",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sinks: []kubermaticv1.AuditSink{
+					{File: &kubermaticv1.AuditFileSink{Name: "shipped.log"}},
+					{File: &kubermaticv1.AuditFileSink{Name: "other.log"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "file sink overwriting the audit log",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sinks: []kubermaticv1.AuditSink{
+					{File: &kubermaticv1.AuditFileSink{Name: "audit.log"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAuditLoggingSettings(test.settings)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
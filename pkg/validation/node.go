@@ -18,6 +18,7 @@ package validation
 
 import (
 	"errors"
+	"fmt"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -30,5 +31,20 @@ func ValidateCreateNodeSpec(c *kubermaticv1.Cluster, spec *apiv1.NodeSpec, dc *k
 		}
 	}
 
+	if c.Spec.Cloud.AWS != nil && spec.Cloud.AWS != nil {
+		if c.Spec.Cloud.AWS.PrivateNodes && spec.Cloud.AWS.AssignPublicIP != nil && *spec.Cloud.AWS.AssignPublicIP {
+			return errors.New("public ips are not allowed for nodes of a private cluster")
+		}
+		if len(c.Spec.Cloud.AWS.SubnetIDs) > 0 {
+			subnetID, ok := c.Spec.Cloud.AWS.SubnetIDs[spec.Cloud.AWS.AvailabilityZone]
+			if !ok {
+				return fmt.Errorf("no subnet specified for availability zone %q", spec.Cloud.AWS.AvailabilityZone)
+			}
+			if spec.Cloud.AWS.SubnetID != "" && spec.Cloud.AWS.SubnetID != subnetID {
+				return fmt.Errorf("subnet %q does not belong to availability zone %q", spec.Cloud.AWS.SubnetID, spec.Cloud.AWS.AvailabilityZone)
+			}
+		}
+	}
+
 	return nil
 }
@@ -35,6 +35,7 @@ func EqualError(a, b error) bool {
 func TestValidateCreateNodeSpec(t *testing.T) {
 	t.Parallel()
 
+	assignPublicIP := true
 	cases := []struct {
 		Name       string
 		Cluster    *kubermaticv1.Cluster
@@ -141,7 +142,86 @@ func TestValidateCreateNodeSpec(t *testing.T) {
 			},
 			nil,
 		},
-	}
+		{
+			"should pass validation when aws node is placed in a mapped availability zone",
+			&kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						AWS: &kubermaticv1.AWSCloudSpec{
+							SubnetIDs:    map[string]string{"eu-central-1a": "subnet-a"},
+							PrivateNodes: true,
+						},
+					},
+				},
+			},
+			&apiv1.NodeSpec{
+				Cloud: apiv1.NodeCloudSpec{
+					AWS: &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1a"},
+				},
+			},
+			&kubermaticv1.Datacenter{},
+			nil,
+		},
+		{
+			"should fail validation when aws node is placed in an unmapped availability zone",
+			&kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						AWS: &kubermaticv1.AWSCloudSpec{
+							SubnetIDs:    map[string]string{"eu-central-1a": "subnet-a"},
+							PrivateNodes: true,
+						},
+					},
+				},
+			},
+			&apiv1.NodeSpec{
+				Cloud: apiv1.NodeCloudSpec{
+					AWS: &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1b"},
+				},
+			},
+			&kubermaticv1.Datacenter{},
+			errors.New(`no subnet specified for availability zone "eu-central-1b"`),
+		},
+		{
+			"should fail validation when aws node uses a subnet of another availability zone",
+			&kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						AWS: &kubermaticv1.AWSCloudSpec{
+							SubnetIDs:    map[string]string{"eu-central-1a": "subnet-a"},
+							PrivateNodes: true,
+						},
+					},
+				},
+			},
+			&apiv1.NodeSpec{
+				Cloud: apiv1.NodeCloudSpec{
+					AWS: &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1a", SubnetID: "subnet-b"},
+				},
+			},
+			&kubermaticv1.Datacenter{},
+			errors.New(`subnet "subnet-b" does not belong to availability zone "eu-central-1a"`),
+		},
+		{
+			"should fail validation when aws node of a private cluster requests a public ip",
+			&kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						AWS: &kubermaticv1.AWSCloudSpec{
+							SubnetIDs:    map[string]string{"eu-central-1a": "subnet-a"},
+							PrivateNodes: true,
+						},
+					},
+				},
+			},
+			&apiv1.NodeSpec{
+				Cloud: apiv1.NodeCloudSpec{
+					AWS: &apiv1.AWSNodeSpec{AvailabilityZone: "eu-central-1a", AssignPublicIP: &assignPublicIP},
+				},
+			},
+			&kubermaticv1.Datacenter{},
+			errors.New("public ips are not allowed for nodes of a private cluster"),
+		}}
 
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
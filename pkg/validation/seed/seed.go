@@ -19,13 +19,18 @@ package seed
 import (
 	"context"
 	"fmt"
+	"net"
+	"path"
+	"strings"
 	"sync"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -151,6 +156,28 @@ func (sv *Validator) validate(ctx context.Context, subject *kubermaticv1.Seed, s
 		}
 	}
 
+	if !isDelete {
+		if err := validateEtcdBackupStorage(subject.Spec.EtcdBackupStorage); err != nil {
+			return fmt.Errorf("invalid etcd backup storage: %v", err)
+		}
+		if encryption := subject.Spec.EtcdBackupEncryption; encryption != nil && (encryption.KeySecret == "" || encryption.ActiveKeyID == "") {
+			return fmt.Errorf("invalid etcd backup encryption: both key_secret and active_key_id must be set")
+		}
+		if err := validateEtcdDefragmentation(subject.Spec.EtcdDefragmentation); err != nil {
+			return fmt.Errorf("invalid etcd defragmentation settings: %v", err)
+		}
+		for _, ipRange := range subject.Spec.MasterEgressIPRanges {
+			if _, _, err := net.ParseCIDR(ipRange); err != nil {
+				return fmt.Errorf("invalid master egress IP range %q: %v", ipRange, err)
+			}
+		}
+		switch subject.Spec.NodeportProxy.ExternalTrafficPolicy {
+		case "", corev1.ServiceExternalTrafficPolicyTypeCluster, corev1.ServiceExternalTrafficPolicyTypeLocal:
+		default:
+			return fmt.Errorf("invalid nodeport proxy external traffic policy %q", subject.Spec.NodeportProxy.ExternalTrafficPolicy)
+		}
+	}
+
 	// check if there are still clusters using DCs not defined anymore
 	clusters := &kubermaticv1.ClusterList{}
 	if err := seedClient.List(ctx, clusters, sv.listOpts); err != nil {
@@ -169,6 +196,62 @@ func (sv *Validator) validate(ctx context.Context, subject *kubermaticv1.Seed, s
 	return nil
 }
 
+func validateEtcdBackupStorage(storage *kubermaticv1.EtcdBackupStorage) error {
+	if storage == nil {
+		return nil
+	}
+	if storage.Bucket == "" {
+		return fmt.Errorf("no bucket specified")
+	}
+
+	switch storage.Backend {
+	case kubermaticv1.EtcdBackupStorageBackendS3:
+		if storage.S3 == nil || storage.S3.Endpoint == "" {
+			return fmt.Errorf("the s3 backend requires an endpoint")
+		}
+	case kubermaticv1.EtcdBackupStorageBackendFilesystem:
+		if storage.Filesystem == nil || storage.Filesystem.PersistentVolumeClaim == "" {
+			return fmt.Errorf("the filesystem backend requires a persistent volume claim")
+		}
+		if p := storage.Filesystem.Path; p != "" && (path.IsAbs(p) || strings.HasPrefix(path.Clean(p), "..")) {
+			return fmt.Errorf("the path of the filesystem backend must be relative to the root of the volume")
+		}
+	case kubermaticv1.EtcdBackupStorageBackendSFTP:
+		if storage.SFTP == nil || storage.SFTP.Address == "" || storage.SFTP.HostKey == "" {
+			return fmt.Errorf("the sftp backend requires an address and a host key")
+		}
+		if storage.CredentialsSecret == "" {
+			return fmt.Errorf("the sftp backend requires a credentials secret")
+		}
+	default:
+		return fmt.Errorf("unknown backend %q", storage.Backend)
+	}
+
+	return nil
+}
+
+func validateEtcdDefragmentation(settings *kubermaticv1.EtcdDefragmentationSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.DBSizeThresholdPercent < 0 || settings.DBSizeThresholdPercent > 100 {
+		return fmt.Errorf("db_size_threshold_percent must be between 0 and 100")
+	}
+	if settings.FragmentationThresholdPercent < 0 || settings.FragmentationThresholdPercent > 100 {
+		return fmt.Errorf("fragmentation_threshold_percent must be between 0 and 100")
+	}
+	if settings.Interval != "" {
+		interval, err := time.ParseDuration(settings.Interval)
+		if err != nil {
+			return fmt.Errorf("interval %q is not a valid duration: %v", settings.Interval, err)
+		}
+		if interval < time.Minute {
+			return fmt.Errorf("interval must be at least one minute")
+		}
+	}
+	return nil
+}
+
 //EnsureSingleSeedValidator ensures that only the seed with the given Name and
 //Namespace can be created.
 type EnsureSingleSeedValidator struct {
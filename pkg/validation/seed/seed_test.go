@@ -26,6 +26,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -331,6 +332,152 @@ func TestValidate(t *testing.T) {
 			},
 			errExpected: true,
 		},
+		{
+			name: "Etcd backup storage with a complete S3 backend should be valid",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupStorage: &kubermaticv1.EtcdBackupStorage{
+						Backend: kubermaticv1.EtcdBackupStorageBackendS3,
+						Bucket:  "backups",
+						S3: &kubermaticv1.EtcdBackupS3Storage{
+							Endpoint: "minio.minio.svc.cluster.local:9000",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Etcd backup storage with a filesystem backend requires a PVC",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupStorage: &kubermaticv1.EtcdBackupStorage{
+						Backend:    kubermaticv1.EtcdBackupStorageBackendFilesystem,
+						Bucket:     "backups",
+						Filesystem: &kubermaticv1.EtcdBackupFilesystemStorage{},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Etcd backup storage with a filesystem path outside of the volume should be rejected",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupStorage: &kubermaticv1.EtcdBackupStorage{
+						Backend: kubermaticv1.EtcdBackupStorageBackendFilesystem,
+						Bucket:  "backups",
+						Filesystem: &kubermaticv1.EtcdBackupFilesystemStorage{
+							PersistentVolumeClaim: "etcd-backups",
+							Path:                  "../other",
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Etcd backup storage with a complete filesystem backend should be valid",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupStorage: &kubermaticv1.EtcdBackupStorage{
+						Backend: kubermaticv1.EtcdBackupStorageBackendFilesystem,
+						Bucket:  "backups",
+						Filesystem: &kubermaticv1.EtcdBackupFilesystemStorage{
+							PersistentVolumeClaim: "etcd-backups",
+							Path:                  "kubermatic",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Etcd backup storage with an unknown backend should be rejected",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupStorage: &kubermaticv1.EtcdBackupStorage{
+						Backend: "ftp",
+						Bucket:  "backups",
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Etcd backup encryption requires an active key",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupEncryption: &kubermaticv1.EtcdBackupEncryption{
+						KeySecret: "etcd-backup-keys",
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Etcd defragmentation interval must be a duration",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdDefragmentation: &kubermaticv1.EtcdDefragmentationSettings{
+						Interval: "15",
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Etcd defragmentation threshold must be a percentage",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdDefragmentation: &kubermaticv1.EtcdDefragmentationSettings{
+						DBSizeThresholdPercent: 120,
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Valid etcd defragmentation settings",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					EtcdDefragmentation: &kubermaticv1.EtcdDefragmentationSettings{
+						DBSizeThresholdPercent:        70,
+						FragmentationThresholdPercent: 20,
+						Interval:                      "10m",
+					},
+				},
+			},
+		},
+		{
+			name: "Master egress IP ranges must be CIDRs",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					MasterEgressIPRanges: []string{"203.0.113.7"},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Unknown nodeport proxy external traffic policy",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					NodeportProxy: kubermaticv1.NodeportProxyConfig{
+						ExternalTrafficPolicy: "Preserve",
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Valid master egress IP ranges with source IP preserving nodeport proxy",
+			seedToValidate: &kubermaticv1.Seed{
+				Spec: kubermaticv1.SeedSpec{
+					MasterEgressIPRanges: []string{"203.0.113.0/28"},
+					NodeportProxy: kubermaticv1.NodeportProxyConfig{
+						ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+					},
+				},
+			},
+		},
 		{
 			name: "Cannot remove datacenters that are used by clusters",
 			existingSeeds: map[string]*kubermaticv1.Seed{
@@ -0,0 +1,310 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upgradecheck implements the pre-flight checks that are run against a
+// user cluster before its control plane is updated to a new Kubernetes version.
+package upgradecheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/validation/nodeupdate"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CheckRemovedAPIs reports resources that were applied using an API version
+	// which is no longer served by the target version.
+	CheckRemovedAPIs = "RemovedAPIs"
+	// CheckKubeletVersionSkew reports MachineDeployments whose kubelet version
+	// would be incompatible with the target control plane version.
+	CheckKubeletVersionSkew = "KubeletVersionSkew"
+	// CheckAdmissionPlugins reports admission plugins that are enabled for the
+	// cluster but not available in the target version.
+	CheckAdmissionPlugins = "AdmissionPlugins"
+)
+
+// Blocker describes an issue which prevents a control plane update.
+type Blocker struct {
+	// Check is the name of the check which reported the blocker.
+	Check string
+	// Message is a human readable description of the issue.
+	Message string
+}
+
+func (b Blocker) String() string {
+	return fmt.Sprintf("%s: %s", b.Check, b.Message)
+}
+
+// AdmissionPluginsLister returns the names of all admission plugins available
+// in a given Kubernetes version. It is implemented by provider.AdmissionPluginsProvider.
+type AdmissionPluginsLister interface {
+	ListPluginNamesFromVersion(fromVersion string) ([]string, error)
+}
+
+// removedAPI is a kind that was served in a group version up to (excluding)
+// the Kubernetes minor version it was removed in.
+type removedAPI struct {
+	groupVersion schema.GroupVersion
+	kind         string
+	removedIn    *semver.Version
+	replacement  string
+}
+
+var (
+	v116 = semver.MustParse("1.16.0")
+	v122 = semver.MustParse("1.22.0")
+	v125 = semver.MustParse("1.25.0")
+)
+
+// removedAPIs lists the API versions removed from Kubernetes, see
+// https://kubernetes.io/docs/reference/using-api/deprecation-guide/
+var removedAPIs = []removedAPI{
+	{groupVersion: schema.GroupVersion{Group: "extensions", Version: "v1beta1"}, kind: "DaemonSet", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "extensions", Version: "v1beta1"}, kind: "Deployment", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "extensions", Version: "v1beta1"}, kind: "ReplicaSet", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "extensions", Version: "v1beta1"}, kind: "NetworkPolicy", removedIn: v116, replacement: "networking.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "extensions", Version: "v1beta1"}, kind: "PodSecurityPolicy", removedIn: v116, replacement: "policy/v1beta1"},
+	{groupVersion: schema.GroupVersion{Group: "apps", Version: "v1beta1"}, kind: "Deployment", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "apps", Version: "v1beta1"}, kind: "StatefulSet", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "apps", Version: "v1beta2"}, kind: "DaemonSet", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "apps", Version: "v1beta2"}, kind: "Deployment", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "apps", Version: "v1beta2"}, kind: "ReplicaSet", removedIn: v116, replacement: "apps/v1"},
+	{groupVersion: schema.GroupVersion{Group: "apps", Version: "v1beta2"}, kind: "StatefulSet", removedIn: v116, replacement: "apps/v1"},
+
+	{groupVersion: schema.GroupVersion{Group: "extensions", Version: "v1beta1"}, kind: "Ingress", removedIn: v122, replacement: "networking.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "networking.k8s.io", Version: "v1beta1"}, kind: "Ingress", removedIn: v122, replacement: "networking.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "networking.k8s.io", Version: "v1beta1"}, kind: "IngressClass", removedIn: v122, replacement: "networking.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "apiextensions.k8s.io", Version: "v1beta1"}, kind: "CustomResourceDefinition", removedIn: v122, replacement: "apiextensions.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "admissionregistration.k8s.io", Version: "v1beta1"}, kind: "MutatingWebhookConfiguration", removedIn: v122, replacement: "admissionregistration.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "admissionregistration.k8s.io", Version: "v1beta1"}, kind: "ValidatingWebhookConfiguration", removedIn: v122, replacement: "admissionregistration.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "apiregistration.k8s.io", Version: "v1beta1"}, kind: "APIService", removedIn: v122, replacement: "apiregistration.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "rbac.authorization.k8s.io", Version: "v1beta1"}, kind: "ClusterRole", removedIn: v122, replacement: "rbac.authorization.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "rbac.authorization.k8s.io", Version: "v1beta1"}, kind: "ClusterRoleBinding", removedIn: v122, replacement: "rbac.authorization.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "rbac.authorization.k8s.io", Version: "v1beta1"}, kind: "Role", removedIn: v122, replacement: "rbac.authorization.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "rbac.authorization.k8s.io", Version: "v1beta1"}, kind: "RoleBinding", removedIn: v122, replacement: "rbac.authorization.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "scheduling.k8s.io", Version: "v1beta1"}, kind: "PriorityClass", removedIn: v122, replacement: "scheduling.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "storage.k8s.io", Version: "v1beta1"}, kind: "StorageClass", removedIn: v122, replacement: "storage.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "storage.k8s.io", Version: "v1beta1"}, kind: "CSIDriver", removedIn: v122, replacement: "storage.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "storage.k8s.io", Version: "v1beta1"}, kind: "CSINode", removedIn: v122, replacement: "storage.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "storage.k8s.io", Version: "v1beta1"}, kind: "VolumeAttachment", removedIn: v122, replacement: "storage.k8s.io/v1"},
+
+	{groupVersion: schema.GroupVersion{Group: "batch", Version: "v1beta1"}, kind: "CronJob", removedIn: v125, replacement: "batch/v1"},
+	{groupVersion: schema.GroupVersion{Group: "discovery.k8s.io", Version: "v1beta1"}, kind: "EndpointSlice", removedIn: v125, replacement: "discovery.k8s.io/v1"},
+	{groupVersion: schema.GroupVersion{Group: "policy", Version: "v1beta1"}, kind: "PodDisruptionBudget", removedIn: v125, replacement: "policy/v1"},
+	{groupVersion: schema.GroupVersion{Group: "policy", Version: "v1beta1"}, kind: "PodSecurityPolicy", removedIn: v125, replacement: "none, migrate to Pod Security Admission"},
+	{groupVersion: schema.GroupVersion{Group: "autoscaling", Version: "v2beta1"}, kind: "HorizontalPodAutoscaler", removedIn: v125, replacement: "autoscaling/v2"},
+	{groupVersion: schema.GroupVersion{Group: "node.k8s.io", Version: "v1beta1"}, kind: "RuntimeClass", removedIn: v125, replacement: "node.k8s.io/v1"},
+}
+
+// ClusterState holds the resources of a user cluster the pre-flight checks are
+// evaluated against. It is gathered once and can be checked against any number
+// of target versions.
+type ClusterState struct {
+	cluster *kubermaticv1.Cluster
+	// removedAPIUsers maps the entries of removedAPIs to the objects that were
+	// last applied using them
+	removedAPIUsers map[int][]string
+	// kubelets are the kubelet versions of the MachineDeployments
+	kubelets []machineDeploymentKubelet
+}
+
+type machineDeploymentKubelet struct {
+	name    string
+	version *semver.Version
+}
+
+// GatherClusterState lists the resources that are relevant for updating the
+// control plane of the given cluster to any version up to maxTarget. client must
+// be a client for the user cluster.
+func GatherClusterState(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, maxTarget *semver.Version) (*ClusterState, error) {
+	state := &ClusterState{
+		cluster:         cluster,
+		removedAPIUsers: map[int][]string{},
+	}
+	current := cluster.Spec.Version.Semver()
+
+	for i, api := range removedAPIs {
+		if !current.LessThan(api.removedIn) || maxTarget.LessThan(api.removedIn) {
+			continue
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(api.groupVersion.WithKind(api.kind + "List"))
+		if err := client.List(ctx, list); err != nil {
+			// The API is not served by the cluster anymore, so nothing can use it
+			if meta.IsNoMatchError(err) || kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s %s: %v", api.groupVersion, api.kind, err)
+		}
+
+		for _, item := range list.Items {
+			if lastAppliedAPIVersion(&item) == api.groupVersion.String() {
+				state.removedAPIUsers[i] = append(state.removedAPIUsers[i], objectName(&item))
+			}
+		}
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		// Happens during cluster creation when the CRD is not setup yet
+		if !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("failed to list MachineDeployments: %v", err)
+		}
+	}
+	for _, md := range machineDeployments.Items {
+		kubeletVersion, err := semver.NewVersion(strings.TrimSpace(md.Spec.Template.Spec.Versions.Kubelet))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kubelet version of MachineDeployment %s: %v", md.Name, err)
+		}
+		state.kubelets = append(state.kubelets, machineDeploymentKubelet{name: md.Name, version: kubeletVersion})
+	}
+
+	return state, nil
+}
+
+// Check evaluates all pre-flight checks for updating the control plane to the
+// target version, which must not be newer than the version the state was gathered
+// for. An empty result means the update can safely be performed.
+func (s *ClusterState) Check(admissionPlugins AdmissionPluginsLister, target *semver.Version) ([]Blocker, error) {
+	blockers := s.checkRemovedAPIs(target)
+
+	skewed, err := s.checkKubeletVersionSkew(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check kubelet version skew: %v", err)
+	}
+	blockers = append(blockers, skewed...)
+
+	plugins, err := UnavailableAdmissionPlugins(admissionPlugins, s.cluster, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check admission plugins: %v", err)
+	}
+	return append(blockers, plugins...), nil
+}
+
+// Run gathers the state of the user cluster and checks it against the target
+// version. client must be a client for the user cluster.
+func Run(ctx context.Context, client ctrlruntimeclient.Client, admissionPlugins AdmissionPluginsLister, cluster *kubermaticv1.Cluster, target *semver.Version) ([]Blocker, error) {
+	state, err := GatherClusterState(ctx, client, cluster, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for removed APIs: %v", err)
+	}
+	return state.Check(admissionPlugins, target)
+}
+
+// checkRemovedAPIs reports resources which were last applied using an API version
+// that is removed between the current and the target version. Such manifests
+// will fail to apply once the control plane has been updated.
+func (s *ClusterState) checkRemovedAPIs(target *semver.Version) []Blocker {
+	var blockers []Blocker
+	for i, api := range removedAPIs {
+		if target.LessThan(api.removedIn) {
+			continue
+		}
+		for _, name := range s.removedAPIUsers[i] {
+			blockers = append(blockers, Blocker{
+				Check:   CheckRemovedAPIs,
+				Message: fmt.Sprintf("%s %s uses %s, which is removed in %d.%d; use %s instead", api.kind, name, api.groupVersion, api.removedIn.Major(), api.removedIn.Minor(), api.replacement),
+			})
+		}
+	}
+	return blockers
+}
+
+// checkKubeletVersionSkew reports MachineDeployments whose kubelet would not be
+// supported by the target control plane version.
+func (s *ClusterState) checkKubeletVersionSkew(target *semver.Version) ([]Blocker, error) {
+	var blockers []Blocker
+	for _, kubelet := range s.kubelets {
+		if err := nodeupdate.EnsureVersionCompatible(target, kubelet.version); err != nil {
+			if _, ok := err.(nodeupdate.ErrVersionSkew); !ok {
+				return nil, err
+			}
+			blockers = append(blockers, Blocker{
+				Check:   CheckKubeletVersionSkew,
+				Message: fmt.Sprintf("MachineDeployment %s runs kubelet %s, which is not compatible with control plane version %s", kubelet.name, kubelet.version, target),
+			})
+		}
+	}
+	return blockers, nil
+}
+
+// lastAppliedAPIVersion returns the apiVersion the object was last applied with
+// using kubectl. Objects not managed by kubectl apply cannot be attributed to an
+// API version, as the apiserver converts them to any served version.
+func lastAppliedAPIVersion(obj *unstructured.Unstructured) string {
+	lastApplied, ok := obj.GetAnnotations()[corev1.LastAppliedConfigAnnotation]
+	if !ok {
+		return ""
+	}
+
+	applied := &unstructured.Unstructured{}
+	if err := applied.UnmarshalJSON([]byte(lastApplied)); err != nil {
+		return ""
+	}
+	return applied.GetAPIVersion()
+}
+
+func objectName(obj metav1.Object) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// UnavailableAdmissionPlugins finds admission plugins enabled for the cluster
+// which are not available in the target version. Unlike the other checks, it
+// does not need access to the user cluster.
+func UnavailableAdmissionPlugins(lister AdmissionPluginsLister, cluster *kubermaticv1.Cluster, target *semver.Version) ([]Blocker, error) {
+	if len(cluster.Spec.AdmissionPlugins) == 0 {
+		return nil, nil
+	}
+
+	available, err := lister.ListPluginNamesFromVersion(target.String())
+	if err != nil {
+		return nil, err
+	}
+	availableSet := map[string]bool{}
+	for _, name := range available {
+		availableSet[name] = true
+	}
+
+	var blockers []Blocker
+	for _, name := range cluster.Spec.AdmissionPlugins {
+		if !availableSet[name] {
+			blockers = append(blockers, Blocker{
+				Check:   CheckAdmissionPlugins,
+				Message: fmt.Sprintf("admission plugin %s is not available in version %s", name, target),
+			})
+		}
+	}
+
+	return blockers, nil
+}
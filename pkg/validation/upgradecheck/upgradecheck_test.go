@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	k8csemver "k8c.io/kubermatic/v2/pkg/semver"
+
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakePluginsLister []string
+
+func (f fakePluginsLister) ListPluginNamesFromVersion(string) ([]string, error) {
+	return f, nil
+}
+
+func init() {
+	if err := clusterv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+}
+
+func genDeployment(name string, lastAppliedAPIVersion string) *extensionsv1beta1.Deployment {
+	deployment := &extensionsv1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+	}
+	if lastAppliedAPIVersion != "" {
+		deployment.Annotations = map[string]string{
+			corev1.LastAppliedConfigAnnotation: `{"apiVersion":"` + lastAppliedAPIVersion + `","kind":"Deployment"}`,
+		}
+	}
+	return deployment
+}
+
+func genMachineDeployment(name, kubelet string) *clusterv1alpha1.MachineDeployment {
+	md := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+		},
+	}
+	md.Spec.Template.Spec.Versions.Kubelet = kubelet
+	return md
+}
+
+// countingClient counts the list requests sent to the user cluster
+type countingClient struct {
+	ctrlruntimeclient.Client
+	lists int
+}
+
+func (c *countingClient) List(ctx context.Context, list runtime.Object, opts ...ctrlruntimeclient.ListOption) error {
+	c.lists++
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name             string
+		currentVersion   string
+		targetVersion    string
+		admissionPlugins []string
+		availablePlugins fakePluginsLister
+		objects          []runtime.Object
+		expected         []Blocker
+	}{
+		{
+			name:           "no blockers",
+			currentVersion: "1.15.5",
+			targetVersion:  "1.16.2",
+			objects: []runtime.Object{
+				genDeployment("modern", "apps/v1"),
+				genMachineDeployment("workers", "1.15.5"),
+			},
+		},
+		{
+			name:           "resource applied with removed API",
+			currentVersion: "1.15.5",
+			targetVersion:  "1.16.2",
+			objects: []runtime.Object{
+				genDeployment("legacy", "extensions/v1beta1"),
+				genDeployment("unmanaged", ""),
+			},
+			expected: []Blocker{
+				{
+					Check:   CheckRemovedAPIs,
+					Message: "Deployment default/legacy uses extensions/v1beta1, which is removed in 1.16; use apps/v1 instead",
+				},
+			},
+		},
+		{
+			name:           "removed API not relevant for patch release",
+			currentVersion: "1.16.1",
+			targetVersion:  "1.16.2",
+			objects: []runtime.Object{
+				genDeployment("legacy", "extensions/v1beta1"),
+			},
+		},
+		{
+			name:           "kubelet version skew",
+			currentVersion: "1.16.2",
+			targetVersion:  "1.17.0",
+			objects: []runtime.Object{
+				genMachineDeployment("old", "1.14.0"),
+				genMachineDeployment("new", "1.16.2"),
+			},
+			expected: []Blocker{
+				{
+					Check:   CheckKubeletVersionSkew,
+					Message: "MachineDeployment old runs kubelet 1.14.0, which is not compatible with control plane version 1.17.0",
+				},
+			},
+		},
+		{
+			name:             "admission plugin not available",
+			currentVersion:   "1.16.2",
+			targetVersion:    "1.17.0",
+			admissionPlugins: []string{"PodNodeSelector", "EventRateLimit"},
+			availablePlugins: fakePluginsLister{"PodNodeSelector"},
+			expected: []Blocker{
+				{
+					Check:   CheckAdmissionPlugins,
+					Message: "admission plugin EventRateLimit is not available in version 1.17.0",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{}
+			cluster.Spec.Version = *k8csemver.NewSemverOrDie(tc.currentVersion)
+			cluster.Spec.AdmissionPlugins = tc.admissionPlugins
+
+			client := ctrlruntimefakeclient.NewFakeClientWithScheme(scheme.Scheme, tc.objects...)
+
+			blockers, err := Run(context.Background(), client, tc.availablePlugins, cluster, semver.MustParse(tc.targetVersion))
+			if err != nil {
+				t.Fatalf("failed to run checks: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(blockers, tc.expected) {
+				t.Fatalf("expected blockers %v, got %v", tc.expected, blockers)
+			}
+		})
+	}
+}
+
+func TestClusterStateCheck(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{}
+	cluster.Spec.Version = *k8csemver.NewSemverOrDie("1.15.5")
+
+	client := &countingClient{Client: ctrlruntimefakeclient.NewFakeClientWithScheme(scheme.Scheme,
+		genDeployment("legacy", "extensions/v1beta1"),
+	)}
+	state, err := GatherClusterState(context.Background(), client, cluster, semver.MustParse("1.16.2"))
+	if err != nil {
+		t.Fatalf("failed to gather cluster state: %v", err)
+	}
+	lists := client.lists
+
+	testCases := []struct {
+		targetVersion string
+		expected      []Blocker
+	}{
+		{
+			targetVersion: "1.15.6",
+		},
+		{
+			targetVersion: "1.16.0",
+			expected: []Blocker{
+				{Check: CheckRemovedAPIs, Message: "Deployment default/legacy uses extensions/v1beta1, which is removed in 1.16; use apps/v1 instead"},
+			},
+		},
+		{
+			targetVersion: "1.16.2",
+			expected: []Blocker{
+				{Check: CheckRemovedAPIs, Message: "Deployment default/legacy uses extensions/v1beta1, which is removed in 1.16; use apps/v1 instead"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.targetVersion, func(t *testing.T) {
+			blockers, err := state.Check(fakePluginsLister{}, semver.MustParse(tc.targetVersion))
+			if err != nil {
+				t.Fatalf("failed to run checks: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(blockers, tc.expected) {
+				t.Fatalf("expected blockers %v, got %v", tc.expected, blockers)
+			}
+		})
+	}
+
+	if client.lists != lists {
+		t.Errorf("expected checks to not access the cluster, got %d additional list requests", client.lists-lists)
+	}
+}
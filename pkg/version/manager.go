@@ -26,6 +26,8 @@ import (
 	v1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/validation/nodeupdate"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -53,6 +55,18 @@ type Update struct {
 	Automatic           bool   `json:"automatic,omitempty"`
 	AutomaticNodeUpdate bool   `json:"automaticNodeUpdate,omitempty"`
 	Type                string `json:"type,omitempty"`
+	// Rollout controls the staged rollout of an automatic control plane update
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
+}
+
+// RolloutPolicy configures how an automatic control plane update is rolled out to the clusters of a seed
+type RolloutPolicy struct {
+	// MaxConcurrentUpdates limits the number of clusters that are updated at the same time, zero means no limit
+	MaxConcurrentUpdates int `json:"maxConcurrentUpdates,omitempty"`
+	// CanarySelector selects the clusters that are updated before all others
+	CanarySelector *metav1.LabelSelector `json:"canarySelector,omitempty"`
+	// SoakTime is the time to wait after the last canary was updated before the other clusters follow
+	SoakTime metav1.Duration `json:"soakTime,omitempty"`
 }
 
 // New returns a instance of Manager
@@ -135,7 +149,7 @@ func (m *Manager) GetVersions(clusterType string) ([]*Version, error) {
 
 // AutomaticNodeUpdate returns an automatic node update or nil
 func (m *Manager) AutomaticNodeUpdate(fromVersionRaw, clusterType, controlPlaneVersion string) (*Version, error) {
-	version, err := m.automaticUpdate(fromVersionRaw, clusterType, true)
+	version, _, err := m.automaticUpdate(fromVersionRaw, clusterType, true)
 	if err != nil || version == nil {
 		return version, err
 	}
@@ -154,13 +168,24 @@ func (m *Manager) AutomaticNodeUpdate(fromVersionRaw, clusterType, controlPlaneV
 // AutomaticControlplaneUpdate returns a version if an automatic update can be found for the version
 // passed in
 func (m *Manager) AutomaticControlplaneUpdate(fromVersionRaw, clusterType string) (*Version, error) {
-	return m.automaticUpdate(fromVersionRaw, clusterType, false)
+	version, _, err := m.automaticUpdate(fromVersionRaw, clusterType, false)
+	return version, err
 }
 
-func (m *Manager) automaticUpdate(fromVersionRaw, clusterType string, isForNode bool) (*Version, error) {
+// AutomaticControlplaneUpdateRollout returns the rollout policy of the automatic update for the version
+// passed in. It returns nil if there is no automatic update or it has no rollout policy.
+func (m *Manager) AutomaticControlplaneUpdateRollout(fromVersionRaw, clusterType string) (*RolloutPolicy, error) {
+	_, update, err := m.automaticUpdate(fromVersionRaw, clusterType, false)
+	if err != nil || update == nil {
+		return nil, err
+	}
+	return update.Rollout, nil
+}
+
+func (m *Manager) automaticUpdate(fromVersionRaw, clusterType string, isForNode bool) (*Version, *Update, error) {
 	from, err := semver.NewVersion(fromVersionRaw)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse version %s: %v", fromVersionRaw, err)
+		return nil, nil, fmt.Errorf("failed to parse version %s: %v", fromVersionRaw, err)
 	}
 
 	isAutomatic := func(u *Update) bool {
@@ -171,6 +196,7 @@ func (m *Manager) automaticUpdate(fromVersionRaw, clusterType string, isForNode
 	}
 
 	var toVersions []string
+	var matchingUpdate *Update
 	for _, u := range m.updates {
 		if u.Type != clusterType {
 			continue
@@ -182,7 +208,7 @@ func (m *Manager) automaticUpdate(fromVersionRaw, clusterType string, isForNode
 
 		uFrom, err := semver.NewConstraint(u.From)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse from constraint %s: %v", u.From, err)
+			return nil, nil, fmt.Errorf("failed to parse from constraint %s: %v", u.From, err)
 		}
 		if !uFrom.Check(from) {
 			continue
@@ -190,24 +216,25 @@ func (m *Manager) automaticUpdate(fromVersionRaw, clusterType string, isForNode
 
 		// Automatic updates must not be a constraint. They must be version.
 		if _, err = semver.NewVersion(u.To); err != nil {
-			return nil, fmt.Errorf("failed to parse to version %s: %v", u.To, err)
+			return nil, nil, fmt.Errorf("failed to parse to version %s: %v", u.To, err)
 		}
 		toVersions = append(toVersions, u.To)
+		matchingUpdate = u
 	}
 
 	if len(toVersions) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	if len(toVersions) > 1 {
-		return nil, fmt.Errorf("more than one automatic update found for version. Not allowed. Automatic updates to: %v", toVersions)
+		return nil, nil, fmt.Errorf("more than one automatic update found for version. Not allowed. Automatic updates to: %v", toVersions)
 	}
 
 	version, err := m.GetVersion(toVersions[0], clusterType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Version for %s: %v", toVersions[0], err)
+		return nil, nil, fmt.Errorf("failed to get Version for %s: %v", toVersions[0], err)
 	}
-	return version, nil
+	return version, matchingUpdate, nil
 }
 
 // GetPossibleUpdates returns possible updates for the version passed in
@@ -85,3 +85,39 @@ func TestAutomaticNodeUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAutomaticControlplaneUpdateRollout(t *testing.T) {
+	rollout := &RolloutPolicy{MaxConcurrentUpdates: 3}
+	m := New(
+		[]*Version{
+			{Version: semver.MustParse("1.5.1")},
+			{Version: semver.MustParse("1.6.1")},
+		},
+		[]*Update{
+			{From: "1.5.0", To: "1.5.1", Automatic: true, Rollout: rollout},
+			{From: "1.6.0", To: "1.6.1", Automatic: true},
+			{From: "1.6.*", To: "1.7.*"},
+		},
+	)
+
+	testCases := []struct {
+		fromVersion     string
+		expectedRollout *RolloutPolicy
+	}{
+		{fromVersion: "1.5.0", expectedRollout: rollout},
+		{fromVersion: "1.6.0", expectedRollout: nil},
+		{fromVersion: "1.6.1", expectedRollout: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fromVersion, func(t *testing.T) {
+			result, err := m.AutomaticControlplaneUpdateRollout(tc.fromVersion, "")
+			if err != nil {
+				t.Fatalf("failed to get rollout: %v", err)
+			}
+			if result != tc.expectedRollout {
+				t.Errorf("expected rollout %v, got %v", tc.expectedRollout, result)
+			}
+		})
+	}
+}